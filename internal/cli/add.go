@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -21,8 +20,12 @@ var (
 	addPriority    string
 	addDescription string
 	addProject     string
+	addNoProject   bool
 	addTags        []string
 	addDueDate     string
+	addWaiting     string
+	addRecurring   string
+	addQuiet       bool
 )
 
 var addCmd = &cobra.Command{
@@ -33,14 +36,30 @@ var addCmd = &cobra.Command{
 If no arguments or flags are provided, an interactive TUI form will open.
 Otherwise, the task will be created directly with the provided values.
 
+New tasks without --project go to the default_project from the config, if one
+is set. Use --no-project to create a task outside of it.
+
+A task created with --recurring repeats: completing it creates the next
+instance, due on the next day the rule falls on after the completion or
+after the due date, whichever is later. Rules are daily, weekly (every 7
+days), weekly:mon,wed, monthly (same day each month) or monthly:15.
+
+With --quiet the only output is the new task's ID, for scripts. Errors go to
+stderr with a non-zero exit code.
+
 Examples:
   taskflow add                                             # Open TUI form
   taskflow add "Implement user authentication"             # CLI mode
   taskflow add "Fix login bug" --priority high --project Backend
   taskflow add "Write documentation" --tags docs,important --due-date "2024-12-31"
-  taskflow add "Database optimization" --project 1 --priority high`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runAdd,
+  taskflow add "Database optimization" --project 1 --priority high
+  taskflow add "Contract review" --waiting "Legal"        # Waiting on someone
+  taskflow add "Personal errand" --no-project              # Skip the default project
+  taskflow add "Standup" --recurring weekly:mon,wed,fri    # Repeats on those days
+  id=$(taskflow add "Deploy" --quiet)                      # Capture the new ID`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runAdd,
 }
 
 func init() {
@@ -50,8 +69,18 @@ func init() {
 	addCmd.Flags().StringVarP(&addPriority, "priority", "p", "medium", "Task priority (low, medium, high, urgent)")
 	addCmd.Flags().StringVarP(&addDescription, "description", "d", "", "Description of your task")
 	addCmd.Flags().StringVarP(&addProject, "project", "P", "", "Project name or ID")
+	addCmd.Flags().BoolVar(&addNoProject, "no-project", false, "Don't put the task in the default project")
 	addCmd.Flags().StringSliceVarP(&addTags, "tags", "t", []string{}, "Comma-separated tags")
-	addCmd.Flags().StringVar(&addDueDate, "due-date", "", "Due date (YYYY-MM-DD format)")
+	addCmd.Flags().StringVar(&addDueDate, "due-date", "", "Due date (YYYY-MM-DD, today, tomorrow, +3d, +2w, friday, eod, eow)")
+	addCmd.Flags().StringVar(&addWaiting, "waiting", "", "Who or what the task is waiting on")
+	addCmd.Flags().StringVar(&addRecurring, "recurring", "", "Repeat the task: daily, weekly, weekly:mon,wed, monthly or monthly:15")
+	addCmd.Flags().BoolVarP(&addQuiet, "quiet", "q", false, "Only print the new task's ID")
+
+	// completion
+	addCmd.MarkFlagsMutuallyExclusive("project", "no-project")
+
+	addCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	addCmd.RegisterFlagCompletionFunc("tags", completeTags)
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
@@ -75,16 +104,23 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		addPriority == "medium" &&
 		addDescription == "" &&
 		addProject == "" &&
+		!addNoProject &&
 		len(addTags) == 0 &&
-		addDueDate == ""
+		addDueDate == "" &&
+		addWaiting == "" &&
+		addRecurring == "" &&
+		!addQuiet
 
 	if shouldUseTUI {
-		return runAddWithTUI(cfg, themeObj, styles)
+		return runAddWithTUI(cmd, cfg, themeObj, styles)
 	}
 
 	title := strings.Join(args, " ")
+	if strings.TrimSpace(title) == "" {
+		return reportError(cmd, styles, "A task title is required")
+	}
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -93,58 +129,63 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewTaskRepository(db)
 	ctx := context.Background()
 
+	priority, err := domain.ParsePriority(addPriority)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("%v", err))
+	}
+
 	task := domain.NewTask(title)
 	task.Description = addDescription
-	task.Priority = domain.Priority(addPriority)
+	task.Priority = priority
 	task.Tags = addTags
 
+	projectRepo := sqlite.NewProjectRepository(db)
 	if addProject != "" {
-		projectRepo := sqlite.NewProjectRepository(db)
-		projectID, err := lookupProjectID(ctx, projectRepo, addProject)
+		projectID, err := promptProjectID(ctx, projectRepo, addProject)
 		if err != nil {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
-			return nil
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
 		}
 		task.ProjectID = projectID
+	} else if !addNoProject {
+		project, err := resolveDefaultProject(ctx, projectRepo, cfg.DefaultProject)
+		if err != nil {
+			fmt.Fprintln(cmd.ErrOrStderr(), styles.Info.Render(fmt.Sprintf("⚠ Ignoring default_project: %v", err)))
+		} else if project != nil {
+			task.ProjectID = &project.ID
+			task.ProjectName = project.Name
+		}
 	}
 
 	// parse due date
 	if addDueDate != "" {
-		dueDate, err := parseDueDate(addDueDate)
+		dueDate, err := domain.ParseDueDate(addDueDate)
 		if err != nil {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Invalid due date format: %v", err)))
-			fmt.Println(styles.Info.Render("  Use YYYY-MM-DD format (e.g., 2024-12-31)"))
-			return nil
+			return reportError(cmd, styles, fmt.Sprintf("Invalid due date: %v", err))
 		}
 		task.DueDate = dueDate
 	}
 
-	if err := repo.Create(ctx, task); err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task: %v", err)))
-		return nil
-	}
-
-	displayTaskCreated(task, styles)
+	task.WaitingOn = strings.TrimSpace(addWaiting)
 
-	return nil
-}
+	if addRecurring != "" {
+		recurrence, err := domain.ParseRecurrence(addRecurring)
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+		task.Recurrence = recurrence.String()
+	}
 
-func parseDueDate(dateStr string) (*time.Time, error) {
-	// supported date formats
-	formats := []string{
-		"2006-01-02",
-		"2006/01/02",
-		"02-01-2006",
-		"02/01/2006",
+	if err := repo.Create(ctx, task); err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to create task: %v", err))
 	}
 
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return &t, nil
-		}
+	if addQuiet {
+		fmt.Fprintln(cmd.OutOrStdout(), task.ID)
+		return nil
 	}
+	displayTaskCreated(task, styles)
 
-	return nil, fmt.Errorf("unable to parse date: %s", dateStr)
+	return nil
 }
 
 func displayTaskCreated(task *domain.Task, styles *theme.Styles) {
@@ -159,8 +200,8 @@ func displayTaskCreated(task *domain.Task, styles *theme.Styles) {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Description:"), task.Description)
 	}
 
-	fmt.Printf("  %s %s\n", styles.Info.Render("Priority:"), task.Priority)
-	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), task.Status)
+	fmt.Printf("  %s %s\n", styles.Info.Render("Priority:"), styles.PriorityLabel(task.Priority))
+	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), styles.StatusLabel(task.Status))
 
 	if task.ProjectName != "" {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Project:"), task.ProjectName)
@@ -174,11 +215,19 @@ func displayTaskCreated(task *domain.Task, styles *theme.Styles) {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Due Date:"), task.DueDate.Format("2006-01-02"))
 	}
 
+	if task.WaitingOn != "" {
+		fmt.Printf("  %s %s\n", styles.Info.Render("Waiting on:"), task.WaitingOn)
+	}
+
+	if task.Recurrence != "" {
+		fmt.Printf("  %s %s\n", styles.Info.Render("Repeats:"), task.Recurrence)
+	}
+
 	fmt.Println()
 }
 
-func runAddWithTUI(cfg *config.Config, themeObj *theme.Theme, styles *theme.Styles) error {
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+func runAddWithTUI(cmd *cobra.Command, cfg *config.Config, themeObj *theme.Theme, styles *theme.Styles) error {
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -189,6 +238,7 @@ func runAddWithTUI(cfg *config.Config, themeObj *theme.Theme, styles *theme.Styl
 	ctx := context.Background()
 
 	model := tui.NewAddFormModel(ctx, projectRepo, themeObj, styles)
+	model.SetDefaultProject(defaultProjectName(ctx, projectRepo, cfg.DefaultProject, styles))
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	finalModel, err := p.Run()
@@ -210,8 +260,7 @@ func runAddWithTUI(cfg *config.Config, themeObj *theme.Theme, styles *theme.Styl
 	}
 
 	if err := repo.Create(ctx, task); err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task: %v", err)))
-		return nil
+		return reportError(cmd, styles, fmt.Sprintf("Failed to create task: %v", err))
 	}
 
 	displayTaskCreated(task, styles)
@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	addManyProject string
+	addManyDryRun  bool
+)
+
+var addManyCmd = &cobra.Command{
+	Use:   "add-many <file>",
+	Short: "Create a task for every line of a checklist file",
+	Long: `Create a task for every non-empty line of a file, or of stdin with "-".
+
+Lines take the same markers as a quick capture:
+
+  !low !medium !high !urgent   priority
+  #tag                         tag, can be repeated
+  @project                     project name, alias or ID
+
+Markdown list bullets and checkboxes are stripped, a checked box ("- [x]")
+creates the task as completed. A line starting with # in the first column is
+a section header: its text is the project of the lines below it, until the
+next header. "#" on its own ends the section. Indent a line to start it with
+a #tag instead.
+
+All tasks are created in one transaction. If any line has a problem, every
+problem is listed with its line number and nothing is created.
+
+Lines without a project go to --project, or to the default_project from the
+config.
+
+Examples:
+  taskflow add-many brainstorm.txt
+  taskflow add-many sprint.md --project Backend --dry-run
+  pbpaste | taskflow add-many -`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runAddMany,
+}
+
+func init() {
+	rootCmd.AddCommand(addManyCmd)
+
+	addManyCmd.Flags().StringVarP(&addManyProject, "project", "P", "", "Project for lines without a section or @project")
+	addManyCmd.Flags().BoolVar(&addManyDryRun, "dry-run", false, "Show the tasks that would be created without creating them")
+
+	addManyCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+}
+
+// one task line of a checklist file
+type checklistItem struct {
+	line    int
+	section string
+	done    bool
+	entry   *domain.QuickAdd
+	err     error
+}
+
+// an optional list bullet followed by an optional checkbox
+var checklistMarker = regexp.MustCompile(`^(?:[-*+]\s+)?(?:\[([ xX])\]\s*)?`)
+
+func parseChecklist(r io.Reader) ([]checklistItem, error) {
+	var items []checklistItem
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "#") {
+			section = strings.TrimSpace(strings.TrimLeft(text, "#"))
+			continue
+		}
+
+		text = strings.TrimSpace(text)
+		marker := checklistMarker.FindStringSubmatch(text)
+		item := checklistItem{
+			line:    line,
+			section: section,
+			done:    strings.EqualFold(marker[1], "x"),
+		}
+		item.entry, item.err = domain.ParseQuickAdd(text[len(marker[0]):])
+		items = append(items, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read checklist: %w", err)
+	}
+	return items, nil
+}
+
+func runAddMany(cmd *cobra.Command, args []string) error {
+	var input io.Reader = cmd.InOrStdin()
+	if args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open checklist: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	items, err := parseChecklist(input)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	if len(items) == 0 {
+		fmt.Println(styles.Info.Render("No tasks in the checklist."))
+		return nil
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	fallback := addManyProject
+	if fallback == "" {
+		project, err := resolveDefaultProject(ctx, projectRepo, cfg.DefaultProject)
+		if err != nil {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("⚠ Ignoring default_project: %v", err)))
+		} else if project != nil {
+			fallback = project.Name
+		}
+	}
+
+	tasks, problems := checklistTasks(ctx, projectRepo, items, fallback)
+	if len(problems) > 0 {
+		fmt.Fprintln(cmd.ErrOrStderr())
+		for _, problem := range problems {
+			fmt.Fprintln(cmd.ErrOrStderr(), styles.Error.Render("✗ "+problem))
+		}
+		fmt.Fprintln(cmd.ErrOrStderr())
+		fmt.Fprintln(cmd.ErrOrStderr(), styles.Info.Render(fmt.Sprintf("Nothing was created, fix the %d line(s) above and run again.", len(problems))))
+		return reported(cmd)
+	}
+
+	if !addManyDryRun {
+		if err := repo.CreateBatch(ctx, tasks); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("Failed to create tasks, nothing was created: %v", err))
+		}
+	}
+
+	fmt.Println()
+	if addManyDryRun {
+		fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Would create %d task(s):", len(tasks))))
+	} else {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Created %d task(s)", len(tasks))))
+	}
+	for _, task := range tasks {
+		id := "-"
+		if task.ID != 0 {
+			id = fmt.Sprintf("#%d", task.ID)
+		}
+		line := fmt.Sprintf("  %-6s %s %s", id, styles.PrioritySymbol(task.Priority), display.FormatTaskTitle(task, 50))
+		if task.ProjectName != "" {
+			line += styles.Info.Render(" @" + task.ProjectName)
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// turns the checklist items into tasks, or lists every line that can't be
+// one. each project is looked up once
+func checklistTasks(ctx context.Context, projectRepo repository.ProjectRepository, items []checklistItem, fallback string) ([]*domain.Task, []string) {
+	type resolved struct {
+		project *domain.Project
+		err     error
+	}
+	projects := map[string]resolved{}
+
+	var tasks []*domain.Task
+	var problems []string
+	for _, item := range items {
+		if item.err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", item.line, item.err))
+			continue
+		}
+
+		task := domain.NewTask(item.entry.Title)
+		if item.entry.Priority != "" {
+			task.Priority = item.entry.Priority
+		}
+		task.Tags = append(task.Tags, item.entry.Tags...)
+		if item.done {
+			task.Status = domain.StatusCompleted
+		}
+
+		name := item.entry.Project
+		if name == "" {
+			name = item.section
+		}
+		if name == "" {
+			name = fallback
+		}
+		if name != "" {
+			result, ok := projects[name]
+			if !ok {
+				projectID, err := lookupProjectID(ctx, projectRepo, name)
+				if err == nil {
+					result.project, err = projectRepo.GetByID(ctx, *projectID)
+				}
+				result.err = err
+				projects[name] = result
+			}
+			if result.err != nil {
+				problems = append(problems, fmt.Sprintf("line %d: %v", item.line, result.err))
+				continue
+			}
+			task.ProjectID = &result.project.ID
+			task.ProjectName = result.project.Name
+		}
+
+		if err := task.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %v", item.line, err))
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, problems
+}
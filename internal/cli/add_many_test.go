@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+const testChecklist = `Loose idea #someday
+
+# Backend
+- [ ] Fix login !high #auth
+- [x] Write migration
+  #bug Flaky test @Frontend
+
+## Frontend
+* Polish header !urgent
+#
+Call the dentist
+`
+
+func TestParseChecklist(t *testing.T) {
+	items, err := parseChecklist(strings.NewReader(testChecklist))
+	require.NoError(t, err)
+	require.Len(t, items, 6)
+
+	want := []struct {
+		line    int
+		section string
+		done    bool
+		title   string
+	}{
+		{1, "", false, "Loose idea"},
+		{4, "Backend", false, "Fix login"},
+		{5, "Backend", true, "Write migration"},
+		{6, "Backend", false, "Flaky test"},
+		{9, "Frontend", false, "Polish header"},
+		{11, "", false, "Call the dentist"},
+	}
+	for i, w := range want {
+		item := items[i]
+		require.NoError(t, item.err)
+		assert.Equal(t, w.line, item.line)
+		assert.Equal(t, w.section, item.section, "line %d", w.line)
+		assert.Equal(t, w.done, item.done, "line %d", w.line)
+		assert.Equal(t, w.title, item.entry.Title, "line %d", w.line)
+	}
+	assert.Equal(t, []string{"bug"}, items[3].entry.Tags, "an indented #tag is a tag, not a header")
+}
+
+func TestChecklistTasks(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+	for _, name := range []string{"Backend", "Frontend", "Personal"} {
+		require.NoError(t, repo.Create(ctx, domain.NewProject(name)))
+	}
+
+	t.Run("projects come from markers, sections and the fallback", func(t *testing.T) {
+		items, err := parseChecklist(strings.NewReader(testChecklist))
+		require.NoError(t, err)
+
+		tasks, problems := checklistTasks(ctx, repo, items, "Personal")
+		require.Empty(t, problems)
+		require.Len(t, tasks, 6)
+
+		projects := make([]string, len(tasks))
+		for i, task := range tasks {
+			projects[i] = task.ProjectName
+		}
+		assert.Equal(t, []string{"Personal", "Backend", "Backend", "Frontend", "Frontend", "Personal"}, projects)
+		assert.Equal(t, domain.PriorityHigh, tasks[1].Priority)
+		assert.Equal(t, domain.StatusCompleted, tasks[2].Status)
+		assert.Equal(t, domain.PriorityMedium, tasks[3].Priority)
+	})
+
+	t.Run("every bad line is reported", func(t *testing.T) {
+		items, err := parseChecklist(strings.NewReader("Good line\nBad !asap\n# Nowhere\nLost task\nAlso lost\n"))
+		require.NoError(t, err)
+
+		tasks, problems := checklistTasks(ctx, repo, items, "")
+		assert.Len(t, tasks, 1)
+		require.Len(t, problems, 3)
+		assert.Contains(t, problems[0], "line 2: unknown priority")
+		assert.Contains(t, problems[1], "line 4: project 'Nowhere' not found")
+		assert.Contains(t, problems[2], "line 5:")
+	})
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -43,7 +44,9 @@ var (
 
 	// safety
 	bulkConfirm bool
+	bulkPreview bool
 	bulkDryRun  bool
+	bulkForce   bool
 )
 
 var bulkCmd = &cobra.Command{
@@ -57,7 +60,12 @@ Available operations:
   - tag: Add or remove tags from multiple tasks
   - delete: Delete multiple tasks
 
-Use filters to specify which tasks to operate on.`,
+Use filters to specify which tasks to operate on. Operations matching more
+tasks than bulk_max_affected in the config (100 by default, -1 for no limit)
+are refused unless --force is passed.
+
+Changes are only applied with --confirm. --preview lists the matched tasks
+with their IDs and projects and asks before applying instead.`,
 }
 
 var bulkUpdateCmd = &cobra.Command{
@@ -76,8 +84,12 @@ Examples:
   taskflow bulk update --tags urgent --set-status in_progress --set-priority high --confirm
 
   # Preview changes without applying (dry run)
-  taskflow bulk update --status pending --set-status completed --dry-run`,
-	RunE: runBulkUpdate,
+  taskflow bulk update --status pending --set-status completed --dry-run
+
+  # See every matched task, then answer y/N
+  taskflow bulk update --tags old --set-status cancelled --preview`,
+	Annotations: writesDatabase,
+	RunE:        runBulkUpdate,
 }
 
 var bulkMoveCmd = &cobra.Command{
@@ -97,7 +109,8 @@ Examples:
 
   # Preview move without applying
   taskflow bulk move --project 1 --to-project 2 --dry-run`,
-	RunE: runBulkMove,
+	Annotations: writesDatabase,
+	RunE:        runBulkMove,
 }
 
 var bulkTagCmd = &cobra.Command{
@@ -117,7 +130,8 @@ Examples:
 
   # Preview changes
   taskflow bulk tag --status pending --add-tags backlog --dry-run`,
-	RunE: runBulkTag,
+	Annotations: writesDatabase,
+	RunE:        runBulkTag,
 }
 
 var bulkDeleteCmd = &cobra.Command{
@@ -139,7 +153,8 @@ Examples:
 
   # Preview deletion without applying
   taskflow bulk delete --status cancelled --dry-run`,
-	RunE: runBulkDelete,
+	Annotations: writesDatabase,
+	RunE:        runBulkDelete,
 }
 
 func init() {
@@ -154,6 +169,11 @@ func init() {
 		cmd.Flags().StringVar(&bulkSearch, "search", "", "Search query in title/description")
 		cmd.Flags().StringVar(&bulkSearchMode, "search-mode", "text", "Search mode (text or regex)")
 		cmd.Flags().BoolVar(&bulkDryRun, "dry-run", false, "Preview changes without applying")
+		cmd.Flags().BoolVar(&bulkPreview, "preview", false, fmt.Sprintf("List the matched tasks (up to %d) and ask before applying", bulkPreviewLimit))
+		cmd.Flags().BoolVar(&bulkForce, "force", false, "Run even if more tasks match than bulk_max_affected allows")
+
+		cmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+		cmd.RegisterFlagCompletionFunc("tags", completeTags)
 	}
 
 	// update
@@ -163,6 +183,7 @@ func init() {
 	bulkUpdateCmd.Flags().StringVar(&bulkSetDescription, "set-description", "", "New description to set")
 	bulkUpdateCmd.Flags().StringVar(&bulkSetDueDate, "set-due-date", "", "New due date (YYYY-MM-DD)")
 	bulkUpdateCmd.Flags().BoolVar(&bulkUnsetProject, "unset-project", false, "Remove project assignment")
+	bulkUpdateCmd.RegisterFlagCompletionFunc("set-project", completeProjectNames)
 	bulkUpdateCmd.Flags().BoolVar(&bulkUnsetDueDate, "unset-due-date", false, "Remove due date")
 	bulkUpdateCmd.Flags().BoolVar(&bulkConfirm, "confirm", false, "Confirm the operation")
 
@@ -195,7 +216,7 @@ func runBulkUpdate(cmd *cobra.Command, args []string) error {
 	styles := theme.NewStyles(themeObj)
 
 	// initialize db
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -210,32 +231,28 @@ func runBulkUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter.MaxAffected = bulkMaxAffected(cfg, bulkForce)
 
 	if bulkSetStatus == "" && bulkSetPriority == "" && bulkSetProject == "" &&
 		bulkSetDescription == "" && bulkSetDueDate == "" && !bulkUnsetProject && !bulkUnsetDueDate {
 		return fmt.Errorf("no update fields specified. Use --set-status, --set-priority, etc.")
 	}
 
-	tasks, err := taskRepo.List(ctx, filter)
+	tasks, locked, err := listBulkTasks(ctx, taskRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to query tasks: %w", err)
+		return bulkError("update tasks", err)
 	}
 
 	if len(tasks) == 0 {
 		fmt.Println(styles.Info.Render("No tasks match the specified filters."))
+		printSkippedLocked(locked, styles)
 		return nil
 	}
 
 	// show preview
 	fmt.Println(styles.Title.Render(fmt.Sprintf("Bulk Update Preview - %d tasks will be updated:", len(tasks))))
 	fmt.Println()
-	for i, task := range tasks {
-		if i >= 10 {
-			fmt.Println(styles.Subtitle.Render(fmt.Sprintf("... and %d more tasks", len(tasks)-10)))
-			break
-		}
-		fmt.Printf("  • %s\n", task.Title)
-	}
+	printBulkPreview(tasks, styles)
 	fmt.Println()
 
 	// show what will be changed
@@ -263,42 +280,31 @@ func runBulkUpdate(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	printSkippedLocked(locked, styles)
+
 	if bulkDryRun {
 		fmt.Println(styles.Info.Render("Dry run mode - no changes will be applied"))
 		return nil
 	}
 
-	if !bulkConfirm {
-		fmt.Println(styles.Error.Render("Operation not confirmed. Use --confirm to apply changes"))
+	if !confirmBulk(len(tasks), styles) {
 		return nil
 	}
 
 	updates := repository.TaskUpdate{}
 
 	if bulkSetStatus != "" {
-		status := domain.Status(bulkSetStatus)
-		validStatuses := map[domain.Status]bool{
-			domain.StatusPending:    true,
-			domain.StatusInProgress: true,
-			domain.StatusCompleted:  true,
-			domain.StatusCancelled:  true,
-		}
-		if !validStatuses[status] {
-			return fmt.Errorf("invalid status: %s (must be pending, in_progress, completed, or cancelled)", bulkSetStatus)
+		status, err := domain.ParseStatus(bulkSetStatus)
+		if err != nil {
+			return err
 		}
 		updates.Status = &status
 	}
 
 	if bulkSetPriority != "" {
-		priority := domain.Priority(bulkSetPriority)
-		validPriorities := map[domain.Priority]bool{
-			domain.PriorityLow:    true,
-			domain.PriorityMedium: true,
-			domain.PriorityHigh:   true,
-			domain.PriorityUrgent: true,
-		}
-		if !validPriorities[priority] {
-			return fmt.Errorf("invalid priority: %s (must be low, medium, high, or urgent)", bulkSetPriority)
+		priority, err := domain.ParsePriority(bulkSetPriority)
+		if err != nil {
+			return err
 		}
 		updates.Priority = &priority
 	}
@@ -333,7 +339,7 @@ func runBulkUpdate(cmd *cobra.Command, args []string) error {
 
 	count, err := taskRepo.BulkUpdate(ctx, filter, updates)
 	if err != nil {
-		return fmt.Errorf("failed to update tasks: %w", err)
+		return bulkError("update tasks", err)
 	}
 
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Successfully updated %d tasks", count)))
@@ -353,7 +359,7 @@ func runBulkMove(cmd *cobra.Command, args []string) error {
 	styles := theme.NewStyles(themeObj)
 
 	// initialize db
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -367,14 +373,16 @@ func runBulkMove(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter.MaxAffected = bulkMaxAffected(cfg, bulkForce)
 
-	tasks, err := taskRepo.List(ctx, filter)
+	tasks, locked, err := listBulkTasks(ctx, taskRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to query tasks: %w", err)
+		return bulkError("move tasks", err)
 	}
 
 	if len(tasks) == 0 {
 		fmt.Println(styles.Info.Render("No tasks match the specified filters."))
+		printSkippedLocked(locked, styles)
 		return nil
 	}
 
@@ -403,30 +411,25 @@ func runBulkMove(cmd *cobra.Command, args []string) error {
 
 	fmt.Println(styles.Title.Render(fmt.Sprintf("Bulk Move Preview - %d tasks will be moved:", len(tasks))))
 	fmt.Println()
-	for i, task := range tasks {
-		if i >= 10 {
-			fmt.Println(styles.Subtitle.Render(fmt.Sprintf("... and %d more tasks", len(tasks)-10)))
-			break
-		}
-		fmt.Printf("  • %s\n", task.Title)
-	}
+	printBulkPreview(tasks, styles)
 	fmt.Println()
 	fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Target project: %s", targetProjectName)))
 	fmt.Println()
 
+	printSkippedLocked(locked, styles)
+
 	if bulkDryRun {
 		fmt.Println(styles.Info.Render("Dry run mode - no changes will be applied"))
 		return nil
 	}
 
-	if !bulkConfirm {
-		fmt.Println(styles.Error.Render("Operation not confirmed. Use --confirm to apply changes"))
+	if !confirmBulk(len(tasks), styles) {
 		return nil
 	}
 
 	count, err := taskRepo.BulkMove(ctx, filter, targetProjectID)
 	if err != nil {
-		return fmt.Errorf("failed to move tasks: %w", err)
+		return bulkError("move tasks", err)
 	}
 
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Successfully moved %d tasks to %s", count, targetProjectName)))
@@ -445,7 +448,7 @@ func runBulkTag(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -463,26 +466,22 @@ func runBulkTag(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter.MaxAffected = bulkMaxAffected(cfg, bulkForce)
 
-	tasks, err := taskRepo.List(ctx, filter)
+	tasks, locked, err := listBulkTasks(ctx, taskRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to query tasks: %w", err)
+		return bulkError("tag tasks", err)
 	}
 
 	if len(tasks) == 0 {
 		fmt.Println(styles.Info.Render("No tasks match the specified filters."))
+		printSkippedLocked(locked, styles)
 		return nil
 	}
 
 	fmt.Println(styles.Title.Render(fmt.Sprintf("Bulk Tag Preview - %d tasks will be updated:", len(tasks))))
 	fmt.Println()
-	for i, task := range tasks {
-		if i >= 10 {
-			fmt.Println(styles.Subtitle.Render(fmt.Sprintf("... and %d more tasks", len(tasks)-10)))
-			break
-		}
-		fmt.Printf("  • %s\n", task.Title)
-	}
+	printBulkPreview(tasks, styles)
 	fmt.Println()
 
 	if len(bulkAddTags) > 0 {
@@ -493,13 +492,14 @@ func runBulkTag(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	printSkippedLocked(locked, styles)
+
 	if bulkDryRun {
 		fmt.Println(styles.Info.Render("Dry run mode - no changes will be applied"))
 		return nil
 	}
 
-	if !bulkConfirm {
-		fmt.Println(styles.Error.Render("Operation not confirmed. Use --confirm to apply changes"))
+	if !confirmBulk(len(tasks), styles) {
 		return nil
 	}
 
@@ -508,7 +508,7 @@ func runBulkTag(cmd *cobra.Command, args []string) error {
 	if len(bulkAddTags) > 0 {
 		count, err := taskRepo.BulkAddTags(ctx, filter, bulkAddTags)
 		if err != nil {
-			return fmt.Errorf("failed to add tags: %w", err)
+			return bulkError("add tags", err)
 		}
 		totalCount = count
 	}
@@ -516,7 +516,7 @@ func runBulkTag(cmd *cobra.Command, args []string) error {
 	if len(bulkRemoveTags) > 0 {
 		count, err := taskRepo.BulkRemoveTags(ctx, filter, bulkRemoveTags)
 		if err != nil {
-			return fmt.Errorf("failed to remove tags: %w", err)
+			return bulkError("remove tags", err)
 		}
 		if totalCount == 0 {
 			totalCount = count
@@ -539,7 +539,7 @@ func runBulkDelete(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -553,49 +553,148 @@ func runBulkDelete(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter.MaxAffected = bulkMaxAffected(cfg, bulkForce)
 
-	tasks, err := taskRepo.List(ctx, filter)
+	tasks, locked, err := listBulkTasks(ctx, taskRepo, filter)
 	if err != nil {
-		return fmt.Errorf("failed to query tasks: %w", err)
+		return bulkError("delete tasks", err)
 	}
 
 	if len(tasks) == 0 {
 		fmt.Println(styles.Info.Render("No tasks match the specified filters."))
+		printSkippedLocked(locked, styles)
 		return nil
 	}
 
 	fmt.Println(styles.Error.Render(fmt.Sprintf("Bulk Delete Preview - %d tasks will be PERMANENTLY DELETED:", len(tasks))))
 	fmt.Println()
-	for i, task := range tasks {
-		if i >= 10 {
-			fmt.Println(styles.Subtitle.Render(fmt.Sprintf("... and %d more tasks", len(tasks)-10)))
-			break
-		}
-		fmt.Printf("  • %s\n", task.Title)
-	}
+	printBulkPreview(tasks, styles)
 	fmt.Println()
 	fmt.Println(styles.Error.Render("WARNING: This operation is irreversible!"))
 	fmt.Println()
 
+	printSkippedLocked(locked, styles)
+
 	if bulkDryRun {
 		fmt.Println(styles.Info.Render("Dry run mode - no changes will be applied"))
 		return nil
 	}
 
-	if !bulkConfirm {
-		fmt.Println(styles.Error.Render("Operation not confirmed. Use --confirm to apply changes"))
+	if !confirmBulk(len(tasks), styles) {
 		return nil
 	}
 
 	count, err := taskRepo.BulkDelete(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("failed to delete tasks: %w", err)
+		return bulkError("delete tasks", err)
 	}
 
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Successfully deleted %d tasks", count)))
 	return nil
 }
 
+// the most matched tasks listed with --preview, the plain preview lists 10
+const bulkPreviewLimit = 50
+
+func printBulkPreview(tasks []*domain.Task, styles *theme.Styles) {
+	limit := 10
+	if bulkPreview {
+		limit = bulkPreviewLimit
+	}
+	lines, more := bulkPreviewLines(tasks, limit)
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	if more > 0 {
+		fmt.Println(styles.Subtitle.Render(fmt.Sprintf("… and %d more", more)))
+	}
+	fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Total: %d task(s)", len(tasks))))
+}
+
+// one line per task up to limit with its ID, title and project, and how many
+// tasks were left out
+func bulkPreviewLines(tasks []*domain.Task, limit int) ([]string, int) {
+	shown := tasks[:min(limit, len(tasks))]
+	lines := make([]string, len(shown))
+	for i, task := range shown {
+		project := task.ProjectName
+		if project == "" {
+			project = "no project"
+		}
+		lines[i] = fmt.Sprintf("  #%-5d %s (%s)", task.ID, task.Title, project)
+	}
+	return lines, len(tasks) - len(shown)
+}
+
+// whether to go ahead: --confirm does, --preview asks, otherwise nothing is
+// applied
+func confirmBulk(count int, styles *theme.Styles) bool {
+	if bulkConfirm {
+		return true
+	}
+	if !bulkPreview {
+		fmt.Println(styles.Error.Render("Operation not confirmed. Use --confirm to apply changes, or --preview to be asked"))
+		return false
+	}
+	if !isInteractiveTerminal() {
+		fmt.Println(styles.Error.Render("Can't ask without a terminal. Use --confirm to apply changes"))
+		return false
+	}
+	if !promptForConfirmation(fmt.Sprintf("Apply to %d task(s)?", count)) {
+		fmt.Println(styles.Info.Render("Bulk operation cancelled."))
+		return false
+	}
+	return true
+}
+
+// the tasks a bulk operation with this filter changes, and how many matching
+// tasks it skips because they are locked. more tasks than the filter's
+// MaxAffected is a TooManyAffectedError, so nothing is asked before the
+// operation is refused
+func listBulkTasks(ctx context.Context, repo repository.TaskRepository, filter repository.TaskFilter) ([]*domain.Task, int64, error) {
+	filter.UnlockedOnly = true
+	tasks, err := repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.MaxAffected > 0 && len(tasks) > filter.MaxAffected {
+		return nil, 0, &repository.TooManyAffectedError{Count: int64(len(tasks)), Max: filter.MaxAffected}
+	}
+
+	filter.UnlockedOnly = false
+	filter.LockedOnly = true
+	locked, err := repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, locked, nil
+}
+
+func printSkippedLocked(locked int64, styles *theme.Styles) {
+	if locked == 0 {
+		return
+	}
+	fmt.Println(styles.Info.Render(fmt.Sprintf("🔒 Skipping %d locked task(s), unlock them with 'taskflow unlock <id>'", locked)))
+}
+
+// the most tasks a bulk operation may touch, 0 when --force is set or the
+// guard is turned off in the config
+func bulkMaxAffected(cfg *config.Config, force bool) int {
+	if force || cfg.BulkMaxAffected < 0 {
+		return 0
+	}
+	return cfg.BulkMaxAffected
+}
+
+func bulkError(action string, err error) error {
+	var tooMany *repository.TooManyAffectedError
+	if errors.As(err, &tooMany) {
+		return fmt.Errorf("refusing to %s: %d tasks match, more than bulk_max_affected (%d). Use --force to run it anyway", action, tooMany.Count, tooMany.Max)
+	}
+	return fmt.Errorf("failed to %s: %w", action, err)
+}
+
 // build a TaskFilter from the global filter flags
 func buildTaskFilter(ctx context.Context, projectRepo *sqlite.ProjectRepository) (repository.TaskFilter, error) {
 	filter := repository.TaskFilter{
@@ -604,11 +703,19 @@ func buildTaskFilter(ctx context.Context, projectRepo *sqlite.ProjectRepository)
 	}
 
 	if bulkStatus != "" {
-		filter.Status = domain.Status(bulkStatus)
+		status, err := domain.ParseStatus(bulkStatus)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = status
 	}
 
 	if bulkPriority != "" {
-		filter.Priority = domain.Priority(bulkPriority)
+		priority, err := domain.ParsePriority(bulkPriority)
+		if err != nil {
+			return filter, err
+		}
+		filter.Priority = priority
 	}
 
 	if bulkProject != "" {
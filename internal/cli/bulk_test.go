@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+func TestBulkPreviewLines(t *testing.T) {
+	var tasks []*domain.Task
+	for i := 1; i <= 12; i++ {
+		tasks = append(tasks, &domain.Task{ID: int64(i), Title: fmt.Sprintf("Task %d", i)})
+	}
+	tasks[0].ProjectName = "Backend"
+
+	lines, more := bulkPreviewLines(tasks, 10)
+	assert.Len(t, lines, 10)
+	assert.Equal(t, 2, more)
+	assert.Equal(t, "  #1     Task 1 (Backend)", lines[0])
+	assert.Equal(t, "  #2     Task 2 (no project)", lines[1])
+
+	lines, more = bulkPreviewLines(tasks, bulkPreviewLimit)
+	assert.Len(t, lines, 12)
+	assert.Zero(t, more)
+}
+
+func TestConfirmBulk(t *testing.T) {
+	original := isInteractiveTerminal
+	isInteractiveTerminal = func() bool { return false }
+	defer func() {
+		isInteractiveTerminal = original
+		bulkConfirm, bulkPreview = false, false
+	}()
+	styles := theme.NewStyles(theme.GetDefaultTheme())
+
+	bulkConfirm, bulkPreview = false, false
+	assert.False(t, confirmBulk(3, styles))
+
+	// --preview only asks in a terminal
+	bulkPreview = true
+	assert.False(t, confirmBulk(3, styles))
+
+	bulkConfirm = true
+	assert.True(t, confirmBulk(3, styles))
+}
+
+func TestListBulkTasksChecksMaxAffected(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, repo.Create(ctx, domain.NewTask(fmt.Sprintf("Task %d", i))))
+	}
+	locked := domain.NewTask("Locked")
+	require.NoError(t, repo.Create(ctx, locked))
+	require.NoError(t, repo.SetLocked(ctx, locked.ID, true))
+
+	tasks, skipped, err := listBulkTasks(ctx, repo, repository.TaskFilter{MaxAffected: 3})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3, "locked tasks don't count against the limit")
+	assert.Equal(t, int64(1), skipped)
+
+	_, _, err = listBulkTasks(ctx, repo, repository.TaskFilter{MaxAffected: 2})
+	var tooMany *repository.TooManyAffectedError
+	require.True(t, errors.As(err, &tooMany))
+	assert.Equal(t, int64(3), tooMany.Count)
+	assert.Contains(t, bulkError("update tasks", err).Error(), "refusing to update tasks")
+}
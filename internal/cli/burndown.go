@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	burndownProject     string
+	burndownDays        int
+	burndownDescendants bool
+	burndownFormat      string
+)
+
+// widest bar in the text chart, the day with the most open tasks
+const burndownChartWidth = 40
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart a project's open task count over time",
+	Long: `Chart how many tasks of a project were open at the end of each day.
+
+The counts are rebuilt from when tasks were created and completed, so tasks
+that were deleted don't show up. A cancelled task counts as closed from the
+last time it was updated.
+
+Use --format csv to plot the numbers elsewhere.`,
+	Example: `  taskflow burndown --project "Web App"
+  taskflow burndown --project backend --days 90 --descendants
+  taskflow burndown --project 3 --format csv > burndown.csv`,
+	Args: cobra.NoArgs,
+	RunE: runBurndown,
+}
+
+func init() {
+	rootCmd.AddCommand(burndownCmd)
+
+	burndownCmd.Flags().StringVarP(&burndownProject, "project", "P", "", "Project to chart (name, alias or ID)")
+	burndownCmd.Flags().IntVarP(&burndownDays, "days", "d", 30, "Number of days to chart, ending today")
+	burndownCmd.Flags().BoolVar(&burndownDescendants, "descendants", false, "Include tasks of child projects")
+	burndownCmd.Flags().StringVarP(&burndownFormat, "format", "f", "text", "Output format (text, csv)")
+	burndownCmd.MarkFlagRequired("project")
+
+	burndownCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	burndownCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "csv"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runBurndown(cmd *cobra.Command, args []string) error {
+	if burndownFormat != "text" && burndownFormat != "csv" {
+		return fmt.Errorf("unsupported format: %s (use text or csv)", burndownFormat)
+	}
+	if strings.TrimSpace(burndownProject) == "" {
+		return fmt.Errorf("--project is required")
+	}
+	if burndownDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	statsRepo := sqlite.NewStatisticsRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, projectRepo, burndownProject)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+	projectName, err := getProjectName(ctx, projectRepo, projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -(burndownDays - 1))
+	points, err := statsRepo.GetBurndown(ctx, *projectID, burndownDescendants, from, to)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to get burndown: %v", err)))
+		return nil
+	}
+
+	if len(points) == 0 {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("No task history for %s yet", projectName)))
+		return nil
+	}
+
+	if burndownFormat == "csv" {
+		return writeBurndownCSV(cmd.OutOrStdout(), points)
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("📉 Burndown: %s", projectName)))
+	fmt.Println()
+	fmt.Print(renderBurndownChart(points, burndownChartWidth))
+	fmt.Println()
+	return nil
+}
+
+func writeBurndownCSV(w io.Writer, points []domain.BurndownPoint) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "open"})
+	for _, p := range points {
+		cw.Write([]string{p.Date.Format("2006-01-02"), strconv.Itoa(p.Open)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// one bar per day, scaled so the busiest day fills width
+func renderBurndownChart(points []domain.BurndownPoint, width int) string {
+	peak := 0
+	for _, p := range points {
+		peak = max(peak, p.Open)
+	}
+
+	var b strings.Builder
+	for _, p := range points {
+		bar := 0
+		if peak > 0 {
+			bar = (p.Open*width + peak - 1) / peak
+		}
+		fmt.Fprintf(&b, "  %s │%s %d\n", p.Date.Format("Jan 02"), renderBar(bar, width, "█"), p.Open)
+	}
+	return b.String()
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+// completionData caches repository lookups for the lifetime of a single
+// completion request, so completing several flags only hits the db once
+type completionData struct {
+	values map[string][]string
+}
+
+var completionCache = &completionData{values: make(map[string][]string)}
+
+func (c *completionData) load(kind string, fetch func(ctx context.Context, db *sqlite.DB) ([]string, error)) []string {
+	if values, ok := c.values[kind]; ok {
+		return values
+	}
+	c.values[kind] = nil
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	values, err := fetch(context.Background(), db)
+	if err != nil {
+		return nil
+	}
+
+	c.values[kind] = values
+	return values
+}
+
+func fetchProjectCompletions(ctx context.Context, db *sqlite.DB) ([]string, error) {
+	repo := sqlite.NewProjectRepository(db)
+	projects, err := repo.List(ctx, repository.ProjectFilter{ExcludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(projects))
+	for _, project := range projects {
+		values = append(values, project.Name)
+		values = append(values, project.Aliases...)
+	}
+	return values, nil
+}
+
+func fetchTagCompletions(ctx context.Context, db *sqlite.DB) ([]string, error) {
+	return sqlite.NewTaskRepository(db).ListTags(ctx)
+}
+
+func fetchTemplateCompletions(ctx context.Context, db *sqlite.DB) ([]string, error) {
+	repo := sqlite.NewTemplateRepository(db)
+	templates, err := repo.List(ctx, repository.TemplateFilter{SortBy: "name"})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(templates))
+	for _, template := range templates {
+		values = append(values, template.Name)
+	}
+	return values, nil
+}
+
+func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := completionCache.load("projects", fetchProjectCompletions)
+	return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeTemplateNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	values := completionCache.load("templates", fetchTemplateCompletions)
+	return filterCompletions(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeColors(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	colors := domain.GetValidColors()
+	sort.Strings(colors)
+	return filterCompletions(colors, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// tags flags are comma separated, so only the last element is completed
+// and the already typed ones are kept as a prefix
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	values := completionCache.load("tags", fetchTagCompletions)
+
+	prefix := ""
+	current := toComplete
+	if idx := strings.LastIndex(toComplete, ","); idx >= 0 {
+		prefix = toComplete[:idx+1]
+		current = toComplete[idx+1:]
+	}
+
+	typed := make(map[string]bool)
+	for _, tag := range strings.Split(prefix, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			typed[tag] = true
+		}
+	}
+
+	var completions []string
+	for _, tag := range filterCompletions(values, current) {
+		if typed[tag] {
+			continue
+		}
+		completions = append(completions, prefix+tag)
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// filterCompletions returns the unique candidates matching the typed prefix.
+// quotes the user opened are ignored for matching, and candidates containing
+// tabs or newlines are dropped since they would break the completion protocol
+func filterCompletions(values []string, toComplete string) []string {
+	needle := strings.ToLower(strings.TrimLeft(toComplete, `"'`))
+
+	seen := make(map[string]bool)
+	var result []string
+	for _, value := range values {
+		if value == "" || strings.ContainsAny(value, "\t\n\r") {
+			continue
+		}
+		if seen[value] {
+			continue
+		}
+		if !strings.HasPrefix(strings.ToLower(value), needle) {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	values := []string{"backend", "Backlog", "frontend", "backend", "", "bad\tname", "multi\nline", "my project"}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{"empty prefix", "", []string{"Backlog", "backend", "frontend", "my project"}},
+		{"prefix", "back", []string{"Backlog", "backend"}},
+		{"case insensitive", "BACKE", []string{"backend"}},
+		{"no match", "zzz", nil},
+		{"double quote opened", `"my`, []string{"my project"}},
+		{"single quote opened", "'front", []string{"frontend"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filterCompletions(values, tt.toComplete))
+		})
+	}
+}
+
+func TestCompleteTags(t *testing.T) {
+	oldCache := completionCache
+	defer func() { completionCache = oldCache }()
+	completionCache = &completionData{values: map[string][]string{
+		"tags": {"bug", "backend", "urgent", "ui"},
+	}}
+
+	tests := []struct {
+		name       string
+		toComplete string
+		want       []string
+	}{
+		{"first tag", "b", []string{"backend", "bug"}},
+		{"all tags", "", []string{"backend", "bug", "ui", "urgent"}},
+		{"after comma", "bug,u", []string{"bug,ui", "bug,urgent"}},
+		{"typed tags are skipped", "bug,", []string{"bug,backend", "bug,ui", "bug,urgent"}},
+		{"spaces around typed tags", "bug, ui,", []string{"bug, ui,backend", "bug, ui,urgent"}},
+		{"no match", "bug,zzz", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, directive := completeTags(&cobra.Command{}, nil, tt.toComplete)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, directive)
+		})
+	}
+}
+
+func TestCompleteColors(t *testing.T) {
+	got, directive := completeColors(&cobra.Command{}, nil, "'bl")
+	assert.Contains(t, got, "blue")
+	for _, color := range got {
+		assert.Contains(t, color, "bl")
+	}
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
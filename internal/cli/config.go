@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/theme"
+)
+
+var configDoctorFix bool
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect, check and change the config file",
+	Long: `Inspect and check the config file at ~/.taskflow/config.yaml.
+
+Examples:
+  taskflow config show          # Effective settings and where they come from
+  taskflow config doctor        # Report problems in the config
+  taskflow config doctor --fix  # Repair the problems that have an obvious fix
+  taskflow config columns       # Widths and visibility of the TUI table columns`,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective config",
+	Long: `Show every setting with its effective value and whether it was set in the
+config file or is a built-in default.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+var configDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the config for problems",
+	Long: `Check the config file for problems: a database directory that doesn't exist,
+an unknown theme or fuzzy algorithm, page sizes out of bounds, invalid status
+transitions and keys taskflow doesn't know.
+
+With --fix the problems that have an obvious fix are repaired and the config
+file is saved. Unknown keys are only reported, they might be a typo of a key
+you want to keep.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configDoctorCmd)
+
+	configDoctorCmd.Flags().BoolVar(&configDoctorFix, "fix", false, "Repair problems that have an obvious fix")
+}
+
+// styles of the configured theme, or the default theme when the config names
+// one that doesn't exist. config commands must work on a broken config
+func configStyles(cfg *config.Config) *theme.Styles {
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	return theme.NewStyles(themeObj)
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	styles := configStyles(cfg)
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render("Config"))
+	fmt.Printf("  File: %s", config.GetConfigFile())
+	if !config.ConfigExists() {
+		fmt.Print(styles.Info.Render("  (not created yet)"))
+	}
+	fmt.Println()
+	fmt.Println()
+
+	for _, setting := range config.Settings(cfg) {
+		fmt.Printf("  %-28s %-30v %s\n", setting.Key, setting.Value, styles.Info.Render(setting.Source))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runConfigDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	styles := configStyles(cfg)
+
+	problems := config.Validate(cfg)
+	unknown := config.UnknownKeys()
+
+	if len(problems) == 0 && len(unknown) == 0 {
+		fmt.Println(styles.Success.Render("✓ Config looks good"))
+		return nil
+	}
+
+	fixable := 0
+	for _, problem := range problems {
+		if problem.Fix != nil {
+			fixable++
+		}
+	}
+
+	if !configDoctorFix {
+		for _, problem := range problems {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %s", problem)))
+		}
+		for _, key := range unknown {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("⚠ %s: unknown key", key)))
+		}
+		if fixable > 0 {
+			fmt.Println()
+			fmt.Println(styles.Info.Render(fmt.Sprintf("Run 'taskflow config doctor --fix' to repair %d of them.", fixable)))
+		}
+		return nil
+	}
+
+	fixed := 0
+	for _, problem := range problems {
+		if problem.Fix == nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %s (fix it by hand)", problem)))
+			continue
+		}
+		if err := problem.Fix(cfg); err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %s: failed to fix: %v", problem.Key, err)))
+			continue
+		}
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Fixed %s", problem)))
+		fixed++
+	}
+	for _, key := range unknown {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("⚠ %s: unknown key, remove it by hand if it is a typo", key)))
+	}
+
+	if fixed > 0 {
+		if err := config.SaveConfig(cfg); err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to save config: %v", err)))
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// warns on stderr when the config has problems, so commands point at doctor
+// instead of failing in odd ways
+func warnConfigProblems() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return
+	}
+	if problems := config.Validate(cfg); len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "⚠ Config has %d problem(s), run 'taskflow config doctor' for details\n", len(problems))
+	}
+}
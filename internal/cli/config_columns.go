@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+)
+
+var (
+	configColumnsHide  []string
+	configColumnsShow  []string
+	configColumnsReset bool
+)
+
+var configColumnsCmd = &cobra.Command{
+	Use:   "columns [column=width]...",
+	Short: "Show or change the TUI table columns",
+	Long: `Show the width and visibility of the columns of the TUI task table, or change
+them. Widths are in characters, a width of 0 goes back to the default. The
+TUI narrows the widest columns when they don't fit the window. The Title
+column can't be hidden.
+
+In the TUI, | and then a column's number shows or hides it, and that is
+saved here too.
+
+Columns: status, priority, title, project, tags, due, updated
+
+Examples:
+  taskflow config columns                      # Show the current columns
+  taskflow config columns title=60 project=20
+  taskflow config columns --hide tags,updated
+  taskflow config columns --show tags title=0
+  taskflow config columns --reset`,
+	RunE: runConfigColumns,
+}
+
+func init() {
+	configCmd.AddCommand(configColumnsCmd)
+
+	configColumnsCmd.Flags().StringSliceVar(&configColumnsHide, "hide", nil, "Columns to hide (comma-separated)")
+	configColumnsCmd.Flags().StringSliceVar(&configColumnsShow, "show", nil, "Columns to show again (comma-separated)")
+	configColumnsCmd.Flags().BoolVar(&configColumnsReset, "reset", false, "Go back to the default columns")
+
+	configColumnsCmd.ValidArgsFunction = cobra.NoFileCompletions
+	for _, flag := range []string{"hide", "show"} {
+		configColumnsCmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(config.ColumnNames(), cobra.ShellCompDirectiveNoFileComp))
+	}
+}
+
+func runConfigColumns(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	styles := configStyles(cfg)
+
+	changed := configColumnsReset || len(args) > 0 || len(configColumnsHide) > 0 || len(configColumnsShow) > 0
+	if changed {
+		current := cfg.Columns
+		if configColumnsReset {
+			current = nil
+		}
+		columns, err := changeColumns(current, args, configColumnsHide, configColumnsShow)
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+		if err := config.UpdateColumns(columns); err != nil {
+			return fmt.Errorf("failed to save columns: %w", err)
+		}
+		cfg.Columns = columns
+		fmt.Println(styles.Success.Render("✓ Columns saved"))
+	}
+
+	defaults := config.TableColumns(nil)
+	fmt.Println()
+	for i, column := range config.TableColumns(cfg.Columns) {
+		width := strconv.Itoa(column.Width)
+		if column.Width == defaults[i].Width {
+			width += styles.Info.Render(" (default)")
+		}
+		visibility := "shown"
+		if column.Hidden {
+			visibility = styles.Info.Render("hidden")
+		}
+		fmt.Printf("  %d  %-10s %-24s %s\n", i+1, column.Name, width, visibility)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// the column settings after applying column=width arguments and the columns
+// to hide and show. nothing changes when one of them is invalid. every
+// column is written out, so a value put back to its default replaces the
+// saved one
+func changeColumns(current map[string]config.ColumnConfig, widths, hide, show []string) (map[string]config.ColumnConfig, error) {
+	columns := make(map[string]config.ColumnConfig, len(config.ColumnNames()))
+	maps.Copy(columns, current)
+	for _, name := range config.ColumnNames() {
+		columns[name] = current[name]
+	}
+
+	for _, arg := range widths {
+		nameArg, widthArg, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid argument '%s', use column=width", arg)
+		}
+		name, err := config.ParseColumnName(nameArg)
+		if err != nil {
+			return nil, err
+		}
+		width, err := strconv.Atoi(strings.TrimSpace(widthArg))
+		if err != nil || width < 0 || (width > 0 && width < config.MinColumnWidth) {
+			return nil, fmt.Errorf("invalid width '%s' for %s, use 0 for the default or at least %d", widthArg, name, config.MinColumnWidth)
+		}
+		column := columns[name]
+		column.Width = width
+		columns[name] = column
+	}
+
+	for _, group := range []struct {
+		names  []string
+		hidden bool
+	}{{hide, true}, {show, false}} {
+		for _, nameArg := range group.names {
+			name, err := config.ParseColumnName(nameArg)
+			if err != nil {
+				return nil, err
+			}
+			if name == "title" && group.hidden {
+				return nil, fmt.Errorf("the title column can't be hidden")
+			}
+			column := columns[name]
+			column.Hidden = group.hidden
+			columns[name] = column
+		}
+	}
+
+	return columns, nil
+}
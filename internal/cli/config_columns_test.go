@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/config"
+)
+
+func TestChangeColumns(t *testing.T) {
+	current := map[string]config.ColumnConfig{"tags": {Hidden: true}, "title": {Width: 50}}
+
+	columns, err := changeColumns(current, []string{"title=60", "Project=20"}, []string{"updated"}, []string{"tags"})
+	require.NoError(t, err)
+	assert.Equal(t, config.ColumnConfig{Width: 60}, columns["title"])
+	assert.Equal(t, config.ColumnConfig{Width: 20}, columns["project"])
+	assert.Equal(t, config.ColumnConfig{}, columns["tags"], "shown again")
+	assert.True(t, columns["updated"].Hidden)
+	assert.Len(t, columns, len(config.ColumnNames()), "every column is written out")
+	assert.True(t, current["tags"].Hidden, "the current settings are left alone")
+
+	columns, err = changeColumns(current, []string{"title=0"}, nil, nil)
+	require.NoError(t, err)
+	assert.Zero(t, columns["title"].Width, "0 goes back to the default")
+
+	for _, tt := range []struct {
+		widths, hide []string
+		want         string
+	}{
+		{widths: []string{"title"}, want: "use column=width"},
+		{widths: []string{"titel=30"}, want: "unknown column 'titel'"},
+		{widths: []string{"due=2"}, want: "at least 4"},
+		{widths: []string{"due=wide"}, want: "invalid width"},
+		{hide: []string{"title"}, want: "can't be hidden"},
+	} {
+		_, err := changeColumns(current, tt.widths, tt.hide, nil)
+		assert.ErrorContains(t, err, tt.want)
+	}
+}
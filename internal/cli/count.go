@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+var (
+	countStatus   string
+	countPriority string
+	countProject  string
+	countTags     []string
+	countQuery    string
+	countDue      string
+	countOverdue  bool
+	countOpen     bool
+)
+
+var countCmd = &cobra.Command{
+	Use:   "count",
+	Short: "Print the number of matching tasks",
+	Long: `Print the number of tasks matching the filters and nothing else.
+
+Meant for scripts, shell prompts and status bars: the only output is the
+integer on stdout. Errors go to stderr with a non-zero exit code.
+
+--due accepts the same values as the query language's due: field (today,
+tomorrow, +7d, 2025-01-31, today..+7d, none). --overdue counts open tasks due
+before today.`,
+	Example: `  taskflow count --overdue
+  taskflow count --due today --open
+  taskflow count --status pending --project backend
+  taskflow count --query "priority:urgent tag:bug"
+  echo "you have $(taskflow count --overdue) overdue"`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runCount,
+}
+
+func init() {
+	rootCmd.AddCommand(countCmd)
+
+	countCmd.Flags().StringVarP(&countStatus, "status", "s", "", "Filter by status (pending, in_progress, completed, cancelled)")
+	countCmd.Flags().StringVarP(&countPriority, "priority", "p", "", "Filter by priority (low, medium, high, urgent)")
+	countCmd.Flags().StringVarP(&countProject, "project", "P", "", "Filter by project (name, alias or ID)")
+	countCmd.Flags().StringSliceVarP(&countTags, "tags", "t", []string{}, "Filter by tags (comma-separated)")
+	countCmd.Flags().StringVarP(&countQuery, "query", "q", "", "Query language filter, combined with the other flags")
+	countCmd.Flags().StringVar(&countDue, "due", "", "Filter by due date (today, tomorrow, +7d, YYYY-MM-DD, none, ...)")
+	countCmd.Flags().BoolVar(&countOverdue, "overdue", false, "Only open tasks due before today")
+	countCmd.Flags().BoolVar(&countOpen, "open", false, "Only tasks that are not completed or cancelled")
+
+	countCmd.MarkFlagsMutuallyExclusive("due", "overdue")
+
+	countCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	countCmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"pending", "in_progress", "completed", "cancelled"}, cobra.ShellCompDirectiveNoFileComp))
+	countCmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions([]string{"low", "medium", "high", "urgent"}, cobra.ShellCompDirectiveNoFileComp))
+	countCmd.RegisterFlagCompletionFunc("due", cobra.FixedCompletions([]string{"today", "tomorrow", "yesterday", "none"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+type countOptions struct {
+	status   string
+	priority string
+	project  string
+	tags     []string
+	query    string
+	due      string
+	overdue  bool
+	// days past its due day before a task counts as overdue
+	graceDays int
+	open      bool
+}
+
+func runCount(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	filter, err := buildCountFilter(ctx, projectRepo, countOptions{
+		status:    countStatus,
+		priority:  countPriority,
+		project:   countProject,
+		tags:      countTags,
+		query:     countQuery,
+		due:       countDue,
+		overdue:   countOverdue,
+		graceDays: cfg.OverdueGraceDays,
+		open:      countOpen,
+	})
+	if err != nil {
+		return err
+	}
+
+	count, err := taskRepo.Count(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), count)
+	return nil
+}
+
+// builds the filter for count. the query goes first so that the flags can
+// narrow or override it
+func buildCountFilter(ctx context.Context, projectRepo repository.ProjectRepository, opts countOptions) (repository.TaskFilter, error) {
+	var filter repository.TaskFilter
+
+	if opts.query != "" {
+		parsed, err := query.ParseQuery(opts.query)
+		if err != nil {
+			return filter, fmt.Errorf("query parse error: %w", err)
+		}
+		converted, err := query.ConvertToTaskFilter(ctx, parsed, &query.ConverterContext{ProjectRepo: projectRepo, OverdueGraceDays: opts.graceDays})
+		if err != nil {
+			return filter, fmt.Errorf("query conversion error: %w", err)
+		}
+		filter = converted
+	}
+
+	if opts.status != "" {
+		status, err := domain.ParseStatus(opts.status)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = status
+	}
+
+	if opts.priority != "" {
+		priority, err := domain.ParsePriority(opts.priority)
+		if err != nil {
+			return filter, err
+		}
+		filter.Priority = priority
+	}
+
+	if opts.project != "" {
+		projectID, err := lookupProjectID(ctx, projectRepo, opts.project)
+		if err != nil {
+			return filter, err
+		}
+		filter.ProjectID = projectID
+	}
+
+	filter.Tags = append(filter.Tags, opts.tags...)
+
+	if opts.due != "" {
+		from, to, err := parseDueFilter(opts.due)
+		if err != nil {
+			return filter, err
+		}
+		filter.DueDateFrom, filter.DueDateTo = from, to
+	}
+
+	if opts.overdue {
+		dueTo := query.OverdueDueTo(time.Now(), opts.graceDays)
+		filter.DueDateFrom, filter.DueDateTo = nil, &dueTo
+	}
+
+	if opts.open || opts.overdue {
+		filter.ExcludeStatuses = append(filter.ExcludeStatuses, domain.StatusCompleted, domain.StatusCancelled)
+	}
+
+	return filter, nil
+}
+
+// the due date bounds of a --due value: a date, a from..to range or none for
+// tasks without a due date
+func parseDueFilter(value string) (from, to *string, err error) {
+	if strings.EqualFold(value, "none") {
+		none := "none"
+		return &none, nil, nil
+	}
+	fromDate, toDate, err := query.ParseDateRange(value, ":")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --due value %q: %w", value, err)
+	}
+	return formatDueBound(fromDate), formatDueBound(toDate), nil
+}
+
+func formatDueBound(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := query.FormatDateForSQL(*t)
+	return &formatted
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestBuildCountFilter(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	backend := domain.NewProject("backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+
+	today := time.Now()
+	yesterday := today.AddDate(0, 0, -1)
+	lastWeek := today.AddDate(0, 0, -7)
+	nextWeek := today.AddDate(0, 0, 7)
+
+	newTask := func(title string, status domain.Status, priority domain.Priority, due *time.Time, inBackend bool, tags ...string) {
+		task := domain.NewTask(title)
+		task.Status = status
+		task.Priority = priority
+		task.DueDate = due
+		task.Tags = tags
+		if inBackend {
+			task.ProjectID = &backend.ID
+		}
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	newTask("late", domain.StatusPending, domain.PriorityHigh, &yesterday, true, "bug")
+	newTask("very late", domain.StatusInProgress, domain.PriorityUrgent, &lastWeek, false)
+	newTask("late but done", domain.StatusCompleted, domain.PriorityHigh, &lastWeek, true)
+	newTask("due today", domain.StatusPending, domain.PriorityMedium, &today, true, "bug")
+	newTask("later", domain.StatusPending, domain.PriorityLow, &nextWeek, false)
+	newTask("someday", domain.StatusCancelled, domain.PriorityLow, nil, false)
+
+	tests := []struct {
+		name    string
+		opts    countOptions
+		want    int64
+		wantErr string
+	}{
+		{"all", countOptions{}, 6, ""},
+		{"overdue", countOptions{overdue: true}, 2, ""},
+		{"overdue in project", countOptions{overdue: true, project: "backend"}, 1, ""},
+		{"overdue with grace", countOptions{overdue: true, graceDays: 1}, 1, ""},
+		{"due today", countOptions{due: "today"}, 1, ""},
+		{"due next 7 days", countOptions{due: "today..+7d"}, 2, ""},
+		{"no due date", countOptions{due: "none"}, 1, ""},
+		{"open", countOptions{open: true}, 4, ""},
+		{"status", countOptions{status: "pending"}, 3, ""},
+		{"priority", countOptions{priority: "HIGH"}, 2, ""},
+		{"tags", countOptions{tags: []string{"bug"}}, 2, ""},
+		{"query", countOptions{query: "@backend tag:bug"}, 2, ""},
+		{"query narrowed by flags", countOptions{query: "tag:bug", overdue: true}, 1, ""},
+		{"invalid status", countOptions{status: "done"}, 0, "invalid status"},
+		{"invalid due", countOptions{due: "someday"}, 0, "invalid --due value"},
+		{"unknown project", countOptions{project: "nope"}, 0, "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := buildCountFilter(ctx, projectRepo, tt.opts)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			count, err := taskRepo.Count(ctx, filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
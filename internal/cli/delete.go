@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,17 +20,21 @@ var (
 )
 
 var deleteCmd = &cobra.Command{
-	Use:   "delete [task-id...]",
+	Use:   "delete <task-id...|->",
 	Short: "Delete one or more tasks",
 	Long: `Delete one or more tasks by their IDs.
 You will be prompted for confirmation unless you use the --force flag.
+With - as the task ID, the IDs are read from stdin, one per line, as printed
+by list --ids-only and search --ids-only. That needs --force.
 
 Examples:
   taskflow delete 1
   taskflow delete 1 2 3
-  taskflow delete 5 --force`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runDelete,
+  taskflow delete 5 --force
+  taskflow list --status cancelled --ids-only | taskflow delete - --force`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runDelete,
 }
 
 func init() {
@@ -42,15 +45,11 @@ func init() {
 }
 
 func runDelete(cmd *cobra.Command, args []string) error {
-	// parse task IDs
-	var taskIDs []int64
-	for _, arg := range args {
-		id, err := strconv.ParseInt(arg, 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid task ID: %s", arg)
-		}
-		taskIDs = append(taskIDs, id)
+	taskIDs, invalid, err := taskIDsFromArgs(args, cmd.InOrStdin())
+	if err != nil {
+		return err
 	}
+	fromStdin := readsIDsFromStdin(args)
 
 	// get config
 	cfg, err := config.LoadConfig()
@@ -70,6 +69,21 @@ func runDelete(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
+	if fromStdin && len(taskIDs) == 0 {
+		fmt.Println()
+		if len(invalid) == 0 {
+			fmt.Println(styles.Info.Render("No task IDs on stdin, nothing to delete."))
+		}
+		printInvalidTaskIDs(invalid, styles)
+		fmt.Println()
+		return nil
+	}
+
+	// the prompt would read its answer from the piped IDs
+	if fromStdin && !deleteForce {
+		return reportError(cmd, styles, "Deleting IDs read from stdin needs --force, there is no way to confirm")
+	}
+
 	// confirmation prompt
 	if !deleteForce {
 		var taskWord string
@@ -101,7 +115,7 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// initialize db
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -139,13 +153,17 @@ func runDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(failed) > 0 {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to delete %d task(s):", len(failed))))
+		fmt.Fprintln(cmd.ErrOrStderr(), styles.Error.Render(fmt.Sprintf("✗ Failed to delete %d task(s):", len(failed))))
 		for _, f := range failed {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("  %s", f)))
+			fmt.Fprintln(cmd.ErrOrStderr(), styles.Error.Render(fmt.Sprintf("  %s", f)))
 		}
 	}
+	printInvalidTaskIDs(invalid, styles)
 
 	fmt.Println()
 
+	if len(failed) > 0 {
+		return reported(cmd)
+	}
 	return nil
 }
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	doctorFix     bool
+	doctorProject string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the tasks for broken data",
+	Long: `Check the database for tasks whose project doesn't exist anymore.
+
+Deleting a project unassigns its tasks, but an import or a manual edit of the
+database can leave a task pointing at a project ID nothing has. Such tasks
+don't show up under any project.
+
+With --fix they are unassigned, or moved to --project. Problems with the config
+file are checked by 'taskflow config doctor'.`,
+	Example: `  taskflow doctor
+  taskflow doctor --fix
+  taskflow doctor --fix --project inbox`,
+	Args:        cobra.NoArgs,
+	Annotations: writesDatabaseWith("fix"),
+	RunE:        runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Unassign the orphaned tasks, or move them to --project")
+	doctorCmd.Flags().StringVarP(&doctorProject, "project", "P", "", "Project to move orphaned tasks to with --fix (name, alias or ID)")
+
+	doctorCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	if doctorProject != "" && !doctorFix {
+		return fmt.Errorf("--project only applies with --fix")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	filter := repository.TaskFilter{OrphanedOnly: true, SortBy: "created_at", SortOrder: "asc"}
+	orphans, err := repo.List(ctx, filter)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to check tasks: %v", err))
+	}
+
+	if len(orphans) == 0 {
+		fmt.Println(styles.Success.Render("✓ No orphaned tasks"))
+		return nil
+	}
+
+	for _, task := range orphans {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Task #%d %q points to project #%d, which doesn't exist", task.ID, task.Title, *task.ProjectID)))
+	}
+
+	if !doctorFix {
+		fmt.Println()
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Run 'taskflow doctor --fix' to unassign %d task(s), or add --project to move them.", len(orphans))))
+		return nil
+	}
+
+	target := "no project"
+	targetID, err := promptProjectID(ctx, projectRepo, doctorProject)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	if targetID != nil {
+		if target, err = getProjectName(ctx, projectRepo, targetID); err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+	}
+
+	count, err := repo.BulkMove(ctx, filter, targetID)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to fix orphaned tasks: %v", err))
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Moved %d orphaned task(s) to %s", count, target)))
+	return nil
+}
@@ -22,6 +22,12 @@ var (
 	exportIncludeTasks    bool
 	exportIncludeChildren bool
 	exportProjectID       string
+	exportStatus          string
+	exportPriority        string
+	exportTags            []string
+	exportSearch          string
+	exportDue             string
+	exportQuery           string
 )
 
 var exportCmd = &cobra.Command{
@@ -69,12 +75,16 @@ var exportTasksCmd = &cobra.Command{
 	Short: "Export tasks",
 	Long: `Export tasks matching filters to a file.
 
-Use filter flags to specify which tasks to export.
+Use filter flags to specify which tasks to export. --due takes the same
+values as count --due (today, +7d, 2025-01-31, today..+7d, none) and
+--query is combined with the other flags. The CSV and JSON output can be read
+back with 'taskflow task import'.
 
 Examples:
   taskflow export tasks --output all-tasks.json
   taskflow export tasks --project 1 --format csv --output project-tasks.csv
-  taskflow export tasks --status pending --priority high --format markdown`,
+  taskflow export tasks --status pending --priority high --format markdown
+  taskflow export tasks --search login --due today..+7d --format csv`,
 	RunE: runExportTasks,
 }
 
@@ -106,9 +116,14 @@ func init() {
 	exportTasksCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
 	exportTasksCmd.Flags().StringVarP(&exportFormat, "format", "f", "json", "Export format (json, csv, markdown)")
 	exportTasksCmd.Flags().StringVar(&exportProjectID, "project", "", "Filter by project (name or ID)")
-	exportTasksCmd.Flags().StringVar(&bulkStatus, "status", "", "Filter by status")
-	exportTasksCmd.Flags().StringVar(&bulkPriority, "priority", "", "Filter by priority")
-	exportTasksCmd.Flags().StringSliceVar(&bulkTags, "tags", []string{}, "Filter by tags")
+	exportTasksCmd.Flags().StringVar(&exportStatus, "status", "", "Filter by status")
+	exportTasksCmd.Flags().StringVar(&exportPriority, "priority", "", "Filter by priority")
+	exportTasksCmd.Flags().StringSliceVar(&exportTags, "tags", []string{}, "Filter by tags")
+	exportTasksCmd.Flags().StringVar(&exportSearch, "search", "", "Only tasks whose title, description, project or tags contain this")
+	exportTasksCmd.Flags().StringVar(&exportDue, "due", "", "Filter by due date (today, tomorrow, +7d, YYYY-MM-DD, none, ...)")
+	exportTasksCmd.Flags().StringVarP(&exportQuery, "query", "q", "", "Query language filter, combined with the other flags")
+	exportTasksCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	exportTasksCmd.RegisterFlagCompletionFunc("tags", completeTags)
 
 	// backup
 	exportBackupCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (required)")
@@ -129,7 +144,7 @@ func runExportProject(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -207,7 +222,7 @@ func runExportTasks(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -217,18 +232,20 @@ func runExportTasks(cmd *cobra.Command, args []string) error {
 	taskRepo := sqlite.NewTaskRepository(db)
 	ctx := context.Background()
 
-	filter, err := buildTaskFilter(ctx, projectRepo)
+	filter, err := buildCountFilter(ctx, projectRepo, countOptions{
+		status:    exportStatus,
+		priority:  exportPriority,
+		project:   exportProjectID,
+		tags:      exportTags,
+		query:     exportQuery,
+		due:       exportDue,
+		graceDays: cfg.OverdueGraceDays,
+	})
 	if err != nil {
 		return err
 	}
-
-	// override with exportProjectID if set
-	if exportProjectID != "" {
-		projectID, err := resolveProjectID(ctx, projectRepo, exportProjectID)
-		if err != nil {
-			return err
-		}
-		filter.ProjectID = projectID
+	if exportSearch != "" {
+		filter.SearchQuery = exportSearch
 	}
 
 	count, err := taskRepo.Count(ctx, filter)
@@ -296,7 +313,7 @@ func runExportBackup(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -53,8 +53,9 @@ Examples:
   taskflow import project backend.json
   taskflow import project backend.json --parent 1
   taskflow import project backend.json --parent "Systems" --conflict-strategy skip`,
-	Args: cobra.ExactArgs(1),
-	RunE: runImportProject,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runImportProject,
 }
 
 var importRestoreCmd = &cobra.Command{
@@ -71,8 +72,9 @@ Examples:
   taskflow import restore backup.json
   taskflow import restore backup.json --conflict-strategy merge
   taskflow import restore backup.json --dry-run`,
-	Args: cobra.ExactArgs(1),
-	RunE: runImportRestore,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runImportRestore,
 }
 
 func init() {
@@ -102,7 +104,7 @@ func runImportProject(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -195,7 +197,7 @@ func runImportRestore(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var inboxList bool
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox [title]",
+	Short: "Capture a task to the inbox",
+	Long: `Capture a task without choosing a project. It goes to the reserved Inbox
+project, which is created on first use, and can be sorted into a project later.
+
+The title doesn't need quotes, every argument is part of it. The
+default_project from the config is not used.
+
+Examples:
+  taskflow inbox call the dentist
+  taskflow inbox "Look into flaky CI"
+  taskflow inbox --list
+  taskflow update 12 --project Backend       # Move it out of the inbox`,
+	RunE: runInbox,
+}
+
+func init() {
+	rootCmd.AddCommand(inboxCmd)
+
+	inboxCmd.Flags().BoolVarP(&inboxList, "list", "l", false, "List the tasks in the inbox")
+}
+
+func runInbox(cmd *cobra.Command, args []string) error {
+	title := strings.TrimSpace(strings.Join(args, " "))
+	if inboxList && title != "" {
+		return fmt.Errorf("--list doesn't take a title")
+	}
+	if !inboxList && title == "" {
+		return fmt.Errorf("nothing to capture, give a title or use --list")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if !inboxList && readOnlyMode(cfg) {
+		return errReadOnly(cmd)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	if inboxList {
+		// listing doesn't create the inbox, so it works in read-only mode
+		inbox, err := projectRepo.GetInbox(ctx)
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+		return listInbox(ctx, cmd, repo, inbox, styles, cfg.OverdueGraceDays)
+	}
+
+	inbox, err := projectRepo.EnsureInbox(ctx)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	task := domain.NewTask(title)
+	task.ProjectID = &inbox.ID
+	if err := repo.Create(ctx, task); err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to capture task: %v", err))
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Captured #%d to %s", task.ID, inbox.Name)))
+	return nil
+}
+
+func listInbox(ctx context.Context, cmd *cobra.Command, repo repository.TaskRepository, inbox *domain.Project, styles *theme.Styles, graceDays int) error {
+	if inbox == nil {
+		fmt.Println()
+		fmt.Println(styles.Info.Render("Inbox zero, nothing to sort."))
+		fmt.Println()
+		return nil
+	}
+
+	tasks, err := repo.List(ctx, repository.TaskFilter{
+		ProjectID: &inbox.ID,
+		SortBy:    "created_at",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to list the inbox: %v", err))
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("%s (%d)", strings.TrimSpace(inbox.Icon+" "+inbox.Name), len(tasks))))
+
+	if len(tasks) == 0 {
+		fmt.Println(styles.Info.Render("Inbox zero, nothing to sort."))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	for _, task := range tasks {
+		fmt.Printf("  #%-5d ", task.ID)
+		printTaskRow(task, styles, graceDays)
+	}
+	fmt.Println()
+	fmt.Println(styles.Info.Render("Move a task out with 'taskflow update <id> --project <name>'."))
+	fmt.Println()
+
+	return nil
+}
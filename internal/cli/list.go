@@ -2,8 +2,11 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -24,7 +27,12 @@ var (
 	listPriority string
 	listProject  string
 	listTags     []string
+	listPinned   bool
+	listWaiting  bool
+	listSnoozed  bool
+	listArchived bool
 	listCLI      bool
+	listIDsOnly  bool
 
 	// pagination
 	listPage     int
@@ -74,8 +82,13 @@ Examples:
   taskflow list --status pending                   # TUI with filter
   taskflow list --priority high --project backend  # TUI with filters
   taskflow list --tags bug,urgent                  # TUI with tags
+  taskflow list --pinned                           # Only pinned tasks
+  taskflow list --waiting                          # Tasks waiting on someone
+  taskflow list --include-snoozed                  # Also show snoozed tasks
+  taskflow list --include-archived                 # Also show archived tasks
   taskflow list --cli                              # Text table mode
   taskflow list --cli --status pending             # Text table with filter
+  taskflow list --tags bug --ids-only | taskflow update - --priority high  # Pipe IDs
 
   # Query language examples (use 'taskflow query help' for full syntax reference):
   taskflow list --query "status:pending priority:high"      # Combine status + priority
@@ -97,12 +110,17 @@ func init() {
 
 	// display
 	listCmd.Flags().BoolVar(&listCLI, "cli", false, "Display as text table instead of TUI")
+	listCmd.Flags().BoolVar(&listIDsOnly, "ids-only", false, "Print only the IDs of all matching tasks, one per line")
 
 	// filter
 	listCmd.Flags().StringVarP(&listStatus, "status", "s", "", "Filter by status (pending, in_progress, completed, cancelled)")
 	listCmd.Flags().StringVarP(&listPriority, "priority", "p", "", "Filter by priority (low, medium, high, urgent)")
 	listCmd.Flags().StringVarP(&listProject, "project", "P", "", "Filter by project (name or ID)")
 	listCmd.Flags().StringSliceVarP(&listTags, "tags", "t", []string{}, "Filter by tags (comma-separated)")
+	listCmd.Flags().BoolVar(&listPinned, "pinned", false, "Only show pinned tasks")
+	listCmd.Flags().BoolVar(&listWaiting, "waiting", false, "Only show tasks waiting on someone")
+	listCmd.Flags().BoolVar(&listSnoozed, "include-snoozed", false, "Also show tasks that are snoozed")
+	listCmd.Flags().BoolVar(&listArchived, "include-archived", false, "Also show tasks tagged archived by a sprint roll or project archive")
 
 	// pagination
 	listCmd.Flags().IntVar(&listPage, "page", 1, "Page number (starts at 1)")
@@ -114,11 +132,15 @@ func init() {
 	listCmd.Flags().BoolVar(&listRegex, "regex", false, "Use regex mode for search")
 	listCmd.Flags().BoolVar(&listFuzzy, "fuzzy", false, "Use fuzzy search mode (typo-tolerant, abbreviation-friendly)")
 	listCmd.Flags().IntVar(&listFuzzyThreshold, "fuzzy-threshold", 60, "Minimum fuzzy match score (0-100, default 60)")
-	listCmd.Flags().StringVar(&listSortBy, "sort-by", "created_at", "Sort by field (created_at, updated_at, priority, due_date, title)")
+	listCmd.Flags().StringVar(&listSortBy, "sort-by", "created_at", "Sort by field (created_at, updated_at, priority, due_date, title, position)")
 	listCmd.Flags().StringVar(&listSortOrder, "sort-order", "desc", "Sort order (asc, desc)")
 
 	// query language
 	listCmd.Flags().StringVarP(&listQuery, "query", "q", "", "Query language filter (e.g., 'status:pending @backend tag:bug')")
+
+	// completion
+	listCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	listCmd.RegisterFlagCompletionFunc("tags", completeTags)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -138,7 +160,13 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	// IDs are for piping, so every match and never the TUI
+	if listIDsOnly {
+		listCLI = true
+		listAll = true
+	}
+
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -146,8 +174,6 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	repo := sqlite.NewTaskRepository(db)
 	projectRepo := sqlite.NewProjectRepository(db)
-	viewRepo := sqlite.NewViewRepository(db)
-	searchHistoryRepo := sqlite.NewSearchHistoryRepository(db)
 	ctx := context.Background()
 
 	pageSize := listPageSize
@@ -159,7 +185,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 
 	if listQuery != "" {
-		return runListWithQueryLanguage(ctx, repo, projectRepo, viewRepo, searchHistoryRepo, cfg, themeObj, styles, pageSize)
+		return runListWithQueryLanguage(ctx, cmd, db, cfg, themeObj, styles, pageSize)
 	}
 
 	var parsedQuery *query.ProjectMentionQuery
@@ -167,11 +193,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		var err error
 		parsedQuery, err = query.ParseProjectMentions(listSearch)
 		if err != nil {
-			if listCLI {
-				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to parse query: %v", err)))
-				return nil
-			}
-			return fmt.Errorf("failed to parse query: %w", err)
+			return reportError(cmd, styles, fmt.Sprintf("Failed to parse query: %v", err))
 		}
 	}
 
@@ -186,22 +208,14 @@ func runList(cmd *cobra.Command, args []string) error {
 			var err error
 			projectID, err = lookupProjectByFuzzyName(ctx, projectRepo, mention.Name, fuzzyThreshold)
 			if err != nil {
-				if listCLI {
-					fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
-					return nil
-				}
-				return fmt.Errorf("%v", err)
+				return reportError(cmd, styles, err.Error())
 			}
 			projectSource = "@~" + mention.Name
 		} else {
 			var err error
-			projectID, err = lookupProjectID(ctx, projectRepo, mention.Name)
+			projectID, err = promptProjectID(ctx, projectRepo, mention.Name)
 			if err != nil {
-				if listCLI {
-					fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
-					return nil
-				}
-				return fmt.Errorf("%v", err)
+				return reportError(cmd, styles, err.Error())
 			}
 			projectSource = "@" + mention.Name
 		}
@@ -209,38 +223,49 @@ func runList(cmd *cobra.Command, args []string) error {
 		listSearch = parsedQuery.BaseQuery
 	} else if listProject != "" {
 		var err error
-		projectID, err = lookupProjectID(ctx, projectRepo, listProject)
+		projectID, err = promptProjectID(ctx, projectRepo, listProject)
 		if err != nil {
-			if listCLI {
-				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
-				return nil
-			}
-			return fmt.Errorf("%v", err)
+			return reportError(cmd, styles, err.Error())
 		}
 		projectSource = "--project=" + listProject
 	}
 
 	_ = projectSource
 
+	var status domain.Status
+	if listStatus != "" {
+		if status, err = domain.ParseStatus(listStatus); err != nil {
+			return err
+		}
+	}
+	var priority domain.Priority
+	if listPriority != "" {
+		if priority, err = domain.ParsePriority(listPriority); err != nil {
+			return err
+		}
+	}
+
 	filter := repository.TaskFilter{
-		Status:      domain.Status(listStatus),
-		Priority:    domain.Priority(listPriority),
-		ProjectID:   projectID,
-		Tags:        listTags,
-		SearchQuery: listSearch,
-		SortBy:      listSortBy,
-		SortOrder:   listSortOrder,
+		Status:         status,
+		Priority:       priority,
+		ProjectID:      projectID,
+		Tags:           listTags,
+		PinnedOnly:     listPinned,
+		WaitingOnly:    listWaiting,
+		HideSnoozed:    !listSnoozed,
+		HideArchived:   !listArchived,
+		SearchQuery:    listSearch,
+		SortBy:         listSortBy,
+		SortOrder:      listSortOrder,
+		PinnedFirst:    cfg.PinnedFirst,
+		FuzzyAlgorithm: cfg.FuzzyAlgorithm,
 	}
 
 	if listFuzzy && listSearch != "" {
 		filter.SearchMode = "fuzzy"
 		filter.FuzzyThreshold = listFuzzyThreshold
 		if filter.FuzzyThreshold < 0 || filter.FuzzyThreshold > 100 {
-			if listCLI {
-				fmt.Println(styles.Error.Render("✗ Fuzzy threshold must be between 0 and 100"))
-				return nil
-			}
-			return fmt.Errorf("fuzzy threshold must be between 0 and 100")
+			return reportError(cmd, styles, "Fuzzy threshold must be between 0 and 100")
 		}
 	} else if listRegex {
 		filter.SearchMode = "regex"
@@ -256,22 +281,23 @@ func runList(cmd *cobra.Command, args []string) error {
 		filter.Offset = (listPage - 1) * pageSize
 	}
 
+	// regex searches stop early on huge scans but still list what they found
 	totalCount, err := repo.Count(ctx, filter)
-	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to count tasks: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("failed to count tasks: %w", err)
+	truncated := errors.Is(err, repository.ErrSearchTruncated)
+	if err != nil && !truncated {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to count tasks: %v", err))
 	}
 
 	tasks, err := repo.List(ctx, filter)
-	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list tasks: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("failed to list tasks: %w", err)
+	if errors.Is(err, repository.ErrSearchTruncated) {
+		truncated = true
+	} else if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to list tasks: %v", err))
+	}
+
+	if listIDsOnly {
+		printTaskIDs(os.Stdout, tasks)
+		return nil
 	}
 
 	if len(tasks) == 0 && listCLI {
@@ -293,9 +319,15 @@ func runList(cmd *cobra.Command, args []string) error {
 			totalPages = 1
 		}
 
-		displayTasksTable(tasks, styles, filter, listPage, totalPages, totalCount)
+		displayTasksTable(tasks, styles, filter, listPage, totalPages, totalCount, cfg.OverdueGraceDays)
+		if truncated {
+			fmt.Println(styles.Info.Render(searchTruncatedNote))
+		}
 	} else {
-		model := tui.NewModel(repo, projectRepo, viewRepo, searchHistoryRepo, filter, pageSize, themeObj, styles)
+		model, err := newTUIModel(ctx, db, cfg, filter, pageSize, themeObj, styles)
+		if err != nil {
+			return err
+		}
 		p := tea.NewProgram(model, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -309,39 +341,43 @@ func runList(cmd *cobra.Command, args []string) error {
 // handles --query flag using the query language parser
 func runListWithQueryLanguage(
 	ctx context.Context,
-	repo repository.TaskRepository,
-	projectRepo repository.ProjectRepository,
-	viewRepo repository.ViewRepository,
-	searchHistoryRepo repository.SearchHistoryRepository,
+	cmd *cobra.Command,
+	db *sqlite.DB,
 	cfg *config.Config,
 	themeObj *theme.Theme,
 	styles *theme.Styles,
 	pageSize int,
 ) error {
+	repo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+
 	parsed, err := query.ParseQuery(listQuery)
 	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Query parse error: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("query parse error: %w", err)
+		return reportError(cmd, styles, fmt.Sprintf("Query parse error: %v", err))
 	}
 
 	converterCtx := &query.ConverterContext{
-		ProjectRepo: projectRepo,
+		ProjectRepo:      projectRepo,
+		OverdueGraceDays: cfg.OverdueGraceDays,
 	}
 
 	filter, err := query.ConvertToTaskFilter(ctx, parsed, converterCtx)
 	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Query conversion error: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("query conversion error: %w", err)
+		return reportError(cmd, styles, fmt.Sprintf("Query conversion error: %v", err))
 	}
 
 	filter.SortBy = listSortBy
 	filter.SortOrder = listSortOrder
+	filter.PinnedFirst = cfg.PinnedFirst
+	filter.FuzzyAlgorithm = cfg.FuzzyAlgorithm
+	if listPinned {
+		filter.PinnedOnly = true
+	}
+	if listWaiting {
+		filter.WaitingOnly = true
+	}
+	filter.HideSnoozed = !listSnoozed
+	filter.HideArchived = !listArchived
 
 	if !listAll && listCLI {
 		if listPage < 1 {
@@ -353,20 +389,17 @@ func runListWithQueryLanguage(
 
 	totalCount, err := repo.Count(ctx, filter)
 	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to count tasks: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("failed to count tasks: %w", err)
+		return reportError(cmd, styles, fmt.Sprintf("Failed to count tasks: %v", err))
 	}
 
 	tasks, err := repo.List(ctx, filter)
 	if err != nil {
-		if listCLI {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list tasks: %v", err)))
-			return nil
-		}
-		return fmt.Errorf("failed to list tasks: %w", err)
+		return reportError(cmd, styles, fmt.Sprintf("Failed to list tasks: %v", err))
+	}
+
+	if listIDsOnly {
+		printTaskIDs(os.Stdout, tasks)
+		return nil
 	}
 
 	if len(tasks) == 0 && listCLI {
@@ -386,9 +419,12 @@ func runListWithQueryLanguage(
 
 		fmt.Println()
 		fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Query: %s", listQuery)))
-		displayTasksTable(tasks, styles, filter, listPage, totalPages, totalCount)
+		displayTasksTable(tasks, styles, filter, listPage, totalPages, totalCount, cfg.OverdueGraceDays)
 	} else {
-		model := tui.NewModel(repo, projectRepo, viewRepo, searchHistoryRepo, filter, pageSize, themeObj, styles)
+		model, err := newTUIModel(ctx, db, cfg, filter, pageSize, themeObj, styles)
+		if err != nil {
+			return err
+		}
 		p := tea.NewProgram(model, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -399,11 +435,48 @@ func runListWithQueryLanguage(
 	return nil
 }
 
+// the TUI on db's repositories. the settings the repositories enforce come
+// from db so the TUI checks the same rules, the display settings from cfg
+func newTUIModel(ctx context.Context, db *sqlite.DB, cfg *config.Config, filter repository.TaskFilter, pageSize int, themeObj *theme.Theme, styles *theme.Styles) (tui.Model, error) {
+	projectRepo := sqlite.NewProjectRepository(db)
+	model := tui.NewModel(sqlite.NewTaskRepository(db), projectRepo, sqlite.NewViewRepository(db), sqlite.NewSearchHistoryRepository(db), filter, pageSize, themeObj, styles)
+
+	projectOrder, err := projectOrderFromConfig(cfg)
+	if err != nil {
+		return model, err
+	}
+	if err := model.SetQuickKeys(cfg.QuickKeys); err != nil {
+		return model, fmt.Errorf("invalid quick_keys config: %w", err)
+	}
+
+	model.SetStatusTransitions(db.StatusTransitions())
+	model.SetOverdueGraceDays(db.OverdueGraceDays())
+	model.SetCancelledTreatment(db.CancelledTreatment())
+	model.SetReadOnly(db.ReadOnly())
+
+	model.SetThemeSaver(config.UpdateTheme)
+	model.SetColumns(cfg.Columns)
+	model.SetColumnSaver(config.UpdateColumns)
+	model.SetCompact(cfg.CompactMode)
+	model.SetConfirmTimeout(time.Duration(cfg.ConfirmTimeout) * time.Second)
+	model.SetBulkMaxAffected(cfg.BulkMaxAffected)
+	model.SetInheritProjectStyle(cfg.InheritProjectStyle)
+	model.SetProjectJumpWrap(cfg.ProjectJumpWrap)
+	model.SetProjectOrder(projectOrder)
+	model.SetShowViewCounts(cfg.ViewCounts)
+	model.SetFadeDone(cfg.FadeDoneTasks)
+	model.SetPinnedFirst(cfg.PinnedFirst)
+	model.SetDefaultProject(defaultProjectName(ctx, projectRepo, cfg.DefaultProject, styles))
+	return model, nil
+}
+
 func hasActiveFilters(filter repository.TaskFilter) bool {
 	return filter.Status != "" ||
 		filter.Priority != "" ||
 		filter.ProjectID != nil ||
 		len(filter.Tags) > 0 ||
+		filter.PinnedOnly ||
+		filter.WaitingOnly ||
 		filter.SearchQuery != ""
 }
 
@@ -412,10 +485,10 @@ func displayActiveFilters(filter repository.TaskFilter, styles *theme.Styles) {
 	fmt.Println(styles.Subtitle.Render("Active filters:"))
 
 	if filter.Status != "" {
-		fmt.Printf("  Status: %s\n", filter.Status)
+		fmt.Printf("  Status: %s\n", styles.StatusLabel(filter.Status))
 	}
 	if filter.Priority != "" {
-		fmt.Printf("  Priority: %s\n", filter.Priority)
+		fmt.Printf("  Priority: %s\n", styles.PriorityLabel(filter.Priority))
 	}
 	if filter.ProjectID != nil {
 		fmt.Printf("  Project ID: %d\n", *filter.ProjectID)
@@ -423,6 +496,12 @@ func displayActiveFilters(filter repository.TaskFilter, styles *theme.Styles) {
 	if len(filter.Tags) > 0 {
 		fmt.Printf("  Tags: %s\n", strings.Join(filter.Tags, ", "))
 	}
+	if filter.PinnedOnly {
+		fmt.Println("  Pinned only")
+	}
+	if filter.WaitingOnly {
+		fmt.Println("  Waiting on someone")
+	}
 	if filter.SearchQuery != "" {
 		mode := "text"
 		if filter.SearchMode == "regex" {
@@ -437,7 +516,7 @@ func displayActiveFilters(filter repository.TaskFilter, styles *theme.Styles) {
 	}
 }
 
-func displayTasksTable(tasks []*domain.Task, styles *theme.Styles, filter repository.TaskFilter, currentPage, totalPages int, totalCount int64) {
+func displayTasksTable(tasks []*domain.Task, styles *theme.Styles, filter repository.TaskFilter, currentPage, totalPages int, totalCount int64, graceDays int) {
 	fmt.Println()
 
 	if hasActiveFilters(filter) {
@@ -459,7 +538,7 @@ func displayTasksTable(tasks []*domain.Task, styles *theme.Styles, filter reposi
 	fmt.Println(styles.Separator.Render(separator))
 
 	for _, task := range tasks {
-		printTaskRow(task, styles)
+		printTaskRow(task, styles, graceDays)
 	}
 
 	fmt.Println()
@@ -484,51 +563,45 @@ func displayTasksTable(tasks []*domain.Task, styles *theme.Styles, filter reposi
 	fmt.Println()
 }
 
-func printTaskRow(task *domain.Task, styles *theme.Styles) {
+func printTaskRow(task *domain.Task, styles *theme.Styles, graceDays int) {
 	rowStyle := styles.GetPriorityStyle(task.Priority)
 
 	// status
-	statusIcon := display.GetStatusIcon(task.Status)
-	status := fmt.Sprintf("%s %s", statusIcon, task.Status)
+	statusIcon := styles.StatusSymbol(task.Status)
+	status := fmt.Sprintf("%s %s", statusIcon, styles.StatusLabel(task.Status))
 
 	// priority
-	priorityIcon := display.GetPriorityIcon(task.Priority)
-	priority := fmt.Sprintf("%s %s", priorityIcon, task.Priority)
+	priorityIcon := styles.PrioritySymbol(task.Priority)
+	priority := fmt.Sprintf("%s %s", priorityIcon, styles.PriorityLabel(task.Priority))
 
 	// truncate title
-	title := task.Title
-	if len(title) > 40 {
-		title = title[:37] + "..."
-	}
+	title := display.FormatTaskTitle(task, 40)
 
 	// format project
-	project := task.ProjectName
+	project := display.TruncateText(display.TaskProjectLabel(task), 15)
 	if project == "" {
 		project = "-"
 	}
 
 	// format tags
-	tags := strings.Join(task.Tags, ", ")
+	tags := display.TruncateText(strings.Join(task.Tags, ", "), 20)
 	if tags == "" {
 		tags = "-"
 	}
-	if len(tags) > 20 {
-		tags = tags[:17] + "..."
-	}
 
 	// format due date
 	dueDate := "-"
 	if task.DueDate != nil {
-		dueDate = display.FormatDueDate(task.DueDate)
+		dueDate = display.FormatDueDate(task.DueDate, time.Now(), graceDays)
 	}
 
 	// format cells
 	cells := []string{
 		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-15s", status))),
 		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-12s", priority))),
-		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-40s", title))),
-		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-15s", project))),
-		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-20s", tags))),
+		rowStyle.Render(styles.Cell.Render(display.PadText(title, 40))),
+		rowStyle.Render(styles.Cell.Render(display.PadText(project, 15))),
+		rowStyle.Render(styles.Cell.Render(display.PadText(tags, 20))),
 		rowStyle.Render(styles.Cell.Render(fmt.Sprintf("%-12s", dueDate))),
 	}
 
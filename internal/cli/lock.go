@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var lockCmd = &cobra.Command{
+	Use:   "lock <task-id>",
+	Short: "Lock a task against edits and deletes",
+	Long: `Lock a task so it can't be edited or deleted until it is unlocked, e.g. a
+reference task that should stay as it is.
+
+Locked tasks show a 🔒 in the task table. Bulk operations skip them and report
+how many were skipped. Pinning and snoozing still work.
+
+Examples:
+  taskflow lock 12
+  taskflow unlock 12`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetLocked(cmd, args[0], true)
+	},
+}
+
+var unlockCmd = &cobra.Command{
+	Use:         "unlock <task-id>",
+	Short:       "Unlock a locked task",
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetLocked(cmd, args[0], false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+	rootCmd.AddCommand(unlockCmd)
+}
+
+func runSetLocked(cmd *cobra.Command, arg string, locked bool) error {
+	taskID, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", arg)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	task, err := repo.GetByID(ctx, taskID)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	if task.IsLocked == locked {
+		if locked {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("Task #%d is already locked", task.ID)))
+		} else {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("Task #%d isn't locked", task.ID)))
+		}
+		return nil
+	}
+
+	if err := repo.SetLocked(ctx, task.ID, locked); err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to update lock: %v", err))
+	}
+
+	if locked {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d locked (🔒)", task.ID)))
+	} else {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d unlocked", task.ID)))
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	logSince  string
+	logLimit  int
+	logFormat string
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [task-id]",
+	Short: "Show the change history of a task or of all tasks",
+	Long: `Show the audit log: every task created, deleted and every field changed,
+with the old and new value, newest first.
+
+With a task ID only that task's history is shown, which still works after the
+task was deleted. Without one, recent activity across all tasks is shown.
+
+--since accepts the same values as the query language's due: field; a bare
+offset like 7d counts back from now.
+
+Examples:
+  taskflow log 12
+  taskflow log --since 7d
+  taskflow log --since yesterday --format json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLog,
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+
+	logCmd.Flags().StringVar(&logSince, "since", "", "Only show changes since this date (e.g. 7d, yesterday, 2025-01-31)")
+	logCmd.Flags().IntVarP(&logLimit, "limit", "n", 100, "Maximum number of entries to show (0 for all)")
+	logCmd.Flags().StringVarP(&logFormat, "format", "f", "text", "Output format (text, json)")
+	logCmd.RegisterFlagCompletionFunc("since", cobra.FixedCompletions([]string{"7d", "yesterday", "today"}, cobra.ShellCompDirectiveNoFileComp))
+	logCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runLog(cmd *cobra.Command, args []string) error {
+	if logFormat != "text" && logFormat != "json" {
+		return fmt.Errorf("unsupported format: %s (use text or json)", logFormat)
+	}
+
+	filter := repository.AuditFilter{Limit: max(logLimit, 0)}
+	if len(args) > 0 {
+		taskID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid task ID: %s", args[0])
+		}
+		filter.TaskID = &taskID
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	if logSince != "" {
+		since, err := parseLogSince(logSince)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Invalid --since value %q", logSince)))
+			return nil
+		}
+		filter.Since = since
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	entries, err := repo.ListAudit(ctx, filter)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to read the log: %v", err)))
+		return nil
+	}
+
+	if logFormat == "json" {
+		if entries == nil {
+			entries = []*domain.TaskAuditEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode log: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Println()
+	if filter.TaskID != nil {
+		fmt.Println(styles.Title.Render(fmt.Sprintf("History of task #%d", *filter.TaskID)))
+	} else {
+		fmt.Println(styles.Title.Render("Recent activity"))
+	}
+
+	if len(entries) == 0 {
+		fmt.Println(styles.Info.Render("No changes recorded."))
+		fmt.Println()
+		return nil
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("  %s  #%-5d %s\n",
+			styles.Info.Render(entry.ChangedAt.Local().Format("2006-01-02 15:04")),
+			entry.TaskID,
+			formatAuditEntry(entry))
+	}
+	if logLimit > 0 && len(entries) == logLimit {
+		fmt.Println()
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Showing the latest %d entries, use --limit 0 for all.", logLimit)))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+var bareOffset = regexp.MustCompile(`^\d+[dwMy]$`)
+
+// parses --since. unlike a due date, a bare offset like 7d means 7 days ago
+func parseLogSince(value string) (*time.Time, error) {
+	if bareOffset.MatchString(value) {
+		value = "-" + value
+	}
+	since, _, err := query.ParseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	if since == nil {
+		return nil, fmt.Errorf("no date given")
+	}
+	return since, nil
+}
+
+func formatAuditEntry(entry *domain.TaskAuditEntry) string {
+	switch entry.Action {
+	case domain.AuditActionCreate:
+		return fmt.Sprintf("created %q", display.TruncateText(entry.NewValue, 60))
+	case domain.AuditActionDelete:
+		return fmt.Sprintf("deleted %q", display.TruncateText(entry.OldValue, 60))
+	default:
+		return fmt.Sprintf("%s: %s → %s", entry.Field, formatAuditValue(entry.OldValue), formatAuditValue(entry.NewValue))
+	}
+}
+
+func formatAuditValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return display.TruncateText(value, 40)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <task-id>",
+	Short: "Toggle the pin on a task",
+	Long: `Pin a task for quick access, or unpin it if it is already pinned.
+
+Pinned tasks show a 📌 in the task table and can be listed with
+'taskflow list --pinned'. Set pinned_first: true in the config to always sort
+them to the top.
+
+Examples:
+  taskflow pin 12
+  taskflow list --pinned --cli`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runPin,
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd)
+}
+
+func runPin(cmd *cobra.Command, args []string) error {
+	taskID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	task, err := repo.GetByID(ctx, taskID)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	pinned := !task.IsPinned
+	if err := repo.SetPinned(ctx, task.ID, pinned); err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to update pin: %v", err))
+	}
+
+	if pinned {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d pinned (📌)", task.ID)))
+	} else {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d unpinned", task.ID)))
+	}
+
+	return nil
+}
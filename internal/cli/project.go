@@ -3,19 +3,26 @@ package cli
 import (
 	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"task-management/internal/config"
+	"task-management/internal/display"
 	"task-management/internal/domain"
 	"task-management/internal/repository"
 	"task-management/internal/repository/sqlite"
 	"task-management/internal/theme"
+	"task-management/internal/tui"
 )
 
 var projectCmd = &cobra.Command{
@@ -50,6 +57,7 @@ func init() {
 	projectCmd.AddCommand(projectAliasesCmd)
 	projectCmd.AddCommand(projectUnaliasCmd)
 	projectCmd.AddCommand(projectNoteCmd)
+	projectCmd.AddCommand(projectMergeCmd)
 }
 
 
@@ -68,13 +76,17 @@ var projectAddCmd = &cobra.Command{
 	Short: "Create a new project",
 	Long: `Create a new project with optional parent hierarchy.
 
+With inherit_project_style enabled in the config, a child project created
+without a color or icon takes them from its parent.
+
 Examples:
   taskflow project add "Backend"
   taskflow project add "API Service" --parent "Backend"
   taskflow project add "Web App" --color blue --icon 🚀
   taskflow project add "Frontend" --description "UI tasks" --favorite`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runProjectAdd,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectAdd,
 }
 
 func init() {
@@ -104,7 +116,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -128,12 +140,11 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		name = args[0]
 	} else {
-		name, err = promptForInput("Project name", "")
+		name, err = promptForName("Project name", func(name string) error {
+			return domain.ValidateName("project", name)
+		})
 		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
-		}
-		if name == "" {
-			return fmt.Errorf("project name is required")
+			return err
 		}
 	}
 
@@ -143,7 +154,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	project.Notes = addProjectNotes
 
 	if addProjectParent != "" {
-		parentID, err := lookupProjectID(ctx, repo, addProjectParent)
+		parentID, err := promptProjectID(ctx, repo, addProjectParent)
 		if err != nil {
 			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 			return nil
@@ -152,7 +163,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	} else if !cmd.Flags().Changed("parent") {
 		fmt.Println()
 		fmt.Println(styles.Info.Render("Select parent project (optional):"))
-		parentID, err := selectParentProject(repo, ctx, styles, 0)
+		parentID, err := selectParentProject(repo, ctx, styles, 0, cfg.ProjectPathWidth)
 		if err != nil {
 			return fmt.Errorf("failed to select parent: %w", err)
 		}
@@ -170,7 +181,20 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 
 	if addProjectColor != "" {
 		project.Color = addProjectColor
-	} else if !cmd.Flags().Changed("color") && project.Color == "" {
+	}
+	if addProjectIcon != "" {
+		project.Icon = addProjectIcon
+	}
+
+	var inherited, parentName string
+	if cfg.InheritProjectStyle && project.ParentID != nil {
+		if parent, err := repo.GetByID(ctx, *project.ParentID); err == nil {
+			inherited = project.InheritStyle(parent)
+			parentName = parent.Name
+		}
+	}
+
+	if !cmd.Flags().Changed("color") && project.Color == "" {
 		color, err := promptForColor(styles)
 		if err != nil {
 			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
@@ -179,9 +203,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 		project.Color = color
 	}
 
-	if addProjectIcon != "" {
-		project.Icon = addProjectIcon
-	} else if !cmd.Flags().Changed("icon") && project.Icon == "" {
+	if !cmd.Flags().Changed("icon") && project.Icon == "" {
 		icon, err := promptForIcon(styles)
 		if err != nil {
 			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
@@ -203,6 +225,10 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := repo.Create(ctx, project); err != nil {
+		if existing := repository.DuplicateProject(ctx, repo, project.Name, err); existing != nil {
+			printDuplicateProject(existing, styles)
+			return nil
+		}
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create project: %v", err)))
 		return nil
 	}
@@ -210,10 +236,11 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	tasksCreated := 0
 	if template != nil && len(template.TaskDefinitions) > 0 {
 		for _, taskDef := range template.TaskDefinitions {
-			task := domain.NewTask(taskDef.Title)
-			task.Description = taskDef.Description
-			task.Priority = domain.Priority(taskDef.Priority)
-			task.Tags = taskDef.Tags
+			task, err := taskDef.NewTask()
+			if err != nil {
+				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task '%s': %v", taskDef.Title, err)))
+				continue
+			}
 			task.ProjectID = &project.ID
 
 			if err := taskRepo.Create(ctx, task); err != nil {
@@ -226,7 +253,12 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 
 	project, err = repo.GetByID(ctx, project.ID)
 	if err == nil {
-		displayProjectCreated(project, styles)
+		loadProjectParents(ctx, repo, project)
+		displayProjectCreated(project, cfg.ProjectPathWidth, styles)
+		if inherited != "" {
+			fmt.Printf("  %s %s from '%s'\n", styles.Info.Render("Inherited:"), inherited, parentName)
+			fmt.Println()
+		}
 		if tasksCreated > 0 {
 			fmt.Printf("  %s %d task(s) created from template '%s'\n",
 				styles.Info.Render("Tasks:"), tasksCreated, template.Name)
@@ -242,7 +274,7 @@ func runProjectAdd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func displayProjectCreated(project *domain.Project, styles *theme.Styles) {
+func displayProjectCreated(project *domain.Project, pathWidth int, styles *theme.Styles) {
 	fmt.Println()
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Project #%d created successfully!", project.ID)))
 	fmt.Println()
@@ -258,14 +290,14 @@ func displayProjectCreated(project *domain.Project, styles *theme.Styles) {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Description:"), project.Description)
 	}
 
-	path := project.BuildPath()
+	path := display.ShortenPath(project.PathNames(), pathWidth)
 	fmt.Printf("  %s %s\n", styles.Info.Render("Path:"), path)
 
 	if project.Color != "" {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Color:"), project.Color)
 	}
 
-	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), project.Status)
+	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), styles.ProjectStatusLabel(project.Status))
 
 	if project.IsFavorite {
 		fmt.Printf("  %s ★\n", styles.Info.Render("Favorite:"))
@@ -324,7 +356,7 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -355,24 +387,51 @@ func runProjectList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	displayProjectTree(projects, listProjectStats, repo, ctx, styles)
+	order, err := projectOrderFromConfig(cfg)
+	if err != nil {
+		return err
+	}
+	treatment, err := cfg.CancelledTreatment()
+	if err != nil {
+		return err
+	}
+
+	displayProjectTree(projects, listProjectStats, order, treatment, repo, ctx, styles)
 
 	return nil
 }
 
 
+var (
+	viewProjectJSON    bool
+	viewProjectContext bool
+)
+
 var projectViewCmd = &cobra.Command{
 	Use:   "view <id|name>",
 	Short: "View detailed project information",
 	Long: `View detailed information about a project including statistics.
 
+Paths wider than project_path_width in the config are shortened to
+"Dev > … > API". --json always includes the full path.
+
+--context adds, for a child project, its parent with the task counts of
+the parent's whole subtree, and its sibling projects.
+
 Examples:
   taskflow project view 1
-  taskflow project view "Backend"`,
+  taskflow project view "Backend"
+  taskflow project view API --context
+  taskflow project view API --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProjectView,
 }
 
+func init() {
+	projectViewCmd.Flags().BoolVar(&viewProjectJSON, "json", false, "Print the project as JSON, with its full path")
+	projectViewCmd.Flags().BoolVar(&viewProjectContext, "context", false, "Also show the parent project's task counts and the sibling projects")
+}
+
 func runProjectView(cmd *cobra.Command, args []string) error {
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -390,7 +449,7 @@ func runProjectView(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -399,7 +458,7 @@ func runProjectView(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -411,23 +470,60 @@ func runProjectView(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	stats, err := repo.GetTaskCountByStatus(ctx, project.ID)
-	if err != nil {
+	var (
+		stats                          map[domain.Status]int
+		timeToDone                     *domain.TimeToDoneStats
+		children                       []*domain.Project
+		statsErr, timeErr, childrenErr error
+	)
+	var wg sync.WaitGroup
+	wg.Go(func() { stats, statsErr = repo.GetTaskCountByStatus(ctx, project.ID) })
+	wg.Go(func() { timeToDone, timeErr = repo.GetTimeToDone(ctx, project.ID) })
+	wg.Go(func() { children, childrenErr = repo.GetChildren(ctx, project.ID) })
+	wg.Wait()
+
+	if err := errors.Join(statsErr, timeErr); err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to load statistics: %v", err)))
 		return nil
 	}
+	if childrenErr != nil {
+		children = []*domain.Project{}
+	}
+
+	loadProjectParents(ctx, repo, project)
 
-	children, err := repo.GetChildren(ctx, project.ID)
+	if viewProjectJSON {
+		project.Path = project.BuildPath()
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		// keeps the " > " in paths readable
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(project); err != nil {
+			return fmt.Errorf("failed to encode project: %w", err)
+		}
+		return nil
+	}
+
+	var projCtx *projectContext
+	if viewProjectContext {
+		projCtx, err = loadProjectContext(ctx, repo, project)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to load project context: %v", err)))
+			return nil
+		}
+	}
+
+	treatment, err := cfg.CancelledTreatment()
 	if err != nil {
-		children = []*domain.Project{}
+		return err
 	}
 
-	displayProjectDetails(project, stats, children, styles)
+	displayProjectDetails(project, stats, timeToDone, children, projCtx, cfg.ProjectPathWidth, treatment, styles)
 
 	return nil
 }
 
-func displayProjectDetails(project *domain.Project, stats map[domain.Status]int, children []*domain.Project, styles *theme.Styles) {
+func displayProjectDetails(project *domain.Project, stats map[domain.Status]int, timeToDone *domain.TimeToDoneStats, children []*domain.Project, projCtx *projectContext, pathWidth int, treatment domain.CancelledTreatment, styles *theme.Styles) {
 	fmt.Println()
 
 	icon := project.Icon
@@ -439,24 +535,36 @@ func displayProjectDetails(project *domain.Project, stats map[domain.Status]int,
 	fmt.Println(styles.Title.Render(title))
 	fmt.Println()
 
-	fmt.Printf("  %s %s", styles.Info.Render("Status:"), project.Status)
+	fmt.Printf("  %s %s", styles.Info.Render("Status:"), styles.ProjectStatusLabel(project.Status))
 	if project.IsFavorite {
 		fmt.Print(" ★")
 	}
 	fmt.Println()
 
-	path := project.BuildPath()
-	if path != project.Name {
+	if project.Parent != nil {
+		path := display.ShortenPath(project.PathNames(), pathWidth)
 		fmt.Printf("  %s %s\n", styles.Info.Render("Path:"), path)
 	}
 
 	fmt.Printf("  %s %s\n", styles.Info.Render("Created:"), project.CreatedAt.Format("2006-01-02 15:04"))
 	fmt.Printf("  %s %s\n", styles.Info.Render("Updated:"), project.UpdatedAt.Format("2006-01-02 15:04"))
 
+	if project.KeyPrefix != "" {
+		fmt.Printf("  %s %s-1, %s-2, ...\n", styles.Info.Render("Task keys:"), project.KeyPrefix, project.KeyPrefix)
+	}
+
 	if project.Color != "" {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Color:"), project.Color)
 	}
 
+	if project.HasSprints() {
+		sprint := fmt.Sprintf("%d (%s)", project.SprintNumber, project.SprintCadence)
+		if end := project.SprintEndsAt(); end != nil {
+			sprint += ", ends " + end.Format("2006-01-02")
+		}
+		fmt.Printf("  %s %s\n", styles.Info.Render("Sprint:"), sprint)
+	}
+
 	if len(project.Aliases) > 0 {
 		fmt.Println()
 		fmt.Println(styles.Subtitle.Render("Aliases:"))
@@ -493,7 +601,28 @@ func displayProjectDetails(project *domain.Project, stats map[domain.Status]int,
 
 	fmt.Println()
 	fmt.Println(styles.Subtitle.Render("Task Statistics:"))
-	fmt.Printf("  %s\n", formatProjectStats(stats, styles))
+	fmt.Printf("  %s\n", formatProjectStats(stats, treatment, styles))
+	if timeToDone != nil && timeToDone.Count > 0 {
+		fmt.Printf("  Time to done: avg %s, median %s (%d completed)\n",
+			display.FormatElapsed(timeToDone.Average), display.FormatElapsed(timeToDone.Median), timeToDone.Count)
+	}
+
+	if projCtx != nil {
+		fmt.Println()
+		fmt.Println(styles.Subtitle.Render("Context:"))
+		parentPath := display.ShortenPath(projCtx.Parent.PathNames(), pathWidth)
+		fmt.Printf("  %s %s (ID: %d)\n", styles.Info.Render("Parent:"), parentPath, projCtx.Parent.ID)
+		fmt.Printf("  %s %s\n", styles.Info.Render("Parent tasks:"), formatProjectStats(projCtx.ParentStats, treatment, styles))
+		if len(projCtx.Siblings) > 0 {
+			names := make([]string, len(projCtx.Siblings))
+			for i, sibling := range projCtx.Siblings {
+				names[i] = sibling.Name
+			}
+			fmt.Printf("  %s %s\n", styles.Info.Render(fmt.Sprintf("Siblings (%d):", len(names))), strings.Join(names, ", "))
+		} else {
+			fmt.Printf("  %s none\n", styles.Info.Render("Siblings:"))
+		}
+	}
 
 	if len(children) > 0 {
 		fmt.Println()
@@ -528,8 +657,9 @@ Examples:
   taskflow project delete 1
   taskflow project delete "Backend"
   taskflow project delete 1 --confirm`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectDelete,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectDelete,
 }
 
 func init() {
@@ -553,7 +683,7 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -562,7 +692,7 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -574,6 +704,11 @@ func runProjectDelete(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if project.IsInbox() {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ The %s project is reserved and cannot be deleted", project.Name)))
+		return nil
+	}
+
 	descendants, err := repo.GetDescendants(ctx, project.ID)
 	if err != nil {
 		descendants = []*domain.Project{}
@@ -643,6 +778,34 @@ func promptForInput(prompt string, defaultVal string) (string, error) {
 	return input, nil
 }
 
+// blank or invalid answers a name prompt takes before giving up
+const maxNamePromptAttempts = 3
+
+// prompts for a required name until validate accepts it. errors instead of
+// waiting on stdin when it isn't a terminal
+func promptForName(prompt string, validate func(string) error) (string, error) {
+	if !isInteractiveTerminal() {
+		return "", fmt.Errorf("%s is required when stdin is not a terminal", strings.ToLower(prompt))
+	}
+	return readName(bufio.NewReader(os.Stdin), os.Stdout, prompt, validate)
+}
+
+func readName(r *bufio.Reader, w io.Writer, prompt string, validate func(string) error) (string, error) {
+	var err error
+	for attempt := 0; attempt < maxNamePromptAttempts; attempt++ {
+		var name string
+		name, err = readPromptLine(r, w, prompt)
+		if err != nil {
+			return "", err
+		}
+		if err = validate(name); err == nil {
+			return name, nil
+		}
+		fmt.Fprintf(w, "  %v\n", err)
+	}
+	return "", fmt.Errorf("no valid %s after %d attempts: %w", strings.ToLower(prompt), maxNamePromptAttempts, err)
+}
+
 func promptForConfirmation(prompt string) bool {
 	reader := bufio.NewReader(os.Stdin)
 
@@ -656,7 +819,7 @@ func promptForConfirmation(prompt string) bool {
 	return input == "y" || input == "yes"
 }
 
-func buildTreeView(projects []*domain.Project, parentID *int64, prefix string, isLast bool, includeStats bool, taskCounts map[int64]int, styles *theme.Styles) []string {
+func buildTreeView(projects []*domain.Project, parentID *int64, prefix string, isLast bool, includeStats bool, taskCounts map[int64]int, completion map[int64]float64, order domain.ProjectOrder, styles *theme.Styles) []string {
 	lines := []string{}
 
 	children := []*domain.Project{}
@@ -667,6 +830,7 @@ func buildTreeView(projects []*domain.Project, parentID *int64, prefix string, i
 			children = append(children, p)
 		}
 	}
+	order.Sort(children)
 
 	for i, project := range children {
 		isLastChild := i == len(children)-1
@@ -695,21 +859,21 @@ func buildTreeView(projects []*domain.Project, parentID *int64, prefix string, i
 		if includeStats {
 			taskCount := taskCounts[project.ID]
 			if taskCount > 0 {
-				statsStr = fmt.Sprintf(" (%d tasks)", taskCount)
+				statsStr = fmt.Sprintf(" (%d tasks, %d%% done)", taskCount, int(completion[project.ID]))
 			}
 		}
 
 		line := fmt.Sprintf("%s%s%s %s%s%s", prefix, connector, icon, name, statsStr, favorite)
 		lines = append(lines, line)
 
-		childLines := buildTreeView(projects, &project.ID, prefix+extension, isLastChild, includeStats, taskCounts, styles)
+		childLines := buildTreeView(projects, &project.ID, prefix+extension, isLastChild, includeStats, taskCounts, completion, order, styles)
 		lines = append(lines, childLines...)
 	}
 
 	return lines
 }
 
-func displayProjectTree(projects []*domain.Project, includeStats bool, repo repository.ProjectRepository, ctx context.Context, styles *theme.Styles) {
+func displayProjectTree(projects []*domain.Project, includeStats bool, order domain.ProjectOrder, treatment domain.CancelledTreatment, repo repository.ProjectRepository, ctx context.Context, styles *theme.Styles) {
 	if len(projects) == 0 {
 		fmt.Println()
 		fmt.Println(styles.Info.Render("No projects found."))
@@ -718,20 +882,19 @@ func displayProjectTree(projects []*domain.Project, includeStats bool, repo repo
 	}
 
 	taskCounts := make(map[int64]int)
-	if includeStats {
-		for _, p := range projects {
-			count, err := repo.GetTaskCount(ctx, p.ID)
-			if err == nil {
-				taskCounts[p.ID] = count
-			}
+	completion := make(map[int64]float64)
+	if includeStats || order.By == domain.ProjectSortTaskCount {
+		for id, byStatus := range projectStatusCounts(ctx, repo, projects, projectStatsWorkers) {
+			taskCounts[id], completion[id] = completionOf(byStatus, treatment)
 		}
 	}
+	order.TaskCounts = taskCounts
 
 	fmt.Println()
 	fmt.Println(styles.Title.Render("Projects"))
 	fmt.Println()
 
-	lines := buildTreeView(projects, nil, "", false, includeStats, taskCounts, styles)
+	lines := buildTreeView(projects, nil, "", false, includeStats, taskCounts, completion, order, styles)
 	for _, line := range lines {
 		fmt.Println(line)
 	}
@@ -821,7 +984,7 @@ func promptForIcon(styles *theme.Styles) (string, error) {
 	return input, nil
 }
 
-func selectParentProject(repo repository.ProjectRepository, ctx context.Context, styles *theme.Styles, excludeID int64) (*int64, error) {
+func selectParentProject(repo repository.ProjectRepository, ctx context.Context, styles *theme.Styles, excludeID int64, pathWidth int) (*int64, error) {
 	filter := repository.ProjectFilter{
 		ExcludeArchived: true,
 		SortBy:          "name",
@@ -832,6 +995,7 @@ func selectParentProject(repo repository.ProjectRepository, ctx context.Context,
 	if err != nil {
 		return nil, fmt.Errorf("failed to list projects: %w", err)
 	}
+	domain.LinkParents(projects)
 
 	filtered := []*domain.Project{}
 	for _, p := range projects {
@@ -854,7 +1018,7 @@ func selectParentProject(repo repository.ProjectRepository, ctx context.Context,
 		if icon == "" {
 			icon = "📦"
 		}
-		path := p.BuildPath()
+		path := display.ShortenPath(p.PathNames(), pathWidth)
 		fmt.Printf("  %d. %s %s\n", i+1, icon, path)
 	}
 	fmt.Println()
@@ -881,37 +1045,35 @@ func selectParentProject(repo repository.ProjectRepository, ctx context.Context,
 	return &parentID, nil
 }
 
-func formatProjectStats(stats map[domain.Status]int, styles *theme.Styles) string {
-	total := 0
-	for _, count := range stats {
-		total += count
-	}
-
+func formatProjectStats(stats map[domain.Status]int, treatment domain.CancelledTreatment, styles *theme.Styles) string {
+	total, percentage := completionOf(stats, treatment)
 	if total == 0 {
 		return "No tasks"
 	}
 
-	completed := stats[domain.StatusCompleted]
-	percentage := 0
-	if total > 0 {
-		percentage = (completed * 100) / total
+	// labels read as prose here, in_progress becomes "in progress"
+	label := func(status domain.Status) string {
+		return strings.ReplaceAll(styles.StatusLabel(status), "_", " ")
 	}
 
 	parts := []string{}
-	if stats[domain.StatusPending] > 0 {
-		parts = append(parts, fmt.Sprintf("%d pending", stats[domain.StatusPending]))
-	}
-	if stats[domain.StatusInProgress] > 0 {
-		parts = append(parts, fmt.Sprintf("%d in progress", stats[domain.StatusInProgress]))
-	}
-	if completed > 0 {
-		parts = append(parts, fmt.Sprintf("%d completed (%d%%)", completed, percentage))
-	}
-	if stats[domain.StatusCancelled] > 0 {
-		parts = append(parts, fmt.Sprintf("%d cancelled", stats[domain.StatusCancelled]))
+	for _, status := range []domain.Status{domain.StatusPending, domain.StatusInProgress, domain.StatusCompleted, domain.StatusCancelled} {
+		if stats[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", stats[status], label(status)))
+		}
 	}
 
-	return fmt.Sprintf("Total: %d tasks (%s)", total, strings.Join(parts, ", "))
+	return fmt.Sprintf("Total: %d tasks, %d%% done (%s)", total, int(percentage), strings.Join(parts, ", "))
+}
+
+// the task total of counts by status and the percentage done, cancelled
+// tasks counted the way treatment says
+func completionOf(stats map[domain.Status]int, treatment domain.CancelledTreatment) (int, float64) {
+	total := 0
+	for _, count := range stats {
+		total += count
+	}
+	return total, domain.CompletionPercent(stats[domain.StatusCompleted], stats[domain.StatusCancelled], total, treatment)
 }
 
 
@@ -948,8 +1110,9 @@ Examples:
   taskflow project update 5 --icon 🚀 --color blue
   taskflow project update 1 --add-alias api-backend    # Add alias
   taskflow project update "Backend" --remove-alias old-name  # Remove alias`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectUpdate,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectUpdate,
 }
 
 func init() {
@@ -983,7 +1146,7 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -992,7 +1155,7 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1036,7 +1199,7 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 		if updateProjectNoParent || updateProjectParent == "" {
 			project.ParentID = nil
 		} else {
-			newParentID, err := lookupProjectID(ctx, repo, updateProjectParent)
+			newParentID, err := promptProjectID(ctx, repo, updateProjectParent)
 			if err != nil {
 				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 				return nil
@@ -1131,6 +1294,10 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := repo.Update(ctx, project); err != nil {
+		if existing := repository.DuplicateProject(ctx, repo, project.Name, err); existing != nil {
+			printDuplicateProject(existing, styles)
+			return nil
+		}
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to update project: %v", err)))
 		return nil
 	}
@@ -1141,12 +1308,13 @@ func runProjectUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	displayProjectUpdated(updated, styles)
+	loadProjectParents(ctx, repo, updated)
+	displayProjectUpdated(updated, cfg.ProjectPathWidth, styles)
 
 	return nil
 }
 
-func displayProjectUpdated(project *domain.Project, styles *theme.Styles) {
+func displayProjectUpdated(project *domain.Project, pathWidth int, styles *theme.Styles) {
 	fmt.Println()
 	icon := project.Icon
 	if icon == "" {
@@ -1155,10 +1323,10 @@ func displayProjectUpdated(project *domain.Project, styles *theme.Styles) {
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ %s %s (ID: %d) updated successfully!", icon, project.Name, project.ID)))
 	fmt.Println()
 
-	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), project.Status)
+	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), styles.ProjectStatusLabel(project.Status))
 
 	if project.ParentID != nil {
-		path := project.BuildPath()
+		path := display.ShortenPath(project.PathNames(), pathWidth)
 		fmt.Printf("  %s %s\n", styles.Info.Render("Path:"), path)
 	} else {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Type:"), "Root Project")
@@ -1187,8 +1355,9 @@ func displayProjectUpdated(project *domain.Project, styles *theme.Styles) {
 
 
 var (
-	archiveNoRecursive bool
-	archiveConfirm     bool
+	archiveNoRecursive     bool
+	archiveConfirm         bool
+	archiveCompletedTasksF bool
 )
 
 var projectArchiveCmd = &cobra.Command{
@@ -1199,20 +1368,34 @@ var projectArchiveCmd = &cobra.Command{
 By default, archiving a project also archives all its child projects (recursive).
 Use --no-recursive to archive only the specified project.
 
+Without a project argument, every active project matching --status, --favorite
+and --older-than is archived (with its children, unless --no-recursive).
+--older-than picks projects where neither the project nor any of its tasks
+changed in that long. A batch without filters needs --force.
+
+Use --archive-completed-tasks to also archive the completed tasks of every
+archived project (they are tagged "archived" and left out of 'taskflow list'
+unless --include-archived is given). Set archive_completed_tasks in the config
+file to make this the default.
+
 Archived projects can be viewed with 'taskflow project list --all' and can be
 restored using 'taskflow project unarchive'.
 
 Examples:
   taskflow project archive "Backend"           # Archive with children (default)
   taskflow project archive 1 --no-recursive    # Archive only this project
-  taskflow project archive 2 --confirm         # Skip confirmation prompt`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectArchive,
+  taskflow project archive 2 --confirm         # Skip confirmation prompt
+  taskflow project archive 3 --archive-completed-tasks
+  taskflow project archive --favorite=false --older-than 90d`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectArchive,
 }
 
 func init() {
 	projectArchiveCmd.Flags().BoolVar(&archiveNoRecursive, "no-recursive", false, "Archive only this project, not children")
 	projectArchiveCmd.Flags().BoolVarP(&archiveConfirm, "confirm", "y", false, "Skip confirmation prompt")
+	projectArchiveCmd.Flags().BoolVar(&archiveCompletedTasksF, "archive-completed-tasks", false, "Also archive the completed tasks of archived projects (default from config)")
 }
 
 func runProjectArchive(cmd *cobra.Command, args []string) error {
@@ -1232,16 +1415,29 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer db.Close()
 
 	repo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	archiveTasks := cfg.ArchiveCompletedTasks
+	if cmd.Flags().Changed("archive-completed-tasks") {
+		archiveTasks = archiveCompletedTasksF
+	}
+
+	if len(args) == 0 {
+		return runProjectArchiveBatch(ctx, cmd, repo, taskRepo, archiveTasks, styles)
+	}
+	if batchFlagsChanged(cmd) {
+		return reportError(cmd, styles, "Give either a project or filters, not both")
+	}
+
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1284,7 +1480,7 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 			icon = "📦"
 		}
 		fmt.Printf("Archive project '%s %s' (ID: %d)?\n", icon, project.Name, project.ID)
-		fmt.Printf("  - Status will change: %s → %s\n", project.Status, domain.ProjectStatusArchived)
+		fmt.Printf("  - Status will change: %s → %s\n", styles.ProjectStatusLabel(project.Status), styles.ProjectStatusLabel(domain.ProjectStatusArchived))
 
 		if !archiveNoRecursive && len(descendants) > 0 {
 			fmt.Printf("  - %d child project(s) will also be archived\n", len(descendants))
@@ -1296,6 +1492,16 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  - %d task(s) will remain accessible\n", taskCount)
 		}
 
+		if archiveTasks {
+			var completedCount int64
+			for _, p := range append([]*domain.Project{project}, descendants...) {
+				if count, err := taskRepo.Count(ctx, unarchivedCompletedFilter(p.ID)); err == nil {
+					completedCount += count
+				}
+			}
+			fmt.Printf("  - %d completed task(s) will be archived\n", completedCount)
+		}
+
 		fmt.Println()
 		if !promptForConfirmation("Proceed?") {
 			fmt.Println(styles.Info.Render("Archive cancelled."))
@@ -1308,17 +1514,26 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	archivedCount := 1
+	archivedIDs := []int64{project.ID}
 
 	if !archiveNoRecursive && len(descendants) > 0 {
 		for _, desc := range descendants {
 			if err := repo.Archive(ctx, desc.ID); err != nil {
 				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to archive child project '%s': %v", desc.Name, err)))
 			} else {
-				archivedCount++
+				archivedIDs = append(archivedIDs, desc.ID)
 			}
 		}
 	}
+	archivedCount := len(archivedIDs)
+
+	var archivedTasks int64
+	if archiveTasks {
+		archivedTasks, err = archiveCompletedTasks(ctx, taskRepo, archivedIDs)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		}
+	}
 
 	fmt.Println()
 	icon := project.Icon
@@ -1329,6 +1544,9 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 	if archivedCount > 1 {
 		fmt.Printf("  %d project(s) archived in total\n", archivedCount)
 	}
+	if archiveTasks {
+		fmt.Printf("  %d completed task(s) archived\n", archivedTasks)
+	}
 	if taskCount > 0 {
 		fmt.Printf("  %d task(s) preserved and remain accessible\n", taskCount)
 	}
@@ -1340,6 +1558,7 @@ func runProjectArchive(cmd *cobra.Command, args []string) error {
 
 var (
 	unarchiveRecursive bool
+	unarchiveConfirm   bool
 )
 
 var projectUnarchiveCmd = &cobra.Command{
@@ -1350,15 +1569,22 @@ var projectUnarchiveCmd = &cobra.Command{
 By default, only the specified project is unarchived. Use --recursive to also
 unarchive all child projects.
 
+Without a project argument, every archived project matching --favorite and
+--older-than is unarchived, after confirmation. A batch without filters needs
+--force.
+
 Examples:
   taskflow project unarchive "Backend"        # Unarchive only this project
-  taskflow project unarchive 1 --recursive    # Unarchive with all children`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectUnarchive,
+  taskflow project unarchive 1 --recursive    # Unarchive with all children
+  taskflow project unarchive --favorite       # Unarchive every archived favorite`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectUnarchive,
 }
 
 func init() {
 	projectUnarchiveCmd.Flags().BoolVarP(&unarchiveRecursive, "recursive", "r", false, "Also unarchive child projects")
+	projectUnarchiveCmd.Flags().BoolVarP(&unarchiveConfirm, "confirm", "y", false, "Skip the confirmation prompt of a batch unarchive")
 }
 
 func runProjectUnarchive(cmd *cobra.Command, args []string) error {
@@ -1378,7 +1604,7 @@ func runProjectUnarchive(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -1387,7 +1613,14 @@ func runProjectUnarchive(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	if len(args) == 0 {
+		return runProjectUnarchiveBatch(ctx, cmd, repo, styles)
+	}
+	if batchFlagsChanged(cmd) {
+		return reportError(cmd, styles, "Give either a project or filters, not both")
+	}
+
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1400,7 +1633,7 @@ func runProjectUnarchive(cmd *cobra.Command, args []string) error {
 	}
 
 	if project.Status != domain.ProjectStatusArchived {
-		fmt.Println(styles.Info.Render(fmt.Sprintf("Project '%s' is not archived (status: %s).", project.Name, project.Status)))
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Project '%s' is not archived (status: %s).", project.Name, styles.ProjectStatusLabel(project.Status))))
 		return nil
 	}
 
@@ -1462,8 +1695,9 @@ Examples:
   taskflow project alias "Backend" api-service
   taskflow project alias 1 "web-app"
   taskflow project alias "MyProject" proj-alias`,
-	Args: cobra.ExactArgs(2),
-	RunE: runProjectAlias,
+	Args:        cobra.ExactArgs(2),
+	Annotations: writesDatabase,
+	RunE:        runProjectAlias,
 }
 
 func runProjectAlias(cmd *cobra.Command, args []string) error {
@@ -1483,7 +1717,7 @@ func runProjectAlias(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -1492,7 +1726,7 @@ func runProjectAlias(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1583,7 +1817,7 @@ func runProjectAliases(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -1592,7 +1826,7 @@ func runProjectAliases(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1638,8 +1872,9 @@ that owns this alias and remove it.
 Examples:
   taskflow project unalias api-service
   taskflow project unalias web-app`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectUnalias,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectUnalias,
 }
 
 func runProjectUnalias(cmd *cobra.Command, args []string) error {
@@ -1659,7 +1894,7 @@ func runProjectUnalias(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -1720,6 +1955,8 @@ var projectNoteCmd = &cobra.Command{
 
 The command uses the $EDITOR environment variable to determine which editor to use.
 If $EDITOR is not set, it will try to use: nano, vim, or vi (in that order).
+When none of those are available, notes are edited in a built-in editor
+(Ctrl+S to save, Esc to cancel).
 
 Notes support markdown formatting and can be up to 10,000 characters.
 
@@ -1727,8 +1964,9 @@ Examples:
   taskflow project note "Backend"
   taskflow project note 1
   taskflow project note api-service  # Using alias`,
-	Args: cobra.ExactArgs(1),
-	RunE: runProjectNote,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runProjectNote,
 }
 
 func runProjectNote(cmd *cobra.Command, args []string) error {
@@ -1748,7 +1986,7 @@ func runProjectNote(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -1757,7 +1995,7 @@ func runProjectNote(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, repo, args[0])
+	projectID, err := promptProjectID(ctx, repo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -1769,50 +2007,40 @@ func runProjectNote(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	var newNotes string
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
 		editor = detectAvailableEditor()
-		if editor == "" {
-			fmt.Println(styles.Error.Render("✗ No editor found. Set $EDITOR or install nano/vim/vi"))
-			return nil
-		}
-	}
-
-	tmpFile, err := os.CreateTemp("", fmt.Sprintf("taskflow-notes-%d-*.md", project.ID))
-	if err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create temp file: %v", err)))
-		return nil
-	}
-	tmpFilePath := tmpFile.Name()
-	defer os.Remove(tmpFilePath)
-
-	if _, err := tmpFile.WriteString(project.Notes); err != nil {
-		tmpFile.Close()
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to write notes: %v", err)))
-		return nil
 	}
-	tmpFile.Close()
 
-	fmt.Println(styles.Info.Render(fmt.Sprintf("Opening notes for project '%s' in %s...", project.Name, editor)))
-
-	editorCmd := exec.Command(editor, tmpFilePath)
-	editorCmd.Stdin = os.Stdin
-	editorCmd.Stdout = os.Stdout
-	editorCmd.Stderr = os.Stderr
+	if editor != "" {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Opening notes for project '%s' in %s...", project.Name, editor)))
 
-	if err := editorCmd.Run(); err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Editor failed: %v", err)))
-		return nil
-	}
+		newNotes, err = editNotesWithEditor(editor, project)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+	} else {
+		// nothing to hand the notes off to, edit them inline if someone is
+		// there to type
+		if !isInteractiveTerminal() {
+			fmt.Println(styles.Error.Render("✗ No editor found. Set $EDITOR or install nano/vim/vi"))
+			return nil
+		}
 
-	modifiedContent, err := os.ReadFile(tmpFilePath)
-	if err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to read modified notes: %v", err)))
-		return nil
+		notes, saved, err := editNotesInline(project, themeObj, styles)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+		if !saved {
+			fmt.Println(styles.Info.Render("Notes editing cancelled."))
+			return nil
+		}
+		newNotes = notes
 	}
 
-	newNotes := string(modifiedContent)
-
 	if newNotes == project.Notes {
 		fmt.Println(styles.Info.Render("No changes made to notes."))
 		return nil
@@ -1856,3 +2084,205 @@ func detectAvailableEditor() string {
 	}
 	return ""
 }
+
+// writes the notes to a temp file, opens it in editor and reads it back
+func editNotesWithEditor(editor string, project *domain.Project) (string, error) {
+	tmpFile, err := os.CreateTemp("", fmt.Sprintf("taskflow-notes-%d-*.md", project.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpFilePath := tmpFile.Name()
+	defer os.Remove(tmpFilePath)
+
+	if _, err := tmpFile.WriteString(project.Notes); err != nil {
+		tmpFile.Close()
+		return "", fmt.Errorf("failed to write notes: %w", err)
+	}
+	tmpFile.Close()
+
+	editorCmd := exec.Command(editor, tmpFilePath)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+
+	if err := editorCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor failed: %w", err)
+	}
+
+	modifiedContent, err := os.ReadFile(tmpFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read modified notes: %w", err)
+	}
+
+	return string(modifiedContent), nil
+}
+
+// edits the notes in a textarea session, saved is false when cancelled
+func editNotesInline(project *domain.Project, themeObj *theme.Theme, styles *theme.Styles) (notes string, saved bool, err error) {
+	title := fmt.Sprintf("Notes: %s", project.Name)
+	model := tui.NewNotesEditorModel(title, project.Notes, 10000, themeObj, styles)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	finalModel, err := p.Run()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to run notes editor: %w", err)
+	}
+
+	editorModel, ok := finalModel.(tui.NotesEditorModel)
+	if !ok {
+		return "", false, fmt.Errorf("unexpected model type")
+	}
+
+	notes, saved = editorModel.GetNotes()
+	return notes, saved, nil
+}
+
+
+var (
+	mergeNoReparent bool
+	mergeConfirm    bool
+)
+
+var projectMergeCmd = &cobra.Command{
+	Use:   "merge <source> <dest>",
+	Short: "Merge one project into another",
+	Long: `Merge a project into another one.
+
+All tasks of the source project are moved to the destination, the source's
+child projects are re-parented under the destination, and the source project
+is deleted. The whole merge runs in a single transaction.
+
+Use --no-reparent to move the source's children up to the source's own parent
+instead of under the destination. A project cannot be merged into one of its
+own descendants.
+
+Examples:
+  taskflow project merge "Old Backend" Backend             # Preview the merge
+  taskflow project merge "Old Backend" Backend --confirm   # Apply the merge
+  taskflow project merge 4 2 --no-reparent --confirm`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeProjectNames,
+	Annotations:       writesDatabase,
+	RunE:              runProjectMerge,
+}
+
+func init() {
+	projectMergeCmd.Flags().BoolVar(&mergeNoReparent, "no-reparent", false, "Move child projects to the source's parent instead of the destination")
+	projectMergeCmd.Flags().BoolVar(&mergeConfirm, "confirm", false, "Confirm the merge")
+}
+
+func runProjectMerge(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	sourceID, err := promptProjectID(ctx, repo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	destID, err := promptProjectID(ctx, repo, args[1])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	if *sourceID == *destID {
+		fmt.Println(styles.Error.Render("✗ Source and destination are the same project"))
+		return nil
+	}
+
+	source, err := repo.GetByID(ctx, *sourceID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	dest, err := repo.GetByID(ctx, *destID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	descendants, err := repo.GetDescendants(ctx, source.ID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to get child projects: %v", err)))
+		return nil
+	}
+	for _, desc := range descendants {
+		if desc.ID == dest.ID {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Cannot merge '%s' into '%s': the destination is one of its descendants", source.Name, dest.Name)))
+			return nil
+		}
+	}
+
+	children, err := repo.GetChildren(ctx, source.ID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to get child projects: %v", err)))
+		return nil
+	}
+
+	taskCount, err := repo.GetTaskCount(ctx, source.ID)
+	if err != nil {
+		taskCount = 0
+	}
+
+	fmt.Println()
+	fmt.Printf("Merge %s into %s\n", formatProjectDisplay(source), formatProjectDisplay(dest))
+	fmt.Printf("  - %d task(s) will move to '%s'\n", taskCount, dest.Name)
+	if len(children) > 0 {
+		if mergeNoReparent {
+			newParent := "top level"
+			if source.ParentID != nil {
+				if name, err := getProjectName(ctx, repo, source.ParentID); err == nil {
+					newParent = "'" + name + "'"
+				}
+			}
+			fmt.Printf("  - %d child project(s) will move to %s\n", len(children), newParent)
+		} else {
+			fmt.Printf("  - %d child project(s) will move under '%s'\n", len(children), dest.Name)
+		}
+	}
+	fmt.Printf("  - Project '%s' will be deleted\n", source.Name)
+	fmt.Println()
+
+	if !mergeConfirm {
+		fmt.Println(styles.Error.Render("Merge not confirmed. Use --confirm to apply changes"))
+		return nil
+	}
+
+	result, err := repo.Merge(ctx, source.ID, dest.ID, !mergeNoReparent)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to merge projects: %v", err)))
+		return nil
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Merged '%s' into %s", source.Name, formatProjectDisplay(dest))))
+	fmt.Printf("  %d task(s) moved\n", result.TasksMoved)
+	fmt.Printf("  %d child project(s) moved\n", result.ChildrenMoved)
+	fmt.Println()
+
+	return nil
+}
@@ -89,6 +89,32 @@ func TestProjectAlias_GlobalUniqueness(t *testing.T) {
 	}
 }
 
+func TestProjectAlias_NameConflict(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	project1 := domain.NewProject("Backend")
+	err := repo.Create(ctx, project1)
+	if err != nil {
+		t.Fatalf("failed to create first project: %v", err)
+	}
+
+	project2 := domain.NewProject("Other Service")
+	err = repo.Create(ctx, project2)
+	if err != nil {
+		t.Fatalf("failed to create second project: %v", err)
+	}
+
+	project2.Aliases = []string{"backend"}
+	err = repo.Update(ctx, project2)
+	if err == nil {
+		t.Error("expected error for alias matching another project's name, got nil")
+	}
+}
+
 func TestProjectAlias_RetrieveByAlias(t *testing.T) {
 	db, _ := setupTestDB(t)
 	defer db.Close()
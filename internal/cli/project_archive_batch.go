@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/theme"
+)
+
+// filter flags shared by project archive and unarchive, used when no project
+// is named
+var (
+	batchArchiveStatus    string
+	batchArchiveFavorite  bool
+	batchArchiveOlderThan string
+	batchArchiveForce     bool
+)
+
+func init() {
+	addBatchArchiveFlags(projectArchiveCmd, true)
+	addBatchArchiveFlags(projectUnarchiveCmd, false)
+}
+
+// unarchive only ever picks archived projects, so it has no --status
+func addBatchArchiveFlags(cmd *cobra.Command, withStatus bool) {
+	if withStatus {
+		cmd.Flags().StringVar(&batchArchiveStatus, "status", "", "Archive the projects with this status (no project argument)")
+	}
+	cmd.Flags().BoolVar(&batchArchiveFavorite, "favorite", false, "Only projects that are (or with =false, aren't) favorites (no project argument)")
+	cmd.Flags().StringVar(&batchArchiveOlderThan, "older-than", "", "Only projects with no project or task change in this long, e.g. 90d (no project argument)")
+	cmd.Flags().BoolVar(&batchArchiveForce, "force", false, "Allow a batch without filters, applying to every project")
+}
+
+var errBatchNeedsFilter = errors.New("no filters given, pass --force to apply to every project")
+
+// builds the project filter from the batch flags. errBatchNeedsFilter when
+// none were given and --force wasn't either
+func batchProjectFilter(cmd *cobra.Command) (repository.ProjectFilter, error) {
+	var filter repository.ProjectFilter
+	given := false
+
+	if cmd.Flags().Changed("status") {
+		status, err := domain.ParseProjectStatus(batchArchiveStatus)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = status
+		given = true
+	}
+	if cmd.Flags().Changed("favorite") {
+		filter.IsFavorite = &batchArchiveFavorite
+		given = true
+	}
+	if cmd.Flags().Changed("older-than") {
+		since, err := parseLogSince(batchArchiveOlderThan)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --older-than: %w", err)
+		}
+		filter.InactiveSince = since
+		given = true
+	}
+
+	if !given && !batchArchiveForce {
+		return filter, errBatchNeedsFilter
+	}
+	return filter, nil
+}
+
+func batchFlagsChanged(cmd *cobra.Command) bool {
+	for _, name := range []string{"status", "favorite", "older-than", "force"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+// the projects matching the filter, plus their descendants when recursive,
+// keeping only those in status from as the rest wouldn't change. matches
+// come first by name, their descendants after
+func batchArchiveTargets(ctx context.Context, repo repository.ProjectRepository, filter repository.ProjectFilter, recursive bool, from domain.ProjectStatus) (matched, descendants []*domain.Project, err error) {
+	projects, err := repo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	seen := make(map[int64]bool)
+	for _, project := range projects {
+		if project.Status == from && !seen[project.ID] {
+			seen[project.ID] = true
+			matched = append(matched, project)
+		}
+	}
+	if !recursive {
+		return matched, nil, nil
+	}
+
+	for _, project := range matched {
+		children, err := repo.GetDescendants(ctx, project.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get child projects of '%s': %w", project.Name, err)
+		}
+		for _, child := range children {
+			if child.Status == from && !seen[child.ID] {
+				seen[child.ID] = true
+				descendants = append(descendants, child)
+			}
+		}
+	}
+	sort.Slice(descendants, func(i, j int) bool { return descendants[i].Name < descendants[j].Name })
+
+	return matched, descendants, nil
+}
+
+func runProjectArchiveBatch(ctx context.Context, cmd *cobra.Command, repo repository.ProjectRepository, taskRepo repository.TaskRepository, archiveTasks bool, styles *theme.Styles) error {
+	filter, err := batchProjectFilter(cmd)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	matched, descendants, err := batchArchiveTargets(ctx, repo, filter, !archiveNoRecursive, domain.ProjectStatusActive)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	if len(matched) == 0 {
+		fmt.Println(styles.Info.Render("No active projects match the filters."))
+		return nil
+	}
+
+	if !archiveConfirm {
+		printBatchTargets("Archive", matched, descendants)
+		if !promptForConfirmation("Proceed?") {
+			fmt.Println(styles.Info.Render("Archive cancelled."))
+			return nil
+		}
+	}
+
+	ids := projectIDs(append(matched, descendants...))
+	archived, err := repo.ArchiveBatch(ctx, ids)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to archive projects: %v", err))
+	}
+
+	var archivedTasks int64
+	if archiveTasks {
+		archivedTasks, err = archiveCompletedTasks(ctx, taskRepo, ids)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ %d project(s) archived", archived)))
+	if len(descendants) > 0 {
+		fmt.Printf("  %d matched the filters, %d child project(s) archived with them\n", len(matched), len(descendants))
+	}
+	if archiveTasks {
+		fmt.Printf("  %d completed task(s) archived\n", archivedTasks)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func runProjectUnarchiveBatch(ctx context.Context, cmd *cobra.Command, repo repository.ProjectRepository, styles *theme.Styles) error {
+	filter, err := batchProjectFilter(cmd)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	filter.Status = domain.ProjectStatusArchived
+
+	matched, descendants, err := batchArchiveTargets(ctx, repo, filter, unarchiveRecursive, domain.ProjectStatusArchived)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	if len(matched) == 0 {
+		fmt.Println(styles.Info.Render("No archived projects match the filters."))
+		return nil
+	}
+
+	if !unarchiveConfirm {
+		printBatchTargets("Unarchive", matched, descendants)
+		if !promptForConfirmation("Proceed?") {
+			fmt.Println(styles.Info.Render("Unarchive cancelled."))
+			return nil
+		}
+	}
+
+	unarchived, err := repo.UnarchiveBatch(ctx, projectIDs(append(matched, descendants...)))
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to unarchive projects: %v", err))
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ %d project(s) restored to active status", unarchived)))
+	if len(descendants) > 0 {
+		fmt.Printf("  %d matched the filters, %d child project(s) restored with them\n", len(matched), len(descendants))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func printBatchTargets(action string, matched, descendants []*domain.Project) {
+	fmt.Println()
+	fmt.Printf("%s %d project(s)?\n", action, len(matched)+len(descendants))
+	for _, project := range matched {
+		fmt.Printf("  - %s (ID: %d)\n", project.Name, project.ID)
+	}
+	if len(descendants) > 0 {
+		fmt.Printf("  - and %d child project(s)\n", len(descendants))
+	}
+	fmt.Println()
+}
+
+func projectIDs(projects []*domain.Project) []int64 {
+	ids := make([]int64, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+	return ids
+}
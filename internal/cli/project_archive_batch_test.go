@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestBatchProjectFilter(t *testing.T) {
+	parse := func(args ...string) (repository.ProjectFilter, error) {
+		cmd := &cobra.Command{}
+		addBatchArchiveFlags(cmd, true)
+		require.NoError(t, cmd.ParseFlags(args))
+		return batchProjectFilter(cmd)
+	}
+
+	_, err := parse()
+	assert.ErrorIs(t, err, errBatchNeedsFilter)
+
+	filter, err := parse("--force")
+	require.NoError(t, err)
+	assert.Equal(t, repository.ProjectFilter{}, filter)
+
+	filter, err = parse("--status", "Active", "--favorite=false", "--older-than", "90d")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProjectStatusActive, filter.Status)
+	require.NotNil(t, filter.IsFavorite)
+	assert.False(t, *filter.IsFavorite)
+	assert.NotNil(t, filter.InactiveSince)
+
+	_, err = parse("--status", "paused")
+	assert.Error(t, err)
+	_, err = parse("--older-than", "whenever")
+	assert.Error(t, err)
+}
+
+func TestBatchArchiveTargets(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := sqlite.NewProjectRepository(db)
+
+	create := func(name string, parent *domain.Project, favorite bool) *domain.Project {
+		project := domain.NewProject(name)
+		project.IsFavorite = favorite
+		if parent != nil {
+			project.ParentID = &parent.ID
+		}
+		require.NoError(t, repo.Create(ctx, project))
+		return project
+	}
+	legacy := create("Legacy", nil, false)
+	create("Legacy API", legacy, true)
+	old := create("Legacy Docs", legacy, true)
+	require.NoError(t, repo.Archive(ctx, old.ID))
+	create("Current", nil, true)
+
+	notFavorite := false
+	filter := repository.ProjectFilter{IsFavorite: &notFavorite}
+
+	matched, descendants, err := batchArchiveTargets(ctx, repo, filter, true, domain.ProjectStatusActive)
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "Legacy", matched[0].Name)
+	require.Len(t, descendants, 1)
+	assert.Equal(t, "Legacy API", descendants[0].Name)
+
+	_, descendants, err = batchArchiveTargets(ctx, repo, filter, false, domain.ProjectStatusActive)
+	require.NoError(t, err)
+	assert.Empty(t, descendants)
+
+	matched, _, err = batchArchiveTargets(ctx, repo, repository.ProjectFilter{Status: domain.ProjectStatusArchived}, true, domain.ProjectStatusArchived)
+	require.NoError(t, err)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "Legacy Docs", matched[0].Name)
+}
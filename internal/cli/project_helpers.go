@@ -2,16 +2,126 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
+	"task-management/internal/config"
 	"task-management/internal/domain"
 	"task-management/internal/fuzzy"
 	"task-management/internal/repository"
+	"task-management/internal/theme"
 )
 
+// how many projects have their stats queried at once
+const projectStatsWorkers = 8
+
+// the task counts by status of every project, queried by up to workers
+// goroutines. a project whose counts can't be loaded is left out
+func projectStatusCounts(ctx context.Context, repo repository.ProjectRepository, projects []*domain.Project, workers int) map[int64]map[domain.Status]int {
+	counts := make([]map[domain.Status]int, len(projects))
+
+	slots := make(chan struct{}, max(workers, 1))
+	var wg sync.WaitGroup
+	for i, project := range projects {
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			byStatus, err := repo.GetTaskCountByStatus(ctx, project.ID)
+			if err == nil {
+				counts[i] = byStatus
+			}
+		})
+	}
+	wg.Wait()
+
+	statusCounts := make(map[int64]map[domain.Status]int, len(projects))
+	for i, project := range projects {
+		if counts[i] != nil {
+			statusCounts[project.ID] = counts[i]
+		}
+	}
+	return statusCounts
+}
+
+// the sibling order for project trees from the config, without task counts
+func projectOrderFromConfig(cfg *config.Config) (domain.ProjectOrder, error) {
+	if !domain.IsValidProjectSort(cfg.ProjectTreeSort) {
+		return domain.ProjectOrder{}, fmt.Errorf("invalid project_tree_sort config: %s (use name or task_count)", cfg.ProjectTreeSort)
+	}
+	return domain.ProjectOrder{By: cfg.ProjectTreeSort, FavoritesFirst: cfg.FavoriteProjectsFirst}, nil
+}
+
+// links the project to its ancestors so its path can be built, GetByID only
+// loads the project itself
+func loadProjectParents(ctx context.Context, repo repository.ProjectRepository, project *domain.Project) {
+	path, err := repo.GetPath(ctx, project.ID)
+	if err != nil || len(path) == 0 {
+		return
+	}
+	path[len(path)-1] = project
+	domain.LinkParents(path)
+}
+
+// where a child project sits: its parent, the task counts of the parent's
+// whole subtree, and the parent's other children
+type projectContext struct {
+	Parent      *domain.Project
+	ParentStats map[domain.Status]int
+	Siblings    []*domain.Project
+}
+
+// nil for a top-level project
+func loadProjectContext(ctx context.Context, repo repository.ProjectRepository, project *domain.Project) (*projectContext, error) {
+	if project.ParentID == nil {
+		return nil, nil
+	}
+
+	path, err := repo.GetPath(ctx, *project.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load parent project: %w", err)
+	}
+	if len(path) == 0 {
+		return nil, fmt.Errorf("parent project %d not found", *project.ParentID)
+	}
+	domain.LinkParents(path)
+	parent := path[len(path)-1]
+
+	children, err := repo.GetChildren(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sibling projects: %w", err)
+	}
+	descendants, err := repo.GetDescendants(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load parent's subprojects: %w", err)
+	}
+
+	stats := make(map[domain.Status]int)
+	for _, p := range append([]*domain.Project{parent}, descendants...) {
+		counts, err := repo.GetTaskCountByStatus(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		for status, count := range counts {
+			stats[status] += count
+		}
+	}
+
+	projCtx := &projectContext{Parent: parent, ParentStats: stats}
+	for _, child := range children {
+		if child.ID != project.ID {
+			projCtx.Siblings = append(projCtx.Siblings, child)
+		}
+	}
+	return projCtx, nil
+}
+
+// resolves an ID, name or alias to a project ID without asking anything. an
+// empty string gives nil
 func lookupProjectID(ctx context.Context, repo repository.ProjectRepository, projectStr string) (*int64, error) {
 	if strings.TrimSpace(projectStr) == "" {
 		return nil, nil
@@ -35,7 +145,170 @@ func lookupProjectID(ctx context.Context, repo repository.ProjectRepository, pro
 		return &project.ID, nil
 	}
 
-	return nil, fmt.Errorf("project '%s' not found (tried name and alias)", projectStr)
+	candidates, err := projectCandidates(ctx, repo, projectStr)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &projectNotFoundError{name: projectStr, candidates: candidates}
+}
+
+// lookupProjectID for the commands run by hand: a name that doesn't resolve
+// but is close to some projects asks which one was meant, when there's a
+// terminal to ask on. scripts get the error listing them instead
+func promptProjectID(ctx context.Context, repo repository.ProjectRepository, projectStr string) (*int64, error) {
+	projectID, err := lookupProjectID(ctx, repo, projectStr)
+	var notFound *projectNotFoundError
+	if !errors.As(err, &notFound) || len(notFound.candidates) == 0 || !isInteractiveTerminal() {
+		return projectID, err
+	}
+
+	project, err := selectProjectCandidate(projectStr, notFound.candidates)
+	if err != nil {
+		return nil, err
+	}
+	return &project.ID, nil
+}
+
+// a project name or alias that matched nothing, with the projects close to it
+type projectNotFoundError struct {
+	name       string
+	candidates []*domain.Project
+}
+
+func (e *projectNotFoundError) Error() string {
+	if len(e.candidates) == 0 {
+		return fmt.Sprintf("project '%s' not found (tried name and alias)", e.name)
+	}
+	names := make([]string, len(e.candidates))
+	for i, candidate := range e.candidates {
+		names[i] = candidate.Name
+	}
+	return fmt.Sprintf("project '%s' not found (tried name and alias), did you mean: %s", e.name, strings.Join(names, ", "))
+}
+
+// the most projects offered when a name doesn't resolve
+const maxProjectCandidates = 9
+
+// how close a name or alias has to be to be offered as a candidate
+const projectCandidateThreshold = 60
+
+// projects whose name or alias is close to projectStr, best match first. a
+// case-insensitive match counts as an exact one
+func projectCandidates(ctx context.Context, repo repository.ProjectRepository, projectStr string) ([]*domain.Project, error) {
+	projects, err := repo.List(ctx, repository.ProjectFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	var scored []projectWithScore
+	for _, proj := range projects {
+		best := 0
+		for _, text := range append([]string{proj.Name}, proj.Aliases...) {
+			best = max(best, fuzzy.Match(projectStr, text), fuzzy.LevenshteinMatch(projectStr, text))
+		}
+		if best >= projectCandidateThreshold {
+			scored = append(scored, projectWithScore{project: proj, score: best})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].project.Name < scored[j].project.Name
+	})
+
+	if len(scored) > maxProjectCandidates {
+		scored = scored[:maxProjectCandidates]
+	}
+
+	candidates := make([]*domain.Project, len(scored))
+	for i, s := range scored {
+		candidates[i] = s.project
+	}
+	return candidates, nil
+}
+
+// whether stdin and stdout are both a terminal, so a prompt can be answered
+// and won't end up in piped output
+var isInteractiveTerminal = func() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stdout} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// numbered prompt for which of the candidates was meant
+func selectProjectCandidate(projectStr string, candidates []*domain.Project) (*domain.Project, error) {
+	fmt.Println()
+	fmt.Printf("Project '%s' not found. Did you mean:\n", projectStr)
+	fmt.Println()
+	for i, p := range candidates {
+		icon := p.Icon
+		if icon == "" {
+			icon = "📦"
+		}
+		line := fmt.Sprintf("  %d. %s %s", i+1, icon, p.Name)
+		if len(p.Aliases) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(p.Aliases, ", "))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	input, err := promptForInput("Select project number (0 to cancel)", "0")
+	if err != nil {
+		return nil, err
+	}
+
+	num, err := strconv.Atoi(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selection: %s", input)
+	}
+	if num == 0 {
+		return nil, fmt.Errorf("no project selected for '%s'", projectStr)
+	}
+	if num < 1 || num > len(candidates) {
+		return nil, fmt.Errorf("invalid selection: %d", num)
+	}
+
+	return candidates[num-1], nil
+}
+
+// resolves the default_project config value (name, alias or ID). an empty value
+// gives nil, a project that no longer exists or is archived is an error
+func resolveDefaultProject(ctx context.Context, repo repository.ProjectRepository, value string) (*domain.Project, error) {
+	projectID, err := lookupProjectID(ctx, repo, value)
+	if err != nil || projectID == nil {
+		return nil, err
+	}
+
+	project, err := repo.GetByID(ctx, *projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Status == domain.ProjectStatusArchived {
+		return nil, fmt.Errorf("project '%s' is archived", project.Name)
+	}
+
+	return project, nil
+}
+
+// the name of the default project new tasks start in, or "" if there is none.
+// a default that doesn't resolve is reported and ignored
+func defaultProjectName(ctx context.Context, repo repository.ProjectRepository, value string, styles *theme.Styles) string {
+	project, err := resolveDefaultProject(ctx, repo, value)
+	if err != nil {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("⚠ Ignoring default_project: %v", err)))
+		return ""
+	}
+	if project == nil {
+		return ""
+	}
+	return project.Name
 }
 
 type projectWithScore struct {
@@ -109,3 +382,32 @@ func formatProjectDisplay(project *domain.Project) string {
 
 	return display
 }
+
+func printDuplicateProject(existing *domain.Project, styles *theme.Styles) {
+	fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %s", repository.DuplicateProjectMessage(existing))))
+	fmt.Println(styles.Info.Render("  Choose a different name, or give the existing project a shorter handle:"))
+	fmt.Println(styles.Info.Render(fmt.Sprintf("  taskflow project alias %d <alias>", existing.ID)))
+}
+
+// completed tasks of the project that have not been archived yet
+func unarchivedCompletedFilter(projectID int64) repository.TaskFilter {
+	return repository.TaskFilter{
+		ProjectID:   &projectID,
+		Status:      domain.StatusCompleted,
+		ExcludeTags: []string{domain.ArchivedTag},
+	}
+}
+
+// tags the completed tasks of the given projects as archived and returns how
+// many tasks were archived
+func archiveCompletedTasks(ctx context.Context, repo repository.TaskRepository, projectIDs []int64) (int64, error) {
+	var archived int64
+	for _, projectID := range projectIDs {
+		count, err := repo.BulkAddTags(ctx, unarchivedCompletedFilter(projectID), []string{domain.ArchivedTag})
+		if err != nil {
+			return archived, fmt.Errorf("failed to archive completed tasks: %w", err)
+		}
+		archived += count
+	}
+	return archived, nil
+}
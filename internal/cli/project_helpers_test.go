@@ -1,13 +1,19 @@
 package cli
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"task-management/internal/domain"
+	"task-management/internal/repository"
 	"task-management/internal/repository/sqlite"
 )
 
@@ -94,6 +100,55 @@ func TestLookupProjectID(t *testing.T) {
 	}
 }
 
+func TestLookupProjectIDSuggestsCandidates(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	for _, name := range []string{"Backend", "Backend Legacy", "Frontend"} {
+		require.NoError(t, repo.Create(ctx, domain.NewProject(name)))
+	}
+
+	t.Run("candidates are ranked", func(t *testing.T) {
+		candidates, err := projectCandidates(ctx, repo, "backend")
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+		assert.Equal(t, "Backend", candidates[0].Name)
+		assert.Equal(t, "Backend Legacy", candidates[1].Name)
+	})
+
+	t.Run("typos still find close matches", func(t *testing.T) {
+		candidates, err := projectCandidates(ctx, repo, "Frontedn")
+		require.NoError(t, err)
+		require.NotEmpty(t, candidates)
+		assert.Equal(t, "Frontend", candidates[0].Name)
+	})
+
+	t.Run("the candidates are listed in the error", func(t *testing.T) {
+		_, err := lookupProjectID(ctx, repo, "backend")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did you mean: Backend, Backend Legacy")
+	})
+
+	t.Run("without a terminal the prompt gives the same error", func(t *testing.T) {
+		original := isInteractiveTerminal
+		isInteractiveTerminal = func() bool { return false }
+		defer func() { isInteractiveTerminal = original }()
+
+		_, err := promptProjectID(ctx, repo, "backend")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did you mean: Backend, Backend Legacy")
+	})
+
+	t.Run("no close match", func(t *testing.T) {
+		_, err := lookupProjectID(ctx, repo, "zzzz")
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "did you mean")
+	})
+}
+
 func TestLookupProjectByFuzzyName(t *testing.T) {
 	db, _ := setupTestDB(t)
 	defer db.Close()
@@ -230,3 +285,259 @@ func TestLookupProjectByFuzzyName_NoProjects(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "no matching project found")
 }
+
+func TestDuplicateProject(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	existing := domain.NewProject("backend")
+	existing.Aliases = []string{"be"}
+	require.NoError(t, repo.Create(ctx, existing))
+
+	err := repo.Create(ctx, domain.NewProject("backend"))
+	require.Error(t, err)
+
+	duplicate := repository.DuplicateProject(ctx, repo, "backend", err)
+	require.NotNil(t, duplicate)
+	assert.Equal(t, existing.ID, duplicate.ID)
+	assert.Equal(t, fmt.Sprintf("A project named 'backend' already exists (ID %d) - aliases: be", existing.ID), repository.DuplicateProjectMessage(duplicate))
+
+	assert.Nil(t, repository.DuplicateProject(ctx, repo, "backend", nil))
+	assert.Nil(t, repository.DuplicateProject(ctx, repo, "backend", fmt.Errorf("failed to insert project: disk full")))
+	assert.Nil(t, repository.DuplicateProject(ctx, repo, "backend", fmt.Errorf("template %q already exists", "backend")))
+}
+
+func TestResolveDefaultProject(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	backend := domain.NewProject("backend")
+	backend.Aliases = []string{"be"}
+	require.NoError(t, repo.Create(ctx, backend))
+
+	old := domain.NewProject("old")
+	old.Status = domain.ProjectStatusArchived
+	require.NoError(t, repo.Create(ctx, old))
+
+	project, err := resolveDefaultProject(ctx, repo, "")
+	require.NoError(t, err)
+	assert.Nil(t, project)
+
+	for _, value := range []string{"backend", "be", fmt.Sprintf("%d", backend.ID)} {
+		project, err := resolveDefaultProject(ctx, repo, value)
+		require.NoError(t, err, value)
+		assert.Equal(t, backend.ID, project.ID, value)
+	}
+
+	_, err = resolveDefaultProject(ctx, repo, "deleted")
+	assert.Error(t, err)
+
+	_, err = resolveDefaultProject(ctx, repo, "old")
+	assert.ErrorContains(t, err, "archived")
+}
+
+func TestArchiveCompletedTasks(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	parent := domain.NewProject("platform")
+	require.NoError(t, projectRepo.Create(ctx, parent))
+	child := domain.NewProject("api")
+	child.ParentID = &parent.ID
+	require.NoError(t, projectRepo.Create(ctx, child))
+	other := domain.NewProject("website")
+	require.NoError(t, projectRepo.Create(ctx, other))
+
+	newTask := func(title string, status domain.Status, projectID int64, tags ...string) *domain.Task {
+		task := domain.NewTask(title)
+		task.Status = status
+		task.ProjectID = &projectID
+		task.Tags = tags
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task
+	}
+
+	done := newTask("Ship v1", domain.StatusCompleted, parent.ID)
+	childDone := newTask("Write docs", domain.StatusCompleted, child.ID, "docs")
+	newTask("Already archived", domain.StatusCompleted, parent.ID, domain.ArchivedTag)
+	open := newTask("Ship v2", domain.StatusPending, parent.ID)
+	otherDone := newTask("Landing page", domain.StatusCompleted, other.ID)
+
+	archived, err := archiveCompletedTasks(ctx, taskRepo, []int64{parent.ID, child.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), archived)
+
+	for _, task := range []*domain.Task{done, childDone} {
+		got, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Contains(t, got.Tags, domain.ArchivedTag, "task %q", task.Title)
+	}
+	for _, task := range []*domain.Task{open, otherDone} {
+		got, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, got.Tags, domain.ArchivedTag, "task %q", task.Title)
+	}
+
+	archived, err = archiveCompletedTasks(ctx, taskRepo, []int64{parent.ID, child.ID})
+	require.NoError(t, err)
+	assert.Zero(t, archived)
+}
+
+func TestProjectStatusCounts(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projects := seedProjectsWithTasks(t, db, 20)
+
+	counts := projectStatusCounts(ctx, sqlite.NewProjectRepository(db), projects, 4)
+	require.Len(t, counts, len(projects))
+	for i, project := range projects {
+		total, _ := completionOf(counts[project.ID], domain.CancelledAsOpen)
+		assert.Equal(t, i%5, total, project.Name)
+	}
+}
+
+// BenchmarkProjectStatusCounts compares querying the counts one project at a
+// time with the worker pool used by project list --stats
+func BenchmarkProjectStatusCounts(b *testing.B) {
+	db, err := sqlite.NewDB(sqlite.Config{Path: filepath.Join(b.TempDir(), "bench.db")})
+	require.NoError(b, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := sqlite.NewProjectRepository(db)
+	projects := seedProjectsWithTasks(b, db, 200)
+
+	for _, workers := range []int{1, projectStatsWorkers} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				projectStatusCounts(ctx, repo, projects, workers)
+			}
+		})
+	}
+}
+
+// creates n projects, project i holding i%5 tasks
+func seedProjectsWithTasks(tb testing.TB, db *sqlite.DB, n int) []*domain.Project {
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	projects := make([]*domain.Project, n)
+	var tasks []*domain.Task
+	for i := range projects {
+		projects[i] = domain.NewProject(fmt.Sprintf("project-%03d", i))
+		require.NoError(tb, projectRepo.Create(ctx, projects[i]))
+		for range i % 5 {
+			task := domain.NewTask("task")
+			task.ProjectID = &projects[i].ID
+			tasks = append(tasks, task)
+		}
+	}
+	require.NoError(tb, taskRepo.CreateBatch(ctx, tasks))
+
+	return projects
+}
+
+func TestLoadProjectContext(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	create := func(name string, parent *domain.Project) *domain.Project {
+		project := domain.NewProject(name)
+		if parent != nil {
+			project.ParentID = &parent.ID
+		}
+		require.NoError(t, projectRepo.Create(ctx, project))
+		return project
+	}
+	addTask := func(project *domain.Project, status domain.Status) {
+		task := domain.NewTask("task")
+		task.ProjectID = &project.ID
+		task.Status = status
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	dev := create("Dev", nil)
+	backend := create("Backend", dev)
+	api := create("API", backend)
+	web := create("Web", backend)
+	create("Auth", api)
+	addTask(backend, domain.StatusPending)
+	addTask(api, domain.StatusCompleted)
+	addTask(web, domain.StatusPending)
+
+	projCtx, err := loadProjectContext(ctx, projectRepo, api)
+	require.NoError(t, err)
+	require.NotNil(t, projCtx)
+	assert.Equal(t, backend.ID, projCtx.Parent.ID)
+	assert.Equal(t, []string{"Dev", "Backend"}, projCtx.Parent.PathNames())
+	assert.Equal(t, map[domain.Status]int{domain.StatusPending: 2, domain.StatusCompleted: 1}, projCtx.ParentStats)
+	require.Len(t, projCtx.Siblings, 1)
+	assert.Equal(t, web.ID, projCtx.Siblings[0].ID)
+
+	projCtx, err = loadProjectContext(ctx, projectRepo, dev)
+	require.NoError(t, err)
+	assert.Nil(t, projCtx)
+
+	missing := int64(9999)
+	_, err = loadProjectContext(ctx, projectRepo, &domain.Project{Name: "Orphan", ParentID: &missing})
+	assert.EqualError(t, err, "parent project 9999 not found")
+}
+
+func TestReadName(t *testing.T) {
+	validate := func(name string) error {
+		return domain.ValidateName("view", name)
+	}
+
+	tests := []struct {
+		name    string
+		answers string
+		want    string
+		wantErr bool
+	}{
+		{"first answer", "Backlog\n", "Backlog", false},
+		{"reprompts after blanks", "\n  \nBacklog\n", "Backlog", false},
+		{"reprompts after too long", strings.Repeat("a", 101) + "\nBacklog\n", "Backlog", false},
+		{"gives up after repeated blanks", "\n\n\nBacklog\n", "", true},
+		{"end of input", "\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := readName(bufio.NewReader(strings.NewReader(tt.answers)), &out, "View name", validate)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPromptForName_NotATerminal(t *testing.T) {
+	original := isInteractiveTerminal
+	isInteractiveTerminal = func() bool { return false }
+	defer func() { isInteractiveTerminal = original }()
+
+	_, err := promptForName("View name", func(string) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "view name is required")
+}
@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var sprintRollConfirm bool
+
+var projectSprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Run a project in fixed-length sprints",
+	Long: `Run a project in fixed-length sprints.
+
+Sprints are opt-in per project. Once a cadence is set, 'sprint roll' closes the
+current sprint: completed tasks are archived (tagged "archived" and with the
+sprint they finished in), incomplete tasks are carried over into the next
+sprint and any of them due before the new sprint starts are moved to its end.
+Archived tasks are left out of 'taskflow list' unless --include-archived or
+--tags archived is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var projectSprintSetCmd = &cobra.Command{
+	Use:   "set <project-id-or-name> <cadence>",
+	Short: "Set the sprint cadence of a project",
+	Long: `Set the sprint cadence of a project, e.g. "2 weeks", "10 days", "1w" or "weekly".
+
+If the project has no sprint running yet, sprint 1 starts now.
+
+Examples:
+  taskflow project sprint set Backend "2 weeks"
+  taskflow project sprint set 3 10d`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeProjectNames,
+	Annotations:       writesDatabase,
+	RunE:              runProjectSprintSet,
+}
+
+var projectSprintClearCmd = &cobra.Command{
+	Use:               "clear <project-id-or-name>",
+	Short:             "Turn sprints off for a project",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Annotations:       writesDatabase,
+	RunE:              runProjectSprintClear,
+}
+
+var projectSprintRollCmd = &cobra.Command{
+	Use:   "roll <project-id-or-name>",
+	Short: "Close the current sprint and start the next one",
+	Long: `Close the current sprint of a project and start the next one.
+
+Completed tasks are tagged "archived" and "sprint-N". Pending and in-progress
+tasks are tagged with the next sprint and, if they are due before it starts,
+their due date is moved to the end of the new sprint.
+
+Examples:
+  taskflow project sprint roll Backend             # Preview the roll
+  taskflow project sprint roll Backend --confirm   # Apply it`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Annotations:       writesDatabase,
+	RunE:              runProjectSprintRoll,
+}
+
+func init() {
+	projectCmd.AddCommand(projectSprintCmd)
+	projectSprintCmd.AddCommand(projectSprintSetCmd)
+	projectSprintCmd.AddCommand(projectSprintClearCmd)
+	projectSprintCmd.AddCommand(projectSprintRollCmd)
+
+	projectSprintRollCmd.Flags().BoolVar(&sprintRollConfirm, "confirm", false, "Confirm the sprint roll")
+}
+
+func runProjectSprintSet(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	days, err := domain.ParseSprintCadence(args[1])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, repo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	project, err := repo.GetByID(ctx, *projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	project.SprintCadence = domain.FormatSprintCadence(days)
+	if project.SprintNumber == 0 || project.SprintStartedAt == nil {
+		now := time.Now()
+		project.SprintNumber = 1
+		project.SprintStartedAt = &now
+	}
+
+	if err := repo.Update(ctx, project); err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to update project: %v", err)))
+		return nil
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ '%s' now runs sprints of %s", project.Name, project.SprintCadence)))
+	fmt.Printf("  Sprint %d ends %s\n", project.SprintNumber, project.SprintEndsAt().Format("2006-01-02"))
+
+	return nil
+}
+
+func runProjectSprintClear(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, repo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	project, err := repo.GetByID(ctx, *projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	if !project.HasSprints() {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Project '%s' does not use sprints", project.Name)))
+		return nil
+	}
+
+	project.SprintCadence = ""
+	project.SprintNumber = 0
+	project.SprintStartedAt = nil
+
+	if err := repo.Update(ctx, project); err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to update project: %v", err)))
+		return nil
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Sprints turned off for '%s'", project.Name)))
+
+	return nil
+}
+
+func runProjectSprintRoll(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, projectRepo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	project, err := projectRepo.GetByID(ctx, *projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	if !project.HasSprints() {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project '%s' does not use sprints. Set a cadence first with 'project sprint set'", project.Name)))
+		return nil
+	}
+
+	completed, err := taskRepo.Count(ctx, unarchivedCompletedFilter(project.ID))
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to count tasks: %v", err)))
+		return nil
+	}
+
+	var incomplete int64
+	for _, status := range []domain.Status{domain.StatusPending, domain.StatusInProgress} {
+		count, err := taskRepo.Count(ctx, repository.TaskFilter{ProjectID: &project.ID, Status: status})
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to count tasks: %v", err)))
+			return nil
+		}
+		incomplete += count
+	}
+
+	sprintNumber := max(project.SprintNumber, 1)
+
+	fmt.Println()
+	fmt.Printf("Roll sprint %d of %s (%s)\n", sprintNumber, formatProjectDisplay(project), project.SprintCadence)
+	fmt.Printf("  - %d completed task(s) will be archived\n", completed)
+	fmt.Printf("  - %d incomplete task(s) will carry over to sprint %d\n", incomplete, sprintNumber+1)
+	fmt.Println()
+
+	if !sprintRollConfirm {
+		fmt.Println(styles.Error.Render("Sprint roll not confirmed. Use --confirm to apply changes"))
+		return nil
+	}
+
+	result, err := projectRepo.RollSprint(ctx, project.ID, time.Now())
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to roll sprint: %v", err)))
+		return nil
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Sprint %d closed: completed %d, carried over %d", result.ClosedSprint, result.Completed, result.CarriedOver)))
+	if result.Rescheduled > 0 {
+		fmt.Printf("  %d overdue task(s) now due %s\n", result.Rescheduled, result.NextEnd.Format("2006-01-02"))
+	}
+	fmt.Printf("  Sprint %d runs %s → %s\n", result.ClosedSprint+1, result.NextStart.Format("2006-01-02"), result.NextEnd.Format("2006-01-02"))
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	projectTasksStatus    string
+	projectTasksPriority  string
+	projectTasksSortBy    string
+	projectTasksSortOrder string
+	projectTasksRecursive bool
+	projectTasksFormat    string
+)
+
+var projectTasksCmd = &cobra.Command{
+	Use:   "tasks <id|name|alias>",
+	Short: "List the tasks of a project",
+	Long: `List the tasks of a project as a table, or as JSON with --format json.
+
+With --recursive the tasks of all subprojects are included too.
+
+Examples:
+  taskflow project tasks Backend
+  taskflow project tasks be --status pending --sort-by priority
+  taskflow project tasks 3 --recursive
+  taskflow project tasks Backend --format json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	RunE:              runProjectTasks,
+}
+
+func init() {
+	projectCmd.AddCommand(projectTasksCmd)
+
+	projectTasksCmd.Flags().StringVarP(&projectTasksStatus, "status", "s", "", "Filter by status (pending, in_progress, completed, cancelled)")
+	projectTasksCmd.Flags().StringVarP(&projectTasksPriority, "priority", "p", "", "Filter by priority (low, medium, high, urgent)")
+	projectTasksCmd.Flags().StringVar(&projectTasksSortBy, "sort-by", "created_at", "Sort by field (created_at, updated_at, priority, due_date, title, position)")
+	projectTasksCmd.Flags().StringVar(&projectTasksSortOrder, "sort-order", "desc", "Sort order (asc, desc)")
+	projectTasksCmd.Flags().BoolVarP(&projectTasksRecursive, "recursive", "r", false, "Include tasks of subprojects")
+	projectTasksCmd.Flags().StringVarP(&projectTasksFormat, "format", "f", "text", "Output format (text, json)")
+
+	projectTasksCmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"pending", "in_progress", "completed", "cancelled"}, cobra.ShellCompDirectiveNoFileComp))
+	projectTasksCmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions([]string{"low", "medium", "high", "urgent"}, cobra.ShellCompDirectiveNoFileComp))
+	projectTasksCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runProjectTasks(cmd *cobra.Command, args []string) error {
+	if projectTasksFormat != "text" && projectTasksFormat != "json" {
+		return fmt.Errorf("unsupported format: %s (use text or json)", projectTasksFormat)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, projectRepo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	project, err := projectRepo.GetByID(ctx, *projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Project not found: %v", err)))
+		return nil
+	}
+
+	filter, err := projectTasksFilter(ctx, projectRepo, project, projectTasksRecursive)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	if projectTasksStatus != "" {
+		status, err := domain.ParseStatus(projectTasksStatus)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+		filter.Status = status
+	}
+	if projectTasksPriority != "" {
+		priority, err := domain.ParsePriority(projectTasksPriority)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+		filter.Priority = priority
+	}
+	filter.SortBy = projectTasksSortBy
+	filter.SortOrder = projectTasksSortOrder
+	filter.PinnedFirst = cfg.PinnedFirst
+
+	tasks, err := taskRepo.List(ctx, filter)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list tasks: %v", err)))
+		return nil
+	}
+
+	if projectTasksFormat == "json" {
+		data, err := json.MarshalIndent(tasks, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode tasks: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	heading := fmt.Sprintf("Tasks in %s", formatProjectDisplay(project))
+	if projectTasksRecursive {
+		heading += " and its subprojects"
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(heading))
+
+	if len(tasks) == 0 {
+		fmt.Println()
+		fmt.Println(styles.Info.Render("No tasks found."))
+		fmt.Println()
+		return nil
+	}
+
+	displayTasksTable(tasks, styles, filter, 1, 1, int64(len(tasks)), cfg.OverdueGraceDays)
+
+	return nil
+}
+
+// filters the tasks of a project and, if recursive, of all its descendants
+func projectTasksFilter(ctx context.Context, repo repository.ProjectRepository, project *domain.Project, recursive bool) (repository.TaskFilter, error) {
+	filter := repository.TaskFilter{ProjectIDs: []int64{project.ID}}
+	if !recursive {
+		return filter, nil
+	}
+
+	descendants, err := repo.GetDescendants(ctx, project.ID)
+	if err != nil {
+		return filter, fmt.Errorf("failed to get subprojects: %w", err)
+	}
+	for _, descendant := range descendants {
+		filter.ProjectIDs = append(filter.ProjectIDs, descendant.ID)
+	}
+
+	return filter, nil
+}
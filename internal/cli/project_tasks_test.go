@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestProjectTasksFilter(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	parent := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, parent))
+	child := domain.NewProject("API")
+	child.ParentID = &parent.ID
+	require.NoError(t, projectRepo.Create(ctx, child))
+	grandchild := domain.NewProject("Auth")
+	grandchild.ParentID = &child.ID
+	require.NoError(t, projectRepo.Create(ctx, grandchild))
+	other := domain.NewProject("Frontend")
+	require.NoError(t, projectRepo.Create(ctx, other))
+
+	for _, projectID := range []int64{parent.ID, child.ID, grandchild.ID, other.ID} {
+		task := domain.NewTask(fmt.Sprintf("Task in %d", projectID))
+		task.ProjectID = &projectID
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	listProjectIDs := func(filter repository.TaskFilter) []int64 {
+		tasks, err := taskRepo.List(ctx, filter)
+		require.NoError(t, err)
+		var ids []int64
+		for _, task := range tasks {
+			ids = append(ids, *task.ProjectID)
+		}
+		return ids
+	}
+
+	filter, err := projectTasksFilter(ctx, projectRepo, parent, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{parent.ID}, listProjectIDs(filter))
+
+	filter, err = projectTasksFilter(ctx, projectRepo, parent, true)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{parent.ID, child.ID, grandchild.ID}, listProjectIDs(filter))
+
+	filter, err = projectTasksFilter(ctx, projectRepo, child, true)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{child.ID, grandchild.ID}, listProjectIDs(filter))
+}
@@ -52,15 +52,21 @@ DATE FILTERS:
   due:<date>           Due on specific date (YYYY-MM-DD)
   due:+<N>d            Due in next N days
   due:-<N>d            Due in last N days (overdue)
+  due:overdue          Overdue, honouring overdue_grace_days
   due:today            Due today
   due:tomorrow         Due tomorrow
   due:none             No due date
 
 COMBINING FILTERS:
   Use spaces to combine multiple filters
-  All filters are combined with AND logic
+  Filters next to each other are combined with AND logic
   Example: status:pending priority:high @backend -tag:wontfix
 
+  a OR b, a | b        Either side matches
+  ( ... )              Groups filters
+  AND binds tighter than OR: "a b OR c" means "(a b) OR c", use
+  parentheses for "a (b OR c)"
+
 EXAMPLES:
   taskflow list --query "status:pending @frontend"
     → Show pending tasks in frontend project
@@ -77,11 +83,19 @@ EXAMPLES:
   taskflow list --query "due:-7d status:pending"
     → Show overdue pending tasks (due in last 7 days)
 
+  taskflow list --query "(status:pending OR status:in_progress) priority:high"
+    → Show open high priority tasks
+
+  taskflow list --query "@backend tag:bug | @frontend tag:ui"
+    → Show bugs in backend and UI tasks in frontend
+
 TIPS:
   - Filters are case-insensitive
   - Use @~ for typo-tolerant project matching
   - Combine multiple negations to exclude multiple values
   - Date filters use relative (+7d) or absolute (2025-01-15) formats
+  - Check a query without running it: taskflow query validate "your query"
+  - Build one from the filter panel's choices: taskflow query build
 `
 	fmt.Println(help)
 }
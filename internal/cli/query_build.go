@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+var queryBuildSave string
+
+var queryBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build a query step by step from the filter panel's choices",
+	Long: `Walk through the same status, priority, project and due date choices as
+the TUI filter panel, plus tags, and print the equivalent query-language
+string. The result is validated before it is printed, so it can be pasted
+straight into list --query.
+
+Pass --save to also store the filters as a saved view. Views don't keep
+relative due dates or excluded tags, so queries using those can't be saved.`,
+	Example: `  taskflow query build
+  taskflow query build --save "Urgent backend"`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	Annotations:   writesDatabaseWith("save"),
+	RunE:          runQueryBuild,
+}
+
+func init() {
+	queryCmd.AddCommand(queryBuildCmd)
+	queryBuildCmd.Flags().StringVar(&queryBuildSave, "save", "", "Save the built filters as a view with this name")
+}
+
+func runQueryBuild(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	viewRepo := sqlite.NewViewRepository(db)
+	ctx := context.Background()
+
+	projects, err := projectRepo.List(ctx, repository.ProjectFilter{ExcludeArchived: true})
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	input, err := buildQueryInteractive(bufio.NewReader(cmd.InOrStdin()), w, projects)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Query: %s\n", input)
+	fmt.Fprintln(w)
+	if err := validateQuery(ctx, w, projectRepo, input, cfg.OverdueGraceDays); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Run it with: taskflow list --query %s\n", strconv.Quote(input))
+
+	if queryBuildSave == "" {
+		return nil
+	}
+	return saveQueryAsView(ctx, w, viewRepo, projectRepo, queryBuildSave, input)
+}
+
+// asks for each filter panel section that has a query-language form, then
+// for tags, and returns the joined query. an empty query means every task
+func buildQueryInteractive(r *bufio.Reader, w io.Writer, projects []*domain.Project) (string, error) {
+	projectNames := make(map[string]string, len(projects))
+	for _, proj := range projects {
+		projectNames[strconv.FormatInt(proj.ID, 10)] = proj.Name
+	}
+
+	var parts []string
+	for _, section := range display.FilterSections(projects) {
+		if !queryBuildSupports(section.FilterType) {
+			continue
+		}
+
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, section.Title)
+		for i, choice := range section.Choices {
+			fmt.Fprintf(w, "  %2d. %s\n", i+1, choice.Label)
+		}
+
+		choice, err := promptChoice(r, w, section.Choices)
+		if err != nil {
+			return "", err
+		}
+		if part := filterChoiceQuery(section.FilterType, choice.Value, projectNames); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	fmt.Fprintln(w)
+	tags, err := readPromptLine(r, w, "Tags (comma-separated, prefix with - to exclude, blank for any)")
+	if err != nil {
+		return "", err
+	}
+	parts = append(parts, tagQueryParts(tags)...)
+
+	if len(parts) == 0 {
+		return "", errors.New("no filters chosen, the query would match every task")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// pinned, waiting and snoozed have no query-language field yet
+func queryBuildSupports(filterType string) bool {
+	switch filterType {
+	case "status", "priority", "project", "duedate":
+		return true
+	}
+	return false
+}
+
+// the query-language form of one filter panel choice, "" for "All"
+func filterChoiceQuery(filterType, value string, projectNames map[string]string) string {
+	if value == "" {
+		return ""
+	}
+
+	switch filterType {
+	case "status":
+		return "status:" + value
+	case "priority":
+		return "priority:" + value
+	case "project":
+		return "project:" + quoteQueryValue(projectNames[value])
+	case "duedate":
+		switch value {
+		case "overdue":
+			return "due:overdue"
+		case "week":
+			return "due:today..+7d"
+		case "month":
+			return "due:today..+30d"
+		default:
+			return "due:" + value
+		}
+	}
+	return ""
+}
+
+func tagQueryParts(input string) []string {
+	var parts []string
+	for _, tag := range strings.Split(input, ",") {
+		tag = strings.TrimSpace(tag)
+		prefix := "tag:"
+		if strings.HasPrefix(tag, "-") {
+			prefix = "-tag:"
+			tag = strings.TrimSpace(strings.TrimPrefix(tag, "-"))
+		}
+		if tag == "" {
+			continue
+		}
+		parts = append(parts, prefix+quoteQueryValue(tag))
+	}
+	return parts
+}
+
+// values with spaces or query punctuation need quoting to lex as one value
+func quoteQueryValue(value string) string {
+	if strings.ContainsAny(value, " \t:@()\"'<>=!|") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// reads a choice by number, blank picks the first one ("All")
+func promptChoice(r *bufio.Reader, w io.Writer, choices []display.FilterChoice) (display.FilterChoice, error) {
+	for {
+		input, err := readPromptLine(r, w, "Choice [1]")
+		if err != nil {
+			return display.FilterChoice{}, err
+		}
+		if input == "" {
+			return choices[0], nil
+		}
+
+		num, err := strconv.Atoi(input)
+		if err == nil && num >= 1 && num <= len(choices) {
+			return choices[num-1], nil
+		}
+		fmt.Fprintf(w, "  Pick a number from 1 to %d\n", len(choices))
+	}
+}
+
+func readPromptLine(r *bufio.Reader, w io.Writer, prompt string) (string, error) {
+	fmt.Fprintf(w, "%s: ", prompt)
+	line, err := r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// stores the query's filters on a new view. refuses queries whose filters
+// a view can't hold rather than saving something narrower or frozen in time
+func saveQueryAsView(ctx context.Context, w io.Writer, viewRepo repository.ViewRepository, projectRepo repository.ProjectRepository, name, input string) error {
+	parsed, err := query.ParseQuery(input)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidQuery, err)
+	}
+	if parsed.HasOr() {
+		return fmt.Errorf("OR groups can't be stored in a view, use list --query instead")
+	}
+	for _, qf := range parsed.Filters {
+		if qf.IsNot || qf.Field == "due" {
+			return fmt.Errorf("%s can't be stored in a view, use list --query instead", qf.String())
+		}
+	}
+
+	filter, err := query.ConvertToTaskFilter(ctx, parsed, &query.ConverterContext{ProjectRepo: projectRepo})
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidQuery, err)
+	}
+
+	view := domain.NewSavedView(name)
+	view.FilterConfig = domain.SavedViewFilter{
+		Status:    filter.Status,
+		Priority:  filter.Priority,
+		ProjectID: filter.ProjectID,
+		Tags:      filter.Tags,
+	}
+	if err := view.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if err := viewRepo.Create(ctx, view); err != nil {
+		return fmt.Errorf("failed to save view: %w", err)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "✓ View '%s' saved [#%d]: %s\n", view.Name, view.ID, view.GetFilterSummary())
+	return nil
+}
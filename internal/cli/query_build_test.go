@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestBuildQueryInteractive(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	require.NoError(t, projectRepo.Create(ctx, domain.NewProject("Mobile App")))
+	projects, err := projectRepo.List(ctx, repository.ProjectFilter{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		answers string
+		want    string
+		wantErr bool
+	}{
+		{"every section", "2\n5\n2\n3\nbug, -wontfix\n", `status:pending priority:urgent project:"Mobile App" due:today tag:bug -tag:wontfix`, false},
+		{"blank keeps all", "\n\n\n2\n\n", "due:overdue", false},
+		{"reprompts out of range", "9\n4\n\n\n\n\n", "status:completed", false},
+		{"ranges for week", "\n\n\n4\n\n", "due:today..+7d", false},
+		{"nothing chosen", "\n\n\n\n\n", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := buildQueryInteractive(bufio.NewReader(strings.NewReader(tt.answers)), &out, projects)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			parsed, err := query.ParseQuery(got)
+			require.NoError(t, err)
+			_, err = query.ConvertToTaskFilter(ctx, parsed, &query.ConverterContext{ProjectRepo: projectRepo})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSaveQueryAsView(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	viewRepo := sqlite.NewViewRepository(db)
+	backend := domain.NewProject("backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+
+	var out bytes.Buffer
+	require.NoError(t, saveQueryAsView(ctx, &out, viewRepo, projectRepo, "Hot backend", "priority:high project:backend tag:bug"))
+
+	view, err := viewRepo.GetByName(ctx, "Hot backend")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PriorityHigh, view.FilterConfig.Priority)
+	require.NotNil(t, view.FilterConfig.ProjectID)
+	assert.Equal(t, backend.ID, *view.FilterConfig.ProjectID)
+	assert.Equal(t, []string{"bug"}, view.FilterConfig.Tags)
+
+	assert.Error(t, saveQueryAsView(ctx, &out, viewRepo, projectRepo, "Soon", "due:+7d"))
+	assert.Error(t, saveQueryAsView(ctx, &out, viewRepo, projectRepo, "Not bugs", "-tag:bug"))
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+var errInvalidQuery = errors.New("invalid query")
+
+var queryValidateCmd = &cobra.Command{
+	Use:   "validate <query>",
+	Short: "Check a query and print the filter it resolves to",
+	Long: `Parse a query-language string and print the filter it resolves to,
+without running it. Project mentions are looked up and relative dates are
+expanded, so the output is exactly what list --query would filter on.
+
+Invalid queries print the offending position and exit with a non-zero code,
+which makes this handy when building saved views or scripts.`,
+	Example: `  taskflow query validate "status:pending @backend due:+7d"
+  taskflow query validate "@~back -tag:wontfix"`,
+	Args:          cobra.ExactArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runQueryValidate,
+}
+
+func init() {
+	queryCmd.AddCommand(queryValidateCmd)
+}
+
+func runQueryValidate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	return validateQuery(ctx, cmd.OutOrStdout(), projectRepo, args[0], cfg.OverdueGraceDays)
+}
+
+// parses and converts the query, printing either the resolved filter or
+// every error found. returns errInvalidQuery when there were any
+func validateQuery(ctx context.Context, w io.Writer, projectRepo repository.ProjectRepository, input string, graceDays int) error {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		fmt.Fprintln(w, "✗ Query is empty")
+		return errInvalidQuery
+	}
+
+	parsed, err := query.ParseQuery(input)
+	if err != nil {
+		fmt.Fprintln(w, "✗ Invalid query")
+		for _, parseErr := range queryErrors(input, parsed) {
+			fmt.Fprintln(w)
+			printQueryErrorAt(w, input, parseErr)
+		}
+		return errInvalidQuery
+	}
+
+	// apply the filters one at a time so a bad value can be pinned to the
+	// filter it came from
+	var filter repository.TaskFilter
+	converterCtx := &query.ConverterContext{ProjectRepo: projectRepo, OverdueGraceDays: graceDays}
+	var failed bool
+	for _, qf := range parsed.Filters {
+		if err := query.ApplyFilter(ctx, &filter, qf, converterCtx); err != nil {
+			if !failed {
+				fmt.Fprintln(w, "✗ Invalid query")
+				fmt.Fprintln(w)
+				failed = true
+			}
+			fmt.Fprintf(w, "  %s: %v\n", qf.String(), err)
+		}
+	}
+	if failed {
+		return errInvalidQuery
+	}
+
+	fmt.Fprintln(w, "✓ Valid query")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Filters:")
+	for _, qf := range parsed.Filters {
+		fmt.Fprintf(w, "  %s\n", qf.String())
+	}
+	fmt.Fprintln(w)
+
+	// with OR groups the filters don't simply stack, show how they combine
+	if parsed.HasOr() {
+		fmt.Fprintln(w, "Matches:")
+		fmt.Fprintf(w, "  %s\n", parsed.Expr.String())
+		for _, resolution := range converterCtx.ResolvedProjects {
+			fmt.Fprintf(w, "  %-16s %s [#%d]\n", "Project:", resolution.String(), resolution.Project.ID)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(w, "Resolved filter:")
+	printResolvedFilter(w, filter, converterCtx.ResolvedProjects)
+	return nil
+}
+
+// the parser's own errors, or the lexer's when it failed before parsing
+func queryErrors(input string, parsed *query.ParsedQuery) []query.ParseError {
+	if parsed != nil && len(parsed.Errors) > 0 {
+		return parsed.Errors
+	}
+
+	tokens, _ := query.Tokenize(input)
+	for _, token := range tokens {
+		if token.Type == query.TokenError {
+			return []query.ParseError{{Message: token.Value, Pos: token.Pos}}
+		}
+	}
+	return []query.ParseError{{Message: "could not parse query", Pos: 0}}
+}
+
+// prints the query with a caret under the offending position
+func printQueryErrorAt(w io.Writer, input string, parseErr query.ParseError) {
+	pos := parseErr.Pos
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > len(input) {
+		pos = len(input)
+	}
+
+	fmt.Fprintf(w, "  %s\n", input)
+	fmt.Fprintf(w, "  %s^\n", strings.Repeat(" ", pos))
+	fmt.Fprintf(w, "  %s\n", parseErr.String())
+}
+
+func printResolvedFilter(w io.Writer, filter repository.TaskFilter, projects []query.ProjectResolution) {
+	var rows int
+	row := func(label, value string) {
+		rows++
+		fmt.Fprintf(w, "  %-16s %s\n", label+":", value)
+	}
+
+	if filter.Status != "" {
+		row("Status", string(filter.Status))
+	}
+	if filter.Priority != "" {
+		row("Priority", string(filter.Priority))
+	}
+	for _, resolution := range projects {
+		row("Project", fmt.Sprintf("%s [#%d]", resolution.String(), resolution.Project.ID))
+	}
+	if len(filter.Tags) > 0 {
+		row("Tags", strings.Join(filter.Tags, ", "))
+	}
+	if len(filter.ExcludeTags) > 0 {
+		row("Exclude tags", strings.Join(filter.ExcludeTags, ", "))
+	}
+	if value := formatFilterRange(filter.DueDateFrom, filter.DueDateTo); value != "" {
+		row("Due", value)
+	}
+	if value := formatFilterRange(filter.CreatedFrom, filter.CreatedTo); value != "" {
+		row("Created", value)
+	}
+	if value := formatFilterRange(filter.UpdatedFrom, filter.UpdatedTo); value != "" {
+		row("Updated", value)
+	}
+	if rows == 0 {
+		fmt.Fprintln(w, "  (no conditions, matches every task)")
+	}
+}
+
+// e.g. "2025-01-01 00:00:00 .. 2025-01-07 23:59:59"
+func formatFilterRange(from, to *string) string {
+	switch {
+	case from == nil && to == nil:
+		return ""
+	case from != nil && to != nil:
+		return fmt.Sprintf("%s .. %s", *from, *to)
+	case from != nil:
+		return fmt.Sprintf("from %s", *from)
+	default:
+		return fmt.Sprintf("until %s", *to)
+	}
+}
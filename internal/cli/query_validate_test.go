@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestValidateQuery(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	require.NoError(t, projectRepo.Create(ctx, domain.NewProject("backend")))
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+		want    []string
+	}{
+		{"resolves projects", "status:pending @~bakend -tag:wontfix", false, []string{"✓ Valid query", "bakend → backend (fuzzy)", "Exclude tags:    wontfix"}},
+		{"expands dates", "due:2025-01-15", false, []string{"Due:             2025-01-15 00:00:00 .. 2025-01-15 23:59:59"}},
+		{"parse error position", "status:pending tag:", true, []string{"                     ^", "parse error at position 19"}},
+		{"lexer error position", `tag:"abc`, true, []string{"      ^", "unterminated quoted string"}},
+		{"bad values", "status:done @nope", true, []string{"status:done: invalid status", "project:nope: project not found"}},
+		{"empty", "  ", true, []string{"Query is empty"}},
+		{"or groups", "(status:pending | status:in_progress) @backend", false, []string{"Matches:", "(status:pending OR status:in_progress) project:backend", "backend → backend"}},
+		{"unbalanced parens", "(status:pending", true, []string{"^", "never closed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := validateQuery(ctx, &out, projectRepo, tt.input, 0)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, errInvalidQuery)
+			} else {
+				assert.NoError(t, err)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, out.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/notify"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	remindWithin time.Duration
+	remindNotify string
+)
+
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "Show or send reminders for overdue tasks and tasks due soon",
+	Long: `List the open tasks that are overdue or due within --within of now, grouped
+into "Overdue" and "Due soon". Due dates are whole days, so a window that
+reaches into a day takes in everything due that day. Snoozed tasks are left
+out.
+
+--notify sends one notification per group instead, and nothing at all when no
+task is due, so it can run from cron:
+
+  desktop   notify-send on Linux, osascript on macOS, msg on Windows. falls
+            back to stdout when the tool isn't installed
+  stdout    plain text, for cron to mail
+
+Examples:
+  taskflow remind
+  taskflow remind --within 72h
+  taskflow remind --notify desktop
+
+  # every morning at 9
+  0 9 * * * taskflow remind --notify desktop`,
+	Args: cobra.NoArgs,
+	RunE: runRemind,
+}
+
+func init() {
+	rootCmd.AddCommand(remindCmd)
+
+	remindCmd.Flags().DurationVar(&remindWithin, "within", 24*time.Hour, "Include tasks due within this long from now (e.g. 24h, 72h)")
+	remindCmd.Flags().StringVar(&remindNotify, "notify", "", "Send notifications instead of listing (desktop, stdout)")
+	remindCmd.RegisterFlagCompletionFunc("within", cobra.FixedCompletions([]string{"24h", "48h", "72h", "168h"}, cobra.ShellCompDirectiveNoFileComp))
+	remindCmd.RegisterFlagCompletionFunc("notify", cobra.FixedCompletions([]string{"desktop", "stdout"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+type reminders struct {
+	Overdue []*domain.Task
+	DueSoon []*domain.Task
+}
+
+func runRemind(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	if remindWithin <= 0 {
+		return reportError(cmd, styles, fmt.Sprintf("--within must be positive, got %s", remindWithin))
+	}
+
+	var notifier notify.Notifier
+	switch remindNotify {
+	case "":
+	case "stdout":
+		notifier = notify.NewWriter(cmd.OutOrStdout())
+	case "desktop":
+		if desktop, ok := notify.Desktop(runtime.GOOS, exec.LookPath); ok {
+			notifier = desktop
+		} else {
+			fmt.Fprintln(cmd.ErrOrStderr(), styles.Info.Render("⚠ No desktop notification tool found, writing to stdout"))
+			notifier = notify.NewWriter(cmd.OutOrStdout())
+		}
+	default:
+		return reportError(cmd, styles, fmt.Sprintf("Unsupported --notify value: %s (use desktop or stdout)", remindNotify))
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	due, err := findReminders(context.Background(), repo, time.Now(), remindWithin, cfg.OverdueGraceDays)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to find due tasks: %v", err))
+	}
+
+	if notifier != nil {
+		if err := sendReminders(notifier, due); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("Failed to send reminders: %v", err))
+		}
+		return nil
+	}
+
+	displayReminders(due, remindWithin, styles)
+	return nil
+}
+
+// the open, unsnoozed tasks that are overdue on now's day and those due
+// within the window that aren't overdue yet, both soonest due first
+func findReminders(ctx context.Context, repo repository.TaskRepository, now time.Time, within time.Duration, graceDays int) (*reminders, error) {
+	filter := repository.TaskFilter{
+		ExcludeStatuses: []domain.Status{domain.StatusCompleted, domain.StatusCancelled},
+		HideSnoozed:     true,
+		SortBy:          "due_date",
+		SortOrder:       "asc",
+	}
+
+	overdue := filter
+	_, overdue.DueDateTo = query.DueDateRange("overdue", now, graceDays)
+	overdueTasks, err := repo.List(ctx, overdue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue tasks: %w", err)
+	}
+
+	dueSoon := filter
+	from, to := query.DueSoonRange(now, within, graceDays)
+	dueSoon.DueDateFrom, dueSoon.DueDateTo = &from, &to
+	dueSoonTasks, err := repo.List(ctx, dueSoon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks due soon: %w", err)
+	}
+
+	return &reminders{Overdue: overdueTasks, DueSoon: dueSoonTasks}, nil
+}
+
+// one notification per group that has tasks
+func sendReminders(notifier notify.Notifier, due *reminders) error {
+	for _, group := range []struct {
+		title string
+		tasks []*domain.Task
+	}{
+		{"Overdue", due.Overdue},
+		{"Due soon", due.DueSoon},
+	} {
+		if len(group.tasks) == 0 {
+			continue
+		}
+		lines := make([]string, len(group.tasks))
+		for i, task := range group.tasks {
+			lines[i] = fmt.Sprintf("#%d %s (due %s)", task.ID, task.Title, task.DueDate.Format("2006-01-02"))
+		}
+		title := fmt.Sprintf("taskflow: %s (%d)", group.title, len(group.tasks))
+		if err := notifier.Notify(title, strings.Join(lines, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func displayReminders(due *reminders, within time.Duration, styles *theme.Styles) {
+	if len(due.Overdue) == 0 && len(due.DueSoon) == 0 {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Nothing overdue or due within %s", within)))
+		return
+	}
+
+	for _, group := range []struct {
+		title string
+		tasks []*domain.Task
+		style func(...string) string
+	}{
+		{"Overdue", due.Overdue, styles.Error.Render},
+		{"Due soon", due.DueSoon, styles.HighText.Render},
+	} {
+		fmt.Println()
+		fmt.Println(group.style(fmt.Sprintf("%s (%d)", group.title, len(group.tasks))))
+		if len(group.tasks) == 0 {
+			fmt.Println("  (none)")
+			continue
+		}
+		for _, task := range group.tasks {
+			line := fmt.Sprintf("  #%-5d %s", task.ID, task.Title)
+			note := "due " + task.DueDate.Format("2006-01-02")
+			if task.ProjectName != "" {
+				note += ", " + task.ProjectName
+			}
+			fmt.Println(line + styles.Info.Render(fmt.Sprintf("  (%s)", note)))
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestFindReminders(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := sqlite.NewTaskRepository(db)
+
+	now := time.Date(2026, 3, 13, 10, 0, 0, 0, time.Local)
+	create := func(title string, dueInDays int, edit func(*domain.Task)) int64 {
+		task := domain.NewTask(title)
+		due := time.Date(2026, 3, 13+dueInDays, 0, 0, 0, 0, time.UTC)
+		task.DueDate = &due
+		if edit != nil {
+			edit(task)
+		}
+		require.NoError(t, repo.Create(ctx, task))
+		return task.ID
+	}
+
+	lastWeek := create("Last week", -7, nil)
+	yesterday := create("Yesterday", -1, nil)
+	today := create("Today", 0, nil)
+	tomorrow := create("Tomorrow", 1, nil)
+	create("In two days", 2, nil)
+	create("Done tomorrow", 1, func(task *domain.Task) { task.Status = domain.StatusCompleted })
+	create("Cancelled yesterday", -1, func(task *domain.Task) { task.Status = domain.StatusCancelled })
+	create("Snoozed", 0, func(task *domain.Task) {
+		until := time.Now().AddDate(0, 0, 7)
+		task.SnoozedUntil = &until
+	})
+	create("No due date", 0, func(task *domain.Task) { task.DueDate = nil })
+
+	tests := []struct {
+		name      string
+		within    time.Duration
+		graceDays int
+		overdue   []int64
+		dueSoon   []int64
+	}{
+		{"a day reaches into tomorrow", 24 * time.Hour, 0, []int64{lastWeek, yesterday}, []int64{today, tomorrow}},
+		{"window ending today", 13 * time.Hour, 0, []int64{lastWeek, yesterday}, []int64{today}},
+		{"grace days are due soon, not overdue", 13 * time.Hour, 1, []int64{lastWeek}, []int64{yesterday, today}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			due, err := findReminders(ctx, repo, now, tt.within, tt.graceDays)
+			require.NoError(t, err)
+			assert.Equal(t, tt.overdue, taskIDsOf(due.Overdue), "overdue")
+			assert.Equal(t, tt.dueSoon, taskIDsOf(due.DueSoon), "due soon")
+		})
+	}
+}
+
+type fakeNotifier struct {
+	titles   []string
+	messages []string
+	err      error
+}
+
+func (n *fakeNotifier) Notify(title, message string) error {
+	n.titles = append(n.titles, title)
+	n.messages = append(n.messages, message)
+	return n.err
+}
+
+func TestSendReminders(t *testing.T) {
+	yesterday := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	renew := &domain.Task{ID: 3, Title: "Renew cert", DueDate: &yesterday}
+	docs := &domain.Task{ID: 7, Title: "Write docs", DueDate: &tomorrow}
+	review := &domain.Task{ID: 9, Title: "Review PR", DueDate: &tomorrow}
+
+	t.Run("one notification per group", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		require.NoError(t, sendReminders(notifier, &reminders{Overdue: []*domain.Task{renew}, DueSoon: []*domain.Task{docs, review}}))
+		assert.Equal(t, []string{"taskflow: Overdue (1)", "taskflow: Due soon (2)"}, notifier.titles)
+		assert.Equal(t, []string{
+			"#3 Renew cert (due 2026-03-12)",
+			"#7 Write docs (due 2026-03-14)\n#9 Review PR (due 2026-03-14)",
+		}, notifier.messages)
+	})
+
+	t.Run("empty groups are skipped", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		require.NoError(t, sendReminders(notifier, &reminders{DueSoon: []*domain.Task{docs}}))
+		assert.Equal(t, []string{"taskflow: Due soon (1)"}, notifier.titles)
+
+		notifier = &fakeNotifier{}
+		require.NoError(t, sendReminders(notifier, &reminders{}))
+		assert.Empty(t, notifier.titles)
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		notifier := &fakeNotifier{err: errors.New("no display")}
+		err := sendReminders(notifier, &reminders{Overdue: []*domain.Task{renew}, DueSoon: []*domain.Task{docs}})
+		assert.EqualError(t, err, "no display")
+		assert.Len(t, notifier.titles, 1)
+	})
+}
+
+func taskIDsOf(tasks []*domain.Task) []int64 {
+	var ids []int64
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
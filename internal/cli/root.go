@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
 	"task-management/internal/theme"
 	"task-management/internal/tui"
 )
@@ -19,21 +22,142 @@ var rootCmd = &cobra.Command{
 the simplicity of traditional todo lists with powerful features inspired by
 modern project management tools.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// shell completion and prompt helpers must never block on the interactive setup
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd || cmd == countCmd {
+			return nil
+		}
+		applyLabels()
+
+		if writesToDatabase(cmd) {
+			if cfg, err := config.LoadConfig(); err == nil && readOnlyMode(cfg) {
+				return errReadOnly(cmd)
+			}
+		}
+
+		// the config commands report problems themselves
+		if cmd == configCmd || cmd.Parent() == configCmd {
+			return nil
+		}
+
 		// check if we need to run initial setup
-		return checkAndRunSetup()
+		if err := checkAndRunSetup(); err != nil {
+			return err
+		}
+		warnConfigProblems()
+		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		displayWelcome()
 	},
 }
 
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&readOnly, "read-only", false, "Open the database read-only and refuse commands that change it")
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		if !errors.Is(err, errReported) {
+			fmt.Fprintln(os.Stderr, err)
+		}
 		os.Exit(1)
 	}
 }
 
+// returned by commands that already told the user what went wrong, Execute
+// then only sets the exit code
+var errReported = errors.New("error already reported")
+
+// for a command that printed its own errors but should still exit non-zero
+func reported(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return errReported
+}
+
+// prints msg to stderr as an error and makes the command exit non-zero
+func reportError(cmd *cobra.Command, styles *theme.Styles, msg string) error {
+	fmt.Fprintln(cmd.ErrOrStderr(), styles.Error.Render("✗ "+msg))
+	return reported(cmd)
+}
+
+// set by --read-only, read_only in the config does the same
+var readOnly bool
+
+// commands carrying this annotation change the database and are refused up
+// front in read-only mode. a flag name instead of "true" refuses the command
+// only when that flag is passed
+const writesAnnotation = "writes_database"
+
+var writesDatabase = map[string]string{writesAnnotation: "true"}
+
+// for a command that only changes the database when flag is passed
+func writesDatabaseWith(flag string) map[string]string {
+	return map[string]string{writesAnnotation: flag}
+}
+
+func writesToDatabase(cmd *cobra.Command) bool {
+	writes := cmd.Annotations[writesAnnotation]
+	return writes == "true" || (writes != "" && cmd.Flags().Changed(writes))
+}
+
+func readOnlyMode(cfg *config.Config) bool {
+	return readOnly || cfg.ReadOnly
+}
+
+// opens the database with the settings the repositories take from the
+// config, so every command and the TUI enforce the same rules
+func openDB(cfg *config.Config) (*sqlite.DB, error) {
+	transitions, err := loadStatusTransitions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	treatment, err := cfg.CancelledTreatment()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cancelled_completion config: %w", err)
+	}
+	return sqlite.NewDB(sqlite.Config{
+		Path:               cfg.DBPath,
+		ReadOnly:           readOnlyMode(cfg),
+		StatusTransitions:  transitions,
+		OverdueGraceDays:   cfg.OverdueGraceDays,
+		MaxProjectDepth:    cfg.MaxProjectDepth,
+		CancelledTreatment: treatment,
+	})
+}
+
+// returns the configured transition rules, or nil when enforcement is off
+func loadStatusTransitions(cfg *config.Config) (domain.StatusTransitions, error) {
+	if !cfg.EnforceStatusTransitions {
+		return nil, nil
+	}
+
+	transitions, err := domain.ParseStatusTransitions(cfg.StatusTransitions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status_transitions config: %w", err)
+	}
+	return transitions, nil
+}
+
+// for a command, or a flag of one, that would change the database
+func errReadOnly(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return fmt.Errorf("read-only mode: '%s' would change the database", cmd.CommandPath())
+}
+
+// shows statuses and priorities with the configured labels. a broken
+// mapping keeps the defaults, warnConfigProblems reports it
+func applyLabels() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return
+	}
+	if labels, err := cfg.Labels(); err == nil {
+		theme.SetLabels(labels)
+	}
+}
+
 func displayWelcome() {
 	// load theme
 	cfg, err := config.LoadConfig()
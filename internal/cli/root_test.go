@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+func TestOpenDBAppliesStatusTransitions(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DBPath = filepath.Join(t.TempDir(), "tasks.db")
+	cfg.EnforceStatusTransitions = true
+
+	db, err := openDB(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// every repository built on the DB checks the rules, not just the ones
+	// a command remembered to configure
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+	task := domain.NewTask("Shipped")
+	task.Status = domain.StatusCompleted
+	require.NoError(t, repo.Create(ctx, task))
+
+	task.Status = domain.StatusCancelled
+	assert.ErrorContains(t, repo.Update(ctx, task), "terminal state")
+
+	cancelled := domain.StatusCancelled
+	_, err = repo.BulkUpdate(ctx, repository.TaskFilter{IDs: []int64{task.ID}}, repository.TaskUpdate{Status: &cancelled})
+	assert.ErrorContains(t, err, "terminal state")
+
+	cfg.StatusTransitions = map[string][]string{"pending": {"done"}}
+	_, err = openDB(cfg)
+	assert.ErrorContains(t, err, "invalid status_transitions config")
+
+	cfg.EnforceStatusTransitions = false
+	unchecked, err := openDB(cfg)
+	require.NoError(t, err)
+	defer unchecked.Close()
+	assert.Nil(t, unchecked.StatusTransitions())
+}
+
+func TestOpenDBAppliesRepositorySettings(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DBPath = filepath.Join(t.TempDir(), "tasks.db")
+	cfg.OverdueGraceDays = 2
+	cfg.MaxProjectDepth = 1
+
+	db, err := openDB(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	parent := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, parent))
+	child := domain.NewProject("API")
+	child.ParentID = &parent.ID
+	assert.ErrorContains(t, projectRepo.Create(ctx, child), "max_project_depth")
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	task := domain.NewTask("Within the grace days")
+	task.DueDate = &yesterday
+	require.NoError(t, taskRepo.Create(ctx, task))
+	stats, err := taskRepo.Aggregate(ctx, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, stats.Overdue)
+	assert.Equal(t, 2, db.OverdueGraceDays(), "the TUI takes the grace days from the DB")
+
+	cfg.CancelledCompletion = "sometimes"
+	_, err = openDB(cfg)
+	assert.ErrorContains(t, err, "invalid cancelled_completion config")
+}
+
+func TestWritesToDatabase(t *testing.T) {
+	assert.True(t, writesToDatabase(&cobra.Command{Annotations: writesDatabase}))
+	assert.False(t, writesToDatabase(&cobra.Command{}))
+
+	cmd := &cobra.Command{Annotations: writesDatabaseWith("save")}
+	cmd.Flags().String("save", "", "")
+	assert.False(t, writesToDatabase(cmd), "without --save")
+	require.NoError(t, cmd.ParseFlags([]string{"--save", "Urgent"}))
+	assert.True(t, writesToDatabase(cmd), "with --save")
+
+	assert.False(t, writesToDatabase(doctorCmd), "doctor without --fix")
+	defer func() {
+		doctorFix = false
+		doctorCmd.Flags().Lookup("fix").Changed = false
+	}()
+	require.NoError(t, doctorCmd.ParseFlags([]string{"--fix"}))
+	assert.True(t, writesToDatabase(doctorCmd), "doctor with --fix")
+}
+
+func TestNewTUIModelChecksTheConfig(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DBPath = filepath.Join(t.TempDir(), "tasks.db")
+	db, err := openDB(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	themeObj := theme.GetDefaultTheme()
+	styles := theme.NewStyles(themeObj)
+	ctx := context.Background()
+
+	_, err = newTUIModel(ctx, db, cfg, repository.TaskFilter{}, 20, themeObj, styles)
+	require.NoError(t, err)
+
+	cfg.QuickKeys = map[string]string{"someday": "S"}
+	_, err = newTUIModel(ctx, db, cfg, repository.TaskFilter{}, 20, themeObj, styles)
+	assert.ErrorContains(t, err, "invalid quick_keys config")
+
+	cfg.QuickKeys = nil
+	cfg.ProjectTreeSort = "color"
+	_, err = newTUIModel(ctx, db, cfg, repository.TaskFilter{}, 20, themeObj, styles)
+	assert.ErrorContains(t, err, "invalid project_tree_sort config")
+}
+
+func TestListQueryErrorsExitNonZero(t *testing.T) {
+	cfg := config.GetDefaultConfig()
+	cfg.DBPath = filepath.Join(t.TempDir(), "tasks.db")
+	db, err := openDB(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	themeObj := theme.GetDefaultTheme()
+	styles := theme.NewStyles(themeObj)
+
+	oldQuery := listQuery
+	defer func() { listQuery = oldQuery }()
+	listQuery = "status:someday"
+
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetErr(&stderr)
+
+	err = runListWithQueryLanguage(context.Background(), cmd, db, cfg, themeObj, styles, 20)
+	assert.ErrorIs(t, err, errReported)
+	assert.Contains(t, stderr.String(), "Query conversion error")
+}
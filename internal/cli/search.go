@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/fuzzy"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	searchMode      string
+	searchThreshold int
+	searchProject   string
+	searchIDsOnly   bool
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tasks by text, regex or fuzzy match",
+	Long: `Search tasks and print the matches as a table.
+
+Searches title, description, project name and tags. Modes:
+  text   - case-insensitive substring match (default)
+  regex  - regular expression match
+  fuzzy  - typo-tolerant match, results ranked by score
+  fts    - every word as a word or its start, looked up in the full-text
+           index and ranked by relevance. leaves out project names. falls
+           back to text when SQLite was built without FTS5
+
+Searches are recorded in the same search history as the TUI. With --ids-only
+only the IDs are printed, for piping into update - or delete -.`,
+	Example: `  taskflow search login
+  taskflow search "^fix" --mode regex
+  taskflow search bcknd --mode fuzzy --threshold 70
+  taskflow search "login bug" --mode fts
+  taskflow search api --project backend
+  taskflow search login --ids-only | taskflow update - --status completed`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	searchCmd.Flags().StringVarP(&searchMode, "mode", "m", "text", "Search mode (text, regex, fuzzy, fts)")
+	searchCmd.Flags().IntVar(&searchThreshold, "threshold", 60, "Minimum fuzzy match score (0-100)")
+	searchCmd.Flags().StringVarP(&searchProject, "project", "p", "", "Only search tasks in this project (name, alias or ID)")
+	searchCmd.Flags().BoolVar(&searchIDsOnly, "ids-only", false, "Print only the IDs of the matches, one per line")
+
+	searchCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	searchCmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions([]string{"text", "regex", "fuzzy", "fts"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+type searchResult struct {
+	task  *domain.Task
+	score int
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	queryText := strings.TrimSpace(args[0])
+	mode := domain.SearchMode(strings.ToLower(searchMode))
+	if err := validateSearchOptions(queryText, mode, searchThreshold); err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	searchHistoryRepo := sqlite.NewSearchHistoryRepository(db)
+	ctx := context.Background()
+
+	filter := repository.TaskFilter{
+		SearchQuery:    queryText,
+		SearchMode:     string(mode),
+		FuzzyAlgorithm: cfg.FuzzyAlgorithm,
+	}
+	if mode == domain.SearchModeText {
+		filter.SearchMode = ""
+	}
+	if mode == domain.SearchModeFuzzy {
+		filter.FuzzyThreshold = searchThreshold
+	}
+
+	if searchProject != "" {
+		projectID, err := promptProjectID(ctx, projectRepo, searchProject)
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+		filter.ProjectID = projectID
+	}
+
+	results, err := searchTasks(ctx, taskRepo, filter)
+	truncated := errors.Is(err, repository.ErrSearchTruncated)
+	if err != nil && !truncated {
+		return reportError(cmd, styles, fmt.Sprintf("Search failed: %v", err))
+	}
+
+	// read-only mode leaves the history as it is
+	if !readOnlyMode(cfg) {
+		entry := &domain.SearchHistory{
+			QueryText:     queryText,
+			SearchMode:    historySearchMode(mode),
+			QueryType:     domain.QueryTypeSimple,
+			ProjectFilter: searchProject,
+			ResultCount:   len(results),
+		}
+		if mode == domain.SearchModeFuzzy {
+			entry.FuzzyThreshold = &searchThreshold
+		}
+		if err := searchHistoryRepo.RecordSearch(ctx, entry); err != nil {
+			// stdout is the IDs with --ids-only
+			out := os.Stdout
+			if searchIDsOnly {
+				out = os.Stderr
+			}
+			fmt.Fprintln(out, styles.Info.Render(fmt.Sprintf("⚠ Failed to record search history: %v", err)))
+		}
+	}
+
+	if searchIDsOnly {
+		tasks := make([]*domain.Task, len(results))
+		for i, result := range results {
+			tasks[i] = result.task
+		}
+		printTaskIDs(os.Stdout, tasks)
+		if truncated {
+			fmt.Fprintln(os.Stderr, styles.Info.Render(searchTruncatedNote))
+		}
+		return nil
+	}
+
+	displaySearchResults(results, mode, styles, cfg.OverdueGraceDays)
+	if truncated {
+		fmt.Println(styles.Info.Render(searchTruncatedNote))
+	}
+
+	return nil
+}
+
+func validateSearchOptions(queryText string, mode domain.SearchMode, threshold int) error {
+	if queryText == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+
+	switch mode {
+	case domain.SearchModeText, domain.SearchModeFTS:
+	case domain.SearchModeRegex:
+		if _, err := regexp.Compile(queryText); err != nil {
+			return fmt.Errorf("invalid regex pattern %q: %v", queryText, err)
+		}
+	case domain.SearchModeFuzzy:
+		if threshold < 0 || threshold > 100 {
+			return fmt.Errorf("fuzzy threshold must be between 0 and 100")
+		}
+	default:
+		return fmt.Errorf("invalid search mode: %s (must be text, regex, fuzzy, or fts)", mode)
+	}
+
+	return nil
+}
+
+// fts searches find what a text search would for whole words, the history
+// only knows the modes it can replay
+func historySearchMode(mode domain.SearchMode) domain.SearchMode {
+	if mode == domain.SearchModeFTS {
+		return domain.SearchModeText
+	}
+	return mode
+}
+
+const searchTruncatedNote = "⚠ Search truncated: only the first matches are shown, narrow the pattern to see the rest"
+
+// runs the search and attaches fuzzy scores, the repository already returns
+// fuzzy matches best first. a truncated regex search returns its partial
+// results with repository.ErrSearchTruncated
+func searchTasks(ctx context.Context, repo repository.TaskRepository, filter repository.TaskFilter) ([]searchResult, error) {
+	tasks, err := repo.List(ctx, filter)
+	truncated := errors.Is(err, repository.ErrSearchTruncated)
+	if err != nil && !truncated {
+		return nil, err
+	}
+
+	var scorer fuzzy.Scorer
+	if filter.SearchMode == string(domain.SearchModeFuzzy) {
+		scorer, err = fuzzy.NewScorer(filter.FuzzyAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]searchResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = searchResult{task: task}
+		if scorer != nil {
+			results[i].score = task.SearchScore(func(text string) int {
+				return scorer.Score(filter.SearchQuery, text)
+			})
+		}
+	}
+
+	if truncated {
+		return results, repository.ErrSearchTruncated
+	}
+	return results, nil
+}
+
+func displaySearchResults(results []searchResult, mode domain.SearchMode, styles *theme.Styles, graceDays int) {
+	fmt.Println()
+
+	if len(results) == 0 {
+		fmt.Println(styles.Info.Render("No tasks matched your search."))
+		fmt.Println()
+		return
+	}
+
+	headers := []string{
+		styles.Header.Render(fmt.Sprintf("%-4s", "#")),
+		styles.Header.Render(fmt.Sprintf("%-6s", "ID")),
+	}
+	if mode == domain.SearchModeFuzzy {
+		headers = append(headers, styles.Header.Render(fmt.Sprintf("%-6s", "Score")))
+	}
+	headers = append(headers,
+		styles.Header.Render("Status"),
+		styles.Header.Render("Priority"),
+		styles.Header.Render("Title"),
+		styles.Header.Render("Project"),
+		styles.Header.Render("Tags"),
+		styles.Header.Render("Due Date"),
+	)
+	fmt.Println(strings.Join(headers, " "))
+
+	separator := strings.Repeat("─", 140)
+	fmt.Println(styles.Separator.Render(separator))
+
+	for i, result := range results {
+		prefix := fmt.Sprintf("%-4d %-6d ", i+1, result.task.ID)
+		if mode == domain.SearchModeFuzzy {
+			prefix += fmt.Sprintf("%-6d ", result.score)
+		}
+		fmt.Print(styles.Cell.Render(prefix))
+		printTaskRow(result.task, styles, graceDays)
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %d task(s)\n", len(results))
+	fmt.Println()
+}
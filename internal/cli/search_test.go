@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestValidateSearchOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		mode      domain.SearchMode
+		threshold int
+		wantErr   string
+	}{
+		{"text", "login", domain.SearchModeText, 60, ""},
+		{"valid regex", "^fix.*bug$", domain.SearchModeRegex, 60, ""},
+		{"invalid regex", "fix(", domain.SearchModeRegex, 60, "invalid regex pattern"},
+		{"fuzzy", "bcknd", domain.SearchModeFuzzy, 70, ""},
+		{"fuzzy threshold out of range", "bcknd", domain.SearchModeFuzzy, 101, "between 0 and 100"},
+		{"fts", "login bug", domain.SearchModeFTS, 60, ""},
+		{"unknown mode", "login", domain.SearchMode("glob"), 60, "invalid search mode"},
+		{"empty query", "", domain.SearchModeText, 60, "cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSearchOptions(tt.query, tt.mode, tt.threshold)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSearchTasks(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := sqlite.NewTaskRepository(db)
+
+	for _, title := range []string{"Fix backend login", "Write backup script", "Plan sprint"} {
+		require.NoError(t, repo.Create(ctx, domain.NewTask(title)))
+	}
+
+	t.Run("text mode has no scores", func(t *testing.T) {
+		results, err := searchTasks(ctx, repo, repository.TaskFilter{SearchQuery: "back"})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		for _, result := range results {
+			assert.Zero(t, result.score)
+		}
+	})
+
+	t.Run("fuzzy mode ranks by score", func(t *testing.T) {
+		results, err := searchTasks(ctx, repo, repository.TaskFilter{
+			SearchQuery:    "backend",
+			SearchMode:     "fuzzy",
+			FuzzyThreshold: 60,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+
+		assert.Equal(t, "Fix backend login", results[0].task.Title)
+		assert.Greater(t, results[0].score, 60)
+		for i := 1; i < len(results); i++ {
+			assert.LessOrEqual(t, results[i].score, results[i-1].score)
+			assert.GreaterOrEqual(t, results[i].score, 60)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show <task-id|key>",
+	Short: "Show the details of one task",
+	Long: `Show every field of a task.
+
+Tasks are numbered within the project they were created in, so besides its
+global ID a task can be named by its project key, e.g. BACKEND-12. The key's
+prefix is the project's key prefix, taken from its name in upper case with
+spaces turned into dashes when the project was created, or one of its aliases
+written the same way. Renaming the project keeps the prefix, and a task keeps
+its key when it moves to another project.
+
+Examples:
+  taskflow show 42
+  taskflow show BACKEND-12
+  taskflow show mobile-app-3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShow,
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+}
+
+func runShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	task, err := resolveTaskRef(ctx, taskRepo, projectRepo, args[0])
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	var blockers []*domain.Task
+	if task.IsBlocked() {
+		if blockers, err = taskRepo.GetBlockers(ctx, task.ID); err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+	}
+
+	displayTaskDetails(task, blockers, cfg.OverdueGraceDays, styles)
+	return nil
+}
+
+// blockers are the unfinished tasks this one depends on
+func displayTaskDetails(task *domain.Task, blockers []*domain.Task, graceDays int, styles *theme.Styles) {
+	fmt.Println()
+
+	title := fmt.Sprintf("#%d %s", task.ID, display.TaskTitlePrefix(task)+task.Title)
+	if key := task.Key(); key != "" {
+		title = fmt.Sprintf("%s (%s)", title, key)
+	}
+	fmt.Println(styles.Title.Render(title))
+	fmt.Println()
+
+	row := func(label, value string) {
+		fmt.Printf("  %s %s\n", styles.Info.Render(label), value)
+	}
+
+	row("Status:", fmt.Sprintf("%s %s", styles.StatusSymbol(task.Status), styles.StatusLabel(task.Status)))
+	row("Priority:", fmt.Sprintf("%s %s", styles.PrioritySymbol(task.Priority), styles.PriorityLabel(task.Priority)))
+	if task.ProjectName != "" {
+		row("Project:", task.ProjectName)
+	}
+	if len(task.Tags) > 0 {
+		row("Tags:", strings.Join(task.Tags, ", "))
+	}
+	if task.DueDate != nil {
+		row("Due:", fmt.Sprintf("%s (%s)", task.DueDate.Format("2006-01-02"), display.FormatDueDate(task.DueDate, time.Now(), graceDays)))
+	}
+	if task.WaitingOn != "" {
+		row("Waiting on:", task.WaitingOn)
+	}
+	if len(task.DependsOn) > 0 {
+		ids := make([]string, len(task.DependsOn))
+		for i, id := range task.DependsOn {
+			ids[i] = fmt.Sprintf("#%d", id)
+		}
+		row("Depends on:", strings.Join(ids, ", "))
+	}
+	for i, blocker := range blockers {
+		label := "Blocked by:"
+		if i > 0 {
+			label = strings.Repeat(" ", len(label))
+		}
+		row(label, fmt.Sprintf("⛔ #%d %s (%s)", blocker.ID, blocker.Title, styles.StatusLabel(blocker.Status)))
+	}
+	if task.Recurrence != "" {
+		row("Repeats:", task.Recurrence)
+	}
+	if task.Color != "" {
+		row("Color:", task.Color)
+	}
+	if task.IsSnoozed(time.Now()) {
+		row("Snoozed:", "until "+task.SnoozedUntil.Format("2006-01-02 15:04"))
+	}
+	row("Created:", task.CreatedAt.Format("2006-01-02 15:04"))
+	row("Updated:", task.UpdatedAt.Format("2006-01-02 15:04"))
+	if task.CompletedAt != nil {
+		row("Completed:", task.CompletedAt.Format("2006-01-02 15:04"))
+	}
+
+	if task.Description != "" {
+		fmt.Println()
+		fmt.Println(styles.Subtitle.Render("Description:"))
+		fmt.Printf("  %s\n", task.Description)
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var smartListCmd = &cobra.Command{
+	Use:   "smartlist",
+	Short: "Manage tag-based smart lists",
+	Long: `Smart lists are saved views that follow a single tag, named after it
+("#bug" for the tag bug). They show up in the view picker and view list like
+any other view, and since the filter is live a task joins or leaves the list
+as soon as it gains or loses the tag.
+
+Changing a smart list's filters with view update turns it into a plain view.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var (
+	smartListHotKey   int
+	smartListNoHotKey bool
+)
+
+var smartListCreateCmd = &cobra.Command{
+	Use:   "create <tag>",
+	Short: "Create a smart list for a tag",
+	Long: `Create a smart list for a tag. The first free hot key (1-9) is assigned
+to it unless --hotkey picks one or --no-hotkey is given.`,
+	Example: `  taskflow smartlist create bug
+  taskflow smartlist create release --hotkey 4
+  taskflow smartlist create someday --no-hotkey`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runSmartListCreate,
+}
+
+var smartListListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List smart lists with their task counts",
+	Args:  cobra.NoArgs,
+	RunE:  runSmartListList,
+}
+
+func init() {
+	rootCmd.AddCommand(smartListCmd)
+	smartListCmd.AddCommand(smartListCreateCmd)
+	smartListCmd.AddCommand(smartListListCmd)
+
+	smartListCreateCmd.Flags().IntVarP(&smartListHotKey, "hotkey", "k", 0, "Hot key (1-9) instead of the first free one")
+	smartListCreateCmd.Flags().BoolVar(&smartListNoHotKey, "no-hotkey", false, "Don't assign a hot key")
+	smartListCreateCmd.MarkFlagsMutuallyExclusive("hotkey", "no-hotkey")
+
+	smartListCreateCmd.ValidArgsFunction = completeTags
+}
+
+func runSmartListCreate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	viewRepo := sqlite.NewViewRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	view, err := createSmartList(ctx, viewRepo, args[0], smartListHotKey, !smartListNoHotKey)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Smart list '%s' created", view.Name)))
+	if view.HotKey != nil {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("  Press %d in TUI to quick-apply", *view.HotKey)))
+	}
+	if count, _, err := countViewTasks(ctx, taskRepo, projectRepo, view); err == nil {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("  Tasks: %d", count)))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// creates the smart list for tag. hotKey picks its hot key, 0 takes the
+// first free one when autoHotKey is set
+func createSmartList(ctx context.Context, viewRepo repository.ViewRepository, tag string, hotKey int, autoHotKey bool) (*domain.SavedView, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+	if tag == "" {
+		return nil, fmt.Errorf("tag cannot be empty")
+	}
+
+	if existing, err := viewRepo.GetByName(ctx, domain.SmartListName(tag)); err == nil && existing != nil {
+		return nil, fmt.Errorf("view '%s' already exists", existing.Name)
+	}
+
+	view := domain.NewSmartList(tag)
+
+	switch {
+	case hotKey != 0:
+		view.HotKey = &hotKey
+	case autoHotKey:
+		free, err := firstFreeHotKey(ctx, viewRepo)
+		if err != nil {
+			return nil, err
+		}
+		view.HotKey = free
+	}
+
+	if err := viewRepo.Create(ctx, view); err != nil {
+		return nil, fmt.Errorf("failed to create smart list: %w", err)
+	}
+	return view, nil
+}
+
+// the lowest hot key no view uses yet, nil when all nine are taken
+func firstFreeHotKey(ctx context.Context, viewRepo repository.ViewRepository) (*int, error) {
+	views, err := viewRepo.List(ctx, repository.ViewFilter{HasHotKey: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+
+	used := make(map[int]bool)
+	for _, view := range views {
+		if view.HotKey != nil {
+			used[*view.HotKey] = true
+		}
+	}
+
+	for hotKey := 1; hotKey <= 9; hotKey++ {
+		if !used[hotKey] {
+			return &hotKey, nil
+		}
+	}
+	return nil, nil
+}
+
+func runSmartListList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	viewRepo := sqlite.NewViewRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	views, err := viewRepo.List(ctx, repository.ViewFilter{SortBy: "name", SortOrder: "asc"})
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list views: %v", err)))
+		return nil
+	}
+
+	var smartLists []*domain.SavedView
+	for _, view := range views {
+		if _, ok := view.SmartListTag(); ok {
+			smartLists = append(smartLists, view)
+		}
+	}
+
+	if len(smartLists) == 0 {
+		fmt.Println()
+		fmt.Println(styles.Info.Render("No smart lists found. Create one with: taskflow smartlist create <tag>"))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render("Smart Lists"))
+	fmt.Println()
+
+	headers := []string{
+		styles.Header.Render("ID"),
+		styles.Header.Render("Tag"),
+		styles.Header.Render("Hotkey"),
+		styles.Header.Render("Tasks"),
+	}
+	fmt.Println(strings.Join(headers, " | "))
+	fmt.Println(styles.Separator.Render(strings.Repeat("─", 60)))
+
+	for _, view := range smartLists {
+		tag, _ := view.SmartListTag()
+
+		hotKeyDisplay := "-"
+		if view.HotKey != nil {
+			hotKeyDisplay = fmt.Sprintf("[%d]", *view.HotKey)
+		}
+
+		countDisplay := ""
+		if count, _, err := countViewTasks(ctx, taskRepo, projectRepo, view); err != nil {
+			countDisplay = styles.Error.Render("error")
+		} else {
+			countDisplay = fmt.Sprintf("%d", count)
+		}
+
+		fmt.Println(strings.Join([]string{
+			fmt.Sprintf("%d", view.ID),
+			tag,
+			hotKeyDisplay,
+			countDisplay,
+		}, " | "))
+	}
+
+	fmt.Println()
+	fmt.Printf("Total: %d smart list(s)\n", len(smartLists))
+	fmt.Println()
+
+	return nil
+}
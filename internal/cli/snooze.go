@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/query"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <task-id> <until>",
+	Short: "Hide a task until a later time",
+	Long: `Snooze a task so it is left out of 'taskflow list' until the given time, when
+it shows up again on its own. Use 'none' to wake a snoozed task right away.
+
+<until> accepts the same values as the query language's due: field: today,
+tomorrow, an offset like +3d or +1w, or a date like 2025-01-31.
+
+Snoozed tasks show a 💤 and can be listed with 'taskflow list --include-snoozed'.
+
+Examples:
+  taskflow snooze 12 tomorrow
+  taskflow snooze 12 +1w
+  taskflow snooze 12 none`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: writesDatabase,
+	RunE:        runSnooze,
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	taskID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %s", args[0])
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	until, err := parseSnoozeUntil(args[1], time.Now())
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("%v", err))
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	task, err := repo.GetByID(ctx, taskID)
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	if err := repo.SetSnoozed(ctx, task.ID, until); err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to update snooze: %v", err))
+	}
+
+	if until == nil {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d is no longer snoozed", task.ID)))
+	} else {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Task #%d snoozed until %s (💤)", task.ID, until.Format("2006-01-02 15:04"))))
+	}
+
+	return nil
+}
+
+// parses the <until> of snooze, nil for "none". the time has to be after now
+func parseSnoozeUntil(value string, now time.Time) (*time.Time, error) {
+	until, keyword, err := query.ParseDate(value)
+	if err != nil {
+		return nil, err
+	}
+	if keyword == "none" {
+		return nil, nil
+	}
+	if !until.After(now) {
+		return nil, fmt.Errorf("snooze time %s is not in the future", until.Format("2006-01-02 15:04"))
+	}
+	return until, nil
+}
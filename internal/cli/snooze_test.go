@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSnoozeUntil(t *testing.T) {
+	now := time.Now()
+
+	until, err := parseSnoozeUntil("tomorrow", now)
+	require.NoError(t, err)
+	require.NotNil(t, until)
+	assert.True(t, until.After(now))
+
+	until, err = parseSnoozeUntil("+1w", now)
+	require.NoError(t, err)
+	require.NotNil(t, until)
+	assert.True(t, until.After(now.AddDate(0, 0, 6)))
+
+	until, err = parseSnoozeUntil("none", now)
+	require.NoError(t, err)
+	assert.Nil(t, until)
+
+	_, err = parseSnoozeUntil("yesterday", now)
+	assert.Error(t, err)
+
+	_, err = parseSnoozeUntil("someday", now)
+	assert.Error(t, err)
+}
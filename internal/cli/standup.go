@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	standupSince  string
+	standupFormat string
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Summarise what was done, what's in progress and what's blocked",
+	Long: `Print a standup summary in the classic done / doing / blockers format:
+
+  Done         tasks completed since --since
+  Doing        tasks in progress
+  Blockers     open tasks that are overdue or tagged "blocked"
+  Waiting on   open tasks waiting on someone (update --waiting)
+
+Each section is grouped by project. --since accepts the same values as the
+query language's due: field (yesterday, today, -7d, 2025-01-31, ...).
+
+Examples:
+  taskflow standup
+  taskflow standup --since -7d
+  taskflow standup --format markdown | pbcopy`,
+	Args: cobra.NoArgs,
+	RunE: runStandup,
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+
+	standupCmd.Flags().StringVar(&standupSince, "since", "yesterday", "Count tasks completed since this date")
+	standupCmd.Flags().StringVarP(&standupFormat, "format", "f", "text", "Output format (text, markdown)")
+	standupCmd.RegisterFlagCompletionFunc("since", cobra.FixedCompletions([]string{"yesterday", "today", "-7d"}, cobra.ShellCompDirectiveNoFileComp))
+	standupCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "markdown"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+type standupGroup struct {
+	Project string
+	Tasks   []*domain.Task
+}
+
+type standupSection struct {
+	Title  string
+	Groups []standupGroup
+}
+
+type standupReport struct {
+	Since    time.Time
+	Sections []standupSection
+}
+
+func runStandup(cmd *cobra.Command, args []string) error {
+	if standupFormat != "text" && standupFormat != "markdown" {
+		return fmt.Errorf("unsupported format: %s (use text or markdown)", standupFormat)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	since, _, err := query.ParseDate(standupSince)
+	if err != nil || since == nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Invalid --since value %q", standupSince)))
+		return nil
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	report, err := buildStandup(ctx, repo, *since, time.Now(), cfg.OverdueGraceDays)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to build standup: %v", err)))
+		return nil
+	}
+
+	if standupFormat == "markdown" {
+		writeStandupMarkdown(cmd.OutOrStdout(), report)
+		return nil
+	}
+
+	displayStandup(report, styles)
+	return nil
+}
+
+// collects the done, doing and blockers sections. blockers are open tasks due
+// before the day of now, tagged blocked or waiting on an unfinished task
+func buildStandup(ctx context.Context, repo repository.TaskRepository, since, now time.Time, graceDays int) (*standupReport, error) {
+	sinceStr := query.FormatDateForSQL(since)
+	done, err := repo.List(ctx, repository.TaskFilter{
+		Status:        domain.StatusCompleted,
+		CompletedFrom: &sinceStr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed tasks: %w", err)
+	}
+
+	doing, err := repo.List(ctx, repository.TaskFilter{Status: domain.StatusInProgress})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks in progress: %w", err)
+	}
+
+	closed := []domain.Status{domain.StatusCompleted, domain.StatusCancelled}
+	overdueTo := query.OverdueDueTo(now, graceDays)
+	overdue, err := repo.List(ctx, repository.TaskFilter{
+		ExcludeStatuses: closed,
+		DueDateTo:       &overdueTo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue tasks: %w", err)
+	}
+
+	blocked, err := repo.List(ctx, repository.TaskFilter{
+		ExcludeStatuses: closed,
+		Tags:            []string{domain.BlockedTag},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked tasks: %w", err)
+	}
+	dependent, err := repo.List(ctx, repository.TaskFilter{
+		ExcludeStatuses: closed,
+		BlockedOnly:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked tasks: %w", err)
+	}
+	blocked = append(blocked, dependent...)
+
+	waiting, err := repo.List(ctx, repository.TaskFilter{
+		ExcludeStatuses: closed,
+		WaitingOnly:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list waiting tasks: %w", err)
+	}
+
+	blockers := overdue
+	seen := make(map[int64]bool, len(overdue))
+	for _, task := range overdue {
+		seen[task.ID] = true
+	}
+	for _, task := range blocked {
+		if !seen[task.ID] {
+			seen[task.ID] = true
+			blockers = append(blockers, task)
+		}
+	}
+
+	return &standupReport{
+		Since: since,
+		Sections: []standupSection{
+			{Title: "Done", Groups: groupTasksByProject(done)},
+			{Title: "Doing", Groups: groupTasksByProject(doing)},
+			{Title: "Blockers", Groups: groupTasksByProject(blockers)},
+			{Title: "Waiting on", Groups: groupTasksByProject(waiting)},
+		},
+	}, nil
+}
+
+// groups tasks by project name, projects sorted by name and tasks without a
+// project last
+func groupTasksByProject(tasks []*domain.Task) []standupGroup {
+	byProject := make(map[string][]*domain.Task)
+	var names []string
+	for _, task := range tasks {
+		if _, ok := byProject[task.ProjectName]; !ok {
+			names = append(names, task.ProjectName)
+		}
+		byProject[task.ProjectName] = append(byProject[task.ProjectName], task)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if names[i] == "" || names[j] == "" {
+			return names[j] == ""
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	groups := make([]standupGroup, 0, len(names))
+	for _, name := range names {
+		project := name
+		if project == "" {
+			project = "No project"
+		}
+		groups = append(groups, standupGroup{Project: project, Tasks: byProject[name]})
+	}
+	return groups
+}
+
+func (s standupSection) count() int {
+	count := 0
+	for _, group := range s.Groups {
+		count += len(group.Tasks)
+	}
+	return count
+}
+
+// the detail shown after a task's title: when it was done or how late it is,
+// and who an open task is waiting on
+func standupTaskNote(task *domain.Task) string {
+	var notes []string
+	switch {
+	case task.Status == domain.StatusCompleted && task.CompletedAt != nil:
+		notes = append(notes, "done "+task.CompletedAt.Format("2006-01-02"))
+	case task.DueDate != nil && task.Status != domain.StatusCompleted:
+		notes = append(notes, "due "+task.DueDate.Format("2006-01-02"))
+	}
+	if task.WaitingOn != "" && task.Status != domain.StatusCompleted {
+		notes = append(notes, "waiting on "+task.WaitingOn)
+	}
+	return strings.Join(notes, ", ")
+}
+
+func displayStandup(report *standupReport, styles *theme.Styles) {
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("Standup since %s", report.Since.Format("2006-01-02"))))
+
+	for _, section := range report.Sections {
+		fmt.Println()
+		fmt.Println(styles.Subtitle.Render(fmt.Sprintf("%s (%d)", section.Title, section.count())))
+		if len(section.Groups) == 0 {
+			fmt.Println("  (none)")
+			continue
+		}
+		for _, group := range section.Groups {
+			fmt.Printf("  %s\n", group.Project)
+			for _, task := range group.Tasks {
+				line := fmt.Sprintf("    #%-5d %s", task.ID, task.Title)
+				if note := standupTaskNote(task); note != "" {
+					line += styles.Info.Render(fmt.Sprintf("  (%s)", note))
+				}
+				fmt.Println(line)
+			}
+		}
+	}
+	fmt.Println()
+}
+
+func writeStandupMarkdown(w io.Writer, report *standupReport) {
+	fmt.Fprintf(w, "## Standup since %s\n", report.Since.Format("2006-01-02"))
+
+	for _, section := range report.Sections {
+		fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+		if len(section.Groups) == 0 {
+			fmt.Fprintln(w, "_Nothing_")
+			continue
+		}
+		for i, group := range section.Groups {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "**%s**\n", group.Project)
+			for _, task := range group.Tasks {
+				fmt.Fprintf(w, "- #%d %s", task.ID, task.Title)
+				if note := standupTaskNote(task); note != "" {
+					fmt.Fprintf(w, " (%s)", note)
+				}
+				fmt.Fprintln(w)
+			}
+		}
+	}
+}
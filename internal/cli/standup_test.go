@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestBuildStandup(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	backend := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+
+	now := time.Now()
+	lastWeek := now.AddDate(0, 0, -7)
+	yesterday := time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	create := func(title string, status domain.Status, edit func(*domain.Task)) *domain.Task {
+		task := domain.NewTask(title)
+		task.Status = status
+		task.ProjectID = &backend.ID
+		if edit != nil {
+			edit(task)
+		}
+		require.NoError(t, taskRepo.Create(ctx, task))
+		return task
+	}
+
+	done := create("Ship login", domain.StatusCompleted, nil)
+	create("Old release", domain.StatusCompleted, func(task *domain.Task) { task.CompletedAt = &lastWeek })
+	doing := create("Write docs", domain.StatusInProgress, func(task *domain.Task) { task.ProjectID = nil })
+	overdue := create("Renew cert", domain.StatusPending, func(task *domain.Task) { task.DueDate = &yesterday })
+	blocked := create("Wait for API keys", domain.StatusInProgress, func(task *domain.Task) {
+		task.Tags = []string{"blocked"}
+		task.DueDate = &tomorrow
+	})
+	create("Late but done", domain.StatusCompleted, func(task *domain.Task) {
+		task.DueDate = &yesterday
+		task.CompletedAt = &lastWeek
+	})
+	waiting := create("Contract review", domain.StatusPending, func(task *domain.Task) { task.WaitingOn = "Legal" })
+	create("Signed off", domain.StatusCompleted, func(task *domain.Task) {
+		task.WaitingOn = "Legal"
+		task.CompletedAt = &lastWeek
+	})
+
+	report, err := buildStandup(ctx, taskRepo, now.AddDate(0, 0, -1), now, 0)
+	require.NoError(t, err)
+	require.Len(t, report.Sections, 4)
+
+	ids := func(section standupSection) []int64 {
+		var result []int64
+		for _, group := range section.Groups {
+			for _, task := range group.Tasks {
+				result = append(result, task.ID)
+			}
+		}
+		return result
+	}
+
+	assert.Equal(t, []int64{done.ID}, ids(report.Sections[0]))
+	assert.ElementsMatch(t, []int64{doing.ID, blocked.ID}, ids(report.Sections[1]))
+	assert.ElementsMatch(t, []int64{overdue.ID, blocked.ID}, ids(report.Sections[2]))
+	assert.Equal(t, []int64{waiting.ID}, ids(report.Sections[3]))
+
+	doingGroups := report.Sections[1].Groups
+	require.Len(t, doingGroups, 2)
+	assert.Equal(t, "Backend", doingGroups[0].Project)
+	assert.Equal(t, "No project", doingGroups[1].Project)
+
+	var buf bytes.Buffer
+	writeStandupMarkdown(&buf, report)
+	out := buf.String()
+	assert.Contains(t, out, "### Done\n\n**Backend**\n- #")
+	assert.Contains(t, out, "Ship login (done ")
+	assert.Contains(t, out, "Renew cert (due "+yesterday.Format("2006-01-02")+")")
+	assert.Contains(t, out, "**No project**\n- #")
+	assert.Contains(t, out, "### Waiting on\n\n**Backend**\n- #")
+	assert.Contains(t, out, "Contract review (waiting on Legal)")
+}
@@ -57,7 +57,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -129,7 +129,7 @@ func runProjectStats(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -139,7 +139,7 @@ func runProjectStats(cmd *cobra.Command, args []string) error {
 	statsRepo := sqlite.NewStatisticsRepository(db)
 	ctx := context.Background()
 
-	projectID, err := lookupProjectID(ctx, projectRepo, args[0])
+	projectID, err := promptProjectID(ctx, projectRepo, args[0])
 	if err != nil {
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	tagsCloud bool
+	tagsLimit int
+)
+
+var tagsCmd = &cobra.Command{
+	Use:   "tags",
+	Short: "List tags and how often they are used",
+	Long: `List every tag with the number of tasks using it.
+
+Use --cloud for a frequency view where each tag gets a bar and color scaled
+to how often it is used, most used first.`,
+	Example: `  taskflow tags
+  taskflow tags --cloud
+  taskflow tags --cloud --limit 10`,
+	Args: cobra.NoArgs,
+	RunE: runTags,
+}
+
+func init() {
+	rootCmd.AddCommand(tagsCmd)
+
+	tagsCmd.Flags().BoolVar(&tagsCloud, "cloud", false, "Show tags as a frequency cloud")
+	tagsCmd.Flags().IntVarP(&tagsLimit, "limit", "n", 0, "Only show the N most used tags (0 for all)")
+}
+
+func runTags(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	counts, err := repo.GetTagCounts(ctx)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to load tags: %v", err)))
+		return nil
+	}
+
+	if len(counts) == 0 {
+		fmt.Println(styles.Info.Render("No tags found. Add some with: taskflow add \"Task\" --tags tag1,tag2"))
+		return nil
+	}
+
+	total := len(counts)
+	if tagsLimit > 0 && tagsLimit < len(counts) {
+		counts = counts[:tagsLimit]
+	}
+
+	fmt.Println()
+	if tagsCloud {
+		displayTagCloud(counts, styles)
+	} else {
+		displayTagList(counts, styles)
+	}
+
+	fmt.Println()
+	if len(counts) < total {
+		fmt.Printf("Showing %d of %d tag(s)\n", len(counts), total)
+	} else {
+		fmt.Printf("Total: %d tag(s)\n", total)
+	}
+	fmt.Println(styles.Info.Render("Filter by a tag with: taskflow list --tags <tag>"))
+	fmt.Println()
+
+	return nil
+}
+
+func displayTagList(counts []domain.TagCount, styles *theme.Styles) {
+	fmt.Println(styles.Header.Render(fmt.Sprintf("%-30s %s", "Tag", "Tasks")))
+	fmt.Println(styles.Separator.Render(strings.Repeat("─", 40)))
+
+	for _, count := range counts {
+		fmt.Printf("%-30s %d\n", count.Tag, count.Count)
+	}
+}
+
+// counts arrive most used first, so the first entry sets the scale
+func displayTagCloud(counts []domain.TagCount, styles *theme.Styles) {
+	fmt.Println(styles.Subtitle.Render("Tag Cloud"))
+	fmt.Println()
+
+	maxCount := counts[0].Count
+	width := 0
+	for _, count := range counts {
+		if len(count.Tag) > width {
+			width = len(count.Tag)
+		}
+	}
+
+	for _, count := range counts {
+		style := styles.GetTagWeightStyle(display.TagWeight(count.Count, maxCount))
+		barLength := (count.Count*20 + maxCount - 1) / maxCount
+
+		fmt.Printf("  %s %s %s\n",
+			style.Render(fmt.Sprintf("%-*s", width, count.Tag)),
+			style.Render(renderBar(barLength, 20, "█"))+strings.Repeat(" ", 20-barLength),
+			styles.Cell.Render(fmt.Sprintf("(%d)", count.Count)),
+		)
+	}
+}
@@ -0,0 +1,14 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var taskCmd = &cobra.Command{
+	Use:   "task",
+	Short: "Work with groups of tasks, like moving them, linking them or finding the ready ones",
+}
+
+func init() {
+	rootCmd.AddCommand(taskCmd)
+}
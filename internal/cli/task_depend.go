@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var dependRemove bool
+
+var taskDependCmd = &cobra.Command{
+	Use:   "depend <task-id|key> <blocker-id|key>...",
+	Short: "Make a task wait for other tasks",
+	Long: `Make the first task depend on the others: it stays blocked until every
+task it depends on is completed or cancelled. Blocked tasks are left out of
+task ready, and the TUI asks before completing one.
+
+Dependencies can't form a cycle, a task can't wait for a task that already
+waits for it. Use --remove to drop dependencies again.
+
+Examples:
+  taskflow task depend 12 7                # 12 waits for 7
+  taskflow task depend BACKEND-4 BACKEND-2 BACKEND-3
+  taskflow task depend 12 7 --remove`,
+	Args:        cobra.MinimumNArgs(2),
+	Annotations: writesDatabase,
+	RunE:        runTaskDepend,
+}
+
+func init() {
+	taskCmd.AddCommand(taskDependCmd)
+
+	taskDependCmd.Flags().BoolVar(&dependRemove, "remove", false, "Remove the dependencies instead of adding them")
+}
+
+func runTaskDepend(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	task, err := resolveTaskRef(ctx, taskRepo, projectRepo, args[0])
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	// every reference is resolved before anything changes
+	blockers := make([]*domain.Task, 0, len(args)-1)
+	for _, ref := range args[1:] {
+		blocker, err := resolveTaskRef(ctx, taskRepo, projectRepo, ref)
+		if err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+		}
+		blockers = append(blockers, blocker)
+	}
+
+	if err := changeDependencies(ctx, taskRepo, task, blockers, dependRemove); err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	for _, blocker := range blockers {
+		if dependRemove {
+			fmt.Println(styles.Success.Render(fmt.Sprintf("✓ #%d no longer depends on #%d %s", task.ID, blocker.ID, blocker.Title)))
+		} else {
+			fmt.Println(styles.Success.Render(fmt.Sprintf("✓ #%d now depends on #%d %s", task.ID, blocker.ID, blocker.Title)))
+		}
+	}
+	return nil
+}
+
+// adds or removes the dependencies of task on each blocker, stopping at the
+// first one that fails
+func changeDependencies(ctx context.Context, repo repository.TaskRepository, task *domain.Task, blockers []*domain.Task, remove bool) error {
+	for _, blocker := range blockers {
+		var err error
+		if remove {
+			err = repo.RemoveDependency(ctx, task.ID, blocker.ID)
+		} else {
+			err = repo.AddDependency(ctx, task.ID, blocker.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/theme"
+)
+
+// the arg that makes update and delete read task IDs from stdin
+const stdinArg = "-"
+
+func readsIDsFromStdin(args []string) bool {
+	return len(args) == 1 && args[0] == stdinArg
+}
+
+// the task IDs in args, or on stdin when the only arg is "-". args must all be
+// IDs, while lines on stdin that aren't are returned as invalid so the rest
+// can still be processed
+func taskIDsFromArgs(args []string, stdin io.Reader) ([]int64, []string, error) {
+	if readsIDsFromStdin(args) {
+		return readTaskIDs(stdin)
+	}
+
+	ids := make([]int64, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid task ID: %s", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil, nil
+}
+
+// one ID per line, as printed by --ids-only. blank lines are skipped
+func readTaskIDs(r io.Reader) ([]int64, []string, error) {
+	var ids []int64
+	var invalid []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimPrefix(line, "#"), 10, 64)
+		if err != nil || id <= 0 {
+			invalid = append(invalid, line)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read task IDs: %w", err)
+	}
+
+	return ids, invalid, nil
+}
+
+// the task an argument names: a global ID like 12 or #12, or a project key
+// like BACKEND-12 whose prefix is the project's key prefix or comes from an
+// alias
+func resolveTaskRef(ctx context.Context, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, arg string) (*domain.Task, error) {
+	if id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64); err == nil {
+		return taskRepo.GetByID(ctx, id)
+	}
+
+	prefix, number, ok := domain.ParseTaskKey(arg)
+	if !ok {
+		return nil, fmt.Errorf("invalid task ID or key: %s", arg)
+	}
+
+	projects, err := projectRepo.List(ctx, repository.ProjectFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects: %w", err)
+	}
+
+	// key prefixes are unique and win over aliases, so an alias can't shadow
+	// a project's own key
+	var byAlias []*domain.Project
+	for _, project := range projects {
+		if project.KeyPrefix == prefix {
+			return taskRepo.GetByNumber(ctx, project.ID, number)
+		}
+		for _, alias := range project.Aliases {
+			if domain.ProjectKeyPrefix(alias) == prefix {
+				byAlias = append(byAlias, project)
+				break
+			}
+		}
+	}
+
+	switch len(byAlias) {
+	case 0:
+		return nil, fmt.Errorf("no project has the key %s", prefix)
+	case 1:
+		return taskRepo.GetByNumber(ctx, byAlias[0].ID, number)
+	default:
+		return nil, fmt.Errorf("key %s matches %d projects, use the task ID instead", prefix, len(byAlias))
+	}
+}
+
+func printInvalidTaskIDs(invalid []string, styles *theme.Styles) {
+	if len(invalid) == 0 {
+		return
+	}
+	fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Skipped %d invalid task ID(s):", len(invalid))))
+	for _, line := range invalid {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("  %q", line)))
+	}
+}
+
+// --ids-only output, for piping into update - and delete -
+func printTaskIDs(w io.Writer, tasks []*domain.Task) {
+	for _, task := range tasks {
+		fmt.Fprintln(w, task.ID)
+	}
+}
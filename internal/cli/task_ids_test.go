@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestTaskIDsFromArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		stdin       string
+		wantIDs     []int64
+		wantInvalid []string
+		wantErr     string
+	}{
+		{name: "args", args: []string{"1", "2"}, wantIDs: []int64{1, 2}},
+		{name: "invalid arg", args: []string{"1", "x"}, wantErr: "invalid task ID: x"},
+		{name: "stdin", args: []string{"-"}, stdin: "3\n4\n", wantIDs: []int64{3, 4}},
+		{name: "stdin blank lines and hashes", args: []string{"-"}, stdin: "\n #5 \n\n6", wantIDs: []int64{5, 6}},
+		{name: "stdin invalid lines", args: []string{"-"}, stdin: "7\nfoo\n-1\n8\n", wantIDs: []int64{7, 8}, wantInvalid: []string{"foo", "-1"}},
+		{name: "empty stdin", args: []string{"-"}, stdin: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, invalid, err := taskIDsFromArgs(tt.args, strings.NewReader(tt.stdin))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+			assert.Equal(t, tt.wantInvalid, invalid)
+		})
+	}
+}
+
+func TestPrintTaskIDsRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	printTaskIDs(&buf, []*domain.Task{{ID: 12}, {ID: 3}})
+	assert.Equal(t, "12\n3\n", buf.String())
+
+	ids, invalid, err := readTaskIDs(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{12, 3}, ids)
+	assert.Empty(t, invalid)
+}
+
+func TestResolveTaskRef(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+
+	backend := domain.NewProject("backend")
+	backend.Aliases = []string{"be"}
+	require.NoError(t, projectRepo.Create(ctx, backend))
+	mobile := domain.NewProject("Mobile App")
+	require.NoError(t, projectRepo.Create(ctx, mobile))
+
+	var tasks []*domain.Task
+	for _, projectID := range []int64{backend.ID, backend.ID, mobile.ID} {
+		task := domain.NewTask("Task")
+		task.ProjectID = &projectID
+		require.NoError(t, taskRepo.Create(ctx, task))
+		tasks = append(tasks, task)
+	}
+
+	tests := []struct {
+		arg    string
+		wantID int64
+	}{
+		{fmt.Sprint(tasks[0].ID), tasks[0].ID},
+		{fmt.Sprintf("#%d", tasks[1].ID), tasks[1].ID},
+		{"BACKEND-2", tasks[1].ID},
+		{"mobile-app-1", tasks[2].ID},
+		{"BE-1", tasks[0].ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			task, err := resolveTaskRef(ctx, taskRepo, projectRepo, tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, task.ID)
+		})
+	}
+
+	for _, arg := range []string{"BACKEND-9", "FRONTEND-1", "backend"} {
+		t.Run(arg, func(t *testing.T) {
+			_, err := resolveTaskRef(ctx, taskRepo, projectRepo, arg)
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("keys survive a rename", func(t *testing.T) {
+		backend.Name = "Platform"
+		require.NoError(t, projectRepo.Update(ctx, backend))
+
+		task, err := resolveTaskRef(ctx, taskRepo, projectRepo, "BACKEND-2")
+		require.NoError(t, err)
+		assert.Equal(t, tasks[1].ID, task.ID)
+		assert.Equal(t, "BACKEND-2", task.Key())
+
+		_, err = resolveTaskRef(ctx, taskRepo, projectRepo, "PLATFORM-2")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/display"
+	"task-management/internal/domain"
+	"task-management/internal/export"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	taskImportFile    string
+	taskImportFormat  string
+	taskImportProject string
+	taskImportDryRun  bool
+)
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create tasks from a CSV or JSON file",
+	Long: `Create a task for every row of a CSV or JSON file, in the formats export
+tasks writes. The format comes from the file extension unless --format is
+given.
+
+CSV files need a header row with at least a Title column. Description,
+Status, Priority, Project, Tags (separated by semicolons), Due Date
+(YYYY-MM-DD), Created At and Updated At are read when present, other columns
+are ignored. JSON files hold an object with a "tasks" array, or just the
+array of task objects.
+
+Every row is checked before anything is created. Rows with a problem are
+listed with their row number and skipped, the others are still imported.
+Use --dry-run to see what would be created without creating anything.
+
+Projects are matched by name or alias. Rows without a project go to
+--project.
+
+Examples:
+  taskflow task import --file tasks.json --dry-run
+  taskflow task import --file backlog.csv --project Backend
+  taskflow task import --file export.txt --format csv`,
+	Args:        cobra.NoArgs,
+	Annotations: writesDatabase,
+	RunE:        runTaskImport,
+}
+
+func init() {
+	taskCmd.AddCommand(taskImportCmd)
+
+	taskImportCmd.Flags().StringVar(&taskImportFile, "file", "", "File to import (required)")
+	taskImportCmd.Flags().StringVarP(&taskImportFormat, "format", "f", "", "File format (csv, json), default from the file extension")
+	taskImportCmd.Flags().StringVarP(&taskImportProject, "project", "P", "", "Project for rows without one (name, alias or ID)")
+	taskImportCmd.Flags().BoolVar(&taskImportDryRun, "dry-run", false, "Show what would be imported without creating anything")
+	taskImportCmd.MarkFlagRequired("file")
+
+	taskImportCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"csv", "json"}, cobra.ShellCompDirectiveNoFileComp))
+	taskImportCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+}
+
+func runTaskImport(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	format := taskImportFormat
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(taskImportFile)), ".")
+	}
+	var read func(io.Reader) ([]export.TaskRow, error)
+	switch format {
+	case "csv":
+		read = export.ReadCSV
+	case "json":
+		read = export.ReadJSON
+	default:
+		return reportError(cmd, styles, fmt.Sprintf("Can't tell the format of %s, use --format csv or --format json", taskImportFile))
+	}
+
+	file, err := os.Open(taskImportFile)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	rows, err := read(file)
+	file.Close()
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Failed to read %s: %v", taskImportFile, err))
+	}
+
+	if len(rows) == 0 {
+		fmt.Println(styles.Info.Render("No tasks in the file."))
+		return nil
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	var fallback *domain.Project
+	if taskImportProject != "" {
+		projectID, err := lookupProjectID(ctx, projectRepo, taskImportProject)
+		if err == nil {
+			fallback, err = projectRepo.GetByID(ctx, *projectID)
+		}
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+	}
+
+	valid, problems := importTaskRows(ctx, projectRepo, rows, fallback)
+	imported, failed := createImportedTasks(ctx, taskRepo, valid, taskImportDryRun)
+	problems = append(problems, failed...)
+
+	fmt.Println()
+	if taskImportDryRun {
+		fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Would import %d of %d task(s):", len(imported), len(rows))))
+	} else {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Imported %d of %d task(s)", len(imported), len(rows))))
+	}
+	for _, task := range imported {
+		id := "-"
+		if task.ID != 0 {
+			id = fmt.Sprintf("#%d", task.ID)
+		}
+		line := fmt.Sprintf("  %-6s %s %s", id, styles.PrioritySymbol(task.Priority), display.FormatTaskTitle(task, 50))
+		if task.ProjectName != "" {
+			line += styles.Info.Render(" @" + task.ProjectName)
+		}
+		fmt.Println(line)
+	}
+
+	if len(problems) > 0 {
+		fmt.Println()
+		for _, problem := range problems {
+			fmt.Println(styles.Error.Render("✗ " + problem))
+		}
+		fmt.Println()
+		if taskImportDryRun {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("%d row(s) would be skipped.", len(problems))))
+		} else {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("Skipped %d row(s), fix them and import those again.", len(problems))))
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// resolves the project of every row and validates it, returning the rows
+// that can be created and a problem for each that can't. rows without a
+// project get fallback, each project name is looked up once
+func importTaskRows(ctx context.Context, projectRepo repository.ProjectRepository, rows []export.TaskRow, fallback *domain.Project) ([]export.TaskRow, []string) {
+	type resolved struct {
+		project *domain.Project
+		err     error
+	}
+	projects := map[string]resolved{}
+
+	var valid []export.TaskRow
+	var problems []string
+	for _, row := range rows {
+		if row.Err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row.Row, row.Err))
+			continue
+		}
+		task := row.Task
+
+		project := fallback
+		if name := task.ProjectName; name != "" {
+			result, ok := projects[name]
+			if !ok {
+				projectID, err := lookupProjectID(ctx, projectRepo, name)
+				if err == nil {
+					result.project, err = projectRepo.GetByID(ctx, *projectID)
+				}
+				result.err = err
+				projects[name] = result
+			}
+			if result.err != nil {
+				problems = append(problems, fmt.Sprintf("row %d: %v", row.Row, result.err))
+				continue
+			}
+			project = result.project
+		}
+		if project != nil {
+			task.ProjectID = &project.ID
+			task.ProjectName = project.Name
+		}
+
+		if err := task.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row.Row, err))
+			continue
+		}
+		valid = append(valid, row)
+	}
+
+	return valid, problems
+}
+
+// creates the task of every row one at a time, so a failing row doesn't stop
+// the others. a dry run creates nothing and returns every task
+func createImportedTasks(ctx context.Context, repo repository.TaskRepository, rows []export.TaskRow, dryRun bool) ([]*domain.Task, []string) {
+	var created []*domain.Task
+	var problems []string
+	for _, row := range rows {
+		if !dryRun {
+			if err := repo.Create(ctx, row.Task); err != nil {
+				problems = append(problems, fmt.Sprintf("row %d: %v", row.Row, err))
+				continue
+			}
+		}
+		created = append(created, row.Task)
+	}
+	return created, problems
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/export"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+const testImportCSV = `Title,Status,Priority,Project,Tags,Due Date
+Fix login,pending,high,Backend,bug;auth,2026-05-01
+,pending,low,,,
+Polish header,in_progress,urgent,,ui,
+Write docs,someday,medium,,,
+Call vendor,pending,medium,Nowhere,,
+Plan sprint,pending,medium,,,next friday
+`
+
+func TestImportTaskRows(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	backend := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+	frontend := domain.NewProject("Frontend")
+	require.NoError(t, projectRepo.Create(ctx, frontend))
+
+	read := func(t *testing.T) []export.TaskRow {
+		rows, err := export.ReadCSV(strings.NewReader(testImportCSV))
+		require.NoError(t, err)
+		require.Len(t, rows, 6)
+		return rows
+	}
+
+	t.Run("every failing row is reported, the rest are kept", func(t *testing.T) {
+		valid, problems := importTaskRows(ctx, projectRepo, read(t), frontend)
+
+		require.Len(t, valid, 2)
+		assert.Equal(t, "Fix login", valid[0].Task.Title)
+		assert.Equal(t, backend.ID, *valid[0].Task.ProjectID, "a row's own project wins")
+		assert.Equal(t, "Polish header", valid[1].Task.Title)
+		assert.Equal(t, frontend.ID, *valid[1].Task.ProjectID, "rows without a project get the fallback")
+		assert.Equal(t, "Frontend", valid[1].Task.ProjectName)
+
+		require.Len(t, problems, 4)
+		assert.True(t, strings.HasPrefix(problems[0], "row 2: "), problems[0])
+		assert.Contains(t, problems[0], "title cannot be empty")
+		assert.True(t, strings.HasPrefix(problems[1], "row 4: "), problems[1])
+		assert.Contains(t, problems[1], "invalid status")
+		assert.True(t, strings.HasPrefix(problems[2], "row 5: "), problems[2])
+		assert.Contains(t, problems[2], "Nowhere")
+		assert.True(t, strings.HasPrefix(problems[3], "row 6: "), problems[3])
+		assert.Contains(t, problems[3], "invalid due date")
+	})
+
+	t.Run("without a fallback rows stay outside projects", func(t *testing.T) {
+		valid, _ := importTaskRows(ctx, projectRepo, read(t), nil)
+		require.Len(t, valid, 2)
+		assert.Nil(t, valid[1].Task.ProjectID)
+	})
+
+	t.Run("a dry run creates nothing", func(t *testing.T) {
+		valid, _ := importTaskRows(ctx, projectRepo, read(t), nil)
+
+		tasks, problems := createImportedTasks(ctx, taskRepo, valid, true)
+		assert.Empty(t, problems)
+		assert.Len(t, tasks, 2)
+
+		count, err := taskRepo.Count(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("an import creates the valid rows", func(t *testing.T) {
+		valid, _ := importTaskRows(ctx, projectRepo, read(t), nil)
+
+		tasks, problems := createImportedTasks(ctx, taskRepo, valid, false)
+		assert.Empty(t, problems)
+		require.Len(t, tasks, 2)
+		assert.NotZero(t, tasks[0].ID)
+
+		stored, err := taskRepo.GetByID(ctx, tasks[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Fix login", stored.Title)
+		assert.Equal(t, []string{"bug", "auth"}, stored.Tags)
+		assert.Equal(t, backend.ID, *stored.ProjectID)
+
+		count, err := taskRepo.Count(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	moveProject   string
+	moveNoProject bool
+)
+
+var taskMoveCmd = &cobra.Command{
+	Use:   "move <task-id|key>",
+	Short: "Move a task to another project",
+	Long: `Move one task to another project, given by ID, name or alias. Pass
+--no-project, or an empty --project, to take the task out of its project.
+
+The task keeps its key, e.g. BACKEND-12, in its new project. Locked tasks
+can't be moved. Use move-all to move every task of a project.
+
+Examples:
+  taskflow task move 42 --project Frontend
+  taskflow task move BACKEND-12 -p fe
+  taskflow task move 42 --no-project`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runTaskMove,
+}
+
+func init() {
+	taskCmd.AddCommand(taskMoveCmd)
+
+	taskMoveCmd.Flags().StringVarP(&moveProject, "project", "p", "", "Project to move the task into (ID, name or alias, empty for none)")
+	taskMoveCmd.Flags().BoolVar(&moveNoProject, "no-project", false, "Take the task out of its project")
+	taskMoveCmd.MarkFlagsMutuallyExclusive("project", "no-project")
+
+	taskMoveCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+}
+
+func runTaskMove(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	if !cmd.Flags().Changed("project") && !moveNoProject {
+		return reportError(cmd, styles, "pass --project to move the task or --no-project to unassign it")
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	task, err := resolveTaskRef(ctx, taskRepo, projectRepo, args[0])
+	if err != nil {
+		return reportError(cmd, styles, fmt.Sprintf("Task not found: %v", err))
+	}
+
+	// --no-project leaves moveProject empty, which lookupProjectID reads as none
+	projectID, err := promptProjectID(ctx, projectRepo, moveProject)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	if equalInt64Ptr(task.ProjectID, projectID) {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("Task #%d is already there, nothing to move.", task.ID)))
+		return nil
+	}
+
+	from, to, err := moveTask(ctx, taskRepo, projectRepo, task, projectID)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Moved task #%d: %s → %s", task.ID, from, to)))
+	return nil
+}
+
+// moves the task into the project, nil for none, and returns the paths of
+// the project it left and the one it is in now
+func moveTask(ctx context.Context, taskRepo repository.TaskRepository, projectRepo repository.ProjectRepository, task *domain.Task, projectID *int64) (from, to string, err error) {
+	if from, err = projectPathOf(ctx, projectRepo, task.ProjectID); err != nil {
+		return "", "", err
+	}
+	if to, err = projectPathOf(ctx, projectRepo, projectID); err != nil {
+		return "", "", err
+	}
+
+	task.ProjectID = projectID
+	if err := taskRepo.Update(ctx, task); err != nil {
+		return "", "", fmt.Errorf("failed to move task: %w", err)
+	}
+	return from, to, nil
+}
+
+// the full path of the project, "no project" for nil
+func projectPathOf(ctx context.Context, repo repository.ProjectRepository, projectID *int64) (string, error) {
+	if projectID == nil {
+		return "no project", nil
+	}
+	project, err := repo.GetByID(ctx, *projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+	loadProjectParents(ctx, repo, project)
+	return project.BuildPath(), nil
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
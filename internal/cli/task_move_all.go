@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	moveAllFrom    string
+	moveAllTo      string
+	moveAllStatus  string
+	moveAllConfirm bool
+	moveAllForce   bool
+)
+
+var taskMoveAllCmd = &cobra.Command{
+	Use:   "move-all",
+	Short: "Move the tasks of one project to another",
+	Long: `Move every task of one project, or only those with a given status, to
+another project. Both projects can be given by ID, name or alias.
+
+Locked tasks are left where they are. Moving into an archived project works
+but prints a warning first. Like the bulk commands, moves matching more tasks
+than bulk_max_affected are refused unless --force is passed.
+
+Examples:
+  taskflow task move-all --from Backend --to Archive
+  taskflow task move-all --from Backend --to Archive --status completed
+  taskflow task move-all --from 3 --to be -y`,
+	Args:        cobra.NoArgs,
+	Annotations: writesDatabase,
+	RunE:        runTaskMoveAll,
+}
+
+func init() {
+	taskCmd.AddCommand(taskMoveAllCmd)
+
+	taskMoveAllCmd.Flags().StringVar(&moveAllFrom, "from", "", "Project to move the tasks out of (ID, name or alias)")
+	taskMoveAllCmd.Flags().StringVar(&moveAllTo, "to", "", "Project to move the tasks into (ID, name or alias)")
+	taskMoveAllCmd.Flags().StringVarP(&moveAllStatus, "status", "s", "", "Only move tasks with this status (pending, in_progress, completed, cancelled)")
+	taskMoveAllCmd.Flags().BoolVarP(&moveAllConfirm, "confirm", "y", false, "Skip confirmation prompt")
+	taskMoveAllCmd.Flags().BoolVar(&moveAllForce, "force", false, "Allow moving more tasks than bulk_max_affected")
+	taskMoveAllCmd.MarkFlagRequired("from")
+	taskMoveAllCmd.MarkFlagRequired("to")
+
+	taskMoveAllCmd.RegisterFlagCompletionFunc("from", completeProjectNames)
+	taskMoveAllCmd.RegisterFlagCompletionFunc("to", completeProjectNames)
+	taskMoveAllCmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"pending", "in_progress", "completed", "cancelled"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runTaskMoveAll(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	from, to, err := moveAllProjects(ctx, projectRepo, moveAllFrom, moveAllTo)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	filter, err := moveAllFilter(from, moveAllStatus)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+	filter.MaxAffected = bulkMaxAffected(cfg, moveAllForce)
+
+	tasks, locked, err := listBulkTasks(ctx, taskRepo, filter)
+	if err != nil {
+		return reportError(cmd, styles, bulkError("move tasks", err).Error())
+	}
+	if len(tasks) == 0 {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("No tasks to move in '%s'.", from.Name)))
+		printSkippedLocked(locked, styles)
+		return nil
+	}
+
+	if to.Status == domain.ProjectStatusArchived {
+		fmt.Println(styles.Info.Render(fmt.Sprintf("⚠ '%s' is archived, the moved tasks won't show up with the active projects", to.Name)))
+	}
+	printSkippedLocked(locked, styles)
+
+	if !moveAllConfirm {
+		if !promptForConfirmation(fmt.Sprintf("Move %d task(s) from '%s' to '%s'?", len(tasks), from.Name, to.Name)) {
+			fmt.Println(styles.Info.Render("Move cancelled."))
+			return nil
+		}
+	}
+
+	count, err := taskRepo.BulkMove(ctx, filter, &to.ID)
+	if err != nil {
+		return reportError(cmd, styles, bulkError("move tasks", err).Error())
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Moved %d task(s) from '%s' to '%s'", count, from.Name, to.Name)))
+	return nil
+}
+
+// resolves both ends of a move-all, which have to be two different projects
+func moveAllProjects(ctx context.Context, repo repository.ProjectRepository, fromStr, toStr string) (from, to *domain.Project, err error) {
+	fromID, err := promptProjectID(ctx, repo, fromStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	toID, err := promptProjectID(ctx, repo, toStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fromID == nil || toID == nil {
+		return nil, nil, fmt.Errorf("both --from and --to need a project")
+	}
+	if *fromID == *toID {
+		return nil, nil, fmt.Errorf("--from and --to are the same project")
+	}
+
+	if from, err = repo.GetByID(ctx, *fromID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if to, err = repo.GetByID(ctx, *toID); err != nil {
+		return nil, nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return from, to, nil
+}
+
+func moveAllFilter(from *domain.Project, status string) (repository.TaskFilter, error) {
+	filter := repository.TaskFilter{ProjectID: &from.ID}
+	if status != "" {
+		parsed, err := domain.ParseStatus(status)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = parsed
+	}
+	return filter, nil
+}
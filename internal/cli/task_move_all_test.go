@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestTaskMoveAll(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	backend := domain.NewProject("Backend")
+	backend.Aliases = []string{"be"}
+	require.NoError(t, projectRepo.Create(ctx, backend))
+	archive := domain.NewProject("Archive")
+	require.NoError(t, projectRepo.Create(ctx, archive))
+
+	for _, status := range []domain.Status{domain.StatusCompleted, domain.StatusCompleted, domain.StatusPending} {
+		task := domain.NewTask("task")
+		task.Status = status
+		task.ProjectID = &backend.ID
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	from, to, err := moveAllProjects(ctx, projectRepo, "be", "Archive")
+	require.NoError(t, err)
+	assert.Equal(t, backend.ID, from.ID)
+	assert.Equal(t, archive.ID, to.ID)
+
+	_, _, err = moveAllProjects(ctx, projectRepo, "Backend", "be")
+	assert.Error(t, err)
+	_, _, err = moveAllProjects(ctx, projectRepo, "Backend", "")
+	assert.Error(t, err)
+
+	_, err = moveAllFilter(from, "finished")
+	assert.Error(t, err)
+
+	filter, err := moveAllFilter(from, "completed")
+	require.NoError(t, err)
+	moved, err := taskRepo.BulkMove(ctx, filter, &to.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	left, err := taskRepo.Count(ctx, repository.TaskFilter{ProjectID: &backend.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), left)
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository/sqlite"
+)
+
+func TestTaskMove(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	backend := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+	api := domain.NewProject("API")
+	api.ParentID = &backend.ID
+	api.Aliases = []string{"api"}
+	require.NoError(t, projectRepo.Create(ctx, api))
+
+	task := domain.NewTask("Rate limiting")
+	task.ProjectID = &backend.ID
+	require.NoError(t, taskRepo.Create(ctx, task))
+
+	t.Run("move", func(t *testing.T) {
+		projectID, err := lookupProjectID(ctx, projectRepo, "api")
+		require.NoError(t, err)
+
+		from, to, err := moveTask(ctx, taskRepo, projectRepo, task, projectID)
+		require.NoError(t, err)
+		assert.Equal(t, "Backend", from)
+		assert.Equal(t, "Backend > API", to)
+
+		got, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.ProjectID)
+		assert.Equal(t, api.ID, *got.ProjectID)
+	})
+
+	t.Run("unassign", func(t *testing.T) {
+		from, to, err := moveTask(ctx, taskRepo, projectRepo, task, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Backend > API", from)
+		assert.Equal(t, "no project", to)
+
+		got, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.ProjectID)
+	})
+
+	t.Run("nonexistent target", func(t *testing.T) {
+		_, err := lookupProjectID(ctx, projectRepo, "Mobile")
+		assert.Error(t, err)
+		_, err = lookupProjectID(ctx, projectRepo, "999")
+		assert.Error(t, err)
+	})
+
+	t.Run("nonexistent task", func(t *testing.T) {
+		_, err := resolveTaskRef(ctx, taskRepo, projectRepo, "999")
+		assert.Error(t, err)
+	})
+}
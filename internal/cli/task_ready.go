@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	readyProject string
+	readyLimit   int
+)
+
+var taskReadyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "List the tasks that can be started right now",
+	Long: `List the open tasks nothing is holding back: pending or in progress, not
+snoozed, not waiting on anyone, not tagged "blocked" and not depending on a
+task that is still open (see task depend).
+
+The most urgent come first, scored by priority, whether the task is already
+in progress or pinned, and how soon it is due. Press ! in the TUI for the same
+list.
+
+Examples:
+  taskflow task ready
+  taskflow task ready --project Backend
+  taskflow task ready -n 5`,
+	Args: cobra.NoArgs,
+	RunE: runTaskReady,
+}
+
+func init() {
+	taskCmd.AddCommand(taskReadyCmd)
+
+	taskReadyCmd.Flags().StringVarP(&readyProject, "project", "p", "", "Only tasks in this project (ID, name or alias)")
+	taskReadyCmd.Flags().IntVarP(&readyLimit, "limit", "n", 20, "Show at most this many tasks (0 for all)")
+
+	taskReadyCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+}
+
+func runTaskReady(cmd *cobra.Command, args []string) error {
+	if readyLimit < 0 {
+		return fmt.Errorf("--limit can't be negative")
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	repo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	projectID, err := promptProjectID(ctx, projectRepo, readyProject)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	tasks, total, err := readyTasks(ctx, repo, projectID, readyLimit)
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("Ready to start (%d)", total)))
+	fmt.Println()
+
+	if len(tasks) == 0 {
+		fmt.Println(styles.Info.Render("Nothing is ready, every open task is snoozed, waiting or blocked."))
+		fmt.Println()
+		return nil
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("  #%-5d ", task.ID)
+		printTaskRow(task, styles, cfg.OverdueGraceDays)
+	}
+	if int64(len(tasks)) < total {
+		fmt.Println()
+		fmt.Println(styles.Info.Render(fmt.Sprintf("... and %d more, use --limit 0 to see them all", total-int64(len(tasks)))))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// the ready queue, most urgent first, with the count of all ready tasks
+func readyTasks(ctx context.Context, repo repository.TaskRepository, projectID *int64, limit int) ([]*domain.Task, int64, error) {
+	filter := repository.ReadyFilter(projectID)
+
+	total, err := repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count ready tasks: %w", err)
+	}
+
+	filter.Limit = limit
+	tasks, err := repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list ready tasks: %w", err)
+	}
+	return tasks, total, nil
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	taskStatsProject string
+	taskStatsSince   string
+)
+
+var taskStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize tasks by status and priority",
+	Long: `Show how the tasks break down by status and priority, how many of them
+are done, how many are overdue and how old the open ones are on average.
+
+--project narrows it to one project and --since to the tasks created since a
+date (7d, yesterday, 2025-01-31). For project and system wide overviews see
+taskflow project stats and taskflow stats.
+
+Examples:
+  taskflow task stats
+  taskflow task stats --project Backend
+  taskflow task stats --since 30d`,
+	Args: cobra.NoArgs,
+	RunE: runTaskStats,
+}
+
+func init() {
+	taskCmd.AddCommand(taskStatsCmd)
+
+	taskStatsCmd.Flags().StringVarP(&taskStatsProject, "project", "P", "", "Only tasks in this project (name, alias or ID)")
+	taskStatsCmd.Flags().StringVar(&taskStatsSince, "since", "", "Only tasks created since this date (e.g. 7d, yesterday, 2025-01-31)")
+
+	taskStatsCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	taskStatsCmd.RegisterFlagCompletionFunc("since", cobra.FixedCompletions([]string{"7d", "30d", "yesterday", "today"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+func runTaskStats(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	treatment, err := cfg.CancelledTreatment()
+	if err != nil {
+		return err
+	}
+
+	themeObj, err := theme.GetTheme(cfg.ThemeName)
+	if err != nil {
+		themeObj = theme.GetDefaultTheme()
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	filter, err := buildCountFilter(ctx, projectRepo, countOptions{project: taskStatsProject, graceDays: cfg.OverdueGraceDays})
+	if err != nil {
+		return reportError(cmd, styles, err.Error())
+	}
+
+	var scope []string
+	if taskStatsProject != "" {
+		scope = append(scope, taskStatsProject)
+	}
+	if taskStatsSince != "" {
+		since, err := parseLogSince(taskStatsSince)
+		if err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("Invalid --since value %q", taskStatsSince))
+		}
+		from := query.FormatDateForSQL(*since)
+		filter.CreatedFrom = &from
+		scope = append(scope, "created since "+query.FormatDateForDisplay(*since))
+	}
+
+	stats, err := taskRepo.Aggregate(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to get task stats: %w", err)
+	}
+
+	title := "📊 Task Statistics"
+	if len(scope) > 0 {
+		title += " (" + strings.Join(scope, ", ") + ")"
+	}
+	fmt.Println()
+	fmt.Println(styles.Title.Render(title))
+	fmt.Println()
+
+	if stats.Total == 0 {
+		fmt.Println(styles.Info.Render("No tasks found."))
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Println(styles.Subtitle.Render("By Status"))
+	for _, status := range domain.Statuses {
+		label := fmt.Sprintf("%s %s", styles.StatusSymbol(status), styles.StatusLabel(status))
+		fmt.Printf("  %s\n", renderShare(label, stats.ByStatus[status], stats.Total, styles.GetStatusStyle(status)))
+	}
+	fmt.Println()
+
+	fmt.Println(styles.Subtitle.Render("By Priority"))
+	for _, priority := range domain.Priorities {
+		label := fmt.Sprintf("%s %s", styles.PrioritySymbol(priority), styles.PriorityLabel(priority))
+		fmt.Printf("  %s\n", renderShare(label, stats.ByPriority[priority], stats.Total, styles.GetPriorityStyle(priority)))
+	}
+	fmt.Println()
+
+	fmt.Println(styles.Subtitle.Render("Summary"))
+	fmt.Printf("  Total:            %s\n", styles.Info.Render(fmt.Sprintf("%d", stats.Total)))
+	fmt.Printf("  Completion Rate:  %s\n", renderCompletionRate(stats.CompletionRateFor(treatment), styles))
+	if stats.Overdue > 0 {
+		fmt.Printf("  Overdue:          %s\n", styles.Error.Render(fmt.Sprintf("%d", stats.Overdue)))
+	} else {
+		fmt.Printf("  Overdue:          %s\n", styles.Success.Render("0"))
+	}
+	if stats.OpenTasks() > 0 {
+		fmt.Printf("  Avg Open Age:     %s (%d open)\n", styles.Info.Render(fmt.Sprintf("%.1f days", stats.AverageOpenAge.Hours()/24)), stats.OpenTasks())
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// one row of a breakdown: the label, a bar of count's share of total in the
+// style of what it counts, and the count with its percentage
+func renderShare(label string, count, total int, style lipgloss.Style) string {
+	pct := float64(count) / float64(total) * 100
+	bar := renderBar(int(pct/5), 20, "█")
+	label += strings.Repeat(" ", max(16-lipgloss.Width(label), 0))
+	return fmt.Sprintf("%s %s%s %d (%.1f%%)", label, style.Render(bar), strings.Repeat(" ", 20-len([]rune(bar))), count, pct)
+}
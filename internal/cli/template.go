@@ -49,8 +49,9 @@ Examples:
   taskflow template create "Web Application"
   taskflow template create "Backend Service" --description "Microservice template"
   taskflow template create "Frontend App" --color blue --icon 🚀`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runTemplateCreate,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runTemplateCreate,
 }
 
 func init() {
@@ -76,7 +77,7 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -89,12 +90,16 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		name = args[0]
 	} else {
-		fmt.Print("Template name: ")
-		fmt.Scanln(&name)
+		name, err = promptForName("Template name", func(name string) error {
+			return domain.ValidateName("template", name)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	if strings.TrimSpace(name) == "" {
-		fmt.Println(styles.Error.Render("✗ Template name cannot be empty"))
+	if err := domain.ValidateName("template", name); err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 		return nil
 	}
 
@@ -200,6 +205,10 @@ func runTemplateCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := repo.Create(ctx, template); err != nil {
+		if existing := repository.DuplicateTemplate(ctx, repo, template.Name, err); existing != nil {
+			printDuplicateTemplate(existing, styles)
+			return nil
+		}
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create template: %v", err)))
 		return nil
 	}
@@ -281,7 +290,7 @@ func runTemplateList(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -435,7 +444,7 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -457,7 +466,7 @@ func runTemplateShow(cmd *cobra.Command, args []string) error {
 
 func displayTemplateDetails(template *domain.ProjectTemplate, styles *theme.Styles) {
 	fmt.Println()
-	fmt.Println(styles.Title.Render(fmt.Sprintf("%s (ID: %d)", template.Name, template.ID)))
+	fmt.Println(styles.Title.Render(fmt.Sprintf("%s (ID: %d, v%d)", template.Name, template.ID, template.Version)))
 	fmt.Println()
 
 	if template.Description != "" {
@@ -486,7 +495,7 @@ func displayTemplateDetails(template *domain.ProjectTemplate, styles *theme.Styl
 			fmt.Printf("     Description: %s\n", taskDef.Description)
 		}
 
-		fmt.Printf("     Priority: %s\n", taskDef.Priority)
+		fmt.Printf("     Priority: %s\n", styles.PriorityLabel(domain.Priority(taskDef.Priority)))
 
 		if len(taskDef.Tags) > 0 {
 			fmt.Printf("     Tags: %s\n", strings.Join(taskDef.Tags, ", "))
@@ -508,8 +517,9 @@ var templateEditCmd = &cobra.Command{
 Examples:
   taskflow template edit 1
   taskflow template edit "Web Application"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTemplateEdit,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runTemplateEdit,
 }
 
 func runTemplateEdit(cmd *cobra.Command, args []string) error {
@@ -529,7 +539,7 @@ func runTemplateEdit(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -664,6 +674,10 @@ func runTemplateEdit(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := repo.Update(ctx, template); err != nil {
+		if existing := repository.DuplicateTemplate(ctx, repo, template.Name, err); existing != nil {
+			printDuplicateTemplate(existing, styles)
+			return nil
+		}
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to update template: %v", err)))
 		return nil
 	}
@@ -686,8 +700,9 @@ Examples:
   taskflow template delete 1
   taskflow template delete "Web Application"
   taskflow template delete 1 --confirm`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTemplateDelete,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runTemplateDelete,
 }
 
 func init() {
@@ -711,7 +726,7 @@ func runTemplateDelete(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -771,8 +786,10 @@ Examples:
   taskflow template apply "Web Application" --name "My Website"
   taskflow template apply 1 --name "Backend API" --parent "Development"
   taskflow template apply 2 --name "Mobile App" --no-defaults --color green`,
-	Args: cobra.ExactArgs(1),
-	RunE: runTemplateApply,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeTemplateNames,
+	Annotations:       writesDatabase,
+	RunE:              runTemplateApply,
 }
 
 func init() {
@@ -783,6 +800,7 @@ func init() {
 	templateApplyCmd.Flags().BoolVar(&applyNoDefaults, "no-defaults", false, "Don't use template defaults")
 
 	templateApplyCmd.MarkFlagRequired("name")
+	templateApplyCmd.RegisterFlagCompletionFunc("parent", completeProjectNames)
 }
 
 func runTemplateApply(cmd *cobra.Command, args []string) error {
@@ -802,7 +820,7 @@ func runTemplateApply(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -820,6 +838,8 @@ func runTemplateApply(cmd *cobra.Command, args []string) error {
 	}
 
 	project := domain.NewProject(applyTemplateName)
+	project.SourceTemplateID = &template.ID
+	project.SourceTemplateVersion = template.Version
 
 	if !applyNoDefaults && template.ProjectDefaults != nil {
 		if applyTemplateColor == "" && template.ProjectDefaults.Color != "" {
@@ -838,7 +858,7 @@ func runTemplateApply(cmd *cobra.Command, args []string) error {
 	}
 
 	if applyTemplateParent != "" {
-		parentID, err := lookupProjectID(ctx, projectRepo, applyTemplateParent)
+		parentID, err := promptProjectID(ctx, projectRepo, applyTemplateParent)
 		if err != nil {
 			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 			return nil
@@ -847,16 +867,21 @@ func runTemplateApply(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := projectRepo.Create(ctx, project); err != nil {
+		if existing := repository.DuplicateProject(ctx, projectRepo, project.Name, err); existing != nil {
+			printDuplicateProject(existing, styles)
+			return nil
+		}
 		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create project: %v", err)))
 		return nil
 	}
 
 	tasksCreated := 0
 	for _, taskDef := range template.TaskDefinitions {
-		task := domain.NewTask(taskDef.Title)
-		task.Description = taskDef.Description
-		task.Priority = domain.Priority(taskDef.Priority)
-		task.Tags = taskDef.Tags
+		task, err := taskDef.NewTask()
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task '%s': %v", taskDef.Title, err)))
+			continue
+		}
 		task.ProjectID = &project.ID
 
 		if err := taskRepo.Create(ctx, task); err != nil {
@@ -886,6 +911,12 @@ func lookupTemplate(ctx context.Context, repo *sqlite.TemplateRepository, nameOr
 	return repo.GetByName(ctx, nameOrID)
 }
 
+func printDuplicateTemplate(existing *domain.ProjectTemplate, styles *theme.Styles) {
+	fmt.Println(styles.Error.Render(fmt.Sprintf("✗ A template named '%s' already exists (ID %d)", existing.Name, existing.ID)))
+	fmt.Println(styles.Info.Render("  Choose a different name, or edit the existing template:"))
+	fmt.Println(styles.Info.Render(fmt.Sprintf("  taskflow template edit %d", existing.ID)))
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var (
+	syncTemplateYes    bool
+	syncTemplateDryRun bool
+)
+
+var templateSyncCmd = &cobra.Command{
+	Use:   "sync <template-name|id> <project>",
+	Short: "Add a template's new tasks to a project",
+	Long: `Compare a project with a template and add the template tasks it is missing.
+
+Tasks are matched on title, ignoring case. Tasks already in the project are
+never changed, including ones that came from the template and were edited or
+completed since, so a renamed task counts as missing.
+
+Templates get a new version whenever their tasks change. The project remembers
+which version it was created from or last synced with.
+
+Examples:
+  taskflow template sync "Web Application" "My Website"
+  taskflow template sync 1 backend --dry-run
+  taskflow template sync 1 backend --yes`,
+	Args: cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeTemplateNames(cmd, args, toComplete)
+		}
+		if len(args) == 1 {
+			return completeProjectNames(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	},
+	Annotations: writesDatabase,
+	RunE:        runTemplateSync,
+}
+
+func init() {
+	templateCmd.AddCommand(templateSyncCmd)
+
+	templateSyncCmd.Flags().BoolVarP(&syncTemplateYes, "yes", "y", false, "Add the missing tasks without asking")
+	templateSyncCmd.Flags().BoolVar(&syncTemplateDryRun, "dry-run", false, "Only show the missing tasks")
+}
+
+func runTemplateSync(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	templateRepo := sqlite.NewTemplateRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	template, err := lookupTemplate(ctx, templateRepo, args[0])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	projectID, err := promptProjectID(ctx, projectRepo, args[1])
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+	if projectID == nil {
+		return fmt.Errorf("project cannot be empty")
+	}
+	project, err := projectRepo.GetByID(ctx, *projectID)
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
+	}
+
+	tasks, err := taskRepo.List(ctx, repository.TaskFilter{ProjectID: &project.ID})
+	if err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list project tasks: %v", err)))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("Template '%s' (v%d) → project '%s'", template.Name, template.Version, project.Name)))
+	fmt.Println()
+	printTemplateSource(ctx, templateRepo, project, template, styles)
+
+	missing := template.MissingTasks(tasks)
+	if len(missing) == 0 {
+		fmt.Println(styles.Success.Render(fmt.Sprintf("✓ The project has all %d template task(s)", len(template.TaskDefinitions))))
+		if !syncTemplateDryRun {
+			markSynced(ctx, projectRepo, project, template, styles)
+		}
+		return nil
+	}
+
+	fmt.Println(styles.Subtitle.Render(fmt.Sprintf("Missing template tasks (%d):", len(missing))))
+	for _, taskDef := range missing {
+		line := fmt.Sprintf("  + %s", taskDef.Title)
+		if taskDef.Priority != "" {
+			line += styles.Info.Render(fmt.Sprintf(" (%s)", styles.PriorityLabel(domain.Priority(taskDef.Priority))))
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	if syncTemplateDryRun {
+		fmt.Println(styles.Info.Render("Dry run mode - no tasks will be added"))
+		return nil
+	}
+
+	if !syncTemplateYes && !promptForConfirmation(fmt.Sprintf("Add %d task(s) to '%s'?", len(missing), project.Name)) {
+		fmt.Println(styles.Info.Render("Cancelled"))
+		return nil
+	}
+
+	added := 0
+	for _, taskDef := range missing {
+		task, err := taskDef.NewTask()
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task '%s': %v", taskDef.Title, err)))
+			continue
+		}
+		task.ProjectID = &project.ID
+
+		if err := taskRepo.Create(ctx, task); err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to create task '%s': %v", taskDef.Title, err)))
+			continue
+		}
+		added++
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Added %d task(s) to '%s'", added, project.Name)))
+	if added == len(missing) {
+		markSynced(ctx, projectRepo, project, template, styles)
+	}
+	return nil
+}
+
+// which template and version the project was created from or last synced with
+func printTemplateSource(ctx context.Context, repo *sqlite.TemplateRepository, project *domain.Project, template *domain.ProjectTemplate, styles *theme.Styles) {
+	switch {
+	case project.SourceTemplateID == nil:
+		fmt.Println(styles.Info.Render("The project wasn't created from a template, syncing links it to this one."))
+	case *project.SourceTemplateID != template.ID:
+		source := fmt.Sprintf("#%d", *project.SourceTemplateID)
+		if other, err := repo.GetByID(ctx, *project.SourceTemplateID); err == nil {
+			source = fmt.Sprintf("'%s'", other.Name)
+		}
+		fmt.Println(styles.Info.Render(fmt.Sprintf("The project came from template %s, syncing links it to this one.", source)))
+	case project.SourceTemplateVersion < template.Version:
+		fmt.Println(styles.Info.Render(fmt.Sprintf("The project is at v%d of the template.", project.SourceTemplateVersion)))
+	default:
+		fmt.Println(styles.Info.Render("The project is up to date with the template's version."))
+	}
+	fmt.Println()
+}
+
+// records the template version the project now has all the tasks of
+func markSynced(ctx context.Context, repo *sqlite.ProjectRepository, project *domain.Project, template *domain.ProjectTemplate, styles *theme.Styles) {
+	if project.SourceTemplateID != nil && *project.SourceTemplateID == template.ID && project.SourceTemplateVersion == template.Version {
+		return
+	}
+
+	project.SourceTemplateID = &template.ID
+	project.SourceTemplateVersion = template.Version
+	if err := repo.Update(ctx, project); err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to record the template version: %v", err)))
+	}
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -41,11 +42,14 @@ Available themes:
   - nord
   - gruvbox
 
+Inside the task list, press 't' to cycle through themes without restarting.
+
 Examples:
   taskflow theme set dracula
   taskflow theme set nord`,
-	Args: cobra.ExactArgs(1),
-	RunE: runThemeSet,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: theme.ListThemes(),
+	RunE:      runThemeSet,
 }
 
 var themeListCmd = &cobra.Command{
@@ -95,10 +99,10 @@ func runThemeTUI(cmd *cobra.Command, args []string) error {
 
 // sets the theme directly
 func runThemeSet(cmd *cobra.Command, args []string) error {
-	themeName := args[0]
+	themeName := strings.ToLower(strings.TrimSpace(args[0]))
 
 	if !theme.ThemeExists(themeName) {
-		return fmt.Errorf("theme '%s' not found. Run 'taskflow theme list' to see available themes", themeName)
+		return fmt.Errorf("theme '%s' not found. Available themes: %s", args[0], strings.Join(theme.ListThemes(), ", "))
 	}
 
 	if err := config.UpdateTheme(themeName); err != nil {
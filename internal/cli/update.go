@@ -3,8 +3,8 @@ package cli
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -23,6 +23,9 @@ var (
 	updateTags        []string
 	updateDueDate     string
 	updateClearDue    bool
+	updateWaiting     string
+	updateColor       string
+	updateRecurring   string
 
 	titleSet       bool
 	descriptionSet bool
@@ -31,22 +34,37 @@ var (
 	projectSet     bool
 	tagsSet        bool
 	dueDateSet     bool
+	waitingSet     bool
+	colorSet       bool
+	recurringSet   bool
 )
 
 var updateCmd = &cobra.Command{
-	Use:   "update [task-id]",
+	Use:   "update <task-id|->",
 	Short: "Update an existing task",
 	Long: `Update an existing task with new values.
 Only the fields you specify will be updated; all other fields remain unchanged.
+Completing a recurring task creates its next instance.
+With - as the task ID, the same update is applied to every ID read from stdin,
+one per line, as printed by list --ids-only and search --ids-only.
 
 Examples:
   taskflow update 1 --title "New title"
   taskflow update 2 --priority urgent --status in_progress
   taskflow update 3 --description "Updated description" --tags bug,critical
   taskflow update 4 --project frontend --due-date "2024-12-31"
-  taskflow update 5 --clear-due-date`,
-	Args: cobra.ExactArgs(1),
-	RunE: runUpdate,
+  taskflow update 5 --clear-due-date
+  taskflow update 6 --waiting "Alice"
+  taskflow update 6 --waiting ""                  # No longer waiting
+  taskflow update 7 --color red                   # Highlight the task's row
+  taskflow update 7 --color ""                    # Back to the project color
+  taskflow update 8 --recurring monthly:1         # Repeat on the 1st
+  taskflow update 8 --recurring ""                # Stop repeating
+  taskflow search login --ids-only | taskflow update - --status completed
+  taskflow list --tags api --ids-only | taskflow update - --project backend`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runUpdate,
 }
 
 func init() {
@@ -58,8 +76,11 @@ func init() {
 	updateCmd.Flags().StringVar(&updateStatus, "status", "", "Update status (pending, in_progress, completed, cancelled)")
 	updateCmd.Flags().StringVar(&updateProject, "project", "", "Update project (name or ID, empty to remove)")
 	updateCmd.Flags().StringSliceVar(&updateTags, "tags", nil, "Update tags (comma-separated)")
-	updateCmd.Flags().StringVar(&updateDueDate, "due-date", "", "Update due date (YYYY-MM-DD format)")
+	updateCmd.Flags().StringVar(&updateDueDate, "due-date", "", "Update due date (YYYY-MM-DD, today, tomorrow, +3d, +2w, friday, eod, eow)")
 	updateCmd.Flags().BoolVar(&updateClearDue, "clear-due-date", false, "Clear the due date")
+	updateCmd.Flags().StringVar(&updateWaiting, "waiting", "", "Who or what the task is waiting on (empty to clear)")
+	updateCmd.Flags().StringVar(&updateColor, "color", "", "Row color, overriding the project color (empty to clear)")
+	updateCmd.Flags().StringVar(&updateRecurring, "recurring", "", "Repeat the task: daily, weekly, weekly:mon,wed, monthly or monthly:15 (empty to stop)")
 
 	updateCmd.Flags().Lookup("title").Changed = false
 	updateCmd.Flags().Lookup("description").Changed = false
@@ -68,13 +89,21 @@ func init() {
 	updateCmd.Flags().Lookup("project").Changed = false
 	updateCmd.Flags().Lookup("tags").Changed = false
 	updateCmd.Flags().Lookup("due-date").Changed = false
+	updateCmd.Flags().Lookup("waiting").Changed = false
+	updateCmd.Flags().Lookup("color").Changed = false
+	updateCmd.Flags().Lookup("recurring").Changed = false
+
+	updateCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	updateCmd.RegisterFlagCompletionFunc("tags", completeTags)
+	updateCmd.RegisterFlagCompletionFunc("color", completeColors)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
-	taskID, err := strconv.ParseInt(args[0], 10, 64)
+	taskIDs, invalid, err := taskIDsFromArgs(args, cmd.InOrStdin())
 	if err != nil {
-		return fmt.Errorf("invalid task ID: %s", args[0])
+		return err
 	}
+	fromStdin := readsIDsFromStdin(args)
 
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -92,7 +121,7 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -101,12 +130,6 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	repo := sqlite.NewTaskRepository(db)
 	ctx := context.Background()
 
-	task, err := repo.GetByID(ctx, taskID)
-	if err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Task not found: %v", err)))
-		return nil
-	}
-
 	titleSet = cmd.Flags().Changed("title")
 	descriptionSet = cmd.Flags().Changed("description")
 	prioritySet = cmd.Flags().Changed("priority")
@@ -114,60 +137,140 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	projectSet = cmd.Flags().Changed("project")
 	tagsSet = cmd.Flags().Changed("tags")
 	dueDateSet = cmd.Flags().Changed("due-date")
+	waitingSet = cmd.Flags().Changed("waiting")
+	colorSet = cmd.Flags().Changed("color")
+	recurringSet = cmd.Flags().Changed("recurring")
 
-	if !titleSet && !descriptionSet && !prioritySet && !statusSet && !projectSet && !tagsSet && !dueDateSet && !updateClearDue {
+	if !titleSet && !descriptionSet && !prioritySet && !statusSet && !projectSet && !tagsSet && !dueDateSet && !updateClearDue && !waitingSet && !colorSet && !recurringSet {
 		fmt.Println(styles.Info.Render("No updates specified. Use --help to see available flags."))
 		return nil
 	}
 
-	if titleSet {
-		task.Title = updateTitle
-	}
-	if descriptionSet {
-		task.Description = updateDescription
-	}
+	// flag values are parsed once, before any task is touched
+	var priority domain.Priority
 	if prioritySet {
-		task.Priority = domain.Priority(updatePriority)
+		if priority, err = domain.ParsePriority(updatePriority); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
+		}
 	}
+	var status domain.Status
 	if statusSet {
-		task.Status = domain.Status(updateStatus)
-	}
-	if projectSet {
-		if updateProject == "" {
-			task.ProjectID = nil
-		} else {
-			projectRepo := sqlite.NewProjectRepository(db)
-			projectID, err := lookupProjectID(ctx, projectRepo, updateProject)
-			if err != nil {
-				fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
-				return nil
-			}
-			task.ProjectID = projectID
+		if status, err = domain.ParseStatus(updateStatus); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
+		}
+	}
+	var projectID *int64
+	if projectSet && updateProject != "" {
+		projectRepo := sqlite.NewProjectRepository(db)
+		if projectID, err = promptProjectID(ctx, projectRepo, updateProject); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
 		}
 	}
-	if tagsSet {
-		task.Tags = updateTags
+	var color string
+	if colorSet {
+		if color, err = domain.ParseColor(updateColor); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
+		}
 	}
+	var recurrence string
+	if recurringSet && updateRecurring != "" {
+		parsed, err := domain.ParseRecurrence(updateRecurring)
+		if err != nil {
+			return reportError(cmd, styles, err.Error())
+		}
+		recurrence = parsed.String()
+	}
+	var dueDate *time.Time
 	if dueDateSet {
-		dueDate, err := parseDueDate(updateDueDate)
+		if dueDate, err = domain.ParseDueDate(updateDueDate); err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("Invalid due date: %v", err))
+		}
+	}
+
+	// the next instance a completed recurring task was replaced by, if any
+	var next *domain.Task
+	updateTask := func(taskID int64) (*domain.Task, error) {
+		task, err := repo.GetByID(ctx, taskID)
 		if err != nil {
-			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Invalid due date format: %v", err)))
-			fmt.Println(styles.Info.Render("  Use YYYY-MM-DD format (e.g., 2024-12-31)"))
-			return nil
+			return nil, err
+		}
+
+		if titleSet {
+			task.Title = updateTitle
+		}
+		if descriptionSet {
+			task.Description = updateDescription
+		}
+		if prioritySet {
+			task.Priority = priority
+		}
+		if statusSet {
+			task.Status = status
+		}
+		if projectSet {
+			task.ProjectID = projectID
 		}
-		task.DueDate = dueDate
+		if tagsSet {
+			task.Tags = updateTags
+		}
+		if dueDateSet {
+			task.DueDate = dueDate
+		}
+		if updateClearDue {
+			task.DueDate = nil
+		}
+		if waitingSet {
+			task.WaitingOn = strings.TrimSpace(updateWaiting)
+		}
+		if colorSet {
+			task.Color = color
+		}
+		if recurringSet {
+			task.Recurrence = recurrence
+		}
+
+		if next, err = repo.UpdateWithNext(ctx, task); err != nil {
+			return nil, fmt.Errorf("failed to update task: %w", err)
+		}
+		return task, nil
 	}
-	if updateClearDue {
-		task.DueDate = nil
+
+	if !fromStdin {
+		task, err := updateTask(taskIDs[0])
+		if err != nil {
+			return reportError(cmd, styles, fmt.Sprintf("%v", err))
+		}
+		displayTaskUpdated(task, styles)
+		if next != nil {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("↻ Repeats %s, next one due %s", next.Recurrence, next.DueDate.Format("2006-01-02"))))
+			fmt.Println()
+		}
+		return nil
 	}
 
-	if err := repo.Update(ctx, task); err != nil {
-		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to update task: %v", err)))
+	fmt.Println()
+	if len(taskIDs) == 0 && len(invalid) == 0 {
+		fmt.Println(styles.Info.Render("No task IDs on stdin, nothing to update."))
+		fmt.Println()
 		return nil
 	}
 
-	displayTaskUpdated(task, styles)
+	updated := 0
+	for _, id := range taskIDs {
+		if _, err := updateTask(id); err != nil {
+			fmt.Fprintln(cmd.ErrOrStderr(), styles.Error.Render(fmt.Sprintf("✗ #%d: %v", id, err)))
+			continue
+		}
+		updated++
+	}
+
+	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ Updated %d of %d task(s)", updated, len(taskIDs))))
+	printInvalidTaskIDs(invalid, styles)
+	fmt.Println()
 
+	if updated < len(taskIDs) {
+		return reported(cmd)
+	}
 	return nil
 }
 
@@ -182,8 +285,8 @@ func displayTaskUpdated(task *domain.Task, styles *theme.Styles) {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Description:"), task.Description)
 	}
 
-	fmt.Printf("  %s %s\n", styles.Info.Render("Priority:"), task.Priority)
-	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), task.Status)
+	fmt.Printf("  %s %s\n", styles.Info.Render("Priority:"), styles.PriorityLabel(task.Priority))
+	fmt.Printf("  %s %s\n", styles.Info.Render("Status:"), styles.StatusLabel(task.Status))
 
 	if task.ProjectName != "" {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Project:"), task.ProjectName)
@@ -197,6 +300,18 @@ func displayTaskUpdated(task *domain.Task, styles *theme.Styles) {
 		fmt.Printf("  %s %s\n", styles.Info.Render("Due Date:"), task.DueDate.Format("2006-01-02"))
 	}
 
+	if task.WaitingOn != "" {
+		fmt.Printf("  %s %s\n", styles.Info.Render("Waiting on:"), task.WaitingOn)
+	}
+
+	if task.Recurrence != "" {
+		fmt.Printf("  %s %s\n", styles.Info.Render("Repeats:"), task.Recurrence)
+	}
+
+	if task.Color != "" {
+		fmt.Printf("  %s %s\n", styles.Info.Render("Color:"), task.Color)
+	}
+
 	fmt.Printf("  %s %s\n", styles.Info.Render("Updated:"), task.UpdatedAt.Format("2006-01-02 15:04:05"))
 
 	fmt.Println()
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -38,7 +39,6 @@ func init() {
 	viewCmd.AddCommand(viewFavoriteCmd)
 }
 
-
 var (
 	saveViewDescription string
 	saveViewFavorite    bool
@@ -59,8 +59,9 @@ Examples:
   taskflow view save "High Priority Backend" --status pending --priority high --project Backend
   taskflow view save "Due This Week" --search "due:this-week"
   taskflow view save "My Tasks" --favorite --hotkey 1`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runViewSave,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runViewSave,
 }
 
 func init() {
@@ -72,6 +73,9 @@ func init() {
 	viewSaveCmd.Flags().StringVar(&saveViewProject, "project", "", "Filter by project (name or ID)")
 	viewSaveCmd.Flags().StringSliceVar(&saveViewTags, "tags", nil, "Filter by tags (comma-separated)")
 	viewSaveCmd.Flags().StringVar(&saveViewSearch, "search", "", "Search query")
+
+	viewSaveCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	viewSaveCmd.RegisterFlagCompletionFunc("tags", completeTags)
 }
 
 func runViewSave(cmd *cobra.Command, args []string) error {
@@ -90,7 +94,7 @@ func runViewSave(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -104,23 +108,34 @@ func runViewSave(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 {
 		name = args[0]
 	} else {
-		var err error
-		name, err = promptForInput("View name", "")
+		name, err = promptForName("View name", func(name string) error {
+			return domain.ValidateName("view", name)
+		})
 		if err != nil {
-			return fmt.Errorf("failed to read input: %w", err)
+			return err
 		}
 	}
 
 	filter := domain.SavedViewFilter{}
 
 	if saveViewStatus != "" {
-		filter.Status = domain.Status(saveViewStatus)
+		status, err := domain.ParseStatus(saveViewStatus)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+		filter.Status = status
 	}
 	if saveViewPriority != "" {
-		filter.Priority = domain.Priority(saveViewPriority)
+		priority, err := domain.ParsePriority(saveViewPriority)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+		filter.Priority = priority
 	}
 	if saveViewProject != "" {
-		projectID, err := lookupProjectID(ctx, projectRepo, saveViewProject)
+		projectID, err := promptProjectID(ctx, projectRepo, saveViewProject)
 		if err != nil {
 			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
 			return nil
@@ -169,11 +184,11 @@ func runViewSave(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
 var (
 	listViewFavorite bool
 	listViewHotKey   bool
 	listViewSearch   string
+	listViewCounts   bool
 )
 
 var viewListCmd = &cobra.Command{
@@ -184,7 +199,11 @@ var viewListCmd = &cobra.Command{
 Examples:
   taskflow view list                   # Show all views
   taskflow view list --favorite        # Show only favorites
-  taskflow view list --hotkey          # Show views with hot keys assigned`,
+  taskflow view list --hotkey          # Show views with hot keys assigned
+  taskflow view list --counts          # With how many tasks each view matches
+
+Counting runs a query per view. Set view_counts: true in the config to always
+show the counts, --counts=false turns them off again.`,
 	RunE: runViewList,
 }
 
@@ -192,6 +211,7 @@ func init() {
 	viewListCmd.Flags().BoolVar(&listViewFavorite, "favorite", false, "Show only favorite views")
 	viewListCmd.Flags().BoolVar(&listViewHotKey, "hotkey", false, "Show only views with hot keys")
 	viewListCmd.Flags().StringVar(&listViewSearch, "search", "", "Search views by name or description")
+	viewListCmd.Flags().BoolVar(&listViewCounts, "counts", false, "Show how many tasks each view matches (default from view_counts)")
 }
 
 func runViewList(cmd *cobra.Command, args []string) error {
@@ -210,7 +230,7 @@ func runViewList(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -254,6 +274,13 @@ func runViewList(cmd *cobra.Command, args []string) error {
 	fmt.Println(styles.Title.Render("Saved Views"))
 	fmt.Println()
 
+	showCounts := cfg.ViewCounts
+	if cmd.Flags().Changed("counts") {
+		showCounts = listViewCounts
+	}
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+
 	headers := []string{
 		styles.Header.Render("ID"),
 		styles.Header.Render("Name"),
@@ -261,6 +288,9 @@ func runViewList(cmd *cobra.Command, args []string) error {
 		styles.Header.Render("Hotkey"),
 		styles.Header.Render("Favorite"),
 	}
+	if showCounts {
+		headers = append(headers, styles.Header.Render("Tasks"))
+	}
 	fmt.Println(strings.Join(headers, " | "))
 
 	fmt.Println(styles.Separator.Render(strings.Repeat("─", 100)))
@@ -283,6 +313,18 @@ func runViewList(cmd *cobra.Command, args []string) error {
 			hotKeyDisplay,
 			favoriteDisplay,
 		}
+		if showCounts {
+			count, ok, err := countViewTasks(ctx, taskRepo, projectRepo, view)
+			switch {
+			case err != nil:
+				row = append(row, styles.Error.Render("error"))
+			case !ok:
+				// filters on a project that was deleted
+				row = append(row, "—")
+			default:
+				row = append(row, fmt.Sprintf("%d", count))
+			}
+		}
 		fmt.Println(strings.Join(row, " | "))
 	}
 
@@ -293,7 +335,6 @@ func runViewList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
 var viewShowCmd = &cobra.Command{
 	Use:   "show <name|id>",
 	Short: "Show detailed view information",
@@ -322,7 +363,7 @@ func runViewShow(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -352,8 +393,8 @@ func runViewShow(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s\n", styles.Subtitle.Render("Filter Configuration:"))
-	fmt.Printf("  Status:        %s\n", displayValue(string(view.FilterConfig.Status)))
-	fmt.Printf("  Priority:      %s\n", displayValue(string(view.FilterConfig.Priority)))
+	fmt.Printf("  Status:        %s\n", displayValue(styles.StatusLabel(view.FilterConfig.Status)))
+	fmt.Printf("  Priority:      %s\n", displayValue(styles.PriorityLabel(view.FilterConfig.Priority)))
 	fmt.Printf("  Tags:          %s\n", displayTags(view.FilterConfig.Tags))
 	fmt.Printf("  Search:        %s\n", displayValue(view.FilterConfig.SearchQuery))
 	fmt.Println()
@@ -374,7 +415,6 @@ func runViewShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
 var viewApplyCmd = &cobra.Command{
 	Use:   "apply <name|id|hotkey>",
 	Short: "Apply a saved view (launch TUI with filter)",
@@ -394,7 +434,7 @@ func runViewApply(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -426,18 +466,7 @@ func runViewApply(cmd *cobra.Command, args []string) error {
 
 	_ = viewRepo.RecordViewAccess(ctx, view.ID)
 
-	taskFilter := repository.TaskFilter{
-		Status:       view.FilterConfig.Status,
-		Priority:     view.FilterConfig.Priority,
-		ProjectID:    view.FilterConfig.ProjectID,
-		Tags:         view.FilterConfig.Tags,
-		SearchQuery:  view.FilterConfig.SearchQuery,
-		SearchMode:   view.FilterConfig.SearchMode,
-		SortBy:       view.FilterConfig.SortBy,
-		SortOrder:    view.FilterConfig.SortOrder,
-		DueDateFrom:  view.FilterConfig.DueDateFrom,
-		DueDateTo:    view.FilterConfig.DueDateTo,
-	}
+	taskFilter := viewTaskFilter(view)
 
 	listStatus = string(taskFilter.Status)
 	listPriority = string(taskFilter.Priority)
@@ -447,7 +476,6 @@ func runViewApply(cmd *cobra.Command, args []string) error {
 	return runList(cmd, []string{})
 }
 
-
 var viewDeleteConfirm bool
 
 var viewDeleteCmd = &cobra.Command{
@@ -459,8 +487,9 @@ Examples:
   taskflow view delete "Old View"
   taskflow view delete 1
   taskflow view delete "Temp" --confirm`,
-	Args: cobra.ExactArgs(1),
-	RunE: runViewDelete,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runViewDelete,
 }
 
 func init() {
@@ -483,7 +512,7 @@ func runViewDelete(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -529,34 +558,58 @@ func runViewDelete(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
-var (
-	updateViewName        string
-	updateViewDescription string
-	updateViewFavorite    *bool
-	updateViewHotKey      int
-)
-
 var viewUpdateCmd = &cobra.Command{
 	Use:   "update <name|id>",
-	Short: "Update view properties",
-	Long: `Update properties of an existing saved view.
+	Short: "Update a view's filters and properties",
+	Long: `Update the name, description, favorite, hot key or filters of a saved view.
+
+Only the flags you pass are changed, every other filter of the view is kept.
+Use the --clear-* flags to remove a filter from the view.
 
 Examples:
   taskflow view update "My View" --name "New Name"
   taskflow view update 1 --description "Updated description"
-  taskflow view update "View" --favorite --hotkey 5`,
-	Args: cobra.ExactArgs(1),
-	RunE: runViewUpdate,
+  taskflow view update "View" --favorite true --hotkey 5
+  taskflow view update "Backend" --priority urgent --tags api,db
+  taskflow view update "Backend" --due +7d --search "deploy.*prod" --mode regex
+  taskflow view update "Backend" --clear-status --clear-search`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runViewUpdate,
 }
 
 func init() {
-	viewUpdateCmd.Flags().StringVar(&updateViewName, "name", "", "New view name")
-	viewUpdateCmd.Flags().StringVar(&updateViewDescription, "description", "", "New description")
-	viewUpdateCmd.Flags().IntVar(&updateViewHotKey, "hotkey", 0, "New hot key (1-9, or 0 to clear)")
+	addViewUpdateFlags(viewUpdateCmd)
+}
 
-	var favStr string
-	viewUpdateCmd.Flags().StringVar(&favStr, "favorite", "", "Set favorite (true/false)")
+// the filters view update can set and clear, by flag name
+var viewUpdateFilterFlags = []string{"status", "priority", "project", "tags", "search", "due"}
+
+func addViewUpdateFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.String("name", "", "New view name")
+	flags.String("description", "", "New description")
+	flags.Int("hotkey", 0, "New hot key (1-9, or 0 to clear)")
+	flags.String("favorite", "", "Set favorite (true/false)")
+
+	flags.String("status", "", "Filter by status (pending, in_progress, completed, cancelled)")
+	flags.String("priority", "", "Filter by priority (low, medium, high, urgent)")
+	flags.String("project", "", "Filter by project (name or ID)")
+	flags.StringSlice("tags", nil, "Filter by tags (comma-separated), replacing the view's tags")
+	flags.String("search", "", "Search query")
+	flags.String("mode", "", "Search mode (text, regex, fuzzy, fts), kept from the view when omitted")
+	flags.String("due", "", "Filter by due date (today, +7d, YYYY-MM-DD, today..+7d, none)")
+	for _, name := range viewUpdateFilterFlags {
+		flags.Bool("clear-"+name, false, fmt.Sprintf("Remove the %s filter from the view", name))
+	}
+
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{"pending", "in_progress", "completed", "cancelled"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("priority", cobra.FixedCompletions([]string{"low", "medium", "high", "urgent"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions([]string{"text", "regex", "fuzzy", "fts"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("due", cobra.FixedCompletions([]string{"today", "tomorrow", "eow", "+7d", "none"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("favorite", cobra.FixedCompletions([]string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp))
+	cmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	cmd.RegisterFlagCompletionFunc("tags", completeTags)
 }
 
 func runViewUpdate(cmd *cobra.Command, args []string) error {
@@ -575,13 +628,14 @@ func runViewUpdate(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer db.Close()
 
 	viewRepo := sqlite.NewViewRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
 	ctx := context.Background()
 
 	viewID, err := lookupViewID(ctx, viewRepo, args[0])
@@ -596,24 +650,10 @@ func runViewUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if updateViewName != "" {
-		view.Name = updateViewName
-	}
-	if updateViewDescription != "" {
-		view.Description = updateViewDescription
-	}
-	if cmd.Flags().Changed("favorite") {
-		favStr, _ := cmd.Flags().GetString("favorite")
-		if favStr == "true" {
-			view.IsFavorite = true
-		} else if favStr == "false" {
-			view.IsFavorite = false
-		}
-	}
-	if updateViewHotKey > 0 {
-		view.HotKey = &updateViewHotKey
-	} else if cmd.Flags().Changed("hotkey") && updateViewHotKey == 0 {
-		view.HotKey = nil
+	oldHotKey := view.HotKey
+	if err := applyViewUpdate(ctx, projectRepo, cmd, view); err != nil {
+		fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+		return nil
 	}
 
 	if err := view.Validate(); err != nil {
@@ -628,11 +668,139 @@ func runViewUpdate(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 	fmt.Println(styles.Success.Render(fmt.Sprintf("✓ View '%s' updated successfully!", view.Name)))
+	if !equalIntPtr(oldHotKey, view.HotKey) {
+		if view.HotKey != nil {
+			fmt.Println(styles.Info.Render(fmt.Sprintf("  Press %d in TUI to quick-apply", *view.HotKey)))
+		} else {
+			fmt.Println(styles.Info.Render("  Hot key cleared"))
+		}
+	}
+	fmt.Println(styles.Info.Render(fmt.Sprintf("  Filters: %s", view.GetFilterSummary())))
 	fmt.Println()
 
 	return nil
 }
 
+// applies the update flags that were passed to view. properties and filters
+// whose flags weren't passed keep their values
+func applyViewUpdate(ctx context.Context, projectRepo repository.ProjectRepository, cmd *cobra.Command, view *domain.SavedView) error {
+	flags := cmd.Flags()
+
+	for _, name := range viewUpdateFilterFlags {
+		clear, _ := flags.GetBool("clear-" + name)
+		if clear && flags.Changed(name) {
+			return fmt.Errorf("--%s and --clear-%s can't be used together", name, name)
+		}
+	}
+	if clear, _ := flags.GetBool("clear-search"); clear && flags.Changed("mode") {
+		return fmt.Errorf("--mode and --clear-search can't be used together")
+	}
+
+	if flags.Changed("name") {
+		view.Name, _ = flags.GetString("name")
+	}
+	if flags.Changed("description") {
+		view.Description, _ = flags.GetString("description")
+	}
+	if flags.Changed("favorite") {
+		favStr, _ := flags.GetString("favorite")
+		favorite, err := strconv.ParseBool(favStr)
+		if err != nil {
+			return fmt.Errorf("invalid --favorite value %q (use true or false)", favStr)
+		}
+		view.IsFavorite = favorite
+	}
+	if flags.Changed("hotkey") {
+		hotKey, _ := flags.GetInt("hotkey")
+		if hotKey == 0 {
+			view.HotKey = nil
+		} else {
+			view.HotKey = &hotKey
+		}
+	}
+
+	filter := &view.FilterConfig
+	if flags.Changed("status") {
+		value, _ := flags.GetString("status")
+		status, err := domain.ParseStatus(value)
+		if err != nil {
+			return err
+		}
+		filter.Status = status
+	}
+	if flags.Changed("priority") {
+		value, _ := flags.GetString("priority")
+		priority, err := domain.ParsePriority(value)
+		if err != nil {
+			return err
+		}
+		filter.Priority = priority
+	}
+	if flags.Changed("project") {
+		projectStr, _ := flags.GetString("project")
+		projectID, err := promptProjectID(ctx, projectRepo, projectStr)
+		if err != nil {
+			return err
+		}
+		filter.ProjectID = projectID
+	}
+	if flags.Changed("tags") {
+		filter.Tags, _ = flags.GetStringSlice("tags")
+	}
+	// a new query keeps the view's search mode unless --mode is passed too
+	if flags.Changed("search") {
+		filter.SearchQuery, _ = flags.GetString("search")
+	}
+	if flags.Changed("mode") {
+		if filter.SearchQuery == "" {
+			return fmt.Errorf("--mode needs a search, pass --search or update a view that has one")
+		}
+		filter.SearchMode, _ = flags.GetString("mode")
+	}
+	if (flags.Changed("search") || flags.Changed("mode")) && filter.SearchQuery != "" && filter.SearchMode != "" {
+		if err := validateSearchOptions(filter.SearchQuery, domain.SearchMode(filter.SearchMode), 0); err != nil {
+			return err
+		}
+	}
+	if flags.Changed("due") {
+		value, _ := flags.GetString("due")
+		from, to, err := parseDueFilter(value)
+		if err != nil {
+			return err
+		}
+		filter.DueDateFrom, filter.DueDateTo = from, to
+	}
+
+	if clear, _ := flags.GetBool("clear-status"); clear {
+		filter.Status = ""
+	}
+	if clear, _ := flags.GetBool("clear-priority"); clear {
+		filter.Priority = ""
+	}
+	if clear, _ := flags.GetBool("clear-project"); clear {
+		filter.ProjectID = nil
+	}
+	if clear, _ := flags.GetBool("clear-tags"); clear {
+		filter.Tags = nil
+	}
+	if clear, _ := flags.GetBool("clear-search"); clear {
+		filter.SearchQuery = ""
+		filter.SearchMode = ""
+	}
+	if clear, _ := flags.GetBool("clear-due"); clear {
+		filter.DueDateFrom = nil
+		filter.DueDateTo = nil
+	}
+
+	return nil
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
 
 var viewHotkeyCmd = &cobra.Command{
 	Use:   "hotkey <name|id> <1-9|clear>",
@@ -643,8 +811,9 @@ Examples:
   taskflow view hotkey "My View" 5
   taskflow view hotkey 1 3
   taskflow view hotkey "View" clear`,
-	Args: cobra.ExactArgs(2),
-	RunE: runViewHotkey,
+	Args:        cobra.ExactArgs(2),
+	Annotations: writesDatabase,
+	RunE:        runViewHotkey,
 }
 
 func runViewHotkey(cmd *cobra.Command, args []string) error {
@@ -663,7 +832,7 @@ func runViewHotkey(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -710,7 +879,6 @@ func runViewHotkey(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
 var viewFavoriteCmd = &cobra.Command{
 	Use:   "favorite <name|id>",
 	Short: "Toggle favorite status for a view",
@@ -719,8 +887,9 @@ var viewFavoriteCmd = &cobra.Command{
 Examples:
   taskflow view favorite "My View"
   taskflow view favorite 1`,
-	Args: cobra.ExactArgs(1),
-	RunE: runViewFavorite,
+	Args:        cobra.ExactArgs(1),
+	Annotations: writesDatabase,
+	RunE:        runViewFavorite,
 }
 
 func runViewFavorite(cmd *cobra.Command, args []string) error {
@@ -739,7 +908,7 @@ func runViewFavorite(cmd *cobra.Command, args []string) error {
 	}
 	styles := theme.NewStyles(themeObj)
 
-	db, err := sqlite.NewDB(sqlite.Config{Path: cfg.DBPath})
+	db, err := openDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
@@ -777,7 +946,6 @@ func runViewFavorite(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-
 func displayValue(value string) string {
 	if value == "" {
 		return "-"
@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"task-management/internal/config"
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+	"task-management/internal/theme"
+)
+
+var viewDiffFormat string
+
+var viewDiffCmd = &cobra.Command{
+	Use:   "diff <view-a> <view-b>",
+	Short: "Compare the tasks matched by two saved views",
+	Long: `Run the filters of two saved views and show which tasks only the first view
+matches, which only the second matches and which both match.
+
+Examples:
+  taskflow view diff Backlog Sprint       # What's in my backlog but not my sprint
+  taskflow view diff 1 2 --format json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runViewDiff,
+}
+
+func init() {
+	viewCmd.AddCommand(viewDiffCmd)
+
+	viewDiffCmd.Flags().StringVarP(&viewDiffFormat, "format", "f", "text", "Output format (text, json)")
+	viewDiffCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{"text", "json"}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+type viewDiffTask struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+}
+
+type viewDiff struct {
+	ViewA  string         `json:"view_a"`
+	ViewB  string         `json:"view_b"`
+	OnlyA  []viewDiffTask `json:"only_a"`
+	OnlyB  []viewDiffTask `json:"only_b"`
+	InBoth []viewDiffTask `json:"in_both"`
+}
+
+func runViewDiff(cmd *cobra.Command, args []string) error {
+	if viewDiffFormat != "text" && viewDiffFormat != "json" {
+		return fmt.Errorf("unsupported format: %s (use text or json)", viewDiffFormat)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	themeName := cfg.ThemeName
+	if themeName == "" {
+		themeName = "default"
+	}
+	themeObj, err := theme.GetTheme(themeName)
+	if err != nil {
+		return fmt.Errorf("failed to load theme: %w", err)
+	}
+	styles := theme.NewStyles(themeObj)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer db.Close()
+
+	viewRepo := sqlite.NewViewRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	var views [2]*domain.SavedView
+	var tasks [2][]*domain.Task
+	for i, arg := range args {
+		viewID, err := lookupViewID(ctx, viewRepo, arg)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ %v", err)))
+			return nil
+		}
+
+		views[i], err = viewRepo.GetByID(ctx, *viewID)
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to load view: %v", err)))
+			return nil
+		}
+
+		tasks[i], err = listViewTasks(ctx, taskRepo, views[i])
+		if err != nil {
+			fmt.Println(styles.Error.Render(fmt.Sprintf("✗ Failed to list tasks for view '%s': %v", views[i].Name, err)))
+			return nil
+		}
+	}
+
+	diff := diffTaskLists(tasks[0], tasks[1])
+	diff.ViewA, diff.ViewB = views[0].Name, views[1].Name
+
+	if viewDiffFormat == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode diff: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Println(styles.Title.Render(fmt.Sprintf("%s vs %s", diff.ViewA, diff.ViewB)))
+	printViewDiffSection(styles, fmt.Sprintf("Only in %s", diff.ViewA), diff.OnlyA)
+	printViewDiffSection(styles, fmt.Sprintf("Only in %s", diff.ViewB), diff.OnlyB)
+	printViewDiffSection(styles, "In both", diff.InBoth)
+	fmt.Println()
+
+	return nil
+}
+
+// lists every task a view matches, ignoring pagination
+func listViewTasks(ctx context.Context, repo repository.TaskRepository, view *domain.SavedView) ([]*domain.Task, error) {
+	filter := viewTaskFilter(view)
+	filter.Limit = 0
+	filter.Offset = 0
+	return repo.List(ctx, filter)
+}
+
+// splits two task lists by ID into tasks only in a, only in b and in both,
+// each sorted by ID
+func diffTaskLists(a, b []*domain.Task) viewDiff {
+	inA := make(map[int64]bool, len(a))
+	for _, task := range a {
+		inA[task.ID] = true
+	}
+	inB := make(map[int64]bool, len(b))
+	for _, task := range b {
+		inB[task.ID] = true
+	}
+
+	diff := viewDiff{
+		OnlyA:  []viewDiffTask{},
+		OnlyB:  []viewDiffTask{},
+		InBoth: []viewDiffTask{},
+	}
+	for _, task := range a {
+		entry := viewDiffTask{ID: task.ID, Title: task.Title}
+		if inB[task.ID] {
+			diff.InBoth = append(diff.InBoth, entry)
+		} else {
+			diff.OnlyA = append(diff.OnlyA, entry)
+		}
+	}
+	for _, task := range b {
+		if !inA[task.ID] {
+			diff.OnlyB = append(diff.OnlyB, viewDiffTask{ID: task.ID, Title: task.Title})
+		}
+	}
+
+	for _, list := range [][]viewDiffTask{diff.OnlyA, diff.OnlyB, diff.InBoth} {
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	}
+
+	return diff
+}
+
+func printViewDiffSection(styles *theme.Styles, heading string, tasks []viewDiffTask) {
+	fmt.Println()
+	fmt.Println(styles.Subtitle.Render(fmt.Sprintf("%s (%d)", heading, len(tasks))))
+	if len(tasks) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, task := range tasks {
+		fmt.Printf("  #%-5d %s\n", task.ID, task.Title)
+	}
+}
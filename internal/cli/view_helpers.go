@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"task-management/internal/domain"
 	"task-management/internal/repository"
 )
 
@@ -29,3 +30,35 @@ func lookupViewID(ctx context.Context, repo repository.ViewRepository, viewStr s
 
 	return &view.ID, nil
 }
+
+func viewTaskFilter(view *domain.SavedView) repository.TaskFilter {
+	return repository.TaskFilter{
+		Status:      view.FilterConfig.Status,
+		Priority:    view.FilterConfig.Priority,
+		ProjectID:   view.FilterConfig.ProjectID,
+		Tags:        view.FilterConfig.Tags,
+		SearchQuery: view.FilterConfig.SearchQuery,
+		SearchMode:  view.FilterConfig.SearchMode,
+		SortBy:      view.FilterConfig.SortBy,
+		SortOrder:   view.FilterConfig.SortOrder,
+		DueDateFrom: view.FilterConfig.DueDateFrom,
+		DueDateTo:   view.FilterConfig.DueDateTo,
+	}
+}
+
+// how many tasks the view matches. ok is false when it filters on a project
+// that no longer exists
+func countViewTasks(ctx context.Context, repo repository.TaskRepository, projectRepo repository.ProjectRepository, view *domain.SavedView) (count int64, ok bool, err error) {
+	filter := viewTaskFilter(view)
+	if filter.ProjectID != nil {
+		if _, err := projectRepo.GetByID(ctx, *filter.ProjectID); err != nil {
+			return 0, false, nil
+		}
+	}
+
+	count, err = repo.Count(ctx, filter)
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
@@ -2,8 +2,12 @@ package cli
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/spf13/cobra"
+
 	"task-management/internal/domain"
 	"task-management/internal/repository/sqlite"
 )
@@ -265,6 +269,176 @@ func TestViewUpdate_UpdateName(t *testing.T) {
 	}
 }
 
+func TestViewUpdate_PartialFilterUpdate(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	viewRepo := sqlite.NewViewRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	project := &domain.Project{Name: "Backend", Status: domain.ProjectStatusActive}
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	dueTo := "2025-01-31 23:59:59"
+	newView := func(t *testing.T, name string, hotKey *int) *domain.SavedView {
+		view := &domain.SavedView{
+			Name:        name,
+			Description: "Backend work",
+			IsFavorite:  true,
+			HotKey:      hotKey,
+			FilterConfig: domain.SavedViewFilter{
+				Status:      domain.StatusPending,
+				Priority:    domain.PriorityHigh,
+				ProjectID:   &project.ID,
+				Tags:        []string{"api"},
+				SearchQuery: "deploy",
+				SortBy:      "priority",
+				DueDateTo:   &dueTo,
+			},
+		}
+		if err := viewRepo.Create(ctx, view); err != nil {
+			t.Fatalf("failed to create view: %v", err)
+		}
+		return view
+	}
+
+	update := func(t *testing.T, view *domain.SavedView, args ...string) (*domain.SavedView, error) {
+		cmd := &cobra.Command{}
+		addViewUpdateFlags(cmd)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("failed to parse flags: %v", err)
+		}
+		if err := applyViewUpdate(ctx, projectRepo, cmd, view); err != nil {
+			return nil, err
+		}
+		if err := viewRepo.Update(ctx, view); err != nil {
+			t.Fatalf("failed to update view: %v", err)
+		}
+		return viewRepo.GetByID(ctx, view.ID)
+	}
+
+	t.Run("one filter keeps the rest", func(t *testing.T) {
+		hotKey := 3
+		view := newView(t, "Partial", &hotKey)
+		want := view.FilterConfig
+		want.Priority = domain.PriorityUrgent
+
+		updated, err := update(t, view, "--priority", "urgent")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(updated.FilterConfig, want) {
+			t.Errorf("expected filter %+v, got %+v", want, updated.FilterConfig)
+		}
+		if updated.Name != "Partial" || updated.Description != "Backend work" || !updated.IsFavorite {
+			t.Errorf("properties changed: %+v", updated)
+		}
+		if updated.HotKey == nil || *updated.HotKey != 3 {
+			t.Errorf("expected hot key 3 to be kept, got %v", updated.HotKey)
+		}
+	})
+
+	t.Run("clear flags remove one filter", func(t *testing.T) {
+		view := newView(t, "Clearing", nil)
+		updated, err := update(t, view, "--clear-status", "--clear-due", "--description", "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if updated.FilterConfig.Status != "" || updated.FilterConfig.DueDateTo != nil {
+			t.Errorf("expected status and due filters cleared, got %+v", updated.FilterConfig)
+		}
+		if updated.FilterConfig.Priority != domain.PriorityHigh || updated.FilterConfig.SearchQuery != "deploy" ||
+			updated.FilterConfig.ProjectID == nil || len(updated.FilterConfig.Tags) != 1 {
+			t.Errorf("expected other filters kept, got %+v", updated.FilterConfig)
+		}
+		if updated.Description != "" {
+			t.Errorf("expected description cleared, got %q", updated.Description)
+		}
+	})
+
+	t.Run("search keeps the view's mode unless --mode is passed", func(t *testing.T) {
+		view := newView(t, "Modes", nil)
+		view.FilterConfig.SearchMode = string(domain.SearchModeFuzzy)
+
+		updated, err := update(t, view, "--search", "deploy prod")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.FilterConfig.SearchQuery != "deploy prod" || updated.FilterConfig.SearchMode != "fuzzy" {
+			t.Errorf("expected the new query with fuzzy mode, got %+v", updated.FilterConfig)
+		}
+
+		updated, err = update(t, updated, "--mode", "regex")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.FilterConfig.SearchQuery != "deploy prod" || updated.FilterConfig.SearchMode != "regex" {
+			t.Errorf("expected the query kept with regex mode, got %+v", updated.FilterConfig)
+		}
+
+		if _, err := update(t, updated, "--search", "deploy("); err == nil {
+			t.Error("expected the kept regex mode to reject an invalid pattern")
+		}
+		if _, err := update(t, updated, "--mode", "exact"); err == nil {
+			t.Error("expected an error for an unknown mode")
+		}
+		if _, err := update(t, updated, "--mode", "text", "--clear-search"); err == nil {
+			t.Error("expected an error for --mode with --clear-search")
+		}
+	})
+
+	t.Run("due sets the due date bounds", func(t *testing.T) {
+		view := newView(t, "Due", nil)
+		updated, err := update(t, view, "--due", "2025-02-01..2025-02-28")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		from, to := updated.FilterConfig.DueDateFrom, updated.FilterConfig.DueDateTo
+		if from == nil || to == nil || !strings.HasPrefix(*from, "2025-02-01") || !strings.HasPrefix(*to, "2025-02-28") {
+			t.Errorf("expected February due bounds, got %v %v", from, to)
+		}
+
+		updated, err = update(t, updated, "--due", "none")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if updated.FilterConfig.DueDateFrom == nil || *updated.FilterConfig.DueDateFrom != "none" || updated.FilterConfig.DueDateTo != nil {
+			t.Errorf("expected the no due date filter, got %+v", updated.FilterConfig)
+		}
+
+		if _, err := update(t, updated, "--due", "someday"); err == nil {
+			t.Error("expected an error for an invalid --due value")
+		}
+		if _, err := update(t, updated, "--due", "today", "--clear-due"); err == nil {
+			t.Error("expected an error for --due with --clear-due")
+		}
+	})
+
+	t.Run("setting and clearing a filter conflict", func(t *testing.T) {
+		view := newView(t, "Conflict", nil)
+		if _, err := update(t, view, "--status", "completed", "--clear-status"); err == nil {
+			t.Error("expected an error for --status with --clear-status")
+		}
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		view := newView(t, "Invalid", nil)
+		if _, err := update(t, view, "--favorite", "maybe"); err == nil {
+			t.Error("expected an error for --favorite maybe")
+		}
+
+		view.FilterConfig.Status = "someday"
+		if err := view.Validate(); err == nil {
+			t.Error("expected validation to reject an unknown status")
+		}
+	})
+}
+
 func TestViewUpdate_UpdateDescription(t *testing.T) {
 	db, _ := setupTestDB(t)
 	defer db.Close()
@@ -701,3 +875,149 @@ func TestViewGetRecentViews(t *testing.T) {
 		}
 	}
 }
+
+func TestDiffViewTasks(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	ctx := context.Background()
+
+	for _, spec := range []struct {
+		title string
+		tags  []string
+	}{
+		{"Backlog only", []string{"backlog"}},
+		{"Sprint only", []string{"sprint"}},
+		{"Both", []string{"backlog", "sprint"}},
+		{"Neither", nil},
+	} {
+		task := domain.NewTask(spec.title)
+		task.Tags = spec.tags
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+
+	backlog := &domain.SavedView{Name: "Backlog", FilterConfig: domain.SavedViewFilter{Tags: []string{"backlog"}}}
+	sprint := &domain.SavedView{Name: "Sprint", FilterConfig: domain.SavedViewFilter{Tags: []string{"sprint"}}}
+
+	a, err := listViewTasks(ctx, taskRepo, backlog)
+	if err != nil {
+		t.Fatalf("failed to list backlog: %v", err)
+	}
+	b, err := listViewTasks(ctx, taskRepo, sprint)
+	if err != nil {
+		t.Fatalf("failed to list sprint: %v", err)
+	}
+
+	diff := diffTaskLists(a, b)
+
+	titles := func(tasks []viewDiffTask) []string {
+		var out []string
+		for _, task := range tasks {
+			out = append(out, task.Title)
+		}
+		return out
+	}
+
+	if got := titles(diff.OnlyA); len(got) != 1 || got[0] != "Backlog only" {
+		t.Errorf("expected only-in-A to be [Backlog only], got %v", got)
+	}
+	if got := titles(diff.OnlyB); len(got) != 1 || got[0] != "Sprint only" {
+		t.Errorf("expected only-in-B to be [Sprint only], got %v", got)
+	}
+	if got := titles(diff.InBoth); len(got) != 1 || got[0] != "Both" {
+		t.Errorf("expected in-both to be [Both], got %v", got)
+	}
+}
+
+func TestCountViewTasks(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	projectRepo := sqlite.NewProjectRepository(db)
+	ctx := context.Background()
+
+	project := domain.NewProject("Backend")
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	for _, title := range []string{"one", "two"} {
+		task := domain.NewTask(title)
+		task.ProjectID = &project.ID
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+	done := domain.NewTask("done")
+	done.Status = domain.StatusCompleted
+	if err := taskRepo.Create(ctx, done); err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	pending := &domain.SavedView{Name: "Pending", FilterConfig: domain.SavedViewFilter{Status: domain.StatusPending}}
+	backend := &domain.SavedView{Name: "Backend", FilterConfig: domain.SavedViewFilter{ProjectID: &project.ID}}
+
+	count, ok, err := countViewTasks(ctx, taskRepo, projectRepo, pending)
+	if err != nil || !ok || count != 2 {
+		t.Errorf("pending view: count = %d, ok = %v, err = %v, want 2 tasks", count, ok, err)
+	}
+	count, ok, err = countViewTasks(ctx, taskRepo, projectRepo, backend)
+	if err != nil || !ok || count != 2 {
+		t.Errorf("backend view: count = %d, ok = %v, err = %v, want 2 tasks", count, ok, err)
+	}
+
+	if err := projectRepo.Delete(ctx, project.ID); err != nil {
+		t.Fatalf("failed to delete project: %v", err)
+	}
+	if _, ok, err = countViewTasks(ctx, taskRepo, projectRepo, backend); err != nil || ok {
+		t.Errorf("view on a deleted project: ok = %v, err = %v, want no count", ok, err)
+	}
+}
+
+func TestCreateSmartList(t *testing.T) {
+	db, _ := setupTestDB(t)
+	defer db.Close()
+
+	viewRepo := sqlite.NewViewRepository(db)
+	ctx := context.Background()
+
+	one := 1
+	taken := domain.NewSavedView("Taken")
+	taken.HotKey = &one
+	if err := viewRepo.Create(ctx, taken); err != nil {
+		t.Fatalf("failed to create view: %v", err)
+	}
+
+	view, err := createSmartList(ctx, viewRepo, "#bug", 0, true)
+	if err != nil {
+		t.Fatalf("failed to create smart list: %v", err)
+	}
+	if view.Name != "#bug" {
+		t.Errorf("expected name '#bug', got %q", view.Name)
+	}
+	if tag, ok := view.SmartListTag(); !ok || tag != "bug" {
+		t.Errorf("expected smart list for 'bug', got %q (ok=%v)", tag, ok)
+	}
+	if view.HotKey == nil || *view.HotKey != 2 {
+		t.Errorf("expected first free hot key 2, got %v", view.HotKey)
+	}
+
+	if _, err := createSmartList(ctx, viewRepo, "bug", 0, true); err == nil {
+		t.Error("expected error creating a second smart list for the same tag")
+	}
+
+	view, err = createSmartList(ctx, viewRepo, "docs", 0, false)
+	if err != nil {
+		t.Fatalf("failed to create smart list: %v", err)
+	}
+	if view.HotKey != nil {
+		t.Errorf("expected no hot key, got %d", *view.HotKey)
+	}
+
+	if _, err := createSmartList(ctx, viewRepo, "release", 2, true); err == nil {
+		t.Error("expected error reusing a taken hot key")
+	}
+}
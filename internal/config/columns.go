@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// the width and visibility of one column of the TUI task table. a width of
+// 0 keeps the default
+type ColumnConfig struct {
+	Width  int  `mapstructure:"width"`
+	Hidden bool `mapstructure:"hidden"`
+}
+
+// one column of the task table with the config applied
+type TableColumn struct {
+	Name   string
+	Title  string
+	Width  int
+	Hidden bool
+}
+
+// narrower columns can't show more than an ellipsis
+const MinColumnWidth = 4
+
+// the task table's columns in the order they are shown, with their default
+// widths. title can't be hidden, blockers is hidden until the config or |
+// shows it
+var defaultTableColumns = []TableColumn{
+	{Name: "status", Title: "Status", Width: 15},
+	{Name: "priority", Title: "Priority", Width: 12},
+	{Name: "title", Title: "Title", Width: 40},
+	{Name: "project", Title: "Project", Width: 15},
+	{Name: "tags", Title: "Tags", Width: 20},
+	{Name: "due", Title: "Due", Width: 12},
+	{Name: "updated", Title: "Updated", Width: 10},
+	{Name: "blockers", Title: "Blocked", Width: 8, Hidden: true},
+}
+
+// the names columns are configured by, in table order
+func ColumnNames() []string {
+	names := make([]string, len(defaultTableColumns))
+	for i, column := range defaultTableColumns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// the task table's columns with the configured widths and visibility over
+// the defaults. unknown names and widths below MinColumnWidth are ignored,
+// so a broken entry only costs that entry
+func TableColumns(columns map[string]ColumnConfig) []TableColumn {
+	resolved := slices.Clone(defaultTableColumns)
+	for i, column := range resolved {
+		configured, ok := columns[column.Name]
+		if !ok {
+			continue
+		}
+		if configured.Width >= MinColumnWidth {
+			resolved[i].Width = configured.Width
+		}
+		resolved[i].Hidden = configured.Hidden && column.Name != "title"
+	}
+	return resolved
+}
+
+// checks a column name, case doesn't matter
+func ParseColumnName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !slices.Contains(ColumnNames(), name) {
+		return "", fmt.Errorf("unknown column '%s' (use %s)", name, strings.Join(ColumnNames(), ", "))
+	}
+	return name, nil
+}
+
+// the configured columns that taskflow doesn't have, sorted
+func unknownColumns(columns map[string]ColumnConfig) []string {
+	var unknown []string
+	for name := range columns {
+		if !slices.Contains(ColumnNames(), name) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// saves the column settings, keeping the rest of the config file
+func UpdateColumns(columns map[string]ColumnConfig) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cfg.Columns = columns
+	return SaveConfig(cfg)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadConfig_Columns(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	cfg := GetDefaultConfig()
+	cfg.Columns = map[string]ColumnConfig{
+		"title": {Width: 60},
+		"tags":  {Hidden: true},
+	}
+	require.NoError(t, SaveConfig(cfg))
+
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Columns, loaded.Columns)
+
+	// turning a value back to its default has to reach the file too
+	require.NoError(t, UpdateColumns(map[string]ColumnConfig{
+		"title": {Width: 60},
+		"tags":  {},
+	}))
+	loaded, err = LoadConfig()
+	require.NoError(t, err)
+	assert.False(t, loaded.Columns["tags"].Hidden)
+	assert.Equal(t, 60, loaded.Columns["title"].Width)
+}
+
+func TestTableColumns(t *testing.T) {
+	t.Run("no config keeps the defaults", func(t *testing.T) {
+		columns := TableColumns(nil)
+		require.Len(t, columns, len(ColumnNames()))
+		assert.Equal(t, "status", columns[0].Name)
+		assert.Equal(t, 40, columns[2].Width)
+		for _, column := range columns {
+			assert.Equal(t, column.Name == "blockers", column.Hidden, column.Name)
+		}
+	})
+
+	t.Run("config applies over the defaults", func(t *testing.T) {
+		columns := TableColumns(map[string]ColumnConfig{
+			"title":    {Width: 55},
+			"tags":     {Hidden: true},
+			"due":      {Width: 2},
+			"blockers": {},
+			"unknown":  {Width: 30},
+		})
+		require.Len(t, columns, len(ColumnNames()), "unknown columns are ignored")
+		assert.Equal(t, 55, columns[2].Width)
+		assert.True(t, columns[4].Hidden)
+		assert.Equal(t, 12, columns[5].Width, "a width below the minimum keeps the default")
+		assert.False(t, columns[7].Hidden, "an entry without hidden shows the column")
+	})
+
+	t.Run("title can't be hidden", func(t *testing.T) {
+		columns := TableColumns(map[string]ColumnConfig{"title": {Hidden: true}})
+		assert.False(t, columns[2].Hidden)
+	})
+}
+
+func TestValidate_Columns(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	cfg := GetDefaultConfig()
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	cfg.Columns = map[string]ColumnConfig{
+		"titel": {Width: 50},
+		"tags":  {Width: 2},
+	}
+
+	var columnProblems []Problem
+	for _, problem := range Validate(cfg) {
+		if problem.Key == "columns" {
+			columnProblems = append(columnProblems, problem)
+		}
+	}
+	require.Len(t, columnProblems, 2)
+	assert.Contains(t, columnProblems[0].Message, "titel")
+	assert.Contains(t, columnProblems[1].Message, "tags width 2")
+
+	for _, problem := range columnProblems {
+		require.NoError(t, problem.Fix(cfg))
+	}
+	assert.NotContains(t, cfg.Columns, "titel")
+	assert.Zero(t, cfg.Columns["tags"].Width)
+}
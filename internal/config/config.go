@@ -9,14 +9,64 @@ import (
 )
 
 type Config struct {
-	DBPath               string `mapstructure:"db_path"`
-	ThemeName            string `mapstructure:"theme_name"`
-	DefaultPageSize      int    `mapstructure:"default_page_size"`
-	MaxPageSize          int    `mapstructure:"max_page_size"`
-	MaxSearchHistory     int    `mapstructure:"max_search_history"`
-	SearchHistoryEnabled bool   `mapstructure:"search_history_enabled"`
+	DBPath                   string              `mapstructure:"db_path"`
+	ThemeName                string              `mapstructure:"theme_name"`
+	DefaultPageSize          int                 `mapstructure:"default_page_size"`
+	MaxPageSize              int                 `mapstructure:"max_page_size"`
+	MaxSearchHistory         int                 `mapstructure:"max_search_history"`
+	SearchHistoryEnabled     bool                `mapstructure:"search_history_enabled"`
+	FuzzyAlgorithm           string              `mapstructure:"fuzzy_algorithm"`
+	EnforceStatusTransitions bool                `mapstructure:"enforce_status_transitions"`
+	StatusTransitions        map[string][]string `mapstructure:"status_transitions"`
+	CompactMode              bool                `mapstructure:"compact_mode"`
+	ArchiveCompletedTasks    bool                `mapstructure:"archive_completed_tasks"`
+	ConfirmTimeout           int                 `mapstructure:"confirm_timeout"`
+	BulkMaxAffected          int                 `mapstructure:"bulk_max_affected"`
+	InheritProjectStyle      bool                `mapstructure:"inherit_project_style"`
+	ProjectJumpWrap          bool                `mapstructure:"project_jump_wrap"`
+	PinnedFirst              bool                `mapstructure:"pinned_first"`
+	DefaultProject           string              `mapstructure:"default_project"`
+	ProjectPathWidth         int                 `mapstructure:"project_path_width"`
+	// order of sibling projects in trees: "" keeps the list order, "name" or
+	// "task_count"
+	ProjectTreeSort       string `mapstructure:"project_tree_sort"`
+	FavoriteProjectsFirst bool   `mapstructure:"favorite_projects_first"`
+	// task count badges in view list and the view picker, a query per view
+	ViewCounts bool `mapstructure:"view_counts"`
+	// dims and strikes through completed and cancelled tasks in the TUI
+	FadeDoneTasks bool `mapstructure:"fade_done_tasks"`
+	// opens the database read-only and refuses every command that would
+	// change it, same as --read-only
+	ReadOnly bool `mapstructure:"read_only"`
+	// days after its due day before a task counts as overdue
+	OverdueGraceDays int `mapstructure:"overdue_grace_days"`
+	// how cancelled tasks count in completion percentages: "open" (the
+	// default) counts them as not done, "exclude" leaves them out of the
+	// total, "done" counts them as finished
+	CancelledCompletion string `mapstructure:"cancelled_completion"`
+	// how many levels deep the project tree may go, 0 for no limit
+	MaxProjectDepth int `mapstructure:"max_project_depth"`
+	// display names and symbols keyed by status or priority, e.g.
+	// pending: todo. each map given has to cover every value
+	StatusLabels        map[string]string `mapstructure:"status_labels"`
+	StatusSymbols       map[string]string `mapstructure:"status_symbols"`
+	PriorityLabels      map[string]string `mapstructure:"priority_labels"`
+	PrioritySymbols     map[string]string `mapstructure:"priority_symbols"`
+	ProjectStatusLabels map[string]string `mapstructure:"project_status_labels"`
+	// TUI keys that set a status or priority directly, keyed by the status
+	// or priority, e.g. cancelled: X. the rest keep their alt+ defaults
+	QuickKeys map[string]string `mapstructure:"quick_keys"`
+	// width and visibility of the TUI task table columns, keyed by column
+	// name, e.g. tags: {hidden: true}. columns left out keep their defaults
+	Columns map[string]ColumnConfig `mapstructure:"columns"`
 }
 
+// bulk operations touching more tasks than this need --force
+const DefaultBulkMaxAffected = 100
+
+// project paths wider than this are shortened to "Dev > … > API"
+const DefaultProjectPathWidth = 60
+
 var (
 	configDir  string
 	configFile string
@@ -86,6 +136,17 @@ func LoadConfig() (*Config, error) {
 	if cfg.MaxSearchHistory == 0 {
 		cfg.MaxSearchHistory = 50
 	}
+	if cfg.FuzzyAlgorithm == "" {
+		cfg.FuzzyAlgorithm = "subsequence"
+	}
+	// a negative limit turns the bulk guard off
+	if cfg.BulkMaxAffected == 0 {
+		cfg.BulkMaxAffected = DefaultBulkMaxAffected
+	}
+	// a negative width never shortens project paths
+	if cfg.ProjectPathWidth == 0 {
+		cfg.ProjectPathWidth = DefaultProjectPathWidth
+	}
 
 	return &cfg, nil
 }
@@ -102,6 +163,49 @@ func SaveConfig(cfg *Config) error {
 	viper.Set("max_page_size", cfg.MaxPageSize)
 	viper.Set("max_search_history", cfg.MaxSearchHistory)
 	viper.Set("search_history_enabled", cfg.SearchHistoryEnabled)
+	viper.Set("fuzzy_algorithm", cfg.FuzzyAlgorithm)
+	viper.Set("enforce_status_transitions", cfg.EnforceStatusTransitions)
+	viper.Set("compact_mode", cfg.CompactMode)
+	viper.Set("archive_completed_tasks", cfg.ArchiveCompletedTasks)
+	viper.Set("confirm_timeout", cfg.ConfirmTimeout)
+	viper.Set("bulk_max_affected", cfg.BulkMaxAffected)
+	viper.Set("inherit_project_style", cfg.InheritProjectStyle)
+	viper.Set("project_jump_wrap", cfg.ProjectJumpWrap)
+	viper.Set("pinned_first", cfg.PinnedFirst)
+	viper.Set("default_project", cfg.DefaultProject)
+	viper.Set("project_path_width", cfg.ProjectPathWidth)
+	viper.Set("project_tree_sort", cfg.ProjectTreeSort)
+	viper.Set("favorite_projects_first", cfg.FavoriteProjectsFirst)
+	viper.Set("view_counts", cfg.ViewCounts)
+	viper.Set("fade_done_tasks", cfg.FadeDoneTasks)
+	viper.Set("read_only", cfg.ReadOnly)
+	viper.Set("overdue_grace_days", cfg.OverdueGraceDays)
+	viper.Set("max_project_depth", cfg.MaxProjectDepth)
+	viper.Set("cancelled_completion", cfg.CancelledCompletion)
+	if len(cfg.StatusTransitions) > 0 {
+		viper.Set("status_transitions", cfg.StatusTransitions)
+	}
+	for key, mapping := range map[string]map[string]string{
+		"status_labels":         cfg.StatusLabels,
+		"status_symbols":        cfg.StatusSymbols,
+		"priority_labels":       cfg.PriorityLabels,
+		"priority_symbols":      cfg.PrioritySymbols,
+		"project_status_labels": cfg.ProjectStatusLabels,
+		"quick_keys":            cfg.QuickKeys,
+	} {
+		if len(mapping) > 0 {
+			viper.Set(key, mapping)
+		}
+	}
+	// every entry is written whole, so a value turned back to its default
+	// replaces the one in the file
+	if len(cfg.Columns) > 0 {
+		columns := make(map[string]interface{}, len(cfg.Columns))
+		for name, column := range cfg.Columns {
+			columns[name] = map[string]interface{}{"width": column.Width, "hidden": column.Hidden}
+		}
+		viper.Set("columns", columns)
+	}
 
 	if err := viper.WriteConfigAs(configFile); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
@@ -119,6 +223,9 @@ func GetDefaultConfig() *Config {
 		MaxPageSize:          100,
 		MaxSearchHistory:     50,
 		SearchHistoryEnabled: true,
+		FuzzyAlgorithm:       "subsequence",
+		BulkMaxAffected:      DefaultBulkMaxAffected,
+		ProjectPathWidth:     DefaultProjectPathWidth,
 	}
 }
 
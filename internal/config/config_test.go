@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
 )
 
 func setupTestConfig(t *testing.T) func() {
@@ -36,6 +38,16 @@ func TestGetDefaultConfig(t *testing.T) {
 	assert.Equal(t, "", cfg.ThemeName) // empty until set
 	assert.Equal(t, 20, cfg.DefaultPageSize)
 	assert.Equal(t, 100, cfg.MaxPageSize)
+	assert.False(t, cfg.EnforceStatusTransitions)
+	assert.Nil(t, cfg.StatusTransitions)
+	assert.False(t, cfg.CompactMode)
+	assert.False(t, cfg.ArchiveCompletedTasks)
+	assert.Zero(t, cfg.ConfirmTimeout)
+	assert.Equal(t, DefaultBulkMaxAffected, cfg.BulkMaxAffected)
+	assert.False(t, cfg.InheritProjectStyle)
+	assert.False(t, cfg.ProjectJumpWrap)
+	assert.False(t, cfg.PinnedFirst)
+	assert.Empty(t, cfg.DefaultProject)
 }
 
 func TestLoadConfig_Default(t *testing.T) {
@@ -76,6 +88,44 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	assert.Equal(t, cfg.MaxPageSize, loaded.MaxPageSize)
 }
 
+func TestSaveAndLoadConfig_StatusTransitions(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	cfg := GetDefaultConfig()
+	cfg.EnforceStatusTransitions = true
+	cfg.StatusTransitions = map[string][]string{
+		"completed": {"pending"},
+	}
+
+	require.NoError(t, SaveConfig(cfg))
+
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.True(t, loaded.EnforceStatusTransitions)
+	assert.Equal(t, []string{"pending"}, loaded.StatusTransitions["completed"])
+}
+
+func TestSaveAndLoadConfig_Labels(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	cfg := GetDefaultConfig()
+	cfg.StatusLabels = map[string]string{"pending": "todo", "in_progress": "doing", "completed": "done", "cancelled": "dropped"}
+	cfg.ProjectStatusLabels = map[string]string{"active": "live", "archived": "shelved", "completed": "shipped"}
+	require.NoError(t, SaveConfig(cfg))
+
+	loaded, err := LoadConfig()
+	require.NoError(t, err)
+
+	labels, err := loaded.Labels()
+	require.NoError(t, err)
+	assert.Equal(t, "doing", labels.Status[domain.StatusInProgress])
+	assert.Equal(t, "shelved", labels.ProjectStatus[domain.ProjectStatusArchived])
+	assert.Nil(t, labels.Priority)
+}
+
 func TestSaveConfig_CreatesDirectory(t *testing.T) {
 	cleanup := setupTestConfig(t)
 	defer cleanup()
@@ -157,3 +207,101 @@ func TestConfig_PageSizeValidation(t *testing.T) {
 		assert.Equal(t, 100, loaded.MaxPageSize)
 	})
 }
+
+func TestValidate(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	problemKeys := func(problems []Problem) []string {
+		var keys []string
+		for _, p := range problems {
+			keys = append(keys, p.Key)
+		}
+		return keys
+	}
+
+	t.Run("default config is valid", func(t *testing.T) {
+		assert.Empty(t, Validate(GetDefaultConfig()))
+	})
+
+	t.Run("reports and fixes bad values", func(t *testing.T) {
+		cfg := GetDefaultConfig()
+		cfg.ThemeName = "monokai"
+		cfg.DefaultPageSize = 500
+		cfg.ConfirmTimeout = -1
+		cfg.OverdueGraceDays = -2
+		cfg.FuzzyAlgorithm = "soundex"
+		cfg.CancelledCompletion = "ignore"
+		cfg.DBPath = filepath.Join(configDir, "missing", "tasks.db")
+
+		problems := Validate(cfg)
+		assert.ElementsMatch(t, []string{"theme_name", "default_page_size", "confirm_timeout", "overdue_grace_days", "fuzzy_algorithm", "cancelled_completion", "db_path"}, problemKeys(problems))
+
+		for _, p := range problems {
+			require.NotNil(t, p.Fix, p.Key)
+			require.NoError(t, p.Fix(cfg))
+		}
+		assert.Equal(t, "default", cfg.ThemeName)
+		assert.Equal(t, 100, cfg.DefaultPageSize)
+		assert.Equal(t, 0, cfg.ConfirmTimeout)
+		assert.Equal(t, 0, cfg.OverdueGraceDays)
+		assert.Equal(t, "subsequence", cfg.FuzzyAlgorithm)
+		assert.Empty(t, cfg.CancelledCompletion)
+		assert.DirExists(t, filepath.Join(configDir, "missing"))
+		assert.Empty(t, Validate(cfg))
+	})
+
+	t.Run("problems without an obvious fix", func(t *testing.T) {
+		cfg := GetDefaultConfig()
+		cfg.DBPath = configDir
+		cfg.StatusTransitions = map[string][]string{"pending": {"bogus"}}
+
+		problems := Validate(cfg)
+		assert.ElementsMatch(t, []string{"db_path", "status_transitions"}, problemKeys(problems))
+		for _, p := range problems {
+			assert.Nil(t, p.Fix, p.Key)
+		}
+	})
+
+	t.Run("label mappings must cover every value", func(t *testing.T) {
+		cfg := GetDefaultConfig()
+		cfg.PriorityLabels = map[string]string{"low": "meh", "high": "hot"}
+
+		problems := Validate(cfg)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "priority_labels", problems[0].Key)
+		assert.Contains(t, problems[0].Message, "missing 'medium'")
+
+		require.NoError(t, problems[0].Fix(cfg))
+		assert.Nil(t, cfg.PriorityLabels)
+		assert.Empty(t, Validate(cfg))
+
+		cfg.StatusSymbols = map[string]string{"pending": "·", "in_progress": ">", "completed": "x", "cancelled": "-", "blocked": "#"}
+		problems = Validate(cfg)
+		require.Len(t, problems, 1)
+		assert.Equal(t, "status_symbols", problems[0].Key)
+		assert.Contains(t, problems[0].Message, "unknown value 'blocked'")
+	})
+}
+
+func TestUnknownKeysAndSettings(t *testing.T) {
+	cleanup := setupTestConfig(t)
+	defer cleanup()
+
+	content := "theme_name: dracula\ndefault_page_sise: 30\nstatus_transitions:\n  pending: [in_progress]\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(content), 0644))
+
+	cfg, err := LoadConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"default_page_sise"}, UnknownKeys())
+
+	sources := make(map[string]string)
+	for _, s := range Settings(cfg) {
+		sources[s.Key] = s.Source
+	}
+	assert.Len(t, sources, len(Keys()))
+	assert.Equal(t, "file", sources["theme_name"])
+	assert.Equal(t, "file", sources["status_transitions"])
+	assert.Equal(t, "default", sources["default_page_size"])
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"task-management/internal/domain"
+	"task-management/internal/fuzzy"
+	"task-management/internal/theme"
+)
+
+// a problem with a config value. Fix repairs it, nil when it needs a person
+// to decide
+type Problem struct {
+	Key     string
+	Message string
+	Fix     func(cfg *Config) error
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Key, p.Message)
+}
+
+// checks the values of a loaded config, an empty result means it is fine
+func Validate(cfg *Config) []Problem {
+	var problems []Problem
+
+	if cfg.ThemeName != "" && !theme.ThemeExists(cfg.ThemeName) {
+		problems = append(problems, Problem{
+			Key:     "theme_name",
+			Message: fmt.Sprintf("theme '%s' does not exist (available: %s)", cfg.ThemeName, strings.Join(theme.ListThemes(), ", ")),
+			Fix:     func(cfg *Config) error { cfg.ThemeName = "default"; return nil },
+		})
+	}
+
+	if cfg.DefaultPageSize < 1 {
+		problems = append(problems, Problem{
+			Key:     "default_page_size",
+			Message: fmt.Sprintf("%d is not a positive page size", cfg.DefaultPageSize),
+			Fix:     func(cfg *Config) error { cfg.DefaultPageSize = GetDefaultConfig().DefaultPageSize; return nil },
+		})
+	}
+	if cfg.MaxPageSize < 1 {
+		problems = append(problems, Problem{
+			Key:     "max_page_size",
+			Message: fmt.Sprintf("%d is not a positive page size", cfg.MaxPageSize),
+			Fix:     func(cfg *Config) error { cfg.MaxPageSize = GetDefaultConfig().MaxPageSize; return nil },
+		})
+	} else if cfg.DefaultPageSize > cfg.MaxPageSize {
+		problems = append(problems, Problem{
+			Key:     "default_page_size",
+			Message: fmt.Sprintf("%d is larger than max_page_size (%d)", cfg.DefaultPageSize, cfg.MaxPageSize),
+			Fix:     func(cfg *Config) error { cfg.DefaultPageSize = cfg.MaxPageSize; return nil },
+		})
+	}
+
+	if cfg.MaxSearchHistory < 0 {
+		problems = append(problems, Problem{
+			Key:     "max_search_history",
+			Message: fmt.Sprintf("%d is negative", cfg.MaxSearchHistory),
+			Fix:     func(cfg *Config) error { cfg.MaxSearchHistory = GetDefaultConfig().MaxSearchHistory; return nil },
+		})
+	}
+
+	if cfg.OverdueGraceDays < 0 {
+		problems = append(problems, Problem{
+			Key:     "overdue_grace_days",
+			Message: fmt.Sprintf("%d is negative, use 0 for no grace", cfg.OverdueGraceDays),
+			Fix:     func(cfg *Config) error { cfg.OverdueGraceDays = 0; return nil },
+		})
+	}
+
+	if _, err := cfg.CancelledTreatment(); err != nil {
+		problems = append(problems, Problem{
+			Key:     "cancelled_completion",
+			Message: err.Error(),
+			Fix:     func(cfg *Config) error { cfg.CancelledCompletion = ""; return nil },
+		})
+	}
+
+	if cfg.MaxProjectDepth < 0 {
+		problems = append(problems, Problem{
+			Key:     "max_project_depth",
+			Message: fmt.Sprintf("%d is negative, use 0 for no limit", cfg.MaxProjectDepth),
+			Fix:     func(cfg *Config) error { cfg.MaxProjectDepth = 0; return nil },
+		})
+	}
+
+	if cfg.ConfirmTimeout < 0 {
+		problems = append(problems, Problem{
+			Key:     "confirm_timeout",
+			Message: fmt.Sprintf("%d is negative, use 0 to keep dialogs open", cfg.ConfirmTimeout),
+			Fix:     func(cfg *Config) error { cfg.ConfirmTimeout = 0; return nil },
+		})
+	}
+
+	if _, err := fuzzy.NewScorer(cfg.FuzzyAlgorithm); err != nil {
+		problems = append(problems, Problem{
+			Key:     "fuzzy_algorithm",
+			Message: err.Error(),
+			Fix:     func(cfg *Config) error { cfg.FuzzyAlgorithm = fuzzy.AlgorithmSubsequence; return nil },
+		})
+	}
+
+	if _, err := domain.ParseStatusTransitions(cfg.StatusTransitions); err != nil {
+		problems = append(problems, Problem{
+			Key:     "status_transitions",
+			Message: err.Error(),
+		})
+	}
+
+	var labelErr *theme.LabelError
+	if _, err := cfg.Labels(); errors.As(err, &labelErr) {
+		problems = append(problems, Problem{
+			Key:     labelErr.Key,
+			Message: labelErr.Message,
+			Fix:     func(cfg *Config) error { cfg.clearLabels(labelErr.Key); return nil },
+		})
+	}
+
+	if unknown := unknownColumns(cfg.Columns); len(unknown) > 0 {
+		problems = append(problems, Problem{
+			Key:     "columns",
+			Message: fmt.Sprintf("unknown column(s) %s (use %s)", strings.Join(unknown, ", "), strings.Join(ColumnNames(), ", ")),
+			Fix: func(cfg *Config) error {
+				for _, name := range unknown {
+					delete(cfg.Columns, name)
+				}
+				return nil
+			},
+		})
+	}
+	for _, name := range ColumnNames() {
+		if width := cfg.Columns[name].Width; width != 0 && width < MinColumnWidth {
+			problems = append(problems, Problem{
+				Key:     "columns",
+				Message: fmt.Sprintf("%s width %d is below %d", name, width, MinColumnWidth),
+				Fix: func(cfg *Config) error {
+					column := cfg.Columns[name]
+					column.Width = 0
+					cfg.Columns[name] = column
+					return nil
+				},
+			})
+		}
+	}
+
+	dbDir := filepath.Dir(cfg.DBPath)
+	if info, err := os.Stat(cfg.DBPath); err == nil && info.IsDir() {
+		problems = append(problems, Problem{
+			Key:     "db_path",
+			Message: fmt.Sprintf("%s is a directory, not a database file", cfg.DBPath),
+		})
+	} else if _, err := os.Stat(dbDir); os.IsNotExist(err) {
+		problems = append(problems, Problem{
+			Key:     "db_path",
+			Message: fmt.Sprintf("directory %s does not exist", dbDir),
+			Fix:     func(cfg *Config) error { return os.MkdirAll(filepath.Dir(cfg.DBPath), 0755) },
+		})
+	}
+
+	return problems
+}
+
+// the display labels and symbols the config maps statuses and priorities to
+func (cfg *Config) Labels() (theme.Labels, error) {
+	return theme.ParseLabels(cfg.StatusLabels, cfg.StatusSymbols, cfg.PriorityLabels, cfg.PrioritySymbols, cfg.ProjectStatusLabels)
+}
+
+// how cancelled tasks count in completion percentages
+func (cfg *Config) CancelledTreatment() (domain.CancelledTreatment, error) {
+	return domain.ParseCancelledTreatment(cfg.CancelledCompletion)
+}
+
+// drops a label mapping, going back to the default names or symbols
+func (cfg *Config) clearLabels(key string) {
+	switch key {
+	case "status_labels":
+		cfg.StatusLabels = nil
+	case "status_symbols":
+		cfg.StatusSymbols = nil
+	case "priority_labels":
+		cfg.PriorityLabels = nil
+	case "priority_symbols":
+		cfg.PrioritySymbols = nil
+	case "project_status_labels":
+		cfg.ProjectStatusLabels = nil
+	}
+}
+
+// the keys a config file can set, in the order of the Config fields
+func Keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keys in the config file that taskflow doesn't know, most likely typos.
+// only meaningful after LoadConfig read a file
+func UnknownKeys() []string {
+	known := make(map[string]bool)
+	for _, key := range Keys() {
+		known[key] = true
+	}
+
+	seen := make(map[string]bool)
+	var unknown []string
+	for _, key := range viper.AllKeys() {
+		// nested maps like status_transitions.pending belong to their parent
+		top, _, _ := strings.Cut(key, ".")
+		if !known[top] && !seen[top] {
+			seen[top] = true
+			unknown = append(unknown, top)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// a config key with its effective value and where that came from
+type Setting struct {
+	Key    string
+	Value  interface{}
+	Source string
+}
+
+// the effective value of every key. Source is "file" for keys set in the
+// config file and "default" for the rest
+func Settings(cfg *Config) []Setting {
+	v := reflect.ValueOf(*cfg)
+	t := v.Type()
+
+	settings := make([]Setting, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+
+		source := "default"
+		if ConfigExists() && viper.InConfig(key) {
+			source = "file"
+		}
+		settings = append(settings, Setting{Key: key, Value: v.Field(i).Interface(), Source: source})
+	}
+	return settings
+}
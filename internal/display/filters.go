@@ -0,0 +1,66 @@
+package display
+
+import (
+	"fmt"
+
+	"task-management/internal/domain"
+)
+
+// FilterSection is one group of the filter panel's choices. an empty value
+// means no filter
+type FilterSection struct {
+	Title      string
+	FilterType string
+	Choices    []FilterChoice
+}
+
+type FilterChoice struct {
+	Label string
+	Value string
+}
+
+// FilterSections are the filter panel's choices, project values are IDs
+func FilterSections(projects []*domain.Project) []FilterSection {
+	projectChoices := []FilterChoice{{"All", ""}}
+	for _, proj := range projects {
+		projectChoices = append(projectChoices, FilterChoice{proj.Name, fmt.Sprintf("%d", proj.ID)})
+	}
+
+	return []FilterSection{
+		{"Filter by Status", "status", []FilterChoice{
+			{"All", ""},
+			{"Pending", "pending"},
+			{"In Progress", "in_progress"},
+			{"Completed", "completed"},
+			{"Cancelled", "cancelled"},
+		}},
+		{"Filter by Priority", "priority", []FilterChoice{
+			{"All", ""},
+			{"Low", "low"},
+			{"Medium", "medium"},
+			{"High", "high"},
+			{"Urgent", "urgent"},
+		}},
+		{"Filter by Project", "project", projectChoices},
+		{"Filter by Due Date", "duedate", []FilterChoice{
+			{"All", ""},
+			{"Overdue", "overdue"},
+			{"Due Today", "today"},
+			{"Due This Week", "week"},
+			{"Due This Month", "month"},
+			{"No Due Date", "none"},
+		}},
+		{"Filter by Pin", "pinned", []FilterChoice{
+			{"All", ""},
+			{"Pinned Only", "pinned"},
+		}},
+		{"Filter by Waiting On", "waiting", []FilterChoice{
+			{"All", ""},
+			{"Waiting Only", "waiting"},
+		}},
+		{"Snoozed Tasks", "snoozed", []FilterChoice{
+			{"Hide Snoozed", ""},
+			{"Show Snoozed", "show"},
+		}},
+	}
+}
@@ -2,8 +2,11 @@ package display
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/lipgloss"
+
 	"task-management/internal/domain"
 )
 
@@ -37,32 +40,204 @@ func GetPriorityIcon(priority domain.Priority) string {
 	}
 }
 
-func FormatDueDate(dueDate *time.Time) string {
-	if dueDate == nil {
-		return "-"
+// FormatTaskTitle truncates a task title to width cells, leading with a lock
+// for locked tasks, a pin for pinned ones and a 💤 for snoozed ones
+func FormatTaskTitle(task *domain.Task, width int) string {
+	prefix := TaskTitlePrefix(task)
+	return prefix + TruncateText(task.Title, width-lipgloss.Width(prefix))
+}
+
+// TaskTitlePrefix is the marker FormatTaskTitle puts before a title
+func TaskTitlePrefix(task *domain.Task) string {
+	prefix := ""
+	if task.IsLocked {
+		prefix += "🔒 "
+	}
+	if task.IsPinned {
+		prefix += "📌 "
+	}
+	if task.IsSnoozed(time.Now()) {
+		prefix += "💤 "
 	}
+	if task.WaitingOn != "" {
+		prefix += "⏳ "
+	}
+	if task.IsBlocked() {
+		prefix += "⛔ "
+	}
+	if task.Recurrence != "" {
+		prefix += "↻ "
+	}
+	return prefix
+}
 
-	now := time.Now()
-	diff := dueDate.Sub(now)
+// TaskProjectLabel is the project column: the task's key like "BACKEND-12"
+// while it is in the project that numbered it, else the project name
+func TaskProjectLabel(task *domain.Task) string {
+	if key := task.Key(); key != "" && task.ProjectID != nil &&
+		task.NumberProjectID != nil && *task.ProjectID == *task.NumberProjectID {
+		return key
+	}
+	return task.ProjectName
+}
 
-	// overdue
-	if diff < 0 {
-		days := int(-diff.Hours() / 24)
-		if days == 0 {
-			return "TODAY!"
-		}
-		return fmt.Sprintf("-%dd", days)
+// FormatDueDate is the short due column: "-3d" once a task is overdue,
+// "Yesterday" or "2d ago" while it is within the overdue grace, then
+// "TODAY!", "Tomorrow", "5d" or the date
+func FormatDueDate(dueDate *time.Time, now time.Time, graceDays int) string {
+	if dueDate == nil {
+		return "-"
 	}
 
-	// due soon
-	days := int(diff.Hours() / 24)
-	if days == 0 {
-		return "Today"
-	} else if days == 1 {
+	days := domain.DueInDays(*dueDate, now)
+	switch {
+	case domain.IsOverdue(dueDate, now, graceDays):
+		return fmt.Sprintf("-%dd", -days)
+	case days == -1:
+		return "Yesterday"
+	case days < 0:
+		return fmt.Sprintf("%dd ago", -days)
+	case days == 0:
+		return "TODAY!"
+	case days == 1:
 		return "Tomorrow"
-	} else if days <= 7 {
+	case days <= 7:
 		return fmt.Sprintf("%dd", days)
 	}
 
 	return dueDate.Format("2006-01-02")
 }
+
+// FormatRelativeTime shows how long before now t was in a short form, e.g.
+// "just now", "5m ago", "3h ago", "2d ago", "3w ago", "4mo ago" or "2y ago"
+func FormatRelativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	days := int(d.Hours() / 24)
+
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case days < 7:
+		return fmt.Sprintf("%dd ago", days)
+	case days < 30:
+		return fmt.Sprintf("%dw ago", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo ago", days/30)
+	default:
+		return fmt.Sprintf("%dy ago", days/365)
+	}
+}
+
+// FormatElapsed shows a duration with its two largest units, e.g. "3d 4h",
+// "2h 15m" or "45m"
+func FormatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// TagWeight buckets a tag count into 1 (rarely used) through 4 (the most used
+// tag) relative to the highest count
+func TagWeight(count, maxCount int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 1
+	}
+
+	weight := (count*4 + maxCount - 1) / maxCount
+	if weight < 1 {
+		return 1
+	}
+	if weight > 4 {
+		return 4
+	}
+	return weight
+}
+
+// TruncateText puts text on a single line and cuts it to at most width
+// terminal cells, marking the cut with "..."
+func TruncateText(text string, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if lipgloss.Width(text) <= width {
+		return text
+	}
+	if width <= 3 {
+		return strings.Repeat(".", max(width, 0))
+	}
+
+	head, _ := SplitAtWidth(text, width-3)
+	return head + "..."
+}
+
+// ShortenPath joins the names of a project path with " > " and, when that is
+// wider than width cells, leaves out names after the root until it fits:
+// "Dev > … > API". the last name is only truncated when nothing else helps.
+// a width of 0 or less never shortens
+func ShortenPath(names []string, width int) string {
+	full := strings.Join(names, " > ")
+	if width <= 0 || lipgloss.Width(full) <= width {
+		return full
+	}
+	if len(names) == 1 {
+		return TruncateText(full, width)
+	}
+
+	for dropped := 1; dropped < len(names)-1; dropped++ {
+		short := names[0] + " > … > " + strings.Join(names[dropped+1:], " > ")
+		if lipgloss.Width(short) <= width {
+			return short
+		}
+	}
+
+	// not even the root fits next to the project itself
+	last := names[len(names)-1]
+	const ellipsis = "… > "
+	if room := width - lipgloss.Width(ellipsis); room > 3 {
+		return ellipsis + TruncateText(last, room)
+	}
+	return TruncateText(last, width)
+}
+
+// SplitAtWidth splits text after the last rune that still fits in width
+// terminal cells. for a positive width head always gets at least one rune, so
+// callers breaking text into lines keep making progress
+func SplitAtWidth(text string, width int) (head, rest string) {
+	used := 0
+	for i, r := range text {
+		w := lipgloss.Width(string(r))
+		if used+w > width && (i > 0 || width <= 0) {
+			return text[:i], text[i:]
+		}
+		used += w
+	}
+	return text, ""
+}
+
+// PadText pads text with spaces to width terminal cells. unlike %-*s it
+// counts wide characters by the cells they take up
+func PadText(text string, width int) string {
+	if gap := width - lipgloss.Width(text); gap > 0 {
+		return text + strings.Repeat(" ", gap)
+	}
+	return text
+}
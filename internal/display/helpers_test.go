@@ -0,0 +1,74 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestShortenPath(t *testing.T) {
+	deep := []string{"Dev", "Platform", "Services", "Payments", "API"}
+
+	tests := []struct {
+		name  string
+		names []string
+		width int
+		want  string
+	}{
+		{"fits", deep, 60, "Dev > Platform > Services > Payments > API"},
+		{"shortening off", deep, 0, "Dev > Platform > Services > Payments > API"},
+		{"drops the ancestors after the root first", deep, 35, "Dev > … > Services > Payments > API"},
+		{"keeps the closest ancestors that fit", deep, 25, "Dev > … > Payments > API"},
+		{"root and project only", deep, 13, "Dev > … > API"},
+		{"root doesn't fit", deep, 10, "… > API"},
+		{"project name truncated", []string{"Dev", "Authentication"}, 12, "… > Authe..."},
+		{"two levels", []string{"Dev", "API"}, 8, "… > API"},
+		{"root project", []string{"Authentication"}, 8, "Authe..."},
+		{"tiny width", deep, 3, "API"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ShortenPath(tt.names, tt.width)
+			if got != tt.want {
+				t.Errorf("ShortenPath(%v, %d) = %q, want %q", tt.names, tt.width, got, tt.want)
+			}
+			if tt.width > 0 && lipgloss.Width(got) > tt.width {
+				t.Errorf("%q is %d cells wide, want at most %d", got, lipgloss.Width(got), tt.width)
+			}
+		})
+	}
+}
+
+func TestFormatDueDate(t *testing.T) {
+	now := time.Date(2025, 3, 14, 18, 0, 0, 0, time.Local)
+	day := func(offset int) *time.Time {
+		due := time.Date(2025, 3, 14+offset, 0, 0, 0, 0, time.UTC)
+		return &due
+	}
+
+	tests := []struct {
+		name  string
+		due   *time.Time
+		grace int
+		want  string
+	}{
+		{"none", nil, 0, "-"},
+		{"yesterday is overdue", day(-1), 0, "-1d"},
+		{"yesterday within grace", day(-1), 1, "Yesterday"},
+		{"past the grace", day(-3), 2, "-3d"},
+		{"today", day(0), 0, "TODAY!"},
+		{"tomorrow", day(1), 0, "Tomorrow"},
+		{"this week", day(5), 0, "5d"},
+		{"later", day(30), 0, "2025-04-13"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDueDate(tt.due, now, tt.grace); got != tt.want {
+				t.Errorf("FormatDueDate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
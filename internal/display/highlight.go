@@ -0,0 +1,131 @@
+package display
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Match is the byte range [Start, End) of a search match in a string
+type Match struct {
+	Start int
+	End   int
+}
+
+// SearchMatches finds what a search matched in text. regex searches match the
+// pattern itself, text searches every whitespace separated term of the query,
+// ignoring case. overlapping and touching matches are merged, so the result
+// is sorted and disjoint. an invalid pattern matches nothing
+func SearchMatches(text, query string, regex bool) []Match {
+	if text == "" || strings.TrimSpace(query) == "" {
+		return nil
+	}
+
+	var patterns []*regexp.Regexp
+	if regex {
+		re, err := regexp.Compile(query)
+		if err != nil {
+			return nil
+		}
+		patterns = append(patterns, re)
+	} else {
+		for _, term := range strings.Fields(query) {
+			patterns = append(patterns, regexp.MustCompile("(?i)"+regexp.QuoteMeta(term)))
+		}
+	}
+
+	var matches []Match
+	for _, re := range patterns {
+		for _, loc := range re.FindAllStringIndex(text, -1) {
+			// empty matches, like those of a*, have nothing to show
+			if loc[1] > loc[0] {
+				matches = append(matches, Match{Start: loc[0], End: loc[1]})
+			}
+		}
+	}
+
+	return mergeMatches(matches)
+}
+
+func mergeMatches(matches []Match) []Match {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Start < matches[j].Start
+	})
+
+	merged := []Match{matches[0]}
+	for _, match := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if match.Start <= last.End {
+			last.End = max(last.End, match.End)
+			continue
+		}
+		merged = append(merged, match)
+	}
+	return merged
+}
+
+// Highlight renders the matched parts of text with match and the rest with
+// base. every word is styled on its own, so the result can still be wrapped
+// at spaces without a style running into the next line
+func Highlight(text string, matches []Match, match, base lipgloss.Style) string {
+	var b strings.Builder
+	pos := 0
+	for _, m := range matches {
+		if m.Start >= len(text) {
+			break
+		}
+		end := min(m.End, len(text))
+		b.WriteString(renderWords(text[pos:m.Start], base))
+		b.WriteString(renderWords(text[m.Start:end], match))
+		pos = end
+	}
+	b.WriteString(renderWords(text[pos:], base))
+	return b.String()
+}
+
+// renders the runs of non-space characters of text, leaving the spaces plain
+func renderWords(text string, style lipgloss.Style) string {
+	var b strings.Builder
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				b.WriteString(style.Render(text[start:i]))
+				start = -1
+			}
+			b.WriteRune(r)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		b.WriteString(style.Render(text[start:]))
+	}
+	return b.String()
+}
+
+// HighlightTruncated truncates text to width cells like TruncateText and
+// highlights the matches in the part that is still shown. a match cut by the
+// truncation is highlighted up to the "..."
+func HighlightTruncated(text, query string, regex bool, width int, match, base lipgloss.Style) string {
+	text = strings.Join(strings.Fields(text), " ")
+	matches := SearchMatches(text, query, regex)
+
+	shown := TruncateText(text, width)
+	visible, suffix := shown, ""
+	if shown != text {
+		visible = strings.TrimSuffix(shown, "...")
+		suffix = shown[len(visible):]
+	}
+
+	return Highlight(visible, matches, match, base) + renderWords(suffix, base)
+}
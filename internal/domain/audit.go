@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// one row of the task audit log. an update has a row per changed field,
+// create and delete a single row without a field
+type TaskAuditEntry struct {
+	ID        int64       `db:"id" json:"id"`
+	TaskID    int64       `db:"task_id" json:"task_id"`
+	TaskTitle string      `db:"task_title" json:"task_title"`
+	Action    AuditAction `db:"action" json:"action"`
+	Field     string      `db:"field" json:"field,omitempty"`
+	OldValue  string      `db:"old_value" json:"old_value,omitempty"`
+	NewValue  string      `db:"new_value" json:"new_value,omitempty"`
+	ChangedAt time.Time   `db:"changed_at" json:"changed_at"`
+}
+
+type FieldChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// the fields a user can change on a task, compared by DiffTasks. derived
+// fields like updated_at, completed_at and position are left out
+var auditedFields = []struct {
+	name  string
+	value func(t *Task) string
+}{
+	{"title", func(t *Task) string { return t.Title }},
+	{"description", func(t *Task) string { return t.Description }},
+	{"status", func(t *Task) string { return string(t.Status) }},
+	{"priority", func(t *Task) string { return string(t.Priority) }},
+	{"project", func(t *Task) string {
+		if t.ProjectID == nil {
+			return ""
+		}
+		if t.ProjectName != "" {
+			return t.ProjectName
+		}
+		return fmt.Sprintf("#%d", *t.ProjectID)
+	}},
+	{"tags", func(t *Task) string {
+		// tag order carries no meaning, bulk tag edits don't keep it
+		tags := slices.Clone(t.Tags)
+		slices.Sort(tags)
+		return strings.Join(tags, ", ")
+	}},
+	{"due_date", func(t *Task) string { return formatAuditTime(t.DueDate) }},
+	{"pinned", func(t *Task) string { return fmt.Sprintf("%t", t.IsPinned) }},
+	{"snoozed_until", func(t *Task) string { return formatAuditTime(t.SnoozedUntil) }},
+	{"waiting_on", func(t *Task) string { return t.WaitingOn }},
+	{"locked", func(t *Task) string { return fmt.Sprintf("%t", t.IsLocked) }},
+	{"color", func(t *Task) string { return t.Color }},
+	{"recurrence", func(t *Task) string { return t.Recurrence }},
+	{"depends_on", func(t *Task) string {
+		ids := slices.Clone(t.DependsOn)
+		slices.Sort(ids)
+		refs := make([]string, len(ids))
+		for i, id := range ids {
+			refs[i] = fmt.Sprintf("#%d", id)
+		}
+		return strings.Join(refs, ", ")
+	}},
+}
+
+func formatAuditTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// the audited fields that differ between two versions of a task, in a fixed
+// order. identical tasks give no changes
+func DiffTasks(old, new *Task) []FieldChange {
+	var changes []FieldChange
+	for _, field := range auditedFields {
+		oldValue, newValue := field.value(old), field.value(new)
+		if oldValue != newValue {
+			changes = append(changes, FieldChange{Field: field.name, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	return changes
+}
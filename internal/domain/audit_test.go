@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffTasks(t *testing.T) {
+	due := time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC)
+	projectID := int64(3)
+	old := &Task{Title: "Ship", Status: StatusPending, Priority: PriorityMedium, Tags: []string{"b", "a"}}
+	updated := *old
+	updated.Tags = []string{"a", "b"}
+	updated.UpdatedAt = time.Now()
+	updated.Position = 4
+
+	if changes := DiffTasks(old, &updated); len(changes) != 0 {
+		t.Errorf("expected no changes for reordered tags and derived fields, got %+v", changes)
+	}
+
+	updated.Status = StatusCompleted
+	updated.DueDate = &due
+	updated.ProjectID = &projectID
+	updated.ProjectName = "Backend"
+	updated.DependsOn = []int64{9, 2}
+
+	changes := DiffTasks(old, &updated)
+	want := []FieldChange{
+		{Field: "status", OldValue: "pending", NewValue: "completed"},
+		{Field: "project", OldValue: "", NewValue: "Backend"},
+		{Field: "due_date", OldValue: "", NewValue: "2025-01-31 17:00"},
+		{Field: "depends_on", OldValue: "", NewValue: "#2, #9"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d: expected %+v, got %+v", i, want[i], changes[i])
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
@@ -15,6 +17,10 @@ const (
 	ProjectStatusCompleted ProjectStatus = "completed"
 )
 
+// the name the reserved project quick captures go to is created with. it can't
+// be deleted and is created again on the next capture if it goes missing
+const InboxProjectName = "Inbox"
+
 type Project struct {
 	ID          int64          `db:"id" json:"id"`
 	Name        string         `db:"name" json:"name"`
@@ -26,6 +32,18 @@ type Project struct {
 	IsFavorite  bool           `db:"is_favorite" json:"is_favorite"`
 	Aliases     []string       `db:"aliases" json:"aliases,omitempty"`
 	Notes       string         `db:"notes" json:"notes,omitempty"`
+	SprintCadence   string     `db:"sprint_cadence" json:"sprint_cadence,omitempty"`
+	SprintNumber    int        `db:"sprint_number" json:"sprint_number,omitempty"`
+	SprintStartedAt *time.Time `db:"sprint_started_at" json:"sprint_started_at,omitempty"`
+	// the template the project was created from or last synced with, and
+	// that template's version at the time
+	SourceTemplateID      *int64 `db:"source_template_id" json:"source_template_id,omitempty"`
+	SourceTemplateVersion int    `db:"source_template_version" json:"source_template_version,omitempty"`
+	// the prefix of the project's task keys, taken from the name when the
+	// project is created and kept when it is renamed
+	KeyPrefix string `db:"key_prefix" json:"key_prefix,omitempty"`
+	// set on the one reserved project quick captures go to
+	Inbox bool `db:"is_inbox" json:"is_inbox,omitempty"`
 	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time      `db:"updated_at" json:"updated_at"`
 
@@ -36,12 +54,8 @@ type Project struct {
 }
 
 func (p *Project) Validate() error {
-	if strings.TrimSpace(p.Name) == "" {
-		return errors.New("project name cannot be empty")
-	}
-
-	if len(p.Name) > 100 {
-		return errors.New("project name cannot exceed 100 characters")
+	if err := ValidateName("project", p.Name); err != nil {
+		return err
 	}
 
 	if len(p.Description) > 500 {
@@ -56,6 +70,10 @@ func (p *Project) Validate() error {
 		return errors.New("invalid color: must be a valid terminal color name")
 	}
 
+	if p.KeyPrefix != "" && ProjectKeyPrefix(p.KeyPrefix) != p.KeyPrefix {
+		return errors.New("invalid key prefix: use upper case letters and digits, separated by single dashes")
+	}
+
 	if p.ParentID != nil && *p.ParentID == p.ID {
 		return errors.New("project cannot be its own parent")
 	}
@@ -91,6 +109,12 @@ func (p *Project) Validate() error {
 		return errors.New("notes cannot exceed 10,000 characters")
 	}
 
+	if p.SprintCadence != "" {
+		if _, err := ParseSprintCadence(p.SprintCadence); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -107,6 +131,10 @@ func NewProject(name string) *Project {
 	}
 }
 
+func (p *Project) IsInbox() bool {
+	return p.Inbox
+}
+
 func (p *Project) IsRoot() bool {
 	return p.ParentID == nil
 }
@@ -126,18 +154,89 @@ func (p *Project) GetDepth() int {
 }
 
 func (p *Project) BuildPath() string {
-	if p.Parent == nil {
-		return p.Name
+	return strings.Join(p.PathNames(), " > ")
+}
+
+// the names from the root project down to this one, following Parent
+func (p *Project) PathNames() []string {
+	var names []string
+	for current := p; current != nil; current = current.Parent {
+		names = append([]string{current.Name}, names...)
 	}
+	return names
+}
 
-	var parts []string
-	current := p
-	for current != nil {
-		parts = append([]string{current.Name}, parts...)
-		current = current.Parent
+// LinkParents sets Parent on every project whose parent is in the slice, so
+// their paths can be built without loading the hierarchy again
+func LinkParents(projects []*Project) {
+	byID := make(map[int64]*Project, len(projects))
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+	for _, p := range projects {
+		if p.ParentID != nil {
+			if parent, ok := byID[*p.ParentID]; ok {
+				p.Parent = parent
+			}
+		}
 	}
+}
+
+// sort fields for projects that share a parent in a tree
+const (
+	ProjectSortName      = "name"
+	ProjectSortTaskCount = "task_count"
+)
+
+// IsValidProjectSort reports whether by is a ProjectOrder field, "" included
+func IsValidProjectSort(by string) bool {
+	return by == "" || by == ProjectSortName || by == ProjectSortTaskCount
+}
 
-	return strings.Join(parts, " > ")
+// ProjectOrder is how projects that share a parent are ordered in a tree:
+// favorites first if set, then by name or by task count (most first, ties by
+// name). an empty By keeps the listed order
+type ProjectOrder struct {
+	By             string
+	FavoritesFirst bool
+	// only used when sorting by task count
+	TaskCounts map[int64]int
+}
+
+func (o ProjectOrder) Compare(a, b *Project) int {
+	if o.FavoritesFirst && a.IsFavorite != b.IsFavorite {
+		if a.IsFavorite {
+			return -1
+		}
+		return 1
+	}
+
+	byName := func() int { return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)) }
+	switch o.By {
+	case ProjectSortName:
+		return byName()
+	case ProjectSortTaskCount:
+		return cmp.Or(cmp.Compare(o.TaskCounts[b.ID], o.TaskCounts[a.ID]), byName())
+	}
+	return 0
+}
+
+// Sort orders siblings in place. the sort is stable, so projects that compare
+// equal keep their listed order
+func (o ProjectOrder) Sort(siblings []*Project) {
+	slices.SortStableFunc(siblings, o.Compare)
+}
+
+var ProjectStatuses = []ProjectStatus{ProjectStatusActive, ProjectStatusArchived, ProjectStatusCompleted}
+
+// ParseProjectStatus turns user input like "Active" into a ProjectStatus.
+// empty and unknown values are errors listing the valid ones
+func ParseProjectStatus(value string) (ProjectStatus, error) {
+	status := ProjectStatus(strings.ToLower(strings.TrimSpace(value)))
+	if !isValidProjectStatus(status) {
+		return "", invalidValueError("project status", value, ProjectStatuses)
+	}
+	return status, nil
 }
 
 func isValidProjectStatus(s ProjectStatus) bool {
@@ -180,6 +279,17 @@ func GetValidColors() []string {
 	return colors
 }
 
+// ParseColor normalizes a color name. blank gives "", meaning no color
+func ParseColor(value string) (string, error) {
+	color := strings.ToLower(strings.TrimSpace(value))
+	if color != "" && !isValidColor(color) {
+		colors := GetValidColors()
+		slices.Sort(colors)
+		return "", invalidValueError("color", value, colors)
+	}
+	return color, nil
+}
+
 var commonIcons = []string{
 	"📦", "🚀", "💼", "🔧", "⚙️", "🎯", "📊", "🌟",
 	"🔨", "💻", "📱", "🌐", "🔐", "🎨", "📝", "🏠",
@@ -207,6 +317,19 @@ func IsValidAliasFormat(alias string) error {
 	return nil
 }
 
+// trims and lowercases every alias, so aliases differing only in case are
+// caught as duplicates and stored the way lookups compare them
+func NormalizeAliases(aliases []string) []string {
+	if aliases == nil {
+		return nil
+	}
+	normalized := make([]string, len(aliases))
+	for i, alias := range aliases {
+		normalized[i] = strings.ToLower(strings.TrimSpace(alias))
+	}
+	return normalized
+}
+
 func isValidAliasFormatBool(alias string) bool {
 	return IsValidAliasFormat(alias) == nil
 }
@@ -240,3 +363,23 @@ func (p *Project) FormatAliases() string {
 func (p *Project) HasNotes() bool {
 	return strings.TrimSpace(p.Notes) != ""
 }
+
+// InheritStyle fills an empty color and icon from the parent project and
+// returns what was taken over, e.g. "color and icon". values already set are
+// never replaced
+func (p *Project) InheritStyle(parent *Project) string {
+	if parent == nil {
+		return ""
+	}
+
+	var inherited []string
+	if p.Color == "" && parent.Color != "" {
+		p.Color = parent.Color
+		inherited = append(inherited, "color")
+	}
+	if p.Icon == "" && parent.Icon != "" {
+		p.Icon = parent.Icon
+		inherited = append(inherited, "icon")
+	}
+	return strings.Join(inherited, " and ")
+}
@@ -343,6 +343,36 @@ func TestProject_HasNotes(t *testing.T) {
 	}
 }
 
+func TestProject_InheritStyle(t *testing.T) {
+	parent := &Project{Name: "Backend", Color: "blue", Icon: "🚀"}
+
+	tests := []struct {
+		name      string
+		color     string
+		icon      string
+		parent    *Project
+		wantColor string
+		wantIcon  string
+		inherited string
+	}{
+		{"both empty", "", "", parent, "blue", "🚀", "color and icon"},
+		{"color set", "red", "", parent, "red", "🚀", "icon"},
+		{"both set", "red", "📦", parent, "red", "📦", ""},
+		{"parent without style", "", "", &Project{Name: "Plain"}, "", "", ""},
+		{"no parent", "", "", nil, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := &Project{Name: "API", Color: tt.color, Icon: tt.icon}
+			inherited := project.InheritStyle(tt.parent)
+			assert.Equal(t, tt.inherited, inherited)
+			assert.Equal(t, tt.wantColor, project.Color)
+			assert.Equal(t, tt.wantIcon, project.Icon)
+		})
+	}
+}
+
 func TestIsValidAliasFormat(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -368,3 +398,52 @@ func TestIsValidAliasFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectOrder_Sort(t *testing.T) {
+	names := func(projects []*Project) []string {
+		var out []string
+		for _, p := range projects {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+	siblings := func() []*Project {
+		return []*Project{
+			{ID: 1, Name: "web"},
+			{ID: 2, Name: "Api", IsFavorite: true},
+			{ID: 3, Name: "docs"},
+			{ID: 4, Name: "cli", IsFavorite: true},
+		}
+	}
+	counts := map[int64]int{1: 5, 2: 1, 3: 5, 4: 9}
+
+	tests := []struct {
+		name  string
+		order ProjectOrder
+		want  []string
+	}{
+		{"empty keeps list order", ProjectOrder{}, []string{"web", "Api", "docs", "cli"}},
+		{"favorites first keeps list order within groups", ProjectOrder{FavoritesFirst: true}, []string{"Api", "cli", "web", "docs"}},
+		{"by name ignores case", ProjectOrder{By: ProjectSortName}, []string{"Api", "cli", "docs", "web"}},
+		{"by name favorites first", ProjectOrder{By: ProjectSortName, FavoritesFirst: true}, []string{"Api", "cli", "docs", "web"}},
+		{"by task count ties by name", ProjectOrder{By: ProjectSortTaskCount, TaskCounts: counts}, []string{"cli", "docs", "web", "Api"}},
+		{"by task count favorites first", ProjectOrder{By: ProjectSortTaskCount, FavoritesFirst: true, TaskCounts: counts}, []string{"cli", "Api", "docs", "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := siblings()
+			tt.order.Sort(projects)
+			assert.Equal(t, tt.want, names(projects))
+		})
+	}
+}
+
+func TestParseProjectStatus(t *testing.T) {
+	status, err := ParseProjectStatus(" Archived ")
+	assert.NoError(t, err)
+	assert.Equal(t, ProjectStatusArchived, status)
+
+	_, err = ParseProjectStatus("paused")
+	assert.EqualError(t, err, "invalid project status: paused (must be active, archived, or completed)")
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// a task written on one line, with markers for its fields:
+// "Fix login !high #auth #bug @backend"
+type QuickAdd struct {
+	Title    string
+	Priority Priority
+	Tags     []string
+	Project  string
+}
+
+// ParseQuickAdd takes the markers out of a one-line task: !priority, #tag and
+// @project (name, alias or ID, without spaces). a marker is a whole word, so
+// "issue#12" or a lone "!" stay part of the title
+func ParseQuickAdd(line string) (*QuickAdd, error) {
+	entry := &QuickAdd{}
+	var words []string
+
+	for _, word := range strings.Fields(line) {
+		if len(word) < 2 {
+			words = append(words, word)
+			continue
+		}
+
+		switch marker, value := word[0], word[1:]; marker {
+		case '!':
+			priority := Priority(strings.ToLower(value))
+			if !priority.IsValid() {
+				return nil, fmt.Errorf("unknown priority %s, use !low, !medium, !high or !urgent", word)
+			}
+			if entry.Priority != "" && entry.Priority != priority {
+				return nil, fmt.Errorf("more than one priority: !%s and %s", entry.Priority, word)
+			}
+			entry.Priority = priority
+		case '#':
+			if !slices.Contains(entry.Tags, value) {
+				entry.Tags = append(entry.Tags, value)
+			}
+		case '@':
+			if entry.Project != "" && entry.Project != value {
+				return nil, fmt.Errorf("more than one project: @%s and %s", entry.Project, word)
+			}
+			entry.Project = value
+		default:
+			words = append(words, word)
+		}
+	}
+
+	entry.Title = strings.Join(words, " ")
+	if entry.Title == "" {
+		return nil, errors.New("no title left after the markers")
+	}
+	return entry, nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    *QuickAdd
+		wantErr bool
+	}{
+		{
+			name: "plain title",
+			line: "Call the dentist",
+			want: &QuickAdd{Title: "Call the dentist"},
+		},
+		{
+			name: "all markers anywhere in the line",
+			line: "Fix !HIGH login #auth @backend redirect #bug",
+			want: &QuickAdd{Title: "Fix login redirect", Priority: PriorityHigh, Tags: []string{"auth", "bug"}, Project: "backend"},
+		},
+		{
+			name: "markers inside words and lone signs stay",
+			line: "Close issue#12 ! now @ home",
+			want: &QuickAdd{Title: "Close issue#12 ! now @ home"},
+		},
+		{
+			name: "repeated markers",
+			line: "Ship #docs #docs !low !low",
+			want: &QuickAdd{Title: "Ship", Priority: PriorityLow, Tags: []string{"docs"}},
+		},
+		{name: "unknown priority", line: "Ship !asap", wantErr: true},
+		{name: "two priorities", line: "Ship !low !high", wantErr: true},
+		{name: "two projects", line: "Ship @a @b", wantErr: true},
+		{name: "markers only", line: "#idea !low", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuickAdd(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuickAdd(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
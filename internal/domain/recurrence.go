@@ -0,0 +1,189 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	RecurDaily   RecurrenceFrequency = "daily"
+	RecurWeekly  RecurrenceFrequency = "weekly"
+	RecurMonthly RecurrenceFrequency = "monthly"
+)
+
+// how a recurring task repeats, parsed from a rule such as "daily",
+// "weekly:mon,wed" or "monthly:15"
+type Recurrence struct {
+	Frequency RecurrenceFrequency
+	// the days a weekly rule falls on, empty repeats every 7 days
+	Weekdays []time.Weekday
+	// the day of the month a monthly rule falls on, 0 keeps the day of the
+	// previous occurrence. months without that day use their last one
+	MonthDay int
+}
+
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+const recurrenceHelp = `use daily, weekly, weekly:mon,wed, monthly or monthly:15`
+
+// parses a recurrence rule, case and spaces don't matter
+func ParseRecurrence(rule string) (Recurrence, error) {
+	value := strings.ToLower(strings.Join(strings.Fields(rule), ""))
+	if value == "" {
+		return Recurrence{}, fmt.Errorf("recurrence rule cannot be empty (%s)", recurrenceHelp)
+	}
+
+	frequency, arg, hasArg := strings.Cut(value, ":")
+	if hasArg && arg == "" {
+		return Recurrence{}, fmt.Errorf("invalid recurrence %q: nothing after the colon (%s)", rule, recurrenceHelp)
+	}
+
+	recurrence := Recurrence{Frequency: RecurrenceFrequency(frequency)}
+	switch recurrence.Frequency {
+	case RecurDaily:
+		if hasArg {
+			return Recurrence{}, fmt.Errorf("invalid recurrence %q: daily takes no days", rule)
+		}
+	case RecurWeekly:
+		if !hasArg {
+			break
+		}
+		seen := make(map[time.Weekday]bool)
+		for _, name := range strings.Split(arg, ",") {
+			day, ok := parseWeekday(name)
+			if !ok {
+				return Recurrence{}, fmt.Errorf("invalid recurrence %q: unknown weekday %q (use mon, tue, wed, thu, fri, sat or sun)", rule, name)
+			}
+			seen[day] = true
+		}
+		// kept in week order so equal rules print the same
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if seen[day] {
+				recurrence.Weekdays = append(recurrence.Weekdays, day)
+			}
+		}
+	case RecurMonthly:
+		if !hasArg {
+			break
+		}
+		day, err := strconv.Atoi(arg)
+		if err != nil || day < 1 || day > 31 {
+			return Recurrence{}, fmt.Errorf("invalid recurrence %q: day of the month must be 1-31", rule)
+		}
+		recurrence.MonthDay = day
+	default:
+		return Recurrence{}, fmt.Errorf("invalid recurrence %q (%s)", rule, recurrenceHelp)
+	}
+
+	return recurrence, nil
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	if len(name) < 3 {
+		return 0, false
+	}
+	for i, short := range weekdayNames {
+		full := strings.ToLower(time.Weekday(i).String())
+		if name == short || strings.HasPrefix(full, name) {
+			return time.Weekday(i), true
+		}
+	}
+	return 0, false
+}
+
+// the rule in the form it is stored, e.g. "weekly:mon,wed"
+func (r Recurrence) String() string {
+	switch {
+	case len(r.Weekdays) > 0:
+		names := make([]string, len(r.Weekdays))
+		for i, day := range r.Weekdays {
+			names[i] = weekdayNames[day]
+		}
+		return string(r.Frequency) + ":" + strings.Join(names, ",")
+	case r.MonthDay > 0:
+		return fmt.Sprintf("%s:%d", r.Frequency, r.MonthDay)
+	default:
+		return string(r.Frequency)
+	}
+}
+
+// the first day after from's calendar day the rule falls on, as a due date
+func (r Recurrence) Next(from time.Time) time.Time {
+	year, month, day := from.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	switch r.Frequency {
+	case RecurWeekly:
+		if len(r.Weekdays) == 0 {
+			return today.AddDate(0, 0, 7)
+		}
+		for offset := 1; offset <= 7; offset++ {
+			next := today.AddDate(0, 0, offset)
+			for _, weekday := range r.Weekdays {
+				if next.Weekday() == weekday {
+					return next
+				}
+			}
+		}
+	case RecurMonthly:
+		target := r.MonthDay
+		if target == 0 {
+			target = day
+		}
+		if day < min(target, daysIn(year, month)) {
+			return time.Date(year, month, min(target, daysIn(year, month)), 0, 0, 0, 0, time.UTC)
+		}
+		nextMonth := time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+		nextYear, next := nextMonth.Year(), nextMonth.Month()
+		return time.Date(nextYear, next, min(target, daysIn(nextYear, next)), 0, 0, 0, 0, time.UTC)
+	}
+	return today.AddDate(0, 0, 1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// the due date of the task's next instance, counted from from. nil for tasks
+// without a valid rule
+func (t *Task) NextOccurrence(from time.Time) *time.Time {
+	if t.Recurrence == "" {
+		return nil
+	}
+	recurrence, err := ParseRecurrence(t.Recurrence)
+	if err != nil {
+		return nil
+	}
+	next := recurrence.Next(from)
+	return &next
+}
+
+// the pending copy of a recurring task that replaces it once it is completed
+// at now, nil for tasks that don't recur. the next due date counts from the
+// later of the due date and now, so a chore finished late isn't already
+// overdue again
+func (t *Task) NextInstance(now time.Time) *Task {
+	from := now
+	if t.DueDate != nil && t.DueDate.After(now) {
+		from = *t.DueDate
+	}
+	due := t.NextOccurrence(from)
+	if due == nil {
+		return nil
+	}
+
+	next := NewTask(t.Title)
+	next.Description = t.Description
+	next.Priority = t.Priority
+	next.Tags = append(make([]string, 0, len(t.Tags)), t.Tags...)
+	next.ProjectID = t.ProjectID
+	next.IsPinned = t.IsPinned
+	next.Color = t.Color
+	next.Recurrence = t.Recurrence
+	next.DueDate = due
+	return next
+}
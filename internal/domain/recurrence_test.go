@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"daily", "daily", false},
+		{" Daily ", "daily", false},
+		{"weekly", "weekly", false},
+		{"weekly:wed,mon", "weekly:mon,wed", false},
+		{"weekly: Monday, fri, mon", "weekly:mon,fri", false},
+		{"monthly", "monthly", false},
+		{"monthly:15", "monthly:15", false},
+		{"monthly:31", "monthly:31", false},
+		{"", "", true},
+		{"hourly", "", true},
+		{"daily:mon", "", true},
+		{"weekly:", "", true},
+		{"weekly:mon,xyz", "", true},
+		{"weekly:m", "", true},
+		{"monthly:0", "", true},
+		{"monthly:32", "", true},
+		{"monthly:first", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRecurrence(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRecurrenceNext(t *testing.T) {
+	day := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return d
+	}
+
+	tests := []struct {
+		rule string
+		from string
+		want string
+	}{
+		{"daily", "2026-03-10", "2026-03-11"},
+		{"daily", "2026-12-31", "2027-01-01"},
+		{"weekly", "2026-03-10", "2026-03-17"},
+		// 2026-03-09 is a monday
+		{"weekly:mon,wed", "2026-03-09", "2026-03-11"},
+		{"weekly:mon,wed", "2026-03-11", "2026-03-16"},
+		{"weekly:mon", "2026-03-09", "2026-03-16"},
+		{"monthly", "2026-03-10", "2026-04-10"},
+		{"monthly:15", "2026-03-10", "2026-03-15"},
+		{"monthly:15", "2026-03-15", "2026-04-15"},
+		{"monthly:31", "2026-03-31", "2026-04-30"},
+		{"monthly:31", "2026-04-30", "2026-05-31"},
+		{"monthly:30", "2026-01-31", "2026-02-28"},
+		{"monthly:29", "2028-02-01", "2028-02-29"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule+" from "+tt.from, func(t *testing.T) {
+			recurrence, err := ParseRecurrence(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, day(tt.want), recurrence.Next(day(tt.from)))
+		})
+	}
+}
+
+func TestTaskNextInstance(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.Local)
+
+	t.Run("one-off tasks have none", func(t *testing.T) {
+		task := NewTask("Once")
+		assert.Nil(t, task.NextOccurrence(now))
+		assert.Nil(t, task.NextInstance(now))
+	})
+
+	t.Run("copies the task as pending", func(t *testing.T) {
+		projectID := int64(3)
+		task := NewTask("Standup notes")
+		task.Description = "post in #team"
+		task.Priority = PriorityHigh
+		task.Tags = []string{"team"}
+		task.ProjectID = &projectID
+		task.Status = StatusCompleted
+		task.WaitingOn = "Alice"
+		task.IsLocked = true
+		task.Recurrence = "daily"
+
+		next := task.NextInstance(now)
+		require.NotNil(t, next)
+		assert.Equal(t, "Standup notes", next.Title)
+		assert.Equal(t, "post in #team", next.Description)
+		assert.Equal(t, PriorityHigh, next.Priority)
+		assert.Equal(t, []string{"team"}, next.Tags)
+		assert.Equal(t, &projectID, next.ProjectID)
+		assert.Equal(t, StatusPending, next.Status)
+		assert.Empty(t, next.WaitingOn)
+		assert.False(t, next.IsLocked)
+		assert.Equal(t, "daily", next.Recurrence)
+		assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), *next.DueDate)
+
+		next.Tags[0] = "changed"
+		assert.Equal(t, []string{"team"}, task.Tags, "tags are copied")
+	})
+
+	t.Run("counts from a due date still ahead", func(t *testing.T) {
+		due := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+		task := NewTask("Rent")
+		task.Recurrence = "monthly:20"
+		task.DueDate = &due
+		assert.Equal(t, time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC), *task.NextInstance(now).DueDate)
+	})
+
+	t.Run("counts from now once the due date has passed", func(t *testing.T) {
+		due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		task := NewTask("Water plants")
+		task.Recurrence = "weekly"
+		task.DueDate = &due
+		assert.Equal(t, time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC), *task.NextInstance(now).DueDate)
+	})
+
+	t.Run("invalid rules fail validation", func(t *testing.T) {
+		task := NewTask("Broken")
+		task.Recurrence = "every tuesday"
+		assert.Error(t, task.Validate())
+		assert.Nil(t, task.NextInstance(now))
+	})
+}
@@ -15,6 +15,9 @@ const (
 	SearchModeText  SearchMode = "text"
 	SearchModeRegex SearchMode = "regex"
 	SearchModeFuzzy SearchMode = "fuzzy"
+	// words or word starts looked up in the full-text index, plain text
+	// search when there is none. the history records it as text
+	SearchModeFTS SearchMode = "fts"
 )
 
 const (
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// completed tasks get this tag when their sprint is rolled over
+	ArchivedTag = "archived"
+
+	maxSprintCadenceDays = 365
+)
+
+// parses a sprint cadence such as "2 weeks", "10 days", "1w" or "biweekly"
+// into a number of days
+func ParseSprintCadence(s string) (int, error) {
+	value := strings.ToLower(strings.TrimSpace(s))
+	if value == "" {
+		return 0, fmt.Errorf("sprint cadence cannot be empty")
+	}
+
+	switch value {
+	case "weekly":
+		return 7, nil
+	case "biweekly", "fortnightly":
+		return 14, nil
+	}
+
+	split := strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	if split <= 0 {
+		return 0, fmt.Errorf("invalid sprint cadence %q (use e.g. \"2 weeks\" or \"10 days\")", s)
+	}
+
+	count, err := strconv.Atoi(value[:split])
+	if err != nil || count <= 0 {
+		return 0, fmt.Errorf("invalid sprint cadence %q: length must be a positive number", s)
+	}
+
+	var days int
+	switch strings.TrimSpace(value[split:]) {
+	case "d", "day", "days":
+		days = count
+	case "w", "week", "weeks":
+		days = count * 7
+	default:
+		return 0, fmt.Errorf("invalid sprint cadence %q: unit must be days or weeks", s)
+	}
+
+	if days > maxSprintCadenceDays {
+		return 0, fmt.Errorf("sprint cadence cannot exceed %d days", maxSprintCadenceDays)
+	}
+
+	return days, nil
+}
+
+// formats a cadence in days the way it is stored, e.g. "2 weeks" or "10 days"
+func FormatSprintCadence(days int) string {
+	if days%7 == 0 {
+		if days == 7 {
+			return "1 week"
+		}
+		return fmt.Sprintf("%d weeks", days/7)
+	}
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+func SprintTag(number int) string {
+	return fmt.Sprintf("sprint-%d", number)
+}
+
+func (p *Project) HasSprints() bool {
+	return p.SprintCadence != ""
+}
+
+// returns nil when sprints are off or the current sprint has no start date
+func (p *Project) SprintEndsAt() *time.Time {
+	if !p.HasSprints() || p.SprintStartedAt == nil {
+		return nil
+	}
+
+	days, err := ParseSprintCadence(p.SprintCadence)
+	if err != nil {
+		return nil
+	}
+
+	end := p.SprintStartedAt.AddDate(0, 0, days)
+	return &end
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSprintCadence(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"2 weeks", 14, false},
+		{"1 week", 7, false},
+		{"10 days", 10, false},
+		{"1w", 7, false},
+		{"3d", 3, false},
+		{"  2 Weeks ", 14, false},
+		{"weekly", 7, false},
+		{"biweekly", 14, false},
+		{"", 0, true},
+		{"weeks", 0, true},
+		{"0 days", 0, true},
+		{"2 months", 0, true},
+		{"60 weeks", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			days, err := ParseSprintCadence(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, days)
+		})
+	}
+}
+
+func TestFormatSprintCadence(t *testing.T) {
+	assert.Equal(t, "1 week", FormatSprintCadence(7))
+	assert.Equal(t, "2 weeks", FormatSprintCadence(14))
+	assert.Equal(t, "1 day", FormatSprintCadence(1))
+	assert.Equal(t, "10 days", FormatSprintCadence(10))
+}
+
+func TestProjectSprintEndsAt(t *testing.T) {
+	project := NewProject("Backend")
+	assert.Nil(t, project.SprintEndsAt())
+
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	project.SprintCadence = "2 weeks"
+	project.SprintStartedAt = &start
+
+	end := project.SprintEndsAt()
+	require.NotNil(t, end)
+	assert.Equal(t, time.Date(2026, 3, 16, 9, 0, 0, 0, time.UTC), *end)
+
+	project.SprintCadence = "fortnight"
+	assert.Error(t, project.Validate())
+}
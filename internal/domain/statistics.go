@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"time"
 )
 
@@ -75,6 +76,147 @@ type ProjectTaskCount struct {
 	Icon        string `json:"icon,omitempty"`
 }
 
+type TimeToDoneStats struct {
+	Count   int           `json:"count"`
+	Average time.Duration `json:"average"`
+	Median  time.Duration `json:"median"`
+}
+
+func NewTimeToDoneStats(durations []time.Duration) *TimeToDoneStats {
+	stats := &TimeToDoneStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	stats.Average = total / time.Duration(len(sorted))
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.Median = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		stats.Median = sorted[mid]
+	}
+
+	return stats
+}
+
+// how cancelled tasks count in a completion percentage
+type CancelledTreatment string
+
+const (
+	// cancelled tasks are left out of the total, so dropped work doesn't drag
+	// the percentage down
+	CancelledExclude CancelledTreatment = "exclude"
+	// cancelled tasks count as done
+	CancelledAsDone CancelledTreatment = "done"
+	// cancelled tasks count as not done, completed/total
+	CancelledAsOpen CancelledTreatment = "open"
+)
+
+var CancelledTreatments = []CancelledTreatment{CancelledAsOpen, CancelledExclude, CancelledAsDone}
+
+// ParseCancelledTreatment reads the cancelled_completion setting, blank is
+// the default of counting cancelled tasks as open
+func ParseCancelledTreatment(value string) (CancelledTreatment, error) {
+	if value == "" {
+		return CancelledAsOpen, nil
+	}
+	treatment := CancelledTreatment(value)
+	if !slices.Contains(CancelledTreatments, treatment) {
+		return "", fmt.Errorf("invalid cancelled treatment: %s (use open, exclude or done)", value)
+	}
+	return treatment, nil
+}
+
+// CompletionPercent is completed out of total as a percentage, with the
+// cancelled tasks among total counted the way treatment says. 0 when nothing
+// is left to count
+func CompletionPercent(completed, cancelled, total int, treatment CancelledTreatment) float64 {
+	switch treatment {
+	case CancelledExclude:
+		total -= cancelled
+	case CancelledAsDone:
+		completed += cancelled
+	}
+	if total <= 0 {
+		return 0.0
+	}
+	return (float64(completed) / float64(total)) * 100.0
+}
+
+// counts over the tasks matching a filter
+type TaskStats struct {
+	Total      int              `json:"total"`
+	ByStatus   map[Status]int   `json:"by_status"`
+	ByPriority map[Priority]int `json:"by_priority"`
+	// open tasks past their due day and grace days
+	Overdue int `json:"overdue"`
+	// how long the pending and in progress tasks have existed on average, 0
+	// when there are none
+	AverageOpenAge time.Duration `json:"average_open_age"`
+}
+
+func NewTaskStats() TaskStats {
+	return TaskStats{ByStatus: make(map[Status]int), ByPriority: make(map[Priority]int)}
+}
+
+func (ts TaskStats) OpenTasks() int {
+	return ts.ByStatus[StatusPending] + ts.ByStatus[StatusInProgress]
+}
+
+func (ts TaskStats) CompletionRateFor(treatment CancelledTreatment) float64 {
+	return CompletionPercent(ts.ByStatus[StatusCompleted], ts.ByStatus[StatusCancelled], ts.Total, treatment)
+}
+
+type TagCount struct {
+	Tag   string `db:"tag" json:"tag"`
+	Count int    `db:"count" json:"count"`
+}
+
+// when a task was created and, once completed or cancelled, closed
+type TaskSpan struct {
+	CreatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+type BurndownPoint struct {
+	Date time.Time `json:"date"`
+	Open int       `json:"open"`
+}
+
+// the open task count at the end of each day from the day of from through the
+// day of to, in from's location
+func NewBurndown(spans []TaskSpan, from, to time.Time) []BurndownPoint {
+	loc := from.Location()
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+
+	var points []BurndownPoint
+	for ; !day.After(last); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+		open := 0
+		for _, span := range spans {
+			if !span.CreatedAt.Before(end) {
+				continue
+			}
+			if span.ClosedAt != nil && span.ClosedAt.Before(end) {
+				continue
+			}
+			open++
+		}
+		points = append(points, BurndownPoint{Date: day, Open: open})
+	}
+
+	return points
+}
+
 func (ps *ProjectStats) GetCompletionRate() float64 {
 	if ps.TotalTasks == 0 {
 		return 0.0
@@ -82,6 +224,10 @@ func (ps *ProjectStats) GetCompletionRate() float64 {
 	return (float64(ps.CompletedTasks) / float64(ps.TotalTasks)) * 100.0
 }
 
+func (ps *ProjectStats) CompletionRateFor(treatment CancelledTreatment) float64 {
+	return CompletionPercent(ps.CompletedTasks, ps.CancelledTasks, ps.TotalTasks, treatment)
+}
+
 func (ps *ProjectStats) GetActiveTasks() int {
 	return ps.PendingTasks + ps.InProgressTasks
 }
@@ -157,6 +303,10 @@ func (gs *GlobalStats) GetCompletionRate() float64 {
 	return (float64(gs.CompletedTasks) / float64(gs.TotalTasks)) * 100.0
 }
 
+func (gs *GlobalStats) CompletionRateFor(treatment CancelledTreatment) float64 {
+	return CompletionPercent(gs.CompletedTasks, gs.CancelledTasks, gs.TotalTasks, treatment)
+}
+
 func (gs *GlobalStats) GetActiveTasks() int {
 	return gs.PendingTasks + gs.InProgressTasks
 }
@@ -51,6 +51,52 @@ func TestProjectStats_GetCompletionRate(t *testing.T) {
 	}
 }
 
+func TestCompletionPercent(t *testing.T) {
+	// 10 tasks, 4 completed and 2 cancelled
+	tests := []struct {
+		treatment CancelledTreatment
+		want      float64
+	}{
+		{CancelledAsOpen, 40.0},
+		{CancelledExclude, 50.0},
+		{CancelledAsDone, 60.0},
+		{"", 40.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.treatment), func(t *testing.T) {
+			if got := CompletionPercent(4, 2, 10, tt.treatment); got != tt.want {
+				t.Errorf("CompletionPercent() = %v, want %v", got, tt.want)
+			}
+			ps := &ProjectStats{TotalTasks: 10, CompletedTasks: 4, CancelledTasks: 2}
+			if got := ps.CompletionRateFor(tt.treatment); got != tt.want {
+				t.Errorf("CompletionRateFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := CompletionPercent(0, 3, 3, CancelledExclude); got != 0 {
+		t.Errorf("all cancelled and excluded = %v, want 0", got)
+	}
+	if got := CompletionPercent(0, 3, 3, CancelledAsDone); got != 100 {
+		t.Errorf("all cancelled counted as done = %v, want 100", got)
+	}
+}
+
+func TestParseCancelledTreatment(t *testing.T) {
+	for _, value := range []string{"", "open", "exclude", "done"} {
+		if _, err := ParseCancelledTreatment(value); err != nil {
+			t.Errorf("ParseCancelledTreatment(%q) error = %v", value, err)
+		}
+	}
+	if got, _ := ParseCancelledTreatment(""); got != CancelledAsOpen {
+		t.Errorf("blank = %v, want %v", got, CancelledAsOpen)
+	}
+	if _, err := ParseCancelledTreatment("ignore"); err == nil {
+		t.Error("expected an error for an unknown treatment")
+	}
+}
+
 func TestProjectStats_GetActiveTasks(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -391,3 +437,64 @@ func TestProjectStats_GetHealthStatusByScore(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTimeToDoneStats(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		average   time.Duration
+		median    time.Duration
+	}{
+		{"none", nil, 0, 0},
+		{"odd count", []time.Duration{3 * time.Hour, time.Hour, 8 * time.Hour}, 4 * time.Hour, 3 * time.Hour},
+		{"even count", []time.Duration{time.Hour, 4 * time.Hour, 2 * time.Hour, 9 * time.Hour}, 4 * time.Hour, 3 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := NewTimeToDoneStats(tt.durations)
+			if stats.Count != len(tt.durations) {
+				t.Errorf("Count = %d, want %d", stats.Count, len(tt.durations))
+			}
+			if stats.Average != tt.average {
+				t.Errorf("Average = %v, want %v", stats.Average, tt.average)
+			}
+			if stats.Median != tt.median {
+				t.Errorf("Median = %v, want %v", stats.Median, tt.median)
+			}
+		})
+	}
+}
+
+func TestNewBurndown(t *testing.T) {
+	day := func(d, hour int) time.Time {
+		return time.Date(2025, time.March, d, hour, 0, 0, 0, time.UTC)
+	}
+	closed := func(d, hour int) *time.Time {
+		c := day(d, hour)
+		return &c
+	}
+
+	spans := []TaskSpan{
+		{CreatedAt: day(1, 9)},
+		{CreatedAt: day(1, 10), ClosedAt: closed(2, 15)},
+		{CreatedAt: day(2, 11), ClosedAt: closed(2, 12)},
+		{CreatedAt: day(3, 8), ClosedAt: closed(4, 0)},
+		{CreatedAt: day(9, 8)},
+	}
+
+	points := NewBurndown(spans, day(1, 18), day(4, 7))
+
+	want := []int{2, 1, 2, 1}
+	if len(points) != len(want) {
+		t.Fatalf("got %d points, want %d", len(points), len(want))
+	}
+	for i, p := range points {
+		if !p.Date.Equal(day(1+i, 0)) {
+			t.Errorf("points[%d].Date = %v, want %v", i, p.Date, day(1+i, 0))
+		}
+		if p.Open != want[i] {
+			t.Errorf("points[%d].Open = %d, want %d", i, p.Open, want[i])
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// allowed target statuses for each status
+type StatusTransitions map[Status][]Status
+
+// completed and cancelled are terminal: they can only be reopened, never
+// swapped for one another
+func DefaultStatusTransitions() StatusTransitions {
+	return StatusTransitions{
+		StatusPending:    {StatusInProgress, StatusCompleted, StatusCancelled},
+		StatusInProgress: {StatusPending, StatusCompleted, StatusCancelled},
+		StatusCompleted:  {StatusPending, StatusInProgress},
+		StatusCancelled:  {StatusPending, StatusInProgress},
+	}
+}
+
+// builds a ruleset from config, statuses not listed keep their default rules
+func ParseStatusTransitions(raw map[string][]string) (StatusTransitions, error) {
+	transitions := DefaultStatusTransitions()
+
+	for from, targets := range raw {
+		fromStatus := Status(strings.ToLower(strings.TrimSpace(from)))
+		if !isValidStatus(fromStatus) {
+			return nil, fmt.Errorf("invalid status in transition rules: %s", from)
+		}
+
+		allowed := make([]Status, 0, len(targets))
+		for _, target := range targets {
+			toStatus := Status(strings.ToLower(strings.TrimSpace(target)))
+			if !isValidStatus(toStatus) {
+				return nil, fmt.Errorf("invalid status in transition rules: %s", target)
+			}
+			allowed = append(allowed, toStatus)
+		}
+		transitions[fromStatus] = allowed
+	}
+
+	return transitions, nil
+}
+
+func (s Status) IsTerminal() bool {
+	return s == StatusCompleted || s == StatusCancelled
+}
+
+// returns nil if the change is allowed, otherwise an error explaining why not.
+// a nil ruleset allows everything
+func (st StatusTransitions) Validate(from, to Status) error {
+	if st == nil || from == "" || from == to {
+		return nil
+	}
+
+	allowed := st[from]
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+
+	if len(allowed) == 0 {
+		return fmt.Errorf("cannot change status from %s to %s: %s is a final state", from, to, from)
+	}
+
+	names := make([]string, len(allowed))
+	for i, status := range allowed {
+		names[i] = string(status)
+	}
+
+	if from.IsTerminal() {
+		return fmt.Errorf("cannot change status from %s to %s: %s is a terminal state, reopen the task first (allowed: %s)", from, to, from, strings.Join(names, ", "))
+	}
+
+	return fmt.Errorf("cannot change status from %s to %s (allowed: %s)", from, to, strings.Join(names, ", "))
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusTransitionsValidate(t *testing.T) {
+	transitions := DefaultStatusTransitions()
+
+	tests := []struct {
+		name    string
+		from    Status
+		to      Status
+		wantErr bool
+		errMsg  string
+	}{
+		{"start work", StatusPending, StatusInProgress, false, ""},
+		{"complete", StatusInProgress, StatusCompleted, false, ""},
+		{"reopen completed", StatusCompleted, StatusPending, false, ""},
+		{"reopen cancelled", StatusCancelled, StatusInProgress, false, ""},
+		{"unchanged", StatusCompleted, StatusCompleted, false, ""},
+		{"no previous status", "", StatusCompleted, false, ""},
+		{"completed to cancelled", StatusCompleted, StatusCancelled, true, "terminal state"},
+		{"cancelled to completed", StatusCancelled, StatusCompleted, true, "reopen the task first"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := transitions.Validate(tt.from, tt.to)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStatusTransitionsValidate_NilAllowsEverything(t *testing.T) {
+	var transitions StatusTransitions
+	assert.NoError(t, transitions.Validate(StatusCancelled, StatusCompleted))
+}
+
+func TestParseStatusTransitions(t *testing.T) {
+	t.Run("overrides only listed statuses", func(t *testing.T) {
+		transitions, err := ParseStatusTransitions(map[string][]string{
+			"Completed": {"pending"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []Status{StatusPending}, transitions[StatusCompleted])
+		assert.Equal(t, DefaultStatusTransitions()[StatusCancelled], transitions[StatusCancelled])
+		assert.Error(t, transitions.Validate(StatusCompleted, StatusInProgress))
+	})
+
+	t.Run("empty list makes a status final", func(t *testing.T) {
+		transitions, err := ParseStatusTransitions(map[string][]string{
+			"cancelled": {},
+		})
+		require.NoError(t, err)
+
+		err = transitions.Validate(StatusCancelled, StatusPending)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "final state")
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		_, err := ParseStatusTransitions(map[string][]string{
+			"pending": {"done"},
+		})
+		assert.Error(t, err)
+
+		_, err = ParseStatusTransitions(map[string][]string{
+			"blocked": {"pending"},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -2,8 +2,13 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // task priority
@@ -37,8 +42,35 @@ type Task struct {
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
 	DueDate     *time.Time `db:"due_date" json:"due_date,omitempty"`
+	Position    int        `db:"position" json:"position,omitempty"`
+	CompletedAt *time.Time `db:"completed_at" json:"completed_at,omitempty"`
+	IsPinned    bool       `db:"is_pinned" json:"is_pinned,omitempty"`
+	// hidden from default listings until this time
+	SnoozedUntil *time.Time `db:"snoozed_until" json:"snoozed_until,omitempty"`
+	// the person or outside party the task is waiting for, as opposed to
+	// another task. empty when the task isn't waiting
+	WaitingOn string `db:"waiting_on" json:"waiting_on,omitempty"`
+	// locked tasks can't be edited or deleted until they are unlocked
+	IsLocked bool `db:"is_locked" json:"is_locked,omitempty"`
+	// a color name that overrides the project color for the task's row,
+	// empty for none
+	Color string `db:"color" json:"color,omitempty"`
+	// a rule like "daily" or "weekly:mon,wed", empty for one-off tasks.
+	// completing a recurring task creates its next instance
+	Recurrence string `db:"recurrence" json:"recurrence,omitempty"`
+	// IDs of the tasks this one waits for, changed with AddDependency and
+	// RemoveDependency rather than Update
+	DependsOn []int64 `db:"-" json:"depends_on,omitempty"`
+	// how many of those are neither completed nor cancelled
+	OpenBlockers int `db:"-" json:"-"`
+	// numbered within the project the task was created in, 0 without one.
+	// the number and its project stay with the task when it moves
+	ProjectTaskNumber int    `db:"project_task_number" json:"project_task_number,omitempty"`
+	NumberProjectID   *int64 `db:"number_project_id" json:"-"`
 
 	ProjectName string `db:"-" json:"project_name,omitempty"`
+	// the key prefix of the project that numbered the task
+	NumberProjectKey string `db:"-" json:"-"`
 }
 
 func (t *Task) Validate() error {
@@ -50,6 +82,10 @@ func (t *Task) Validate() error {
 		return errors.New("task title cannot exceed 200 characters")
 	}
 
+	if len(t.WaitingOn) > 100 {
+		return errors.New("waiting on cannot exceed 100 characters")
+	}
+
 	if len(t.Description) > 1000 {
 		return errors.New("task description cannot exceed 1000 characters")
 	}
@@ -62,9 +98,81 @@ func (t *Task) Validate() error {
 		return errors.New("invalid status: must be pending, in_progress, completed, or cancelled")
 	}
 
+	if t.Color != "" && !isValidColor(t.Color) {
+		return errors.New("invalid color: must be a valid terminal color name")
+	}
+
+	if t.Recurrence != "" {
+		if _, err := ParseRecurrence(t.Recurrence); err != nil {
+			return err
+		}
+	}
+
+	if t.DueDate != nil {
+		if err := ValidateDueDate(*t.DueDate); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// how much a match in each field counts when ranking search results, a title
+// match beats a tag or project match which beats a description match
+const (
+	titleSearchWeight       = 1.0
+	tagSearchWeight         = 0.9
+	descriptionSearchWeight = 0.8
+)
+
+// SearchScore ranks the task for a fuzzy search. score rates one field on a
+// 0-100 scale, the result is the best field score scaled by its weight
+func (t *Task) SearchScore(score func(text string) int) int {
+	fields := []struct {
+		text   string
+		weight float64
+	}{
+		{t.Title, titleSearchWeight},
+		{strings.Join(t.Tags, " "), tagSearchWeight},
+		{t.ProjectName, tagSearchWeight},
+		{t.Description, descriptionSearchWeight},
+	}
+
+	best := 0
+	for _, field := range fields {
+		if field.text == "" {
+			continue
+		}
+		if weighted := int(math.Round(float64(score(field.text)) * field.weight)); weighted > best {
+			best = weighted
+		}
+	}
+	return best
+}
+
+// how long a completed task took from creation to its latest completion. ok
+// is false for tasks that are not done or have no completion time
+func (t *Task) TimeToDone() (d time.Duration, ok bool) {
+	if t.Status != StatusCompleted || t.CompletedAt == nil {
+		return 0, false
+	}
+	return max(t.CompletedAt.Sub(t.CreatedAt), 0), true
+}
+
+// whether the task is still snoozed at now
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return t.SnoozedUntil != nil && t.SnoozedUntil.After(now)
+}
+
+// whether the task waits for a dependency that isn't finished yet
+func (t *Task) IsBlocked() bool {
+	return t.OpenBlockers > 0
+}
+
+// the tag that marks a task as blocked by something outside taskflow, a
+// dependency on another task blocks it without the tag
+const BlockedTag = "blocked"
+
 // create a new task
 func NewTask(title string) *Task {
 	now := time.Now()
@@ -96,8 +204,121 @@ func isValidStatus(s Status) bool {
 	}
 }
 
-// parses a date string in various formats
+func (p Priority) IsValid() bool {
+	return isValidPriority(p)
+}
+
+func (s Status) IsValid() bool {
+	return isValidStatus(s)
+}
+
+// every priority, lowest first
+var Priorities = []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// every status, in workflow order
+var Statuses = []Status{StatusPending, StatusInProgress, StatusCompleted, StatusCancelled}
+
+// ParsePriority turns user input like "High" into a Priority. empty and
+// unknown values are errors listing the valid ones
+func ParsePriority(value string) (Priority, error) {
+	priority := Priority(strings.ToLower(strings.TrimSpace(value)))
+	if !priority.IsValid() {
+		return "", invalidValueError("priority", value, Priorities)
+	}
+	return priority, nil
+}
+
+// ParseStatus turns user input like "In_Progress" into a Status. empty and
+// unknown values are errors listing the valid ones
+func ParseStatus(value string) (Status, error) {
+	status := Status(strings.ToLower(strings.TrimSpace(value)))
+	if !status.IsValid() {
+		return "", invalidValueError("status", value, Statuses)
+	}
+	return status, nil
+}
+
+func invalidValueError[T ~string](kind, value string, valid []T) error {
+	options := make([]string, len(valid))
+	for i, v := range valid {
+		options[i] = string(v)
+	}
+	list := strings.Join(options[:len(options)-1], ", ") + ", or " + options[len(options)-1]
+
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s cannot be empty (must be %s)", kind, list)
+	}
+	return fmt.Errorf("invalid %s: %s (must be %s)", kind, value, list)
+}
+
+const maxNameLength = 100
+
+// checks a project, view or template name: not blank, at most 100
+// characters and no control characters such as newlines or tabs
+func ValidateName(kind, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%s name cannot be empty", kind)
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("%s name cannot exceed %d characters", kind, maxNameLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return fmt.Errorf("%s name cannot contain control characters", kind)
+	}
+	return nil
+}
+
+// due dates outside these years are typos or broken imports
+const (
+	minDueYear = 1900
+	maxDueYear = 2999
+)
+
+// due dates are whole days, stored as midnight UTC of the calendar day they
+// fall on so every entry point compares the same way against "due today"
+func NormalizeDueDate(due *time.Time) *time.Time {
+	if due == nil {
+		return nil
+	}
+	year, month, day := due.Date()
+	normalized := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &normalized
+}
+
+// due dates before this are overdue on now's day. graceDays gives a task that
+// many extra days after its due day before it is flagged
+func OverdueBefore(now time.Time, graceDays int) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day-max(graceDays, 0), 0, 0, 0, 0, time.UTC)
+}
+
+// calendar days from now's day to the due day, negative once it has passed
+func DueInDays(due, now time.Time) int {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return int(NormalizeDueDate(&due).Sub(today).Hours() / 24)
+}
+
+func IsOverdue(due *time.Time, now time.Time, graceDays int) bool {
+	return due != nil && NormalizeDueDate(due).Before(OverdueBefore(now, graceDays))
+}
+
+func ValidateDueDate(due time.Time) error {
+	if year := due.Year(); year < minDueYear || year > maxDueYear {
+		return fmt.Errorf("due date %s is out of range (years %d-%d)", due.Format("2006-01-02"), minDueYear, maxDueYear)
+	}
+	return nil
+}
+
+// parses a due date, either absolute (YYYY-MM-DD, YYYY/MM/DD, DD-MM-YYYY,
+// DD/MM/YYYY) or relative to today, see ParseDueDateAt
 func ParseDueDate(dateStr string) (*time.Time, error) {
+	return ParseDueDateAt(dateStr, time.Now())
+}
+
+// ParseDueDateAt parses a due date with now as today: one of the absolute
+// formats or a date ParseRelativeDate takes
+func ParseDueDateAt(dateStr string, now time.Time) (*time.Time, error) {
 	formats := []string{
 		"2006-01-02",
 		"2006/01/02",
@@ -105,11 +326,76 @@ func ParseDueDate(dateStr string) (*time.Time, error) {
 		"02/01/2006",
 	}
 
+	value := strings.TrimSpace(dateStr)
 	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
+		if t, err := time.Parse(format, value); err == nil {
 			return &t, nil
 		}
 	}
 
-	return nil, errors.New("unable to parse date: " + dateStr)
+	due, ok, err := ParseRelativeDate(value, now)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("unable to parse date %q, use YYYY-MM-DD, today, tomorrow, yesterday, +3d, -2w, +1M, +1y, a weekday, eod or eow", dateStr)
+	}
+	return &due, nil
+}
+
+var relativeDateOffset = regexp.MustCompile(`^([+-]?)(\d+)([dwMy])$`)
+
+// ParseRelativeDate parses a date relative to now's day: today or eod,
+// tomorrow, yesterday, an offset of days, weeks, months or years (+3d, -2w,
+// 1M, +1y, unsigned counts forward), a weekday name (optionally with "next")
+// for the next such day, never today, and eow for the coming Sunday, the
+// last day of the week. the date is midnight in now's location. ok is false
+// when value is none of these
+func ParseRelativeDate(value string, now time.Time) (date time.Time, ok bool, err error) {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+
+	value = strings.TrimSpace(value)
+	if matches := relativeDateOffset.FindStringSubmatch(value); matches != nil {
+		// one unit's worth of days, to keep the offset inside the due years
+		unitDays := map[string]int{"d": 1, "w": 7, "M": 31, "y": 366}[matches[3]]
+		n, err := strconv.Atoi(matches[2])
+		if err != nil || n > 366*(maxDueYear-minDueYear)/unitDays {
+			return time.Time{}, false, fmt.Errorf("date offset %s is too large", value)
+		}
+		if matches[1] == "-" {
+			n = -n
+		}
+		switch matches[3] {
+		case "w":
+			return today.AddDate(0, 0, n*7), true, nil
+		case "M":
+			return today.AddDate(0, n, 0), true, nil
+		case "y":
+			return today.AddDate(n, 0, 0), true, nil
+		}
+		return today.AddDate(0, 0, n), true, nil
+	}
+
+	value = strings.ToLower(value)
+	switch value {
+	case "today", "eod":
+		return today, true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	case "eow":
+		return today.AddDate(0, 0, (7-int(today.Weekday()))%7), true, nil
+	}
+
+	if weekday, ok := parseWeekday(strings.TrimPrefix(value, "next ")); ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), true, nil
+	}
+
+	return time.Time{}, false, nil
 }
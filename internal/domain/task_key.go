@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ProjectKeyPrefix is the prefix of a project's task keys, its name in upper
+// case with every run of other characters than letters and digits turned
+// into one "-": "Mobile App" gives "MOBILE-APP"
+func ProjectKeyPrefix(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToUpper(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return sb.String()
+}
+
+// UniqueKeyPrefix is base, or base with "-2", "-3" and so on appended when
+// taken says it is used by another project already
+func UniqueKeyPrefix(base string, taken func(string) bool) string {
+	prefix := base
+	for n := 2; taken(prefix); n++ {
+		prefix = base + "-" + strconv.Itoa(n)
+	}
+	return prefix
+}
+
+// Key is the task's per-project key like "BACKEND-12", "" when it has no
+// number or the project that numbered it is gone
+func (t *Task) Key() string {
+	if t.ProjectTaskNumber <= 0 || t.NumberProjectKey == "" {
+		return ""
+	}
+	return t.NumberProjectKey + "-" + strconv.Itoa(t.ProjectTaskNumber)
+}
+
+// ParseTaskKey splits a key like "backend-12" into its upper-cased project
+// prefix and number. ok is false for anything else, plain IDs included
+func ParseTaskKey(key string) (prefix string, number int, ok bool) {
+	key = strings.TrimSpace(key)
+	idx := strings.LastIndex(key, "-")
+	if idx <= 0 {
+		return "", 0, false
+	}
+
+	number, err := strconv.Atoi(key[idx+1:])
+	if err != nil || number <= 0 {
+		return "", 0, false
+	}
+
+	prefix = ProjectKeyPrefix(key[:idx])
+	if prefix != strings.ToUpper(key[:idx]) {
+		return "", 0, false
+	}
+	return prefix, number, true
+}
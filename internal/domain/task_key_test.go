@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectKeyPrefix(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Backend", "BACKEND"},
+		{"Mobile App", "MOBILE-APP"},
+		{"  api / v2 ", "API-V2"},
+		{"🚀 Launch", "LAUNCH"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ProjectKeyPrefix(tt.name))
+		})
+	}
+}
+
+func TestParseTaskKey(t *testing.T) {
+	tests := []struct {
+		key        string
+		wantPrefix string
+		wantNumber int
+		wantOK     bool
+	}{
+		{"BACKEND-12", "BACKEND", 12, true},
+		{"mobile-app-3", "MOBILE-APP", 3, true},
+		{"12", "", 0, false},
+		{"-12", "", 0, false},
+		{"BACKEND-0", "", 0, false},
+		{"BACKEND-x", "", 0, false},
+		{"back end-4", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			prefix, number, ok := ParseTaskKey(tt.key)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantPrefix, prefix)
+			assert.Equal(t, tt.wantNumber, number)
+		})
+	}
+}
+
+func TestTaskKey(t *testing.T) {
+	task := NewTask("Fix login")
+	assert.Empty(t, task.Key())
+
+	task.ProjectTaskNumber = 12
+	task.NumberProjectKey = "MOBILE-APP"
+	assert.Equal(t, "MOBILE-APP-12", task.Key())
+
+	// the numbering project was deleted
+	task.NumberProjectKey = ""
+	assert.Empty(t, task.Key())
+}
+
+func TestUniqueKeyPrefix(t *testing.T) {
+	taken := map[string]bool{"API": true, "API-2": true}
+	isTaken := func(prefix string) bool { return taken[prefix] }
+	assert.Equal(t, "WEB", UniqueKeyPrefix("WEB", isTaken))
+	assert.Equal(t, "API-3", UniqueKeyPrefix("API", isTaken))
+}
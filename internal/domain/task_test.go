@@ -157,6 +157,99 @@ func TestIsValidStatus(t *testing.T) {
 	}
 }
 
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Priority
+		wantErr string
+	}{
+		{"low", PriorityLow, ""},
+		{"High", PriorityHigh, ""},
+		{" URGENT ", PriorityUrgent, ""},
+		{"hgih", "", "invalid priority: hgih (must be low, medium, high, or urgent)"},
+		{"", "", "priority cannot be empty (must be low, medium, high, or urgent)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePriority(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"plain", "Backend API", ""},
+		{"blank", "   ", "view name cannot be empty"},
+		{"too long", strings.Repeat("a", 101), "view name cannot exceed 100 characters"},
+		{"newline", "Back\nend", "view name cannot contain control characters"},
+		{"tab", "Back\tend", "view name cannot contain control characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName("view", tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	color, err := ParseColor(" Bright-Red ")
+	assert.NoError(t, err)
+	assert.Equal(t, "bright-red", color)
+
+	color, err = ParseColor("")
+	assert.NoError(t, err)
+	assert.Empty(t, color)
+
+	_, err = ParseColor("crimson")
+	assert.ErrorContains(t, err, "invalid color: crimson")
+
+	task := NewTask("Flagged")
+	task.Color = "crimson"
+	assert.Error(t, task.Validate())
+}
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Status
+		wantErr string
+	}{
+		{"pending", StatusPending, ""},
+		{"In_Progress", StatusInProgress, ""},
+		{"done", "", "invalid status: done (must be pending, in_progress, completed, or cancelled)"},
+		{"  ", "", "status cannot be empty (must be pending, in_progress, completed, or cancelled)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseStatus(tt.input)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestTaskWithDueDate(t *testing.T) {
 	task := NewTask("Task with due date")
 	dueDate := time.Now().Add(24 * time.Hour)
@@ -166,3 +259,125 @@ func TestTaskWithDueDate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, task.DueDate)
 }
+
+func TestNormalizeDueDate(t *testing.T) {
+	assert.Nil(t, NormalizeDueDate(nil))
+
+	est := time.FixedZone("EST", -5*60*60)
+	due := time.Date(2025, 3, 14, 22, 30, 0, 0, est)
+	assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), *NormalizeDueDate(&due))
+
+	task := NewTask("Out of range")
+	for _, year := range []int{1, 1899, 3000, 20250} {
+		due := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		task.DueDate = &due
+		err := task.Validate()
+		assert.Error(t, err, "year %d", year)
+		assert.Contains(t, err.Error(), "out of range")
+	}
+}
+
+func TestParseDueDateAt(t *testing.T) {
+	// a Friday afternoon
+	now := time.Date(2026, 3, 13, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "2026-04-01", want: "2026-04-01"},
+		{input: "2026/04/01", want: "2026-04-01"},
+		{input: "01-04-2026", want: "2026-04-01"},
+		{input: " 01/04/2026 ", want: "2026-04-01"},
+		{input: "today", want: "2026-03-13"},
+		{input: "EOD", want: "2026-03-13"},
+		{input: "tomorrow", want: "2026-03-14"},
+		{input: "yesterday", want: "2026-03-12"},
+		{input: "+0d", want: "2026-03-13"},
+		{input: "+3d", want: "2026-03-16"},
+		{input: "+20d", want: "2026-04-02"},
+		{input: "+2w", want: "2026-03-27"},
+		{input: "3d", want: "2026-03-16"},
+		{input: "-1d", want: "2026-03-12"},
+		{input: "-2w", want: "2026-02-27"},
+		{input: "+1M", want: "2026-04-13"},
+		{input: "+1y", want: "2027-03-13"},
+		{input: "saturday", want: "2026-03-14"},
+		{input: "monday", want: "2026-03-16"},
+		{input: "Thu", want: "2026-03-19"},
+		{input: "friday", want: "2026-03-20"},
+		{input: "next friday", want: "2026-03-20"},
+		{input: "eow", want: "2026-03-15"},
+		{input: "", wantErr: true},
+		{input: "someday", wantErr: true},
+		{input: "+3", wantErr: true},
+		{input: "+3m", wantErr: true},
+		{input: "+99999999999999999999d", wantErr: true},
+		{input: "+9999y", wantErr: true},
+		{input: "fr", wantErr: true},
+		{input: "2026-02-30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDueDateAt(tt.input, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			if assert.NotNil(t, got) {
+				assert.Equal(t, tt.want, got.Format("2006-01-02"))
+			}
+		})
+	}
+
+	t.Run("end of week on a Sunday is the same day", func(t *testing.T) {
+		got, err := ParseDueDateAt("eow", time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, "2026-03-15", got.Format("2006-01-02"))
+	})
+
+	t.Run("relative dates are days in now's location", func(t *testing.T) {
+		tokyo := time.FixedZone("JST", 9*60*60)
+		got, err := ParseDueDateAt("tomorrow", time.Date(2026, 3, 13, 23, 30, 0, 0, tokyo))
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), *NormalizeDueDate(got))
+	})
+
+	t.Run("the error names the input and the accepted forms", func(t *testing.T) {
+		_, err := ParseDueDateAt("someday", now)
+		assert.ErrorContains(t, err, `"someday"`)
+		assert.ErrorContains(t, err, "YYYY-MM-DD")
+	})
+}
+
+func TestIsOverdue(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 0, 0, 0, time.Local)
+	day := func(offset int) *time.Time {
+		due := time.Date(2025, 3, 14+offset, 0, 0, 0, 0, time.UTC)
+		return &due
+	}
+
+	tests := []struct {
+		name  string
+		due   *time.Time
+		grace int
+		want  bool
+	}{
+		{"no due date", nil, 0, false},
+		{"due today", day(0), 0, false},
+		{"due yesterday", day(-1), 0, true},
+		{"due yesterday with a day of grace", day(-1), 1, false},
+		{"due two days ago with a day of grace", day(-2), 1, true},
+		{"negative grace counts as none", day(-1), -3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsOverdue(tt.due, now, tt.grace))
+		})
+	}
+}
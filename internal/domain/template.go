@@ -12,6 +12,8 @@ type ProjectTemplate struct {
 	Description     string            `db:"description" json:"description"`
 	TaskDefinitions []TaskDefinition  `db:"task_definitions" json:"task_definitions"`
 	ProjectDefaults *ProjectDefaults  `db:"project_defaults" json:"project_defaults,omitempty"`
+	// goes up by one whenever the task definitions change
+	Version         int               `db:"version" json:"version"`
 	CreatedAt       time.Time         `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time         `db:"updated_at" json:"updated_at"`
 }
@@ -29,12 +31,8 @@ type ProjectDefaults struct {
 }
 
 func (t *ProjectTemplate) Validate() error {
-	if strings.TrimSpace(t.Name) == "" {
-		return errors.New("template name cannot be empty")
-	}
-
-	if len(t.Name) > 100 {
-		return errors.New("template name cannot exceed 100 characters")
+	if err := ValidateName("template", t.Name); err != nil {
+		return err
 	}
 
 	if len(t.Description) > 500 {
@@ -78,7 +76,7 @@ func (td *TaskDefinition) Validate() error {
 	}
 
 	if td.Priority != "" && !isValidTaskPriority(td.Priority) {
-		return errors.New("invalid priority: must be low, medium, high, or urgent")
+		return invalidValueError("priority", td.Priority, Priorities)
 	}
 
 	for _, tag := range td.Tags {
@@ -123,6 +121,40 @@ func NewTaskDefinition(title string) TaskDefinition {
 	}
 }
 
+// the task the definition describes. templates are edited by hand, so the
+// priority is parsed rather than trusted; an empty one keeps the default
+func (td *TaskDefinition) NewTask() (*Task, error) {
+	task := NewTask(td.Title)
+	task.Description = td.Description
+	task.Tags = td.Tags
+	if td.Priority != "" {
+		priority, err := ParsePriority(td.Priority)
+		if err != nil {
+			return nil, err
+		}
+		task.Priority = priority
+	}
+	return task, nil
+}
+
+// the task definitions no task in tasks has the title of, ignoring case and
+// surrounding spaces. tasks are matched on title only, so ones the user
+// created or edited are never treated as the template's
+func (t *ProjectTemplate) MissingTasks(tasks []*Task) []TaskDefinition {
+	titles := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		titles[strings.ToLower(strings.TrimSpace(task.Title))] = true
+	}
+
+	var missing []TaskDefinition
+	for _, taskDef := range t.TaskDefinitions {
+		if !titles[strings.ToLower(strings.TrimSpace(taskDef.Title))] {
+			missing = append(missing, taskDef)
+		}
+	}
+	return missing
+}
+
 func (t *ProjectTemplate) GetTaskCount() int {
 	return len(t.TaskDefinitions)
 }
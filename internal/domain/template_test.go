@@ -501,3 +501,31 @@ func TestIsValidTaskPriority(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectTemplate_MissingTasks(t *testing.T) {
+	template := NewTemplate("Release")
+	template.TaskDefinitions = []TaskDefinition{
+		NewTaskDefinition("Write changelog"),
+		NewTaskDefinition("Tag release"),
+		NewTaskDefinition("Announce"),
+	}
+
+	tasks := []*Task{
+		NewTask("  write CHANGELOG "),
+		NewTask("Announce on the blog"),
+		NewTask("Something of my own"),
+	}
+
+	missing := template.MissingTasks(tasks)
+	var titles []string
+	for _, taskDef := range missing {
+		titles = append(titles, taskDef.Title)
+	}
+	if strings.Join(titles, ", ") != "Tag release, Announce" {
+		t.Errorf("MissingTasks() = %v, want [Tag release Announce]", titles)
+	}
+
+	if missing := template.MissingTasks(append(tasks, NewTask("Tag release"), NewTask("announce"))); len(missing) != 0 {
+		t.Errorf("MissingTasks() = %v, want none once every title is there", missing)
+	}
+}
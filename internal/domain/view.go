@@ -35,12 +35,8 @@ type SavedView struct {
 }
 
 func (v *SavedView) Validate() error {
-	if strings.TrimSpace(v.Name) == "" {
-		return errors.New("view name cannot be empty")
-	}
-
-	if len(v.Name) > 100 {
-		return errors.New("view name cannot exceed 100 characters")
+	if err := ValidateName("view", v.Name); err != nil {
+		return err
 	}
 
 	if len(v.Description) > 500 {
@@ -53,6 +49,14 @@ func (v *SavedView) Validate() error {
 		}
 	}
 
+	if v.FilterConfig.Status != "" && !v.FilterConfig.Status.IsValid() {
+		return fmt.Errorf("invalid status filter: %s", v.FilterConfig.Status)
+	}
+
+	if v.FilterConfig.Priority != "" && !v.FilterConfig.Priority.IsValid() {
+		return fmt.Errorf("invalid priority filter: %s", v.FilterConfig.Priority)
+	}
+
 	return nil
 }
 
@@ -116,3 +120,32 @@ func (v *SavedView) GetFavoriteIndicator() string {
 	}
 	return ""
 }
+
+// smart lists are views named after the one tag they filter on, e.g. "#bug"
+const smartListPrefix = "#"
+
+func NewSmartList(tag string) *SavedView {
+	view := NewSavedView(SmartListName(tag))
+	view.Description = fmt.Sprintf("Tasks tagged '%s'", tag)
+	view.FilterConfig.Tags = []string{tag}
+	return view
+}
+
+func SmartListName(tag string) string {
+	return smartListPrefix + tag
+}
+
+// the tag a smart list filters on. ok is false for any other view, including
+// a smart list whose filters have since been changed
+func (v *SavedView) SmartListTag() (tag string, ok bool) {
+	tag, found := strings.CutPrefix(v.Name, smartListPrefix)
+	if !found || len(v.FilterConfig.Tags) != 1 || !strings.EqualFold(v.FilterConfig.Tags[0], tag) {
+		return "", false
+	}
+
+	f := v.FilterConfig
+	if f.Status != "" || f.Priority != "" || f.ProjectID != nil || f.SearchQuery != "" || f.DueDateFrom != nil || f.DueDateTo != nil {
+		return "", false
+	}
+	return v.FilterConfig.Tags[0], true
+}
@@ -288,3 +288,27 @@ func TestSavedViewGetFavoriteIndicator_Favorite(t *testing.T) {
 func contains(s, substr string) bool {
 	return len(s) > 0 && len(substr) > 0 && s != "" && substr != ""
 }
+
+func TestSavedViewSmartListTag(t *testing.T) {
+	if tag, ok := NewSmartList("bug").SmartListTag(); !ok || tag != "bug" {
+		t.Errorf("expected smart list tag %q, got %q (ok=%v)", "bug", tag, ok)
+	}
+
+	narrowed := NewSmartList("bug")
+	narrowed.FilterConfig.Status = StatusPending
+	if _, ok := narrowed.SmartListTag(); ok {
+		t.Error("expected a smart list with extra filters not to count as one")
+	}
+
+	plain := NewSavedView("bug")
+	plain.FilterConfig.Tags = []string{"bug"}
+	if _, ok := plain.SmartListTag(); ok {
+		t.Error("expected a view without the # prefix not to be a smart list")
+	}
+
+	retagged := NewSmartList("bug")
+	retagged.FilterConfig.Tags = []string{"feature"}
+	if _, ok := retagged.SmartListTag(); ok {
+		t.Error("expected a smart list filtering on another tag not to count as one")
+	}
+}
@@ -177,6 +177,7 @@ func (e *JSONExporter) convertTask(task *domain.Task) *TaskData {
 		Description: task.Description,
 		Priority:    string(task.Priority),
 		Status:      string(task.Status),
+		Project:     task.ProjectName,
 		Tags:        task.Tags,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
@@ -0,0 +1,185 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"task-management/internal/domain"
+)
+
+// one entry of an import file. Err is set, and Task nil, when the entry can't
+// be read as a task
+type TaskRow struct {
+	Row  int
+	Task *domain.Task
+	Err  error
+}
+
+// reads the tasks of a JSON file as export tasks writes it, an object with
+// a "tasks" array, or a bare array of the same entries. a file with neither
+// fails as a whole, an entry that doesn't fit a task only fails its row
+func ReadJSON(r io.Reader) ([]TaskRow, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		var file struct {
+			Tasks *[]json.RawMessage `json:"tasks"`
+		}
+		if err := json.Unmarshal(raw, &file); err != nil || file.Tasks == nil {
+			return nil, fmt.Errorf("invalid JSON, expected an object with a tasks array or an array of tasks")
+		}
+		entries = *file.Tasks
+	}
+
+	rows := make([]TaskRow, len(entries))
+	for i, entry := range entries {
+		rows[i].Row = i + 1
+		rows[i].Task, rows[i].Err = jsonTask(entry)
+	}
+	return rows, nil
+}
+
+func jsonTask(entry json.RawMessage) (*domain.Task, error) {
+	var data TaskData
+	if err := json.Unmarshal(entry, &data); err != nil {
+		return nil, fmt.Errorf("invalid task: %w", err)
+	}
+
+	task := &domain.Task{
+		Title:       data.Title,
+		Description: data.Description,
+		Status:      domain.Status(strings.ToLower(data.Status)),
+		Priority:    domain.Priority(strings.ToLower(data.Priority)),
+		ProjectName: data.Project,
+		Tags:        data.Tags,
+		CreatedAt:   data.CreatedAt,
+		UpdatedAt:   data.UpdatedAt,
+	}
+
+	if data.DueDate != nil && *data.DueDate != "" {
+		due, err := time.Parse("2006-01-02", *data.DueDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date %q, use YYYY-MM-DD", *data.DueDate)
+		}
+		task.DueDate = &due
+	}
+
+	return importedTask(task), nil
+}
+
+// reads CSV with a header row naming the columns, as export tasks writes it.
+// column names don't depend on case or order, unknown ones are ignored and
+// only Title is required. ID is ignored, imported tasks get new ones
+func ReadCSV(r io.Reader) ([]TaskRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty CSV file, expected a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("invalid CSV: the header has no Title column")
+	}
+
+	var rows []TaskRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		row := TaskRow{Row: len(rows) + 1}
+		row.Task, row.Err = csvTask(field)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func csvTask(field func(string) string) (*domain.Task, error) {
+	task := &domain.Task{
+		Title:       field("title"),
+		Description: field("description"),
+		Status:      domain.Status(strings.ToLower(field("status"))),
+		Priority:    domain.Priority(strings.ToLower(field("priority"))),
+		ProjectName: field("project"),
+	}
+
+	for _, tag := range strings.Split(field("tags"), ";") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			task.Tags = append(task.Tags, tag)
+		}
+	}
+
+	if value := field("due date"); value != "" {
+		due, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date %q, use YYYY-MM-DD", value)
+		}
+		task.DueDate = &due
+	}
+
+	for _, column := range []struct {
+		name   string
+		target *time.Time
+	}{{"created at", &task.CreatedAt}, {"updated at", &task.UpdatedAt}} {
+		value := field(column.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q, use YYYY-MM-DD HH:MM:SS", column.name, value)
+		}
+		*column.target = parsed
+	}
+
+	return importedTask(task), nil
+}
+
+// fills in the defaults of a new task and drops what only meant something in
+// the database the task was exported from. the project is kept by name only
+func importedTask(task *domain.Task) *domain.Task {
+	task.ID = 0
+	task.ProjectID = nil
+	task.Position = 0
+	task.DependsOn = nil
+	task.ProjectTaskNumber = 0
+	if task.Status == "" {
+		task.Status = domain.StatusPending
+	}
+	if task.Priority == "" {
+		task.Priority = domain.PriorityMedium
+	}
+	if task.Tags == nil {
+		task.Tags = make([]string, 0)
+	}
+	return task
+}
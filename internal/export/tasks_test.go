@@ -0,0 +1,141 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+	"task-management/internal/repository/sqlite"
+)
+
+// a database holding two tasks, the first in the Backend project
+func exportTestRepos(t *testing.T) (*sqlite.ProjectRepository, *sqlite.TaskRepository) {
+	db, err := sqlite.NewDB(sqlite.Config{Path: filepath.Join(t.TempDir(), "tasks.db")})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	ctx := context.Background()
+	projectRepo := sqlite.NewProjectRepository(db)
+	taskRepo := sqlite.NewTaskRepository(db)
+
+	backend := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+
+	due := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	first := domain.NewTask(`Fix login, then "logout"`)
+	first.Description = "Session, cookies"
+	first.Priority = domain.PriorityHigh
+	first.ProjectID = &backend.ID
+	first.Tags = []string{"bug", "auth"}
+	first.DueDate = &due
+	require.NoError(t, taskRepo.Create(ctx, first))
+
+	second := domain.NewTask("Line one\nline two")
+	second.Status = domain.StatusCompleted
+	second.Priority = domain.PriorityLow
+	require.NoError(t, taskRepo.Create(ctx, second))
+
+	return projectRepo, taskRepo
+}
+
+func TestReadCSV(t *testing.T) {
+	projectRepo, taskRepo := exportTestRepos(t)
+	var buf bytes.Buffer
+	require.NoError(t, NewCSVExporter(projectRepo, taskRepo).ExportTasksToCSV(context.Background(), &buf, repository.TaskFilter{SortBy: "id", SortOrder: "asc"}))
+	assert.Contains(t, buf.String(), `"Fix login, then ""logout"""`)
+
+	rows, err := ReadCSV(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	first := rows[0]
+	require.NoError(t, first.Err)
+	assert.Equal(t, 1, first.Row)
+	assert.Zero(t, first.Task.ID, "imported tasks get new IDs")
+	assert.Equal(t, `Fix login, then "logout"`, first.Task.Title)
+	assert.Equal(t, "Session, cookies", first.Task.Description)
+	assert.Equal(t, domain.PriorityHigh, first.Task.Priority)
+	assert.Equal(t, "Backend", first.Task.ProjectName)
+	assert.Equal(t, []string{"bug", "auth"}, first.Task.Tags)
+	require.NotNil(t, first.Task.DueDate)
+	assert.Equal(t, "2026-03-15", first.Task.DueDate.Format("2006-01-02"))
+	assert.Equal(t, "Line one\nline two", rows[1].Task.Title)
+	assert.Equal(t, domain.StatusCompleted, rows[1].Task.Status)
+	assert.Empty(t, rows[1].Task.Tags)
+	assert.Nil(t, rows[1].Task.DueDate)
+
+	t.Run("columns in any order, missing ones take defaults", func(t *testing.T) {
+		rows, err := ReadCSV(strings.NewReader("tags,title\nops;infra,Rotate keys\n,Second\n"))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "Rotate keys", rows[0].Task.Title)
+		assert.Equal(t, []string{"ops", "infra"}, rows[0].Task.Tags)
+		assert.Equal(t, domain.StatusPending, rows[0].Task.Status)
+		assert.Equal(t, domain.PriorityMedium, rows[0].Task.Priority)
+	})
+
+	t.Run("a bad date only fails its row", func(t *testing.T) {
+		rows, err := ReadCSV(strings.NewReader("Title,Due Date\nFine,2026-04-01\nBroken,next week\n"))
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.NoError(t, rows[0].Err)
+		assert.ErrorContains(t, rows[1].Err, "invalid due date")
+		assert.Nil(t, rows[1].Task)
+	})
+
+	t.Run("no title column", func(t *testing.T) {
+		_, err := ReadCSV(strings.NewReader("Name,Status\nx,pending\n"))
+		assert.ErrorContains(t, err, "no Title column")
+
+		_, err = ReadCSV(strings.NewReader(""))
+		assert.Error(t, err)
+	})
+}
+
+func TestReadJSON(t *testing.T) {
+	projectRepo, taskRepo := exportTestRepos(t)
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONExporter(projectRepo, taskRepo).ExportTasksToWriter(context.Background(), &buf, repository.TaskFilter{SortBy: "id", SortOrder: "asc"}))
+
+	rows, err := ReadJSON(&buf)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.NoError(t, rows[0].Err)
+	assert.Zero(t, rows[0].Task.ID)
+	assert.Equal(t, `Fix login, then "logout"`, rows[0].Task.Title)
+	assert.Equal(t, "Backend", rows[0].Task.ProjectName)
+	require.NotNil(t, rows[0].Task.DueDate)
+	assert.Equal(t, "2026-03-15", rows[0].Task.DueDate.Format("2006-01-02"))
+	assert.Equal(t, domain.StatusCompleted, rows[1].Task.Status)
+
+	t.Run("an entry of the wrong shape only fails its row", func(t *testing.T) {
+		rows, err := ReadJSON(strings.NewReader(`[{"title": "Good"}, {"title": 42}, {"title": "Also good", "depends_on": [3]}]`))
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, domain.PriorityMedium, rows[0].Task.Priority)
+		assert.NotNil(t, rows[0].Task.Tags)
+		assert.Error(t, rows[1].Err)
+		assert.Equal(t, 2, rows[1].Row)
+		assert.Nil(t, rows[2].Task.DependsOn, "dependencies point at IDs of another database")
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		_, err := ReadJSON(strings.NewReader(`[{"title": "Unclosed"`))
+		assert.ErrorContains(t, err, "invalid JSON")
+
+		_, err = ReadJSON(strings.NewReader(`{"title": "Not an array"}`))
+		assert.ErrorContains(t, err, "invalid JSON")
+
+		rows, err := ReadJSON(strings.NewReader(`[{"title": "Bad date", "due_date": "soon"}]`))
+		require.NoError(t, err)
+		assert.ErrorContains(t, rows[0].Err, "invalid due date")
+	})
+}
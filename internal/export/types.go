@@ -31,6 +31,7 @@ type TaskData struct {
 	Description string    `json:"description,omitempty"`
 	Priority    string    `json:"priority"`
 	Status      string    `json:"status"`
+	Project     string    `json:"project,omitempty"`
 	Tags        []string  `json:"tags,omitempty"`
 	DueDate     *string   `json:"due_date,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
@@ -0,0 +1,122 @@
+package fuzzy
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	AlgorithmSubsequence = "subsequence"
+	AlgorithmLevenshtein = "levenshtein"
+)
+
+// Scorer rates how well pattern matches text on a 0-100 scale
+type Scorer interface {
+	Score(pattern, text string) int
+}
+
+// SubsequenceScorer is the default scorer. it favours abbreviations and
+// in-order character matches ("bkd" -> "backend")
+type SubsequenceScorer struct{}
+
+func (SubsequenceScorer) Score(pattern, text string) int {
+	return Match(pattern, text)
+}
+
+// LevenshteinScorer is edit distance based. it forgives typos and swapped
+// letters ("bakcend" -> "backend") but does not understand abbreviations
+type LevenshteinScorer struct{}
+
+func (LevenshteinScorer) Score(pattern, text string) int {
+	return LevenshteinMatch(pattern, text)
+}
+
+func NewScorer(algorithm string) (Scorer, error) {
+	switch strings.ToLower(strings.TrimSpace(algorithm)) {
+	case "", AlgorithmSubsequence:
+		return SubsequenceScorer{}, nil
+	case AlgorithmLevenshtein:
+		return LevenshteinScorer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown fuzzy algorithm: %s (must be %s or %s)", algorithm, AlgorithmSubsequence, AlgorithmLevenshtein)
+	}
+}
+
+func Algorithms() []string {
+	return []string{AlgorithmSubsequence, AlgorithmLevenshtein}
+}
+
+// LevenshteinMatch returns the normalized edit distance similarity between
+// pattern and the closest part of text: the whole text, any single word, or
+// any window of text as long as pattern
+func LevenshteinMatch(pattern, text string) int {
+	if pattern == "" || text == "" {
+		return 0
+	}
+
+	patternRunes := []rune(strings.ToLower(pattern))
+	textRunes := []rune(strings.ToLower(text))
+
+	best := similarity(patternRunes, textRunes)
+	if best == 100 {
+		return best
+	}
+
+	words := strings.FieldsFunc(string(textRunes), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if score := similarity(patternRunes, []rune(word)); score > best {
+			best = score
+		}
+	}
+
+	if len(textRunes) > len(patternRunes) {
+		for i := 0; i+len(patternRunes) <= len(textRunes); i++ {
+			window := textRunes[i : i+len(patternRunes)]
+			if score := similarity(patternRunes, window); score > best {
+				best = score
+			}
+		}
+	}
+
+	return best
+}
+
+func similarity(a, b []rune) int {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 0
+	}
+
+	distance := levenshteinDistance(a, b)
+	return int(math.Round((1.0 - float64(distance)/float64(longest)) * 100))
+}
+
+func levenshteinDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
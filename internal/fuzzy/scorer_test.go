@@ -0,0 +1,100 @@
+package fuzzy
+
+import (
+	"testing"
+)
+
+func TestNewScorer(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		want      Scorer
+		wantErr   bool
+	}{
+		{"", SubsequenceScorer{}, false},
+		{"subsequence", SubsequenceScorer{}, false},
+		{"Levenshtein", LevenshteinScorer{}, false},
+		{"soundex", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			scorer, err := NewScorer(tt.algorithm)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewScorer(%q) error = %v, wantErr %v", tt.algorithm, err, tt.wantErr)
+			}
+			if scorer != tt.want {
+				t.Errorf("NewScorer(%q) = %T, want %T", tt.algorithm, scorer, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"backend", "backend", 0},
+		{"bakcend", "backend", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got := levenshteinDistance([]rune(tt.a), []rune(tt.b))
+			if got != tt.want {
+				t.Errorf("levenshteinDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+// documents where the two algorithms disagree: subsequence understands
+// abbreviations, levenshtein understands transposed letters
+func TestScorerComparison(t *testing.T) {
+	tests := []struct {
+		name        string
+		pattern     string
+		text        string
+		subsequence [2]int
+		levenshtein [2]int
+	}{
+		{"exact", "backend", "backend", [2]int{100, 100}, [2]int{100, 100}},
+		{"abbreviation", "bkd", "backend", [2]int{60, 100}, [2]int{0, 59}},
+		{"transposed letters", "bakcend", "backend", [2]int{0, 0}, [2]int{60, 100}},
+		{"transposed short word", "tset", "test runner", [2]int{0, 0}, [2]int{40, 60}},
+		{"missing letter", "backnd", "backend", [2]int{80, 100}, [2]int{80, 100}},
+		{"word inside longer text", "auth", "authentication service", [2]int{70, 100}, [2]int{100, 100}},
+		{"unrelated", "xyz", "backend", [2]int{0, 0}, [2]int{0, 0}},
+	}
+
+	subsequence := SubsequenceScorer{}
+	levenshtein := LevenshteinScorer{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subsequence.Score(tt.pattern, tt.text); got < tt.subsequence[0] || got > tt.subsequence[1] {
+				t.Errorf("subsequence(%q, %q) = %d, want %d-%d", tt.pattern, tt.text, got, tt.subsequence[0], tt.subsequence[1])
+			}
+			if got := levenshtein.Score(tt.pattern, tt.text); got < tt.levenshtein[0] || got > tt.levenshtein[1] {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d-%d", tt.pattern, tt.text, got, tt.levenshtein[0], tt.levenshtein[1])
+			}
+		})
+	}
+}
+
+func BenchmarkScorers(b *testing.B) {
+	pattern := "fix login"
+	text := "Fix the login bug on the authentication page"
+
+	for _, algorithm := range Algorithms() {
+		scorer, _ := NewScorer(algorithm)
+		b.Run(algorithm, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scorer.Score(pattern, text)
+			}
+		})
+	}
+}
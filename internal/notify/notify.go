@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// Notifier shows a notification with a title and a message of one or more
+// lines
+type Notifier interface {
+	Notify(title, message string) error
+}
+
+// Writer writes notifications as text, the title on a line of its own
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (n *Writer) Notify(title, message string) error {
+	_, err := fmt.Fprintf(n.w, "%s\n%s\n", title, message)
+	return err
+}
+
+// Command shows notifications by running a desktop notification tool
+type Command struct {
+	name string
+	args func(title, message string) []string
+}
+
+func (n *Command) Name() string {
+	return n.name
+}
+
+func (n *Command) Notify(title, message string) error {
+	output, err := exec.Command(n.name, n.args(title, message)...).CombinedOutput()
+	if err != nil {
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			return fmt.Errorf("%s failed: %w: %s", n.name, err, detail)
+		}
+		return fmt.Errorf("%s failed: %w", n.name, err)
+	}
+	return nil
+}
+
+// the notification tool for each OS, linux and the BSDs use notify-send
+var commands = map[string]Command{
+	"darwin": {name: "osascript", args: func(title, message string) []string {
+		return []string{"-e", fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))}
+	}},
+	"windows": {name: "msg", args: func(title, message string) []string {
+		return []string{"*", title + "\n" + message}
+	}},
+}
+
+var notifySend = Command{name: "notify-send", args: func(title, message string) []string {
+	return []string{"--app-name", "taskflow", title, message}
+}}
+
+// the desktop notifier for goos, false when its tool isn't installed.
+// lookPath is exec.LookPath outside of tests
+func Desktop(goos string, lookPath func(string) (string, error)) (*Command, bool) {
+	command, ok := commands[goos]
+	if !ok {
+		command = notifySend
+	}
+	if _, err := lookPath(command.name); err != nil {
+		return nil, false
+	}
+	return &command, true
+}
+
+// a quoted AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDesktop(t *testing.T) {
+	installed := func(names ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, installed := range names {
+				if name == installed {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"linux", "notify-send"},
+		{"freebsd", "notify-send"},
+		{"darwin", "osascript"},
+		{"windows", "msg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			notifier, ok := Desktop(tt.goos, installed(tt.want))
+			require.True(t, ok)
+			assert.Equal(t, tt.want, notifier.Name())
+
+			_, ok = Desktop(tt.goos, installed())
+			assert.False(t, ok, "no backend installed")
+		})
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	notifier, ok := Desktop("darwin", func(string) (string, error) { return "", nil })
+	require.True(t, ok)
+	assert.Equal(t,
+		[]string{"-e", `display notification "#3 Say \"hi\" \\ bye" with title "Overdue"`},
+		notifier.args("Overdue", `#3 Say "hi" \ bye`))
+
+	notifier, ok = Desktop("linux", func(string) (string, error) { return "", nil })
+	require.True(t, ok)
+	assert.Equal(t, []string{"--app-name", "taskflow", "Overdue", "#3 Renew cert"}, notifier.args("Overdue", "#3 Renew cert"))
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	var notifier Notifier = NewWriter(&buf)
+	require.NoError(t, notifier.Notify("Due soon (2)", "#1 One\n#2 Two"))
+	assert.Equal(t, "Due soon (2)\n#1 One\n#2 Two\n", buf.String())
+}
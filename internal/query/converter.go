@@ -4,28 +4,127 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"task-management/internal/domain"
+	"task-management/internal/fuzzy"
 	"task-management/internal/repository"
 )
 
 type ConverterContext struct {
 	ProjectRepo ProjectRepository
+	// days after its due day before a task matches due:overdue
+	OverdueGraceDays int
+
+	// filled in by ConvertToTaskFilter with the project each project filter
+	// resolved to
+	ResolvedProjects []ProjectResolution
 }
 
 type ProjectRepository interface {
 	GetByName(ctx context.Context, name string) (*domain.Project, error)
 	GetByAlias(ctx context.Context, alias string) (*domain.Project, error)
-	Search(ctx context.Context, query string, limit int) ([]*domain.Project, error)
+	List(ctx context.Context, filter repository.ProjectFilter) ([]*domain.Project, error)
+}
+
+// fuzzy project mentions below this score don't match
+const projectFuzzyThreshold = 60
+
+// ProjectResolution is the project a name, alias or fuzzy mention resolved to
+type ProjectResolution struct {
+	Input   string
+	Project *domain.Project
+	// MatchedAlias is the alias that matched, empty when the name did
+	MatchedAlias string
+	Fuzzy        bool
+}
+
+// e.g. "api → Backend API (alias)"
+func (r ProjectResolution) String() string {
+	var via []string
+	if r.Fuzzy {
+		via = append(via, "fuzzy")
+	}
+	if r.MatchedAlias != "" {
+		if strings.EqualFold(r.MatchedAlias, r.Input) {
+			via = append(via, "alias")
+		} else {
+			via = append(via, fmt.Sprintf("alias '%s'", r.MatchedAlias))
+		}
+	}
+
+	s := fmt.Sprintf("%s → %s", r.Input, r.Project.Name)
+	if len(via) > 0 {
+		s += fmt.Sprintf(" (%s)", strings.Join(via, ", "))
+	}
+	return s
+}
+
+// ResolveProject finds the project a query refers to. exact lookups try the
+// name and then the aliases, fuzzy ones score every active project's name
+// and aliases and keep the best
+func ResolveProject(ctx context.Context, projectRepo ProjectRepository, value string, fuzzyMatch bool) (*ProjectResolution, error) {
+	if fuzzyMatch {
+		return resolveProjectFuzzy(ctx, projectRepo, value)
+	}
+
+	if project, err := projectRepo.GetByName(ctx, value); err == nil {
+		return &ProjectResolution{Input: value, Project: project}, nil
+	}
+
+	project, err := projectRepo.GetByAlias(ctx, value)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: '%s' (tried name and alias)", value)
+	}
+
+	resolution := &ProjectResolution{Input: value, Project: project, MatchedAlias: value}
+	for _, alias := range project.Aliases {
+		if strings.EqualFold(alias, value) {
+			resolution.MatchedAlias = alias
+		}
+	}
+	return resolution, nil
+}
+
+func resolveProjectFuzzy(ctx context.Context, projectRepo ProjectRepository, value string) (*ProjectResolution, error) {
+	projects, err := projectRepo.List(ctx, repository.ProjectFilter{ExcludeArchived: true})
+	if err != nil {
+		return nil, fmt.Errorf("fuzzy project search failed: %w", err)
+	}
+
+	var best *ProjectResolution
+	bestScore := 0
+	for _, project := range projects {
+		if score := fuzzy.Match(value, project.Name); score > bestScore {
+			best = &ProjectResolution{Input: value, Project: project, Fuzzy: true}
+			bestScore = score
+		}
+		for _, alias := range project.Aliases {
+			if score := fuzzy.Match(value, alias); score > bestScore {
+				best = &ProjectResolution{Input: value, Project: project, MatchedAlias: alias, Fuzzy: true}
+				bestScore = score
+			}
+		}
+	}
+
+	if best == nil || bestScore < projectFuzzyThreshold {
+		return nil, fmt.Errorf("no project found matching '%s' (fuzzy)", value)
+	}
+
+	return best, nil
 }
 
 func ConvertToTaskFilter(ctx context.Context, parsed *ParsedQuery, converterCtx *ConverterContext) (repository.TaskFilter, error) {
 	filter := repository.TaskFilter{}
 	var errors []error
 
-	for _, qf := range parsed.Filters {
-		if err := applyFilter(&filter, qf, ctx, converterCtx); err != nil {
-			errors = append(errors, err)
+	if parsed.HasOr() {
+		errors = applyExpr(&filter, parsed.Expr, ctx, converterCtx)
+	} else {
+		for _, qf := range parsed.Filters {
+			if err := applyFilter(&filter, qf, ctx, converterCtx); err != nil {
+				errors = append(errors, err)
+			}
 		}
 	}
 
@@ -36,6 +135,37 @@ func ConvertToTaskFilter(ctx context.Context, parsed *ParsedQuery, converterCtx
 	return filter, nil
 }
 
+// applies an expression tree to filter. ANDed filters set its fields, an OR
+// becomes one of its AnyOf groups with a filter per alternative
+func applyExpr(filter *repository.TaskFilter, expr *Expr, ctx context.Context, converterCtx *ConverterContext) []error {
+	var errors []error
+
+	switch expr.Op {
+	case ExprFilter:
+		if err := applyFilter(filter, expr.Filter, ctx, converterCtx); err != nil {
+			errors = append(errors, err)
+		}
+	case ExprAnd:
+		for _, child := range expr.Children {
+			errors = append(errors, applyExpr(filter, child, ctx, converterCtx)...)
+		}
+	case ExprOr:
+		group := make([]repository.TaskFilter, len(expr.Children))
+		for i, child := range expr.Children {
+			errors = append(errors, applyExpr(&group[i], child, ctx, converterCtx)...)
+		}
+		filter.AnyOf = append(filter.AnyOf, group)
+	}
+
+	return errors
+}
+
+// ApplyFilter applies a single query filter to filter, for callers that need
+// to know which filter a conversion error came from
+func ApplyFilter(ctx context.Context, filter *repository.TaskFilter, qf QueryFilter, converterCtx *ConverterContext) error {
+	return applyFilter(filter, qf, ctx, converterCtx)
+}
+
 func applyFilter(filter *repository.TaskFilter, qf QueryFilter, ctx context.Context, converterCtx *ConverterContext) error {
 	switch qf.Field {
 	case "status":
@@ -47,7 +177,7 @@ func applyFilter(filter *repository.TaskFilter, qf QueryFilter, ctx context.Cont
 	case "tag":
 		return applyTagFilter(filter, qf)
 	case "due":
-		return applyDueDateFilter(filter, qf)
+		return applyDueDateFilter(filter, qf, converterCtx)
 	case "created":
 		return applyCreatedDateFilter(filter, qf)
 	case "updated":
@@ -65,15 +195,12 @@ func applyStatusFilter(filter *repository.TaskFilter, qf QueryFilter) error {
 		return fmt.Errorf("status only supports exact match (:, =), got: %s", qf.Operator)
 	}
 
-	status := domain.Status(strings.ToLower(qf.Value))
-
-	switch status {
-	case domain.StatusPending, domain.StatusInProgress, domain.StatusCompleted, domain.StatusCancelled:
-		filter.Status = status
-		return nil
-	default:
-		return fmt.Errorf("invalid status value: %s (must be pending, in_progress, completed, or cancelled)", qf.Value)
+	status, err := domain.ParseStatus(qf.Value)
+	if err != nil {
+		return err
 	}
+	filter.Status = status
+	return nil
 }
 
 func applyPriorityFilter(filter *repository.TaskFilter, qf QueryFilter) error {
@@ -84,15 +211,12 @@ func applyPriorityFilter(filter *repository.TaskFilter, qf QueryFilter) error {
 		return fmt.Errorf("priority only supports exact match (:, =), got: %s", qf.Operator)
 	}
 
-	priority := domain.Priority(strings.ToLower(qf.Value))
-
-	switch priority {
-	case domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent:
-		filter.Priority = priority
-		return nil
-	default:
-		return fmt.Errorf("invalid priority value: %s (must be low, medium, high, or urgent)", qf.Value)
+	priority, err := domain.ParsePriority(qf.Value)
+	if err != nil {
+		return err
 	}
+	filter.Priority = priority
+	return nil
 }
 
 func applyProjectFilter(filter *repository.TaskFilter, qf QueryFilter, ctx context.Context, converterCtx *ConverterContext) error {
@@ -107,32 +231,13 @@ func applyProjectFilter(filter *repository.TaskFilter, qf QueryFilter, ctx conte
 		return fmt.Errorf("project repository not available for project lookup")
 	}
 
-	var projectID *int64
-
-	if qf.IsFuzzy {
-		limit := 10
-		projects, err := converterCtx.ProjectRepo.Search(ctx, qf.Value, limit)
-		if err != nil {
-			return fmt.Errorf("fuzzy project search failed: %w", err)
-		}
-		if len(projects) == 0 {
-			return fmt.Errorf("no project found matching '%s' (fuzzy)", qf.Value)
-		}
-		projectID = &projects[0].ID
-	} else {
-		project, err := converterCtx.ProjectRepo.GetByName(ctx, qf.Value)
-		if err != nil {
-			project, aliasErr := converterCtx.ProjectRepo.GetByAlias(ctx, qf.Value)
-			if aliasErr != nil {
-				return fmt.Errorf("project not found: '%s' (tried name and alias)", qf.Value)
-			}
-			projectID = &project.ID
-		} else {
-			projectID = &project.ID
-		}
+	resolution, err := ResolveProject(ctx, converterCtx.ProjectRepo, qf.Value, qf.IsFuzzy)
+	if err != nil {
+		return err
 	}
 
-	filter.ProjectID = projectID
+	converterCtx.ResolvedProjects = append(converterCtx.ResolvedProjects, *resolution)
+	filter.ProjectID = &resolution.Project.ID
 	return nil
 }
 
@@ -155,11 +260,25 @@ func applyTagFilter(filter *repository.TaskFilter, qf QueryFilter) error {
 	return nil
 }
 
-func applyDueDateFilter(filter *repository.TaskFilter, qf QueryFilter) error {
+func applyDueDateFilter(filter *repository.TaskFilter, qf QueryFilter, converterCtx *ConverterContext) error {
 	if qf.IsNot {
 		return fmt.Errorf("negated due date filters not supported yet")
 	}
 
+	if strings.EqualFold(qf.Value, "overdue") {
+		if qf.Operator != ":" && qf.Operator != "=" {
+			return fmt.Errorf("due:overdue only supports exact match (:, =), got: %s", qf.Operator)
+		}
+		var graceDays int
+		if converterCtx != nil {
+			graceDays = converterCtx.OverdueGraceDays
+		}
+		dueTo := OverdueDueTo(time.Now(), graceDays)
+		filter.DueDateFrom = nil
+		filter.DueDateTo = &dueTo
+		return nil
+	}
+
 	startDate, endDate, err := ParseDateRange(qf.Value, qf.Operator)
 	if err != nil {
 		return fmt.Errorf("invalid due date value '%s': %w", qf.Value, err)
@@ -3,8 +3,9 @@ package query
 import (
 	"context"
 	"errors"
-	"strings"
+	"sort"
 	"testing"
+	"time"
 
 	"task-management/internal/domain"
 	"task-management/internal/repository"
@@ -24,6 +25,7 @@ func newMockProjectRepo() *mockProjectRepo {
 			"backend": {ID: 1, Name: "backend"},
 			"frontend": {ID: 2, Name: "frontend"},
 			"mobile-app": {ID: 3, Name: "mobile-app"},
+			"Payments Gateway": {ID: 4, Name: "Payments Gateway", Aliases: []string{"billing", "pay"}},
 		},
 	}
 }
@@ -44,16 +46,12 @@ func (m *mockProjectRepo) GetByAlias(ctx context.Context, alias string) (*domain
 	return nil, errors.New("project not found by alias")
 }
 
-func (m *mockProjectRepo) Search(ctx context.Context, query string, limit int) ([]*domain.Project, error) {
+func (m *mockProjectRepo) List(ctx context.Context, filter repository.ProjectFilter) ([]*domain.Project, error) {
 	var results []*domain.Project
 	for _, project := range m.projects {
-		if strings.Contains(project.Name, query) {
-			results = append(results, project)
-		}
-	}
-	if len(results) > limit {
-		results = results[:limit]
+		results = append(results, project)
 	}
+	sort.Slice(results, func(i, j int) bool { return results[i].ID < results[j].ID })
 	return results, nil
 }
 
@@ -190,6 +188,33 @@ func TestConvertToTaskFilter_Project(t *testing.T) {
 				assert.Equal(t, int64(1), *filter.ProjectID)
 			},
 		},
+		{
+			name:        "exact alias match",
+			query:       "@pay",
+			expectError: false,
+			checkFilter: func(t *testing.T, filter repository.TaskFilter) {
+				require.NotNil(t, filter.ProjectID)
+				assert.Equal(t, int64(4), *filter.ProjectID)
+			},
+		},
+		{
+			name:        "fuzzy alias match",
+			query:       "@~biling",
+			expectError: false,
+			checkFilter: func(t *testing.T, filter repository.TaskFilter) {
+				require.NotNil(t, filter.ProjectID)
+				assert.Equal(t, int64(4), *filter.ProjectID)
+			},
+		},
+		{
+			name:        "project field matches alias",
+			query:       "project:billing",
+			expectError: false,
+			checkFilter: func(t *testing.T, filter repository.TaskFilter) {
+				require.NotNil(t, filter.ProjectID)
+				assert.Equal(t, int64(4), *filter.ProjectID)
+			},
+		},
 		{
 			name:        "project not found",
 			query:       "@nonexistent",
@@ -221,6 +246,46 @@ func TestConvertToTaskFilter_Project(t *testing.T) {
 	}
 }
 
+func TestResolveProject(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockProjectRepo()
+
+	tests := []struct {
+		name      string
+		value     string
+		fuzzy     bool
+		wantID    int64
+		wantAlias string
+		want      string
+	}{
+		{"name", "backend", false, 1, "", "backend → backend"},
+		{"alias", "billing", false, 4, "billing", "billing → Payments Gateway (alias)"},
+		{"fuzzy name", "frntend", true, 2, "", "frntend → frontend (fuzzy)"},
+		{"fuzzy alias", "biling", true, 4, "billing", "biling → Payments Gateway (fuzzy, alias 'billing')"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, err := ResolveProject(ctx, repo, tt.value, tt.fuzzy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, resolution.Project.ID)
+			assert.Equal(t, tt.wantAlias, resolution.MatchedAlias)
+			assert.Equal(t, tt.want, resolution.String())
+		})
+	}
+
+	t.Run("records resolutions in the converter context", func(t *testing.T) {
+		parsed, err := ParseQuery("@pay status:pending")
+		require.NoError(t, err)
+
+		converterCtx := &ConverterContext{ProjectRepo: repo}
+		_, err = ConvertToTaskFilter(ctx, parsed, converterCtx)
+		require.NoError(t, err)
+		require.Len(t, converterCtx.ResolvedProjects, 1)
+		assert.Equal(t, "pay → Payments Gateway (alias)", converterCtx.ResolvedProjects[0].String())
+	})
+}
+
 func TestConvertToTaskFilter_Tags(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -336,6 +401,22 @@ func TestConvertToTaskFilter_DueDates(t *testing.T) {
 				assert.NotNil(t, filter.DueDateTo)
 			},
 		},
+		{
+			name:        "overdue",
+			query:       "due:overdue",
+			expectError: false,
+			checkFilter: func(t *testing.T, filter repository.TaskFilter) {
+				yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+				assert.Nil(t, filter.DueDateFrom)
+				require.NotNil(t, filter.DueDateTo)
+				assert.Equal(t, yesterday+" 23:59:59", *filter.DueDateTo)
+			},
+		},
+		{
+			name:        "overdue before",
+			query:       "due:<overdue",
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -436,3 +517,34 @@ func TestConvertToTaskFilter_ComplexQuery(t *testing.T) {
 	require.NotNil(t, filter.DueDateTo)
 	assert.Contains(t, *filter.DueDateTo, "2025-12-31")
 }
+
+func TestConvertToTaskFilter_OrGroups(t *testing.T) {
+	ctx := context.Background()
+	converterCtx := &ConverterContext{ProjectRepo: newMockProjectRepo()}
+
+	parsed, err := ParseQuery("(status:pending OR status:in_progress) priority:high (@backend | @frontend tag:ui)")
+	require.NoError(t, err)
+
+	filter, err := ConvertToTaskFilter(ctx, parsed, converterCtx)
+	require.NoError(t, err)
+
+	assert.Empty(t, filter.Status, "statuses inside a group stay in the group")
+	assert.Equal(t, domain.PriorityHigh, filter.Priority)
+	require.Len(t, filter.AnyOf, 2)
+
+	statuses := filter.AnyOf[0]
+	require.Len(t, statuses, 2)
+	assert.Equal(t, domain.StatusPending, statuses[0].Status)
+	assert.Equal(t, domain.StatusInProgress, statuses[1].Status)
+
+	projects := filter.AnyOf[1]
+	require.Len(t, projects, 2)
+	assert.Equal(t, int64(1), *projects[0].ProjectID)
+	assert.Equal(t, int64(2), *projects[1].ProjectID)
+	assert.Equal(t, []string{"ui"}, projects[1].Tags)
+
+	parsed, err = ParseQuery("status:pending | status:done")
+	require.NoError(t, err)
+	_, err = ConvertToTaskFilter(ctx, parsed, converterCtx)
+	assert.Error(t, err, "bad values inside a group are still reported")
+}
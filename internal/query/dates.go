@@ -2,10 +2,10 @@ package query
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
+
+	"task-management/internal/domain"
 )
 
 func ParseDate(value string) (*time.Time, string, error) {
@@ -15,11 +15,11 @@ func ParseDate(value string) (*time.Time, string, error) {
 		return nil, "none", nil
 	}
 
-	if t, ok := parseRelativeKeyword(strings.ToLower(value)); ok {
-		return &t, "", nil
+	t, ok, err := domain.ParseRelativeDate(value, time.Now())
+	if err != nil {
+		return nil, "", err
 	}
-
-	if t, err := parseRelativeOffset(value); err == nil {
+	if ok {
 		return &t, "", nil
 	}
 
@@ -41,65 +41,6 @@ func ParseDate(value string) (*time.Time, string, error) {
 	return nil, "", fmt.Errorf("unable to parse date: %s (expected ISO date, relative keyword, or offset)", value)
 }
 
-func parseRelativeKeyword(value string) (time.Time, bool) {
-	now := time.Now()
-
-	switch value {
-	case "today":
-		return startOfDay(now), true
-	case "tomorrow":
-		return startOfDay(now.AddDate(0, 0, 1)), true
-	case "yesterday":
-		return startOfDay(now.AddDate(0, 0, -1)), true
-	default:
-		return time.Time{}, false
-	}
-}
-
-func parseRelativeOffset(value string) (time.Time, error) {
-	re := regexp.MustCompile(`^([+-]?)(\d+)([mhdwMy])$`)
-	matches := re.FindStringSubmatch(value)
-
-	if matches == nil {
-		return time.Time{}, fmt.Errorf("invalid offset format")
-	}
-
-	sign := matches[1]
-	numStr := matches[2]
-	unit := matches[3]
-
-	num, err := strconv.Atoi(numStr)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid number in offset: %s", numStr)
-	}
-
-	if sign == "-" {
-		num = -num
-	}
-
-	now := time.Now()
-	var result time.Time
-
-	switch unit {
-	case "m":
-		result = now.Add(time.Duration(num) * time.Minute)
-	case "h":
-		result = now.Add(time.Duration(num) * time.Hour)
-	case "d":
-		result = now.AddDate(0, 0, num)
-	case "w":
-		result = now.AddDate(0, 0, num*7)
-	case "M":
-		result = now.AddDate(0, num, 0)
-	case "y":
-		result = now.AddDate(num, 0, 0)
-	default:
-		return time.Time{}, fmt.Errorf("unknown unit: %s", unit)
-	}
-
-	return startOfDay(result), nil
-}
-
 func ParseDateRange(value string, operator string) (*time.Time, *time.Time, error) {
 	if operator == "<" || operator == "<=" {
 		t, _, err := ParseDate(value)
@@ -168,6 +109,12 @@ func endOfDay(t time.Time) time.Time {
 	return time.Date(year, month, day, 23, 59, 59, 999999999, t.Location())
 }
 
+// the due date upper bound, for a filter's DueDateTo, of the tasks overdue on
+// now's day
+func OverdueDueTo(now time.Time, graceDays int) string {
+	return FormatDateForSQL(domain.OverdueBefore(now, graceDays).Add(-time.Second))
+}
+
 func FormatDateForSQL(t time.Time) string {
 	return t.Format("2006-01-02 15:04:05")
 }
@@ -175,3 +122,39 @@ func FormatDateForSQL(t time.Time) string {
 func FormatDateForDisplay(t time.Time) string {
 	return t.Format("2006-01-02")
 }
+
+// the due date bounds, for a filter's DueDateFrom and DueDateTo, of the due
+// filter presets on now's day: overdue, today, week, month and none. "" and
+// anything else have no bounds
+func DueDateRange(preset string, now time.Time, graceDays int) (from, to *string) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	upTo := func(days int) (*string, *string) {
+		from, to := FormatDateForDisplay(today), FormatDateForDisplay(today.AddDate(0, 0, days))
+		return &from, &to
+	}
+
+	switch preset {
+	case "overdue":
+		dueTo := OverdueDueTo(now, graceDays)
+		return nil, &dueTo
+	case "today":
+		return upTo(1)
+	case "week":
+		return upTo(7)
+	case "month":
+		return upTo(30)
+	case "none":
+		none := "none"
+		return &none, nil
+	}
+	return nil, nil
+}
+
+// the due date bounds of the open tasks due within the window from now that
+// aren't overdue yet. due dates are whole days, so a window ending partway
+// into a day takes in the tasks due that day
+func DueSoonRange(now time.Time, within time.Duration, graceDays int) (from, to string) {
+	windowEnd := now.Add(within)
+	lastDay := domain.NormalizeDueDate(&windowEnd)
+	return FormatDateForSQL(domain.OverdueBefore(now, graceDays)), FormatDateForSQL(lastDay.AddDate(0, 0, 1).Add(-time.Second))
+}
@@ -139,6 +139,19 @@ func TestParseDate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:        "end of week, as due dates take it",
+			input:       "eow",
+			expectError: false,
+			checkResult: func(t *testing.T, result *time.Time, special string) {
+				if result == nil {
+					t.Fatal("Expected non-nil result")
+				}
+				if result.Weekday() != time.Sunday || result.Before(startOfDay(now)) {
+					t.Errorf("Date = %v, expected the coming Sunday", result)
+				}
+			},
+		},
 		{
 			name:        "special: none",
 			input:       "none",
@@ -347,3 +360,31 @@ func TestDateHelpers(t *testing.T) {
 		}
 	})
 }
+
+func TestDueSoonRange(t *testing.T) {
+	now := time.Date(2026, 3, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		within    time.Duration
+		graceDays int
+		from, to  string
+	}{
+		{"rest of today", 13 * time.Hour, 0, "2026-03-13 00:00:00", "2026-03-13 23:59:59"},
+		{"window reaching into tomorrow", 14 * time.Hour, 0, "2026-03-13 00:00:00", "2026-03-14 23:59:59"},
+		{"a day", 24 * time.Hour, 0, "2026-03-13 00:00:00", "2026-03-14 23:59:59"},
+		{"a week", 7 * 24 * time.Hour, 0, "2026-03-13 00:00:00", "2026-03-20 23:59:59"},
+		{"grace days aren't overdue yet", 24 * time.Hour, 2, "2026-03-11 00:00:00", "2026-03-14 23:59:59"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := DueSoonRange(now, tt.within, tt.graceDays)
+			if from != tt.from || to != tt.to {
+				t.Errorf("DueSoonRange() = %q, %q, expected %q, %q", from, to, tt.from, tt.to)
+			}
+			if overdueTo := OverdueDueTo(now, tt.graceDays); overdueTo >= from {
+				t.Errorf("OverdueDueTo() = %q overlaps the window from %q", overdueTo, from)
+			}
+		})
+	}
+}
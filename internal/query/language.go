@@ -27,14 +27,14 @@ const (
 	TokenGT     // > (future: greater than)
 	TokenEQ     // = (future: equals)
 	TokenNE     // != (future: not equals)
-	TokenPipe   // | (future: OR separator)
-	TokenLParen // ( (future: grouping)
-	TokenRParen // ) (future: grouping)
+	TokenPipe   // | (same as OR)
+	TokenLParen // ( (grouping)
+	TokenRParen // ) (grouping)
 
-	// (future: boolean operators)
-	TokenAND // AND
+	// boolean operators
+	TokenAND // AND (same as a space)
 	TokenOR  // OR
-	TokenNOT // NOT
+	TokenNOT // NOT (future)
 )
 
 type Token struct {
@@ -263,7 +263,7 @@ func (l *Lexer) readQuotedValue() Token {
 			Pos:   pos,
 		}
 	}
-
+	l.advance()
 
 	return Token{Type: TokenValue, Value: sb.String(), Pos: pos}
 }
@@ -291,7 +291,8 @@ func (l *Lexer) peek() rune {
 }
 
 func IsQueryLanguage(input string) bool {
-	input = strings.TrimSpace(input)
+	// a query can open with a group, e.g. (@backend | @frontend)
+	input = strings.TrimLeft(strings.TrimSpace(input), "( ")
 
 	if strings.HasPrefix(input, "@") {
 		return true
@@ -45,6 +45,11 @@ func TestTokenize(t *testing.T) {
 			input:    `status:"in progress"`,
 			expected: []TokenType{TokenField, TokenColon, TokenValue, TokenEOF},
 		},
+		{
+			name:     "quoted value followed by filter",
+			input:    `project:"Mobile App" tag:bug`,
+			expected: []TokenType{TokenField, TokenColon, TokenValue, TokenField, TokenColon, TokenValue, TokenEOF},
+		},
 		{
 			name:     "complex query",
 			input:    "status:pending priority:high @backend tag:bug -tag:wontfix",
@@ -223,6 +228,21 @@ func TestIsQueryLanguage(t *testing.T) {
 			input:    "@~back",
 			expected: true,
 		},
+		{
+			name:     "group of mentions",
+			input:    "(@backend | @frontend) tag:bug",
+			expected: true,
+		},
+		{
+			name:     "OR of fields in a group",
+			input:    "(status:pending OR status:in_progress)",
+			expected: true,
+		},
+		{
+			name:     "plain text in parentheses",
+			input:    "(draft) notes",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
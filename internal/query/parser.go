@@ -25,8 +25,85 @@ func (qf QueryFilter) String() string {
 }
 
 type ParsedQuery struct {
+	// every filter in the query, in order, whatever group it is in
 	Filters []QueryFilter
-	Errors  []ParseError
+	// how the filters combine, nil for an empty query
+	Expr   *Expr
+	Errors []ParseError
+}
+
+type ExprOp int
+
+const (
+	ExprFilter ExprOp = iota
+	ExprAnd
+	ExprOr
+)
+
+// a node of the query's expression tree: one filter, or the AND or OR of its
+// children. filters next to each other are ANDed, which binds tighter than
+// OR, and parentheses group
+type Expr struct {
+	Op       ExprOp
+	Filter   QueryFilter
+	Children []*Expr
+}
+
+// e.g. "(status:pending OR status:in_progress) priority:high"
+func (e *Expr) String() string {
+	switch e.Op {
+	case ExprAnd:
+		parts := make([]string, len(e.Children))
+		for i, child := range e.Children {
+			parts[i] = child.String()
+			if child.Op == ExprOr {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, " ")
+	case ExprOr:
+		parts := make([]string, len(e.Children))
+		for i, child := range e.Children {
+			parts[i] = child.String()
+		}
+		return strings.Join(parts, " OR ")
+	default:
+		return e.Filter.String()
+	}
+}
+
+// whether the tree has an OR anywhere, which a flat filter can't express
+func (e *Expr) HasOr() bool {
+	if e.Op == ExprOr {
+		return true
+	}
+	for _, child := range e.Children {
+		if child.HasOr() {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Expr) collectFilters(filters []QueryFilter) []QueryFilter {
+	if e.Op == ExprFilter {
+		return append(filters, e.Filter)
+	}
+	for _, child := range e.Children {
+		filters = child.collectFilters(filters)
+	}
+	return filters
+}
+
+// nil for no children, the child itself for one
+func combineExprs(op ExprOp, children []*Expr) *Expr {
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	}
+	return &Expr{Op: op, Children: children}
 }
 
 type ParseError struct {
@@ -68,32 +145,27 @@ func ParseQuery(input string) (*ParsedQuery, error) {
 }
 
 func (p *Parser) parse() (*ParsedQuery, error) {
-	filters := []QueryFilter{}
+	expr := p.parseOr(0)
 
+	// only a ) without a ( stops parseOr before the end
 	for !p.isAtEnd() {
-		if p.current().Type == TokenEOF {
-			break
-		}
-
-		filter, err := p.parseFilter()
-		if err != nil {
-			p.errors = append(p.errors, ParseError{
-				Message: err.Error(),
-				Pos:     p.current().Pos,
-			})
-			p.skipToNextFilter()
-			continue
-		}
-
-		if filter != nil {
-			filters = append(filters, *filter)
+		p.addError("unexpected ), there is no ( to close", p.current().Pos)
+		p.advance()
+		if rest := p.parseOr(0); rest != nil && expr != nil {
+			expr = &Expr{Op: ExprAnd, Children: []*Expr{expr, rest}}
+		} else if rest != nil {
+			expr = rest
 		}
 	}
 
 	query := &ParsedQuery{
-		Filters: filters,
+		Filters: []QueryFilter{},
+		Expr:    expr,
 		Errors:  p.errors,
 	}
+	if expr != nil {
+		query.Filters = expr.collectFilters(query.Filters)
+	}
 
 	if len(p.errors) > 0 {
 		return query, fmt.Errorf("%s", p.errors[0].String())
@@ -102,6 +174,86 @@ func (p *Parser) parse() (*ParsedQuery, error) {
 	return query, nil
 }
 
+// alternatives separated by OR or |, each one parsed by parseAnd. depth is
+// how many ( are open
+func (p *Parser) parseOr(depth int) *Expr {
+	var alternatives []*Expr
+	for {
+		pos := p.current().Pos
+		alternative := p.parseAnd(depth)
+		if alternative != nil {
+			alternatives = append(alternatives, alternative)
+		}
+
+		if !isOrToken(p.current()) {
+			if alternative == nil && len(alternatives) > 0 {
+				p.addError("expected a filter after OR", pos)
+			}
+			break
+		}
+		if alternative == nil {
+			p.addError("expected a filter before OR", p.current().Pos)
+		}
+		p.advance() // OR or |
+	}
+
+	return combineExprs(ExprOr, alternatives)
+}
+
+// filters and groups up to the next OR, an unmatched ) or the end, all of
+// which have to match. an explicit AND is the same as a space
+func (p *Parser) parseAnd(depth int) *Expr {
+	var terms []*Expr
+
+	for !p.isAtEnd() {
+		token := p.current()
+
+		switch {
+		case isOrToken(token) || token.Type == TokenRParen:
+			return combineExprs(ExprAnd, terms)
+
+		case token.Type == TokenAND:
+			p.advance()
+
+		case token.Type == TokenLParen:
+			p.advance()
+			group := p.parseOr(depth + 1)
+			if p.current().Type != TokenRParen {
+				p.addError("unbalanced parentheses, this ( is never closed", token.Pos)
+			} else {
+				p.advance()
+				if group == nil {
+					p.addError("empty group ()", token.Pos)
+				}
+			}
+			if group != nil {
+				terms = append(terms, group)
+			}
+
+		default:
+			filter, err := p.parseFilter()
+			if err != nil {
+				p.addError(err.Error(), p.current().Pos)
+				p.skipToNextFilter()
+				continue
+			}
+			if filter != nil {
+				terms = append(terms, &Expr{Op: ExprFilter, Filter: *filter})
+			}
+		}
+	}
+
+	return combineExprs(ExprAnd, terms)
+}
+
+func isOrToken(token Token) bool {
+	return token.Type == TokenOR || token.Type == TokenPipe
+}
+
+func (p *Parser) addError(message string, pos int) {
+	p.errors = append(p.errors, ParseError{Message: message, Pos: pos})
+}
+
 func (p *Parser) parseFilter() (*QueryFilter, error) {
 	token := p.current()
 
@@ -244,7 +396,8 @@ func (p *Parser) parseValue() (string, error) {
 func (p *Parser) skipToNextFilter() {
 	for !p.isAtEnd() {
 		token := p.current()
-		if token.Type == TokenAt || token.Type == TokenMinus || token.Type == TokenField || token.Type == TokenEOF {
+		switch token.Type {
+		case TokenAt, TokenMinus, TokenField, TokenEOF, TokenLParen, TokenRParen, TokenAND, TokenOR, TokenPipe:
 			return
 		}
 		p.advance()
@@ -296,6 +449,11 @@ func (q *ParsedQuery) GetAllFields(field string) []QueryFilter {
 	return filters
 }
 
+// whether the query has an OR, see Expr.HasOr
+func (q *ParsedQuery) HasOr() bool {
+	return q.Expr != nil && q.Expr.HasOr()
+}
+
 func (q *ParsedQuery) HasErrors() bool {
 	return len(q.Errors) > 0
 }
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -348,3 +349,76 @@ func TestQueryFilterString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseQueryGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        string
+		wantFilters int
+		wantOr      bool
+	}{
+		{"plain AND", "status:pending priority:high", "status:pending priority:high", 2, false},
+		{"explicit AND", "status:pending AND priority:high", "status:pending priority:high", 2, false},
+		{"OR", "status:pending OR status:in_progress", "status:pending OR status:in_progress", 2, true},
+		{"pipe", "@api | @web", "project:api OR project:web", 2, true},
+		{"AND binds tighter", "status:pending priority:high OR tag:bug", "status:pending priority:high OR tag:bug", 3, true},
+		{"group", "(status:pending OR status:in_progress) priority:high", "(status:pending OR status:in_progress) priority:high", 3, true},
+		{"nested groups", "((tag:a | tag:b) priority:high | tag:c) @api", "((tag:a OR tag:b) priority:high OR tag:c) project:api", 5, true},
+		{"group without OR", "(status:pending priority:high) tag:bug", "status:pending priority:high tag:bug", 3, false},
+		{"lowercase or", "tag:a or tag:b", "tag:a OR tag:b", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := ParseQuery(tt.input)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if got := q.Expr.String(); got != tt.want {
+				t.Errorf("Expr = %q, want %q", got, tt.want)
+			}
+			if len(q.Filters) != tt.wantFilters {
+				t.Errorf("got %d filters, want %d", len(q.Filters), tt.wantFilters)
+			}
+			if q.HasOr() != tt.wantOr {
+				t.Errorf("HasOr() = %v, want %v", q.HasOr(), tt.wantOr)
+			}
+		})
+	}
+
+	// a b OR c is (a b) OR c, not a (b OR c)
+	q, _ := ParseQuery("status:pending priority:high OR tag:bug")
+	if q.Expr.Op != ExprOr || len(q.Expr.Children) != 2 || q.Expr.Children[0].Op != ExprAnd {
+		t.Errorf("expected OR(AND(status, priority), tag), got %+v", q.Expr)
+	}
+}
+
+func TestParseQueryGroupErrors(t *testing.T) {
+	tests := []struct {
+		input   string
+		message string
+		pos     int
+	}{
+		{"(status:pending", "never closed", 0},
+		{"tag:a ((tag:b | tag:c)", "never closed", 6},
+		{"status:pending)", "no ( to close", 14},
+		{"(tag:a))", "no ( to close", 7},
+		{"() tag:a", "empty group", 0},
+		{"tag:a OR", "after OR", 8},
+		{"OR tag:a", "before OR", 0},
+		{"tag:a | | tag:b", "before OR", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			q, err := ParseQuery(tt.input)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(q.Errors[0].Message, tt.message) || q.Errors[0].Pos != tt.pos {
+				t.Errorf("error = %q at %d, want %q at %d", q.Errors[0].Message, q.Errors[0].Pos, tt.message, tt.pos)
+			}
+		})
+	}
+}
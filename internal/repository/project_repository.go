@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"task-management/internal/domain"
 )
@@ -39,6 +42,10 @@ type ProjectRepository interface {
 
 	Unarchive(ctx context.Context, id int64) error
 
+	ArchiveBatch(ctx context.Context, ids []int64) (int64, error)
+
+	UnarchiveBatch(ctx context.Context, ids []int64) (int64, error)
+
 	SetFavorite(ctx context.Context, id int64, isFavorite bool) error
 
 	GetFavorites(ctx context.Context) ([]*domain.Project, error)
@@ -47,9 +54,64 @@ type ProjectRepository interface {
 
 	GetTaskCountByStatus(ctx context.Context, projectID int64) (map[domain.Status]int, error)
 
+	GetTimeToDone(ctx context.Context, projectID int64) (*domain.TimeToDoneStats, error)
+
 	ValidateHierarchy(ctx context.Context, projectID int64, parentID int64) error
 
 	Search(ctx context.Context, query string, limit int) ([]*domain.Project, error)
+
+	Merge(ctx context.Context, sourceID int64, destID int64, reparentChildren bool) (*ProjectMergeResult, error)
+
+	EnsureInbox(ctx context.Context) (*domain.Project, error)
+	GetInbox(ctx context.Context) (*domain.Project, error)
+
+	RollSprint(ctx context.Context, id int64, now time.Time) (*SprintRollResult, error)
+}
+
+// returned when creating or renaming a project to a name another project has
+var ErrProjectExists = errors.New("project already exists")
+
+// DuplicateProject returns the project holding name when err is
+// ErrProjectExists, or nil when err is something else
+func DuplicateProject(ctx context.Context, repo ProjectRepository, name string, err error) *domain.Project {
+	if !errors.Is(err, ErrProjectExists) {
+		return nil
+	}
+
+	existing, lookupErr := repo.GetByName(ctx, name)
+	if lookupErr != nil {
+		return nil
+	}
+
+	return existing
+}
+
+// DuplicateProjectMessage describes the project a create or rename collided
+// with, for the CLI and the TUI alike
+func DuplicateProjectMessage(existing *domain.Project) string {
+	msg := fmt.Sprintf("A project named '%s' already exists (ID %d)", existing.Name, existing.ID)
+	if existing.Status == domain.ProjectStatusArchived {
+		msg += " [archived]"
+	}
+	if len(existing.Aliases) > 0 {
+		msg += fmt.Sprintf(" - aliases: %s", existing.FormatAliases())
+	}
+	return msg
+}
+
+type ProjectMergeResult struct {
+	TasksMoved    int64
+	ChildrenMoved int64
+}
+
+// SprintRollResult is what closing a project's sprint changed
+type SprintRollResult struct {
+	ClosedSprint int
+	Completed    int64
+	CarriedOver  int64
+	Rescheduled  int64
+	NextStart    time.Time
+	NextEnd      time.Time
 }
 
 type ProjectFilter struct {
@@ -61,6 +123,9 @@ type ProjectFilter struct {
 
 	ExcludeArchived bool
 
+	// no change to the project or any of its tasks since this time
+	InactiveSince *time.Time
+
 	IncludeTaskCount bool
 
 	SearchQuery string
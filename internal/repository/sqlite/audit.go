@@ -0,0 +1,183 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+)
+
+const taskSelect = `
+	SELECT
+		t.id, t.title, t.description, t.priority, t.status, t.tags,
+		t.project_id, p.name as project_name,
+		t.created_at, t.updated_at, t.due_date, t.position, t.completed_at, t.is_pinned, t.snoozed_until, t.waiting_on, t.is_locked, t.color, t.recurrence,
+		t.project_task_number, t.number_project_id, np.key_prefix as number_project_key,
+		` + dependencyColumns + `
+	FROM tasks t
+	LEFT JOIN projects p ON t.project_id = p.id
+	LEFT JOIN projects np ON t.number_project_id = np.id
+`
+
+// the stored state of tasks by ID, taken before a change so the audit log can
+// compare it with the state after. missing IDs are left out
+func snapshotTasks(ctx context.Context, q sqlx.QueryerContext, ids []int64) (map[int64]*domain.Task, error) {
+	snapshot := make(map[int64]*domain.Task, len(ids))
+	if len(ids) == 0 {
+		return snapshot, nil
+	}
+
+	query, args := buildINQuery(taskSelect+" WHERE t.id IN (?)", ids)
+	var rows []dbTask
+	if err := sqlx.SelectContext(ctx, q, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to snapshot tasks: %w", err)
+	}
+
+	for _, row := range rows {
+		task, err := row.toTask()
+		if err != nil {
+			return nil, err
+		}
+		snapshot[task.ID] = task
+	}
+	return snapshot, nil
+}
+
+// snapshots the tasks a bulk operation with this filter touches
+func (r *TaskRepository) snapshotMatching(ctx context.Context, tx *sqlx.Tx, filter repository.TaskFilter) (map[int64]*domain.Task, error) {
+	whereQuery, args := r.buildBulkWhereClause(filter)
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids, "SELECT id FROM tasks"+whereQuery, args...); err != nil {
+		return nil, fmt.Errorf("failed to list affected tasks: %w", err)
+	}
+	return snapshotTasks(ctx, tx, ids)
+}
+
+// snapshots the tasks in a project, and in the projects below it when
+// withDescendants is set. a project merge or delete moves these tasks without
+// a statement on the tasks table naming them
+func snapshotProjectTasks(ctx context.Context, tx *sqlx.Tx, projectID int64, withDescendants bool) (map[int64]*domain.Task, error) {
+	query := `SELECT id FROM tasks WHERE project_id = ?`
+	if withDescendants {
+		query = `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM projects WHERE id = ?
+				UNION ALL
+				SELECT p.id FROM projects p INNER JOIN subtree s ON p.parent_id = s.id
+			)
+			SELECT id FROM tasks WHERE project_id IN (SELECT id FROM subtree)
+		`
+	}
+
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to list the project's tasks: %w", err)
+	}
+	return snapshotTasks(ctx, tx, ids)
+}
+
+// compares the snapshotted tasks with their current state and logs every
+// changed field, or a delete for tasks that are gone. unchanged tasks leave
+// no entries
+func recordChanges(ctx context.Context, tx *sqlx.Tx, before map[int64]*domain.Task, at time.Time) error {
+	ids := make([]int64, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	after, err := snapshotTasks(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		old := before[id]
+		current, ok := after[id]
+		if !ok {
+			if err := insertAuditEntry(ctx, tx, &domain.TaskAuditEntry{
+				TaskID:    id,
+				TaskTitle: old.Title,
+				Action:    domain.AuditActionDelete,
+				OldValue:  old.Title,
+				ChangedAt: at,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, change := range domain.DiffTasks(old, current) {
+			if err := insertAuditEntry(ctx, tx, &domain.TaskAuditEntry{
+				TaskID:    id,
+				TaskTitle: current.Title,
+				Action:    domain.AuditActionUpdate,
+				Field:     change.Field,
+				OldValue:  change.OldValue,
+				NewValue:  change.NewValue,
+				ChangedAt: at,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func insertAuditEntry(ctx context.Context, tx *sqlx.Tx, entry *domain.TaskAuditEntry) error {
+	query := `
+		INSERT INTO task_audit (task_id, task_title, action, field, old_value, new_value, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	if _, err := tx.ExecContext(ctx, query,
+		entry.TaskID,
+		entry.TaskTitle,
+		entry.Action,
+		entry.Field,
+		entry.OldValue,
+		entry.NewValue,
+		entry.ChangedAt,
+	); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// lists audit entries, newest first. the log is append-only, so the ID order
+// is the order the changes happened in
+func (r *TaskRepository) ListAudit(ctx context.Context, filter repository.AuditFilter) ([]*domain.TaskAuditEntry, error) {
+	query := `
+		SELECT id, task_id, task_title, action, field, old_value, new_value, changed_at
+		FROM task_audit
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if filter.TaskID != nil {
+		query += " AND task_id = ?"
+		args = append(args, *filter.TaskID)
+	}
+	if filter.Since != nil {
+		// the stored times carry their zone offset, datetime() compares them in UTC
+		query += " AND datetime(changed_at) >= datetime(?)"
+		args = append(args, filter.Since.UTC().Format("2006-01-02 15:04:05"))
+	}
+
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	var entries []*domain.TaskAuditEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,154 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"task-management/internal/domain"
+)
+
+// the columns a task row carries about its dependencies, see dbTask
+const dependencyColumns = `(SELECT group_concat(depends_on_id) FROM task_dependencies WHERE task_id = t.id) as depends_on,
+		(SELECT COUNT(*) ` + openBlockersFrom + `t.id) as open_blockers`
+
+// the unfinished dependencies of a task, completed with the column holding
+// its ID. cancelled dependencies don't block
+const openBlockersFrom = `FROM task_dependencies d
+			JOIN tasks b ON b.id = d.depends_on_id
+			WHERE b.status NOT IN ('completed', 'cancelled') AND d.task_id = `
+
+// errDependencyExists stops AddDependency without writing, adding a
+// dependency twice is a no-op
+var errDependencyExists = errors.New("dependency exists")
+
+// makes taskID wait for dependsOnID. the checks, the insert and its audit
+// entry share a transaction, and a locked task keeps its dependencies
+func (r *TaskRepository) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	check := func(tx *sqlx.Tx) error {
+		if err := validateDependency(ctx, tx, taskID, dependsOnID); err != nil {
+			return err
+		}
+		exists, err := dependencyExists(ctx, tx, taskID, dependsOnID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errDependencyExists
+		}
+		return nil
+	}
+
+	err := r.execAuditedChecked(ctx, taskID, check, "failed to add dependency",
+		`INSERT INTO task_dependencies (task_id, depends_on_id) SELECT id, ? FROM tasks WHERE id = ? AND is_locked = 0`,
+		dependsOnID, taskID,
+	)
+	if errors.Is(err, errDependencyExists) {
+		return nil
+	}
+	return err
+}
+
+// checks that both tasks exist and that taskID depending on dependsOnID
+// doesn't close a cycle, i.e. dependsOnID doesn't already wait for taskID
+func (r *TaskRepository) ValidateDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	return validateDependency(ctx, r.db, taskID, dependsOnID)
+}
+
+func validateDependency(ctx context.Context, q sqlx.QueryerContext, taskID, dependsOnID int64) error {
+	if taskID == dependsOnID {
+		return fmt.Errorf("task cannot depend on itself")
+	}
+
+	for _, id := range []int64{taskID, dependsOnID} {
+		var exists bool
+		if err := sqlx.GetContext(ctx, q, &exists, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)`, id); err != nil {
+			return fmt.Errorf("failed to get task: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("task not found: %d", id)
+		}
+	}
+
+	query := `
+		WITH RECURSIVE upstream AS (
+			SELECT depends_on_id AS id
+			FROM task_dependencies
+			WHERE task_id = ?
+
+			UNION
+
+			SELECT d.depends_on_id
+			FROM task_dependencies d
+			INNER JOIN upstream u ON d.task_id = u.id
+		)
+		SELECT COUNT(*) FROM upstream WHERE id = ?
+	`
+
+	var count int
+	if err := sqlx.GetContext(ctx, q, &count, query, dependsOnID, taskID); err != nil {
+		return fmt.Errorf("failed to validate dependency: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("cannot add dependency: task %d already depends on task %d, this would create a cycle", dependsOnID, taskID)
+	}
+	return nil
+}
+
+// stops taskID waiting for dependsOnID, audited and refused for a locked task
+// like AddDependency
+func (r *TaskRepository) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	check := func(tx *sqlx.Tx) error {
+		exists, err := dependencyExists(ctx, tx, taskID, dependsOnID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("task %d does not depend on task %d", taskID, dependsOnID)
+		}
+		return nil
+	}
+
+	return r.execAuditedChecked(ctx, taskID, check, "failed to remove dependency",
+		`DELETE FROM task_dependencies
+		WHERE task_id = ? AND depends_on_id = ?
+			AND task_id IN (SELECT id FROM tasks WHERE is_locked = 0)`,
+		taskID, dependsOnID,
+	)
+}
+
+func dependencyExists(ctx context.Context, q sqlx.QueryerContext, taskID, dependsOnID int64) (bool, error) {
+	var exists bool
+	if err := sqlx.GetContext(ctx, q, &exists,
+		`SELECT EXISTS (SELECT 1 FROM task_dependencies WHERE task_id = ? AND depends_on_id = ?)`,
+		taskID, dependsOnID,
+	); err != nil {
+		return false, fmt.Errorf("failed to check dependency: %w", err)
+	}
+	return exists, nil
+}
+
+// the unfinished tasks taskID depends on, in ID order
+func (r *TaskRepository) GetBlockers(ctx context.Context, taskID int64) ([]*domain.Task, error) {
+	query := taskSelect + `
+		WHERE t.id IN (SELECT depends_on_id FROM task_dependencies WHERE task_id = ?)
+			AND t.status NOT IN ('completed', 'cancelled')
+		ORDER BY t.id`
+
+	var rows []dbTask
+	if err := r.db.SelectContext(ctx, &rows, query, taskID); err != nil {
+		return nil, fmt.Errorf("failed to get blockers: %w", err)
+	}
+
+	blockers := make([]*domain.Task, 0, len(rows))
+	for _, row := range rows {
+		task, err := row.toTask()
+		if err != nil {
+			return nil, err
+		}
+		blockers = append(blockers, task)
+	}
+	return blockers, nil
+}
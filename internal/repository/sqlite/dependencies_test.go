@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+)
+
+func TestTaskRepository_Dependencies(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	create := func(title string) *domain.Task {
+		task := domain.NewTask(title)
+		require.NoError(t, repo.Create(ctx, task))
+		return task
+	}
+	schema := create("Schema")
+	api := create("API")
+	deploy := create("Deploy")
+
+	// deploy waits for api, which waits for schema
+	require.NoError(t, repo.AddDependency(ctx, deploy.ID, api.ID))
+	require.NoError(t, repo.AddDependency(ctx, api.ID, schema.ID))
+	require.NoError(t, repo.AddDependency(ctx, api.ID, schema.ID), "adding twice is a no-op")
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		assert.Error(t, repo.AddDependency(ctx, schema.ID, schema.ID))
+		assert.Error(t, repo.AddDependency(ctx, schema.ID, api.ID))
+		assert.Error(t, repo.AddDependency(ctx, schema.ID, deploy.ID), "indirect cycles too")
+		assert.Error(t, repo.AddDependency(ctx, deploy.ID, 9999))
+
+		fetched, err := repo.GetByID(ctx, schema.ID)
+		require.NoError(t, err)
+		assert.Empty(t, fetched.DependsOn)
+	})
+
+	t.Run("loads dependencies with the task", func(t *testing.T) {
+		require.NoError(t, repo.AddDependency(ctx, deploy.ID, schema.ID))
+		fetched, err := repo.GetByID(ctx, deploy.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{schema.ID, api.ID}, fetched.DependsOn)
+		assert.Equal(t, 2, fetched.OpenBlockers)
+		assert.True(t, fetched.IsBlocked())
+	})
+
+	t.Run("blockers resolve as they finish", func(t *testing.T) {
+		blockers, err := repo.GetBlockers(ctx, deploy.ID)
+		require.NoError(t, err)
+		require.Len(t, blockers, 2)
+		assert.Equal(t, schema.ID, blockers[0].ID)
+
+		blocked, err := repo.List(ctx, repository.TaskFilter{BlockedOnly: true})
+		require.NoError(t, err)
+		assert.Len(t, blocked, 2)
+
+		schema.Status = domain.StatusCompleted
+		require.NoError(t, repo.Update(ctx, schema))
+		blockers, err = repo.GetBlockers(ctx, deploy.ID)
+		require.NoError(t, err)
+		require.Len(t, blockers, 1)
+		assert.Equal(t, api.ID, blockers[0].ID)
+
+		ready, err := repo.List(ctx, repository.ReadyFilter(nil))
+		require.NoError(t, err)
+		require.Len(t, ready, 1, "api is unblocked now, deploy still waits")
+		assert.Equal(t, api.ID, ready[0].ID)
+
+		api.Status = domain.StatusCancelled
+		require.NoError(t, repo.Update(ctx, api))
+		fetched, err := repo.GetByID(ctx, deploy.ID)
+		require.NoError(t, err)
+		assert.False(t, fetched.IsBlocked(), "cancelled dependencies don't block")
+		assert.Len(t, fetched.DependsOn, 2)
+	})
+
+	t.Run("changes are audited and locked tasks keep theirs", func(t *testing.T) {
+		docs := create("Docs")
+		require.NoError(t, repo.AddDependency(ctx, docs.ID, deploy.ID))
+		entries, err := repo.ListAudit(ctx, repository.AuditFilter{TaskID: &docs.ID})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "depends_on", entries[0].Field)
+		assert.Equal(t, fmt.Sprintf("#%d", deploy.ID), entries[0].NewValue)
+
+		require.NoError(t, repo.SetLocked(ctx, docs.ID, true))
+		var locked *repository.LockedTaskError
+		assert.ErrorAs(t, repo.AddDependency(ctx, docs.ID, schema.ID), &locked)
+		assert.ErrorAs(t, repo.RemoveDependency(ctx, docs.ID, deploy.ID), &locked)
+
+		require.NoError(t, repo.SetLocked(ctx, docs.ID, false))
+		require.NoError(t, repo.RemoveDependency(ctx, docs.ID, deploy.ID))
+		entries, err = repo.ListAudit(ctx, repository.AuditFilter{TaskID: &docs.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "depends_on", entries[0].Field)
+		assert.Empty(t, entries[0].NewValue)
+		require.NoError(t, repo.Delete(ctx, docs.ID))
+	})
+
+	t.Run("remove and delete", func(t *testing.T) {
+		require.NoError(t, repo.RemoveDependency(ctx, deploy.ID, schema.ID))
+		assert.Error(t, repo.RemoveDependency(ctx, deploy.ID, schema.ID))
+
+		require.NoError(t, repo.Delete(ctx, api.ID))
+		fetched, err := repo.GetByID(ctx, deploy.ID)
+		require.NoError(t, err)
+		assert.Empty(t, fetched.DependsOn, "deleting a task drops its dependencies")
+	})
+}
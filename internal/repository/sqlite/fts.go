@@ -0,0 +1,162 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+)
+
+// the full-text index over task titles, descriptions and tags. it stores no
+// text of its own, tasks is its content table
+const createTaskSearchTable = `CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+	title, description, tags,
+	content = 'tasks', content_rowid = 'id'
+)`
+
+// keep tasks_fts in step with tasks. an external content index has to be
+// told the old values to remove them
+var taskSearchTriggers = []struct{ name, statement string }{
+	{"tasks_fts_insert", `CREATE TRIGGER IF NOT EXISTS tasks_fts_insert AFTER INSERT ON tasks BEGIN
+		INSERT INTO tasks_fts (rowid, title, description, tags) VALUES (new.id, new.title, new.description, new.tags);
+	END`},
+	{"tasks_fts_delete", `CREATE TRIGGER IF NOT EXISTS tasks_fts_delete AFTER DELETE ON tasks BEGIN
+		INSERT INTO tasks_fts (tasks_fts, rowid, title, description, tags) VALUES ('delete', old.id, old.title, old.description, old.tags);
+	END`},
+	{"tasks_fts_update", `CREATE TRIGGER IF NOT EXISTS tasks_fts_update AFTER UPDATE OF title, description, tags ON tasks BEGIN
+		INSERT INTO tasks_fts (tasks_fts, rowid, title, description, tags) VALUES ('delete', old.id, old.title, old.description, old.tags);
+		INSERT INTO tasks_fts (rowid, title, description, tags) VALUES (new.id, new.title, new.description, new.tags);
+	END`},
+}
+
+// the matches with their bm25 rank, lower is better. the title counts most,
+// then tags, then the description, like the ranking of plain text search
+const ftsRanks = `WITH fts_ranks AS (
+		SELECT rowid AS id, bm25(tasks_fts, 3.0, 1.0, 2.0) AS rank FROM tasks_fts WHERE tasks_fts MATCH ?
+	)`
+
+// whether this SQLite was built with FTS5, go-sqlite3 needs the
+// sqlite_fts5 build tag for it
+func fts5Available(db *sql.DB) (bool, error) {
+	var enabled bool
+	if err := db.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&enabled); err != nil {
+		return false, fmt.Errorf("failed to check for FTS5: %w", err)
+	}
+	return enabled, nil
+}
+
+// creates tasks_fts and its triggers, filling the index from tasks when the
+// triggers are new. without FTS5 the triggers are dropped instead: they
+// would fail every write to tasks, and a database a build without FTS5 has
+// written to gets its index rebuilt by the next build with it. reports
+// whether the index can be used
+func ensureTaskSearchIndex(db *sql.DB) (bool, error) {
+	available, err := fts5Available(db)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if !available {
+		for _, trigger := range taskSearchTriggers {
+			if _, err := tx.Exec(`DROP TRIGGER IF EXISTS ` + trigger.name); err != nil {
+				return false, fmt.Errorf("failed to drop %s: %w", trigger.name, err)
+			}
+		}
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.Exec(createTaskSearchTable); err != nil {
+		return false, fmt.Errorf("failed to create the search index: %w", err)
+	}
+
+	triggers, err := countTaskSearchTriggers(tx)
+	if err != nil {
+		return false, err
+	}
+	if triggers < len(taskSearchTriggers) {
+		for _, trigger := range taskSearchTriggers {
+			if _, err := tx.Exec(trigger.statement); err != nil {
+				return false, fmt.Errorf("failed to create %s: %w", trigger.name, err)
+			}
+		}
+		if _, err := tx.Exec(`INSERT INTO tasks_fts (tasks_fts) VALUES ('rebuild')`); err != nil {
+			return false, fmt.Errorf("failed to build the search index: %w", err)
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// a read-only database can only use an index some earlier open kept in sync
+func taskSearchIndexReady(db *sql.DB) (bool, error) {
+	available, err := fts5Available(db)
+	if err != nil || !available {
+		return false, err
+	}
+	triggers, err := countTaskSearchTriggers(db)
+	if err != nil {
+		return false, err
+	}
+	return triggers == len(taskSearchTriggers), nil
+}
+
+func countTaskSearchTriggers(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}) (int, error) {
+	names := make([]any, len(taskSearchTriggers))
+	for i, trigger := range taskSearchTriggers {
+		names[i] = trigger.name
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name IN (?` + strings.Repeat(", ?", len(names)-1) + `)`
+	if err := q.QueryRow(query, names...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to check the search index: %w", err)
+	}
+	return count, nil
+}
+
+// the FTS5 query for a search: every word has to appear, as a word or the
+// start of one. words are quoted so that FTS5 syntax in them is taken
+// literally. empty when there are no words
+func ftsMatch(search string) string {
+	words := strings.Fields(search)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+// fts searches go through the index when there is one and fall back to
+// plain text search when there isn't
+func (r *TaskRepository) resolveSearchMode(filter repository.TaskFilter) repository.TaskFilter {
+	if filter.SearchMode == "fts" && (!r.db.fullTextSearch || ftsMatch(filter.SearchQuery) == "") {
+		filter.SearchMode = "text"
+	}
+	return filter
+}
+
+// lists the matches joined with their ranks, best first, the requested sort
+// only breaks ties. the rank is worked out once per match, looking it up per
+// row would run the whole match again for each one
+func (r *TaskRepository) listWithFTS(ctx context.Context, filter repository.TaskFilter) ([]*domain.Task, error) {
+	unsearched := filter
+	unsearched.SearchQuery = ""
+	unsearched.SearchMode = ""
+	conditions, conditionArgs := taskConditions(unsearched)
+
+	query := ftsRanks + taskSelect + ` JOIN fts_ranks ON fts_ranks.id = t.id WHERE 1=1` + conditions +
+		` ORDER BY fts_ranks.rank,` + strings.TrimPrefix(r.buildOrderClause(filter), " ORDER BY")
+	args := append([]interface{}{ftsMatch(filter.SearchQuery)}, conditionArgs...)
+
+	return r.selectTasks(ctx, query, args, filter)
+}
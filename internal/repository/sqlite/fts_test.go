@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+	"task-management/internal/repository"
+)
+
+func taskIDs(tasks []*domain.Task) []int64 {
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+// without FTS5 compiled in (go test -tags sqlite_fts5) fts searches fall
+// back to LIKE, so these pass either way and the index is only exercised with
+// the tag
+func TestTaskRepository_FTSMatchesTextSearch(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	for _, task := range searchTestTasks() {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	// words or word starts, no project names: where the two modes agree
+	for _, search := range []string{"authentication", "AUTHENTICATION", "password", "auth", "login", "log", "Documentation", "login bug", "nonexistent"} {
+		text, err := repo.List(ctx, repository.TaskFilter{SearchQuery: search, SearchMode: "text"})
+		require.NoError(t, err)
+		fts, err := repo.List(ctx, repository.TaskFilter{SearchQuery: search, SearchMode: "fts"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, taskIDs(text), taskIDs(fts), "search %q", search)
+
+		count, err := repo.Count(ctx, repository.TaskFilter{SearchQuery: search, SearchMode: "fts"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(fts)), count, "count %q", search)
+	}
+
+	t.Run("title matches rank first", func(t *testing.T) {
+		tasks, err := repo.List(ctx, repository.TaskFilter{SearchQuery: "login", SearchMode: "fts", SortBy: "created_at", SortOrder: "asc"})
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, "Fix login bug", tasks[0].Title)
+	})
+
+	t.Run("combined with filters", func(t *testing.T) {
+		tasks, err := repo.List(ctx, repository.TaskFilter{SearchQuery: "auth", SearchMode: "fts", Priority: domain.PriorityHigh})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, "Implement user authentication", tasks[0].Title)
+	})
+
+	t.Run("FTS5 syntax is taken literally", func(t *testing.T) {
+		for _, search := range []string{`"login`, "login OR docs", "auth*", "NEAR(", "-bug", "   "} {
+			_, err := repo.List(ctx, repository.TaskFilter{SearchQuery: search, SearchMode: "fts"})
+			assert.NoError(t, err, "search %q", search)
+		}
+	})
+}
+
+func TestTaskRepository_FTSIndexStaysInSync(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+	if !db.FullTextSearch() {
+		t.Skip("SQLite built without FTS5, run with -tags sqlite_fts5")
+	}
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	search := func(text string) []int64 {
+		tasks, err := repo.List(ctx, repository.TaskFilter{SearchQuery: text, SearchMode: "fts"})
+		require.NoError(t, err)
+		return taskIDs(tasks)
+	}
+
+	task := &domain.Task{Title: "Rotate keys", Description: "before the audit", Status: domain.StatusPending, Priority: domain.PriorityMedium, Tags: []string{"ops"}}
+	require.NoError(t, repo.Create(ctx, task))
+	assert.Equal(t, []int64{task.ID}, search("rotate"))
+	assert.Equal(t, []int64{task.ID}, search("ops"))
+
+	task.Title = "Renew certificates"
+	require.NoError(t, repo.Update(ctx, task))
+	assert.Empty(t, search("rotate"))
+	assert.Equal(t, []int64{task.ID}, search("certificates"))
+
+	_, err := repo.BulkAddTags(ctx, repository.TaskFilter{IDs: []int64{task.ID}}, []string{"security"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{task.ID}, search("security"))
+
+	require.NoError(t, repo.Delete(ctx, task.ID))
+	assert.Empty(t, search("certificates"))
+}
+
+func TestNewDB_RebuildsFTSIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	db, err := NewDB(Config{Path: path})
+	require.NoError(t, err)
+	if !db.FullTextSearch() {
+		db.Close()
+		t.Skip("SQLite built without FTS5, run with -tags sqlite_fts5")
+	}
+
+	// what a build without FTS5 leaves behind: no triggers and tasks the
+	// index has never seen
+	for _, trigger := range taskSearchTriggers {
+		_, err := db.Exec(`DROP TRIGGER ` + trigger.name)
+		require.NoError(t, err)
+	}
+	repo := NewTaskRepository(db)
+	task := &domain.Task{Title: "Written elsewhere", Status: domain.StatusPending, Priority: domain.PriorityLow, Tags: []string{}}
+	require.NoError(t, repo.Create(context.Background(), task))
+	require.NoError(t, db.Close())
+
+	readOnly, err := NewDB(Config{Path: path, ReadOnly: true})
+	require.NoError(t, err)
+	assert.False(t, readOnly.FullTextSearch(), "a stale index isn't used")
+	require.NoError(t, readOnly.Close())
+
+	db, err = NewDB(Config{Path: path})
+	require.NoError(t, err)
+	defer db.Close()
+	assert.True(t, db.FullTextSearch())
+
+	tasks, err := NewTaskRepository(db).List(context.Background(), repository.TaskFilter{SearchQuery: "elsewhere", SearchMode: "fts"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{task.ID}, taskIDs(tasks))
+}
+
+func TestFTSMatch(t *testing.T) {
+	assert.Equal(t, `"login"* "bug"*`, ftsMatch("  login bug "))
+	assert.Equal(t, `"say"* """hi"""*`, ftsMatch(`say "hi"`))
+	assert.Empty(t, ftsMatch(" "))
+}
+
+func BenchmarkTaskRepository_Search(b *testing.B) {
+	dir, err := os.MkdirTemp("", "taskflow_bench_*")
+	require.NoError(b, err)
+	defer os.RemoveAll(dir)
+
+	db, err := NewDB(Config{Path: filepath.Join(dir, "tasks.db")})
+	require.NoError(b, err)
+	defer db.Close()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	words := []string{"deploy", "refactor", "login", "billing", "cache", "report", "migrate", "invoice", "search", "audit"}
+	tasks := make([]*domain.Task, 5000)
+	for i := range tasks {
+		tasks[i] = &domain.Task{
+			Title:       fmt.Sprintf("%s the %s service %d", words[i%len(words)], words[(i/7)%len(words)], i),
+			Description: fmt.Sprintf("follow up on %s after the %s review", words[(i/3)%len(words)], words[(i/11)%len(words)]),
+			Status:      domain.StatusPending,
+			Priority:    domain.PriorityMedium,
+			Tags:        []string{words[(i/5)%len(words)]},
+		}
+	}
+	require.NoError(b, repo.CreateBatch(ctx, tasks))
+
+	// a word in many of the tasks and one in a single task
+	for _, search := range []string{"invoice", "4321"} {
+		for _, mode := range []string{"text", "fts"} {
+			b.Run(search+"/"+mode, func(b *testing.B) {
+				if mode == "fts" && !db.FullTextSearch() {
+					b.Skip("SQLite built without FTS5, run with -tags sqlite_fts5")
+				}
+				filter := repository.TaskFilter{SearchQuery: search, SearchMode: mode, Limit: 50}
+				for b.Loop() {
+					if _, err := repo.List(ctx, filter); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -260,10 +260,10 @@ func TestFuzzySearchThreshold(t *testing.T) {
 	}
 
 	tests := []struct {
-		name           string
-		searchQuery    string
-		threshold      int
-		expectResults  bool
+		name          string
+		searchQuery   string
+		threshold     int
+		expectResults bool
 	}{
 		{
 			name:          "high threshold - exact match passes",
@@ -517,3 +517,95 @@ func TestFuzzySearchDescriptionAndTags(t *testing.T) {
 		})
 	}
 }
+
+func TestFuzzySearchAlgorithm(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	tasks := []*domain.Task{
+		{Title: "Backend API", Priority: domain.PriorityHigh, Status: domain.StatusPending},
+		{Title: "Frontend Dashboard", Priority: domain.PriorityMedium, Status: domain.StatusPending},
+	}
+
+	for _, task := range tasks {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	filter := repository.TaskFilter{
+		SearchQuery:    "bakcend",
+		SearchMode:     "fuzzy",
+		FuzzyThreshold: 60,
+	}
+
+	results, err := repo.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected subsequence scorer to reject transposed letters, got %d results", len(results))
+	}
+
+	filter.FuzzyAlgorithm = "levenshtein"
+	results, err = repo.List(ctx, filter)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Title != "Backend API" {
+		t.Errorf("Expected levenshtein scorer to find 'Backend API', got %d results", len(results))
+	}
+
+	filter.FuzzyAlgorithm = "unknown"
+	if _, err := repo.List(ctx, filter); err == nil {
+		t.Error("Expected error for unknown fuzzy algorithm")
+	}
+}
+
+func TestSearchRanksTitleMatchesFirst(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	// created oldest first so the default newest-first sort alone would
+	// return them in the opposite order
+	tasks := []*domain.Task{
+		{Title: "Deploy", Description: "Ship it", Priority: domain.PriorityMedium, Status: domain.StatusPending},
+		{Title: "Release notes", Tags: []string{"deploy"}, Priority: domain.PriorityMedium, Status: domain.StatusPending},
+		{Title: "Write changelog", Description: "Deploy", Priority: domain.PriorityMedium, Status: domain.StatusPending},
+	}
+	for _, task := range tasks {
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	want := []string{"Deploy", "Release notes", "Write changelog"}
+
+	for _, mode := range []string{"text", "fuzzy"} {
+		t.Run(mode, func(t *testing.T) {
+			results, err := repo.List(ctx, repository.TaskFilter{
+				SearchQuery:    "deploy",
+				SearchMode:     mode,
+				FuzzyThreshold: 60,
+			})
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(results) != len(want) {
+				t.Fatalf("Expected %d results, got %d", len(want), len(results))
+			}
+			for i, task := range results {
+				if task.Title != want[i] {
+					t.Errorf("result %d = %q, want %q", i, task.Title, want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,464 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	"task-management/internal/domain"
+)
+
+// a schema change. migrations run in version order, each in its own
+// transaction, and every applied version is recorded in schema_migrations.
+// new migrations are appended with the next version, never edited or
+// reordered once released
+type migration struct {
+	version     int
+	description string
+	up          func(tx *sql.Tx) error
+}
+
+var migrations = []migration{
+	{1, "create base schema", execStatements(baseSchema...)},
+	{2, "add project aliases", steps(
+		addColumn("projects", "aliases", "TEXT DEFAULT '[]'"),
+		execStatements(`CREATE INDEX IF NOT EXISTS idx_projects_aliases ON projects(aliases)`),
+	)},
+	{3, "add project notes", addColumn("projects", "notes", "TEXT DEFAULT ''")},
+	{4, "add project sprints", steps(
+		addColumn("projects", "sprint_cadence", "TEXT DEFAULT ''"),
+		addColumn("projects", "sprint_number", "INTEGER DEFAULT 0"),
+		addColumn("projects", "sprint_started_at", "DATETIME"),
+	)},
+	{5, "add task positions", addColumn("tasks", "position", "INTEGER NOT NULL DEFAULT 0")},
+	{6, "add task completion times", steps(
+		addColumn("tasks", "completed_at", "DATETIME"),
+		// tasks completed before completed_at existed get their last update as
+		// the closest guess
+		execStatements(`UPDATE tasks SET completed_at = updated_at WHERE status = 'completed' AND completed_at IS NULL`),
+	)},
+	{7, "add pinned tasks", steps(
+		addColumn("tasks", "is_pinned", "BOOLEAN NOT NULL DEFAULT 0"),
+		execStatements(`CREATE INDEX IF NOT EXISTS idx_tasks_is_pinned ON tasks(is_pinned)`),
+	)},
+	{8, "add task snoozing", addColumn("tasks", "snoozed_until", "DATETIME")},
+	{9, "create task audit log", execStatements(
+		// no foreign key, the log outlives deleted tasks
+		`CREATE TABLE IF NOT EXISTS task_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id INTEGER NOT NULL,
+			task_title TEXT NOT NULL,
+			action TEXT NOT NULL,
+			field TEXT NOT NULL DEFAULT '',
+			old_value TEXT NOT NULL DEFAULT '',
+			new_value TEXT NOT NULL DEFAULT '',
+			changed_at DATETIME NOT NULL,
+
+			CHECK(action IN ('create', 'update', 'delete'))
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_audit_task_id ON task_audit(task_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_audit_changed_at ON task_audit(changed_at)`,
+		`CREATE TRIGGER IF NOT EXISTS task_audit_no_update
+			BEFORE UPDATE ON task_audit
+		BEGIN
+			SELECT RAISE(ABORT, 'task_audit is append-only');
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS task_audit_no_delete
+			BEFORE DELETE ON task_audit
+		BEGIN
+			SELECT RAISE(ABORT, 'task_audit is append-only');
+		END`,
+	)},
+	{10, "add task waiting on", addColumn("tasks", "waiting_on", "TEXT NOT NULL DEFAULT ''")},
+	{11, "add locked tasks", addColumn("tasks", "is_locked", "BOOLEAN NOT NULL DEFAULT 0")},
+	{12, "add template versions", steps(
+		addColumn("project_templates", "version", "INTEGER NOT NULL DEFAULT 1"),
+		// no foreign key, a project keeps its tasks when the template is deleted
+		addColumn("projects", "source_template_id", "INTEGER"),
+		addColumn("projects", "source_template_version", "INTEGER NOT NULL DEFAULT 0"),
+	)},
+	{13, "add per-project task numbers", steps(
+		// the project a task was numbered in, kept when the task moves. no
+		// foreign key, the number stays unique after the project is deleted
+		addColumn("tasks", "number_project_id", "INTEGER"),
+		addColumn("tasks", "project_task_number", "INTEGER NOT NULL DEFAULT 0"),
+		execStatements(
+			`CREATE TABLE IF NOT EXISTS project_task_numbers (
+				project_id INTEGER PRIMARY KEY,
+				last_number INTEGER NOT NULL DEFAULT 0
+			)`,
+			// existing tasks are numbered in the order they were created
+			`UPDATE tasks SET
+				number_project_id = project_id,
+				project_task_number = (
+					SELECT COUNT(*) FROM tasks t2
+					WHERE t2.project_id = tasks.project_id AND t2.id <= tasks.id
+				)
+			WHERE project_id IS NOT NULL`,
+			`INSERT INTO project_task_numbers (project_id, last_number)
+				SELECT number_project_id, MAX(project_task_number) FROM tasks
+				WHERE number_project_id IS NOT NULL
+				GROUP BY number_project_id`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_project_number
+				ON tasks(number_project_id, project_task_number)
+				WHERE number_project_id IS NOT NULL`,
+		),
+	)},
+	{14, "add task colors", addColumn("tasks", "color", "TEXT NOT NULL DEFAULT ''")},
+	{15, "add recurring tasks", addColumn("tasks", "recurrence", "TEXT NOT NULL DEFAULT ''")},
+	{16, "create task dependencies", execStatements(
+		`CREATE TABLE IF NOT EXISTS task_dependencies (
+			task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+			depends_on_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+			PRIMARY KEY (task_id, depends_on_id),
+			CHECK(task_id != depends_on_id)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on ON task_dependencies(depends_on_id)`,
+	)},
+	{17, "add project key prefixes", steps(
+		addColumn("projects", "key_prefix", "TEXT NOT NULL DEFAULT ''"),
+		backfillKeyPrefixes,
+		execStatements(`CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_key_prefix ON projects(key_prefix) WHERE key_prefix != ''`),
+	)},
+	{18, "flag the inbox project", steps(
+		addColumn("projects", "is_inbox", "BOOLEAN NOT NULL DEFAULT 0"),
+		execStatements(
+			// until now the inbox was the project with its name
+			`UPDATE projects SET is_inbox = 1 WHERE name = 'Inbox'`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_inbox ON projects(is_inbox) WHERE is_inbox = 1`,
+		),
+	)},
+}
+
+var baseSchema = []string{
+	// create projects table
+	`CREATE TABLE IF NOT EXISTS projects (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT,
+		parent_id INTEGER,
+		color TEXT,
+		icon TEXT,
+		status TEXT NOT NULL DEFAULT 'active',
+		is_favorite BOOLEAN NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		CHECK(name != ''),
+		CHECK(length(name) <= 100),
+		CHECK(length(description) <= 500),
+		CHECK(status IN ('active', 'archived', 'completed')),
+		CHECK(parent_id != id),
+		FOREIGN KEY (parent_id) REFERENCES projects(id) ON DELETE CASCADE
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_projects_name ON projects(name)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_parent_id ON projects(parent_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_status ON projects(status)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_is_favorite ON projects(is_favorite)`,
+	`CREATE INDEX IF NOT EXISTS idx_projects_created_at ON projects(created_at)`,
+
+	`CREATE TRIGGER IF NOT EXISTS update_projects_updated_at
+		AFTER UPDATE ON projects
+		FOR EACH ROW
+	BEGIN
+		UPDATE projects SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+	END`,
+
+	// create tasks table
+	`CREATE TABLE IF NOT EXISTS tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT,
+		priority TEXT NOT NULL DEFAULT 'medium',
+		status TEXT NOT NULL DEFAULT 'pending',
+		tags TEXT,
+		project_id INTEGER,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		due_date DATETIME,
+
+		CHECK(title != ''),
+		CHECK(length(title) <= 200),
+		CHECK(length(description) <= 1000),
+		CHECK(priority IN ('low', 'medium', 'high', 'urgent')),
+		CHECK(status IN ('pending', 'in_progress', 'completed', 'cancelled')),
+		FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date)`,
+	`CREATE INDEX IF NOT EXISTS idx_tasks_created_at ON tasks(created_at)`,
+
+	`CREATE TRIGGER IF NOT EXISTS update_tasks_updated_at
+		AFTER UPDATE ON tasks
+		FOR EACH ROW
+	BEGIN
+		UPDATE tasks SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+	END`,
+
+	// create project_templates table
+	`CREATE TABLE IF NOT EXISTS project_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT,
+		task_definitions TEXT NOT NULL,
+		project_defaults TEXT,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		CHECK(name != ''),
+		CHECK(length(name) <= 100),
+		CHECK(length(description) <= 500)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_templates_name ON project_templates(name)`,
+	`CREATE INDEX IF NOT EXISTS idx_templates_created_at ON project_templates(created_at)`,
+
+	`CREATE TRIGGER IF NOT EXISTS update_templates_updated_at
+		AFTER UPDATE ON project_templates
+		FOR EACH ROW
+	BEGIN
+		UPDATE project_templates SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+	END`,
+
+	// create saved_views table
+	`CREATE TABLE IF NOT EXISTS saved_views (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT,
+		filter_config TEXT NOT NULL,
+		is_favorite BOOLEAN NOT NULL DEFAULT 0,
+		hot_key INTEGER,
+		last_accessed DATETIME,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		CHECK(name != ''),
+		CHECK(length(name) <= 100),
+		CHECK(length(description) <= 500),
+		CHECK(hot_key IS NULL OR (hot_key >= 1 AND hot_key <= 9)),
+		UNIQUE(hot_key)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_saved_views_name ON saved_views(name)`,
+	`CREATE INDEX IF NOT EXISTS idx_saved_views_is_favorite ON saved_views(is_favorite)`,
+	`CREATE INDEX IF NOT EXISTS idx_saved_views_hot_key ON saved_views(hot_key)`,
+	`CREATE INDEX IF NOT EXISTS idx_saved_views_last_accessed ON saved_views(last_accessed DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_saved_views_created_at ON saved_views(created_at)`,
+
+	`CREATE TRIGGER IF NOT EXISTS update_saved_views_updated_at
+		AFTER UPDATE ON saved_views
+		FOR EACH ROW
+	BEGIN
+		UPDATE saved_views SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+	END`,
+
+	// create search_history table
+	`CREATE TABLE IF NOT EXISTS search_history (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		query_text TEXT NOT NULL,
+		search_mode TEXT NOT NULL,
+		fuzzy_threshold INTEGER,
+		query_type TEXT NOT NULL,
+		project_filter TEXT,
+		result_count INTEGER DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+		CHECK(query_text != ''),
+		CHECK(search_mode IN ('text', 'regex', 'fuzzy')),
+		CHECK(query_type IN ('simple', 'query_language', 'project_mention')),
+		CHECK(fuzzy_threshold IS NULL OR (fuzzy_threshold >= 0 AND fuzzy_threshold <= 100))
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_search_history_updated_at ON search_history(updated_at DESC)`,
+	`CREATE INDEX IF NOT EXISTS idx_search_history_query_text ON search_history(query_text)`,
+
+	`CREATE TRIGGER IF NOT EXISTS update_search_history_updated_at
+		AFTER UPDATE ON search_history
+		FOR EACH ROW
+	BEGIN
+		UPDATE search_history SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+	END`,
+}
+
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// brings the schema up to the latest version
+func runMigrations(db *sql.DB) error {
+	return migrate(db, migrations)
+}
+
+// applies the migrations newer than the recorded schema version. a database
+// written by a newer taskflow is refused rather than used with a schema this
+// version doesn't know
+func migrate(db *sql.DB, migrations []migration) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+
+	latest := migrations[len(migrations)-1].version
+	if current > latest {
+		return fmt.Errorf("database schema version %d is newer than the latest version %d this taskflow knows, upgrade taskflow to open it", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.description, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := m.up(tx); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`INSERT INTO schema_migrations (version, description) VALUES (?, ?)`, m.version, m.description); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// the highest applied migration, 0 for a database that has none recorded
+func schemaVersion(db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// gives every project the key prefix its name gives, oldest project first, so
+// that the keys in use keep working. names that only differ in case or
+// punctuation get numbered prefixes
+func backfillKeyPrefixes(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT id, name FROM projects WHERE key_prefix = '' ORDER BY id`)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	prefixes := make(map[int64]string)
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read project: %w", err)
+		}
+		base := domain.ProjectKeyPrefix(name)
+		if base == "" {
+			continue
+		}
+		prefix := domain.UniqueKeyPrefix(base, func(p string) bool { return taken[p] })
+		taken[prefix] = true
+		prefixes[id] = prefix
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	for id, prefix := range prefixes {
+		if _, err := tx.Exec(`UPDATE projects SET key_prefix = ? WHERE id = ?`, prefix, id); err != nil {
+			return fmt.Errorf("failed to set key prefix of project %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+func execStatements(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for i, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to execute statement %d: %w", i+1, err)
+			}
+		}
+		return nil
+	}
+}
+
+func steps(fns ...func(tx *sql.Tx) error) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, fn := range fns {
+			if err := fn(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// adds a column unless it is already there. databases created before
+// schema_migrations existed have some of the columns without a recorded
+// version
+func addColumn(table, column, definition string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		exists, err := columnExists(tx, table, column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+		}
+		return nil
+	}
+}
+
+func columnExists(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
@@ -0,0 +1,229 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"task-management/internal/domain"
+)
+
+func TestMigrations(t *testing.T) {
+	tempDBPath := func(t *testing.T) string {
+		return filepath.Join(t.TempDir(), "tasks.db")
+	}
+
+	t.Run("versions are ordered", func(t *testing.T) {
+		for i := 1; i < len(migrations); i++ {
+			assert.Greater(t, migrations[i].version, migrations[i-1].version)
+		}
+	})
+
+	t.Run("fresh database ends at the latest version", func(t *testing.T) {
+		db, err := NewDB(Config{Path: tempDBPath(t)})
+		require.NoError(t, err)
+		defer db.Close()
+
+		version, err := schemaVersion(db.DB.DB)
+		require.NoError(t, err)
+		assert.Equal(t, latestSchemaVersion(), version)
+	})
+
+	t.Run("in-memory database is fully migrated", func(t *testing.T) {
+		db, err := NewDB(Config{Path: ":memory:"})
+		require.NoError(t, err)
+		defer db.Close()
+
+		version, err := schemaVersion(db.DB.DB)
+		require.NoError(t, err)
+		assert.Equal(t, latestSchemaVersion(), version)
+
+		// a second query must see the same database the migrations ran on
+		_, err = db.Exec(`INSERT INTO tasks (title, snoozed_until) VALUES ('t', NULL)`)
+		assert.NoError(t, err)
+	})
+
+	t.Run("partially migrated database is brought up to date", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		raw, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		require.NoError(t, migrate(raw, migrations[:4]))
+		_, err = raw.Exec(`INSERT INTO tasks (title, status) VALUES ('old', 'completed')`)
+		require.NoError(t, err)
+		require.NoError(t, raw.Close())
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		defer db.Close()
+
+		version, err := schemaVersion(db.DB.DB)
+		require.NoError(t, err)
+		assert.Equal(t, latestSchemaVersion(), version)
+
+		var completedAt sql.NullString
+		require.NoError(t, db.QueryRow(`SELECT completed_at FROM tasks WHERE title = 'old'`).Scan(&completedAt))
+		assert.True(t, completedAt.Valid, "completion time should be backfilled")
+	})
+
+	t.Run("database without recorded versions is adopted", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		_, err = db.Exec(`DROP TABLE schema_migrations`)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		db, err = NewDB(Config{Path: path})
+		require.NoError(t, err)
+		defer db.Close()
+
+		version, err := schemaVersion(db.DB.DB)
+		require.NoError(t, err)
+		assert.Equal(t, latestSchemaVersion(), version)
+	})
+
+	t.Run("newer database is refused", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO schema_migrations (version, description) VALUES (?, 'from the future')`, latestSchemaVersion()+1)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		_, err = NewDB(Config{Path: path})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "newer")
+	})
+
+	t.Run("existing tasks are numbered per project", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		raw, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		require.NoError(t, migrate(raw, migrations[:12]))
+		_, err = raw.Exec(`INSERT INTO projects (id, name) VALUES (1, 'Backend'), (2, 'Web')`)
+		require.NoError(t, err)
+		_, err = raw.Exec(`INSERT INTO tasks (title, project_id) VALUES ('a', 1), ('b', 2), ('c', 1), ('d', NULL)`)
+		require.NoError(t, err)
+		require.NoError(t, raw.Close())
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		defer db.Close()
+
+		var numbers []int
+		require.NoError(t, db.Select(&numbers, `SELECT project_task_number FROM tasks ORDER BY id`))
+		assert.Equal(t, []int{1, 1, 2, 0}, numbers)
+
+		projectID := int64(1)
+		task := &domain.Task{Title: "e", ProjectID: &projectID}
+		require.NoError(t, NewTaskRepository(db).Create(context.Background(), task))
+		assert.Equal(t, 3, task.ProjectTaskNumber)
+	})
+
+	t.Run("existing projects get key prefixes from their names", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		raw, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		require.NoError(t, migrate(raw, migrations[:16]))
+		_, err = raw.Exec(`INSERT INTO projects (id, name) VALUES (1, 'Mobile App'), (2, 'mobile-app'), (3, '!!!')`)
+		require.NoError(t, err)
+		require.NoError(t, raw.Close())
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		defer db.Close()
+
+		var prefixes []string
+		require.NoError(t, db.Select(&prefixes, `SELECT key_prefix FROM projects ORDER BY id`))
+		assert.Equal(t, []string{"MOBILE-APP", "MOBILE-APP-2", ""}, prefixes)
+	})
+
+	t.Run("the project named Inbox becomes the inbox", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		raw, err := sql.Open("sqlite3", path)
+		require.NoError(t, err)
+		require.NoError(t, migrate(raw, migrations[:17]))
+		_, err = raw.Exec(`INSERT INTO projects (id, name) VALUES (1, 'Work'), (2, 'Inbox')`)
+		require.NoError(t, err)
+		require.NoError(t, raw.Close())
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		defer db.Close()
+
+		inbox, err := NewProjectRepository(db).EnsureInbox(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), inbox.ID)
+		assert.True(t, inbox.IsInbox())
+	})
+
+	t.Run("read-only open reads but never writes", func(t *testing.T) {
+		path := tempDBPath(t)
+
+		db, err := NewDB(Config{Path: path})
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO tasks (title) VALUES ('existing')`)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+
+		ro, err := NewDB(Config{Path: path, ReadOnly: true})
+		require.NoError(t, err)
+		defer ro.Close()
+
+		var count int
+		require.NoError(t, ro.Get(&count, `SELECT COUNT(*) FROM tasks`))
+		assert.Equal(t, 1, count)
+
+		_, err = ro.Exec(`INSERT INTO tasks (title) VALUES ('new')`)
+		assert.Error(t, err)
+	})
+
+	t.Run("read-only open needs an existing database", func(t *testing.T) {
+		_, err := NewDB(Config{Path: tempDBPath(t), ReadOnly: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewDB_ForeignKeysOnEveryConnection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	ctx := context.Background()
+
+	// holding connections open makes the pool hand out new ones
+	checkConnections := func(t *testing.T, db *DB) {
+		var conns []*sql.Conn
+		defer func() {
+			for _, conn := range conns {
+				conn.Close()
+			}
+		}()
+		for range maxIdleConns {
+			conn, err := db.Conn(ctx)
+			require.NoError(t, err)
+			conns = append(conns, conn)
+
+			var enabled bool
+			require.NoError(t, conn.QueryRowContext(ctx, `PRAGMA foreign_keys`).Scan(&enabled))
+			assert.True(t, enabled, "connection %d", len(conns))
+		}
+	}
+
+	db, err := NewDB(Config{Path: path})
+	require.NoError(t, err)
+	checkConnections(t, db)
+	require.NoError(t, db.Close())
+
+	ro, err := NewDB(Config{Path: path, ReadOnly: true})
+	require.NoError(t, err)
+	defer ro.Close()
+	checkConnections(t, ro)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -21,18 +22,25 @@ func NewProjectRepository(db *DB) *ProjectRepository {
 }
 
 type dbProject struct {
-	ID          int64          `db:"id"`
-	Name        string         `db:"name"`
-	Description sql.NullString `db:"description"`
-	ParentID    sql.NullInt64  `db:"parent_id"`
-	Color       sql.NullString `db:"color"`
-	Icon        sql.NullString `db:"icon"`
-	Status      string         `db:"status"`
-	IsFavorite  bool           `db:"is_favorite"`
-	Aliases     sql.NullString `db:"aliases"`
-	Notes       sql.NullString `db:"notes"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   time.Time      `db:"updated_at"`
+	ID              int64          `db:"id"`
+	Name            string         `db:"name"`
+	Description     sql.NullString `db:"description"`
+	ParentID        sql.NullInt64  `db:"parent_id"`
+	Color           sql.NullString `db:"color"`
+	Icon            sql.NullString `db:"icon"`
+	Status          string         `db:"status"`
+	IsFavorite      bool           `db:"is_favorite"`
+	Aliases         sql.NullString `db:"aliases"`
+	Notes           sql.NullString `db:"notes"`
+	SprintCadence   sql.NullString `db:"sprint_cadence"`
+	SprintNumber    sql.NullInt64  `db:"sprint_number"`
+	SprintStartedAt sql.NullTime   `db:"sprint_started_at"`
+	SourceTemplateID      sql.NullInt64 `db:"source_template_id"`
+	SourceTemplateVersion int           `db:"source_template_version"`
+	KeyPrefix             string        `db:"key_prefix"`
+	IsInbox               bool          `db:"is_inbox"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"`
 }
 
 func (dp *dbProject) toProject() (*domain.Project, error) {
@@ -73,10 +81,30 @@ func (dp *dbProject) toProject() (*domain.Project, error) {
 		project.Notes = dp.Notes.String
 	}
 
+	if dp.SprintCadence.Valid {
+		project.SprintCadence = dp.SprintCadence.String
+	}
+
+	if dp.SprintNumber.Valid {
+		project.SprintNumber = int(dp.SprintNumber.Int64)
+	}
+
+	if dp.SprintStartedAt.Valid {
+		project.SprintStartedAt = &dp.SprintStartedAt.Time
+	}
+
+	if dp.SourceTemplateID.Valid {
+		project.SourceTemplateID = &dp.SourceTemplateID.Int64
+	}
+	project.SourceTemplateVersion = dp.SourceTemplateVersion
+	project.KeyPrefix = dp.KeyPrefix
+	project.Inbox = dp.IsInbox
+
 	return project, nil
 }
 
 func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
+	project.Aliases = domain.NormalizeAliases(project.Aliases)
 	if err := project.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -93,6 +121,16 @@ func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project)
 		}
 	}
 
+	if project.KeyPrefix == "" {
+		prefix, err := r.freeKeyPrefix(ctx, domain.ProjectKeyPrefix(project.Name))
+		if err != nil {
+			return err
+		}
+		project.KeyPrefix = prefix
+	} else if err := r.validateKeyPrefixUniqueness(ctx, project.KeyPrefix, nil); err != nil {
+		return err
+	}
+
 	aliasesJSON, err := json.Marshal(project.Aliases)
 	if err != nil {
 		return fmt.Errorf("failed to marshal aliases: %w", err)
@@ -110,8 +148,8 @@ func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project)
 	}
 
 	query := `
-		INSERT INTO projects (name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO projects (name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
@@ -124,10 +162,23 @@ func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project)
 		project.IsFavorite,
 		string(aliasesJSON),
 		nullString(project.Notes),
+		project.SprintCadence,
+		project.SprintNumber,
+		nullTime(project.SprintStartedAt),
+		nullInt64(project.SourceTemplateID),
+		project.SourceTemplateVersion,
+		project.KeyPrefix,
+		project.Inbox,
 		project.CreatedAt,
 		project.UpdatedAt,
 	)
 	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: projects.key_prefix") {
+			return fmt.Errorf("key prefix '%s' is already used by another project", project.KeyPrefix)
+		}
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("%w: %q", repository.ErrProjectExists, project.Name)
+		}
 		return fmt.Errorf("failed to insert project: %w", err)
 	}
 
@@ -142,7 +193,7 @@ func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project)
 
 func (r *ProjectRepository) GetByID(ctx context.Context, id int64) (*domain.Project, error) {
 	query := `
-		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at
+		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at
 		FROM projects
 		WHERE id = ?
 	`
@@ -160,7 +211,7 @@ func (r *ProjectRepository) GetByID(ctx context.Context, id int64) (*domain.Proj
 
 func (r *ProjectRepository) GetByName(ctx context.Context, name string) (*domain.Project, error) {
 	query := `
-		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at
+		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at
 		FROM projects
 		WHERE name = ?
 	`
@@ -262,13 +313,13 @@ func (r *ProjectRepository) GetChildren(ctx context.Context, parentID int64) ([]
 func (r *ProjectRepository) GetDescendants(ctx context.Context, parentID int64) ([]*domain.Project, error) {
 	query := `
 		WITH RECURSIVE descendants AS (
-			SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at
+			SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at
 			FROM projects
 			WHERE parent_id = ?
 
 			UNION ALL
 
-			SELECT p.id, p.name, p.description, p.parent_id, p.color, p.icon, p.status, p.is_favorite, p.aliases, p.notes, p.created_at, p.updated_at
+			SELECT p.id, p.name, p.description, p.parent_id, p.color, p.icon, p.status, p.is_favorite, p.aliases, p.notes, p.sprint_cadence, p.sprint_number, p.sprint_started_at, p.source_template_id, p.source_template_version, p.key_prefix, p.is_inbox, p.created_at, p.updated_at
 			FROM projects p
 			INNER JOIN descendants d ON p.parent_id = d.id
 		)
@@ -296,17 +347,17 @@ func (r *ProjectRepository) GetDescendants(ctx context.Context, parentID int64)
 func (r *ProjectRepository) GetPath(ctx context.Context, projectID int64) ([]*domain.Project, error) {
 	query := `
 		WITH RECURSIVE path AS (
-			SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at, 0 as level
+			SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at, 0 as level
 			FROM projects
 			WHERE id = ?
 
 			UNION ALL
 
-			SELECT p.id, p.name, p.description, p.parent_id, p.color, p.icon, p.status, p.is_favorite, p.aliases, p.notes, p.created_at, p.updated_at, path.level + 1
+			SELECT p.id, p.name, p.description, p.parent_id, p.color, p.icon, p.status, p.is_favorite, p.aliases, p.notes, p.sprint_cadence, p.sprint_number, p.sprint_started_at, p.source_template_id, p.source_template_version, p.key_prefix, p.is_inbox, p.created_at, p.updated_at, path.level + 1
 			FROM projects p
 			INNER JOIN path ON p.id = path.parent_id
 		)
-		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at FROM path
+		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at FROM path
 		ORDER BY level DESC
 	`
 
@@ -329,7 +380,7 @@ func (r *ProjectRepository) GetPath(ctx context.Context, projectID int64) ([]*do
 
 func (r *ProjectRepository) GetRoots(ctx context.Context) ([]*domain.Project, error) {
 	query := `
-		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at
+		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at
 		FROM projects
 		WHERE parent_id IS NULL
 		ORDER BY name
@@ -364,6 +415,7 @@ func (r *ProjectRepository) Count(ctx context.Context, filter repository.Project
 }
 
 func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	project.Aliases = domain.NormalizeAliases(project.Aliases)
 	if err := project.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -374,12 +426,26 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 		}
 	}
 
-	if project.ParentID != nil {
-		if err := r.ValidateHierarchy(ctx, project.ID, *project.ParentID); err != nil {
+	if project.KeyPrefix != "" {
+		if err := r.validateKeyPrefixUniqueness(ctx, project.KeyPrefix, &project.ID); err != nil {
 			return err
 		}
 	}
 
+	if project.ParentID != nil {
+		// an unchanged parent can't add a cycle or depth, and a tree that was
+		// already deeper than a lowered limit stays editable
+		var currentParent sql.NullInt64
+		if err := r.db.GetContext(ctx, &currentParent, `SELECT parent_id FROM projects WHERE id = ?`, project.ID); err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("failed to load current parent: %w", err)
+		}
+		if !currentParent.Valid || currentParent.Int64 != *project.ParentID {
+			if err := r.ValidateHierarchy(ctx, project.ID, *project.ParentID); err != nil {
+				return err
+			}
+		}
+	}
+
 	aliasesJSON, err := json.Marshal(project.Aliases)
 	if err != nil {
 		return fmt.Errorf("failed to marshal aliases: %w", err)
@@ -389,7 +455,8 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 
 	query := `
 		UPDATE projects
-		SET name = ?, description = ?, parent_id = ?, color = ?, icon = ?, status = ?, is_favorite = ?, aliases = ?, notes = ?, updated_at = ?
+		SET name = ?, description = ?, parent_id = ?, color = ?, icon = ?, status = ?, is_favorite = ?, aliases = ?, notes = ?, sprint_cadence = ?, sprint_number = ?, sprint_started_at = ?, source_template_id = ?, source_template_version = ?,
+			key_prefix = COALESCE(NULLIF(?, ''), key_prefix), updated_at = ?
 		WHERE id = ?
 	`
 
@@ -403,10 +470,22 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 		project.IsFavorite,
 		string(aliasesJSON),
 		nullString(project.Notes),
+		project.SprintCadence,
+		project.SprintNumber,
+		nullTime(project.SprintStartedAt),
+		nullInt64(project.SourceTemplateID),
+		project.SourceTemplateVersion,
+		project.KeyPrefix,
 		project.UpdatedAt,
 		project.ID,
 	)
 	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed: projects.key_prefix") {
+			return fmt.Errorf("key prefix '%s' is already used by another project", project.KeyPrefix)
+		}
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return fmt.Errorf("%w: %q", repository.ErrProjectExists, project.Name)
+		}
 		return fmt.Errorf("failed to update project: %w", err)
 	}
 
@@ -423,9 +502,30 @@ func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project)
 }
 
 func (r *ProjectRepository) Delete(ctx context.Context, id int64) error {
+	project, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if project.IsInbox() {
+		return fmt.Errorf("the %s project is reserved and cannot be deleted", domain.InboxProjectName)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the delete cascades to child projects and leaves the tasks of all of
+	// them without a project, which the audit log records per task
+	before, err := snapshotProjectTasks(ctx, tx, id, true)
+	if err != nil {
+		return err
+	}
+
 	query := `DELETE FROM projects WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
@@ -439,6 +539,14 @@ func (r *ProjectRepository) Delete(ctx context.Context, id int64) error {
 		return fmt.Errorf("project not found: %d", id)
 	}
 
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -482,6 +590,36 @@ func (r *ProjectRepository) Unarchive(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (r *ProjectRepository) ArchiveBatch(ctx context.Context, ids []int64) (int64, error) {
+	return r.setStatusBatch(ctx, ids, domain.ProjectStatusActive, domain.ProjectStatusArchived)
+}
+
+func (r *ProjectRepository) UnarchiveBatch(ctx context.Context, ids []int64) (int64, error) {
+	return r.setStatusBatch(ctx, ids, domain.ProjectStatusArchived, domain.ProjectStatusActive)
+}
+
+// moves the projects among ids that are in status from to status to, in one
+// statement so a batch is never left half done
+func (r *ProjectRepository) setStatusBatch(ctx context.Context, ids []int64, from, to domain.ProjectStatus) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query, idArgs := buildINQuery(`UPDATE projects SET status = ?, updated_at = ? WHERE status = ? AND id IN (?)`, ids)
+	args := append([]interface{}{to, time.Now(), from}, idArgs...)
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update project status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows, nil
+}
+
 func (r *ProjectRepository) SetFavorite(ctx context.Context, id int64, isFavorite bool) error {
 	query := `UPDATE projects SET is_favorite = ?, updated_at = ? WHERE id = ?`
 
@@ -547,13 +685,40 @@ func (r *ProjectRepository) GetTaskCountByStatus(ctx context.Context, projectID
 	return counts, nil
 }
 
+// average and median time from creation to completion of the project's
+// completed tasks
+func (r *ProjectRepository) GetTimeToDone(ctx context.Context, projectID int64) (*domain.TimeToDoneStats, error) {
+	query := `
+		SELECT created_at, completed_at
+		FROM tasks
+		WHERE project_id = ? AND status = 'completed' AND completed_at IS NOT NULL
+	`
+
+	type completion struct {
+		CreatedAt   time.Time `db:"created_at"`
+		CompletedAt time.Time `db:"completed_at"`
+	}
+
+	var results []completion
+	if err := r.db.SelectContext(ctx, &results, query, projectID); err != nil {
+		return nil, fmt.Errorf("failed to get task completion times: %w", err)
+	}
+
+	durations := make([]time.Duration, 0, len(results))
+	for _, c := range results {
+		durations = append(durations, max(c.CompletedAt.Sub(c.CreatedAt), 0))
+	}
+
+	return domain.NewTimeToDoneStats(durations), nil
+}
+
 func (r *ProjectRepository) ValidateHierarchy(ctx context.Context, projectID int64, parentID int64) error {
 	if projectID == parentID {
 		return fmt.Errorf("project cannot be its own parent")
 	}
 
 	if projectID == 0 {
-		return nil
+		return r.validateDepth(ctx, parentID, 1)
 	}
 
 	query := `
@@ -580,9 +745,290 @@ func (r *ProjectRepository) ValidateHierarchy(ctx context.Context, projectID int
 		return fmt.Errorf("cannot set parent: would create a cycle in project hierarchy")
 	}
 
+	if r.db.settings.MaxProjectDepth <= 0 {
+		return nil
+	}
+	height, err := r.subtreeHeight(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	return r.validateDepth(ctx, parentID, height)
+}
+
+// rejects putting a subtree that is height levels tall under parentID when its
+// deepest project would go past the max depth. a top-level project is level 1
+func (r *ProjectRepository) validateDepth(ctx context.Context, parentID int64, height int) error {
+	maxDepth := r.db.settings.MaxProjectDepth
+	if maxDepth <= 0 {
+		return nil
+	}
+
+	path, err := r.GetPath(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to validate hierarchy depth: %w", err)
+	}
+
+	if depth := len(path) + height; depth > maxDepth {
+		return fmt.Errorf("cannot set parent: the hierarchy would be %d levels deep, the maximum is %d (max_project_depth)", depth, maxDepth)
+	}
 	return nil
 }
 
+// how many levels the project and its descendants span, 1 for a leaf
+func (r *ProjectRepository) subtreeHeight(ctx context.Context, projectID int64) (int, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id, 1 as level
+			FROM projects
+			WHERE id = ?
+
+			UNION ALL
+
+			SELECT p.id, s.level + 1
+			FROM projects p
+			INNER JOIN subtree s ON p.parent_id = s.id
+		)
+		SELECT COALESCE(MAX(level), 1) FROM subtree
+	`
+
+	var height int
+	if err := r.db.GetContext(ctx, &height, query, projectID); err != nil {
+		return 0, fmt.Errorf("failed to measure project subtree: %w", err)
+	}
+	return height, nil
+}
+
+// Merge moves every task of source into dest and deletes source. children of
+// source are re-parented under dest, or lifted to source's own parent when
+// reparentChildren is false, so the cascading delete never removes them
+func (r *ProjectRepository) Merge(ctx context.Context, sourceID int64, destID int64, reparentChildren bool) (*repository.ProjectMergeResult, error) {
+	if sourceID == destID {
+		return nil, fmt.Errorf("cannot merge a project into itself")
+	}
+
+	source, err := r.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.IsInbox() {
+		return nil, fmt.Errorf("the %s project is reserved and cannot be merged into another project", domain.InboxProjectName)
+	}
+
+	dest, err := r.GetByID(ctx, destID)
+	if err != nil {
+		return nil, err
+	}
+
+	descendants, err := r.GetDescendants(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range descendants {
+		if d.ID == destID {
+			return nil, fmt.Errorf("cannot merge '%s' into its descendant '%s'", source.Name, dest.Name)
+		}
+	}
+
+	// the children move under dest, so source's levels below it hang off dest
+	if reparentChildren && r.db.settings.MaxProjectDepth > 0 {
+		height, err := r.subtreeHeight(ctx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		if height > 1 {
+			if err := r.validateDepth(ctx, destID, height-1); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	result := &repository.ProjectMergeResult{}
+
+	before, err := snapshotProjectTasks(ctx, tx, sourceID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, `UPDATE tasks SET project_id = ?, updated_at = ? WHERE project_id = ?`, destID, now, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move tasks: %w", err)
+	}
+	if result.TasksMoved, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	newParent := source.ParentID
+	if reparentChildren {
+		newParent = &destID
+	}
+
+	res, err = tx.ExecContext(ctx, `UPDATE projects SET parent_id = ?, updated_at = ? WHERE parent_id = ?`, nullInt64(newParent), now, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move child projects: %w", err)
+	}
+	if result.ChildrenMoved, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	if err := recordChanges(ctx, tx, before, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// RollSprint closes the project's current sprint and starts the next one at
+// now. completed tasks are archived with the closed sprint's tag, open ones
+// carry over to the next sprint and the overdue among them come due at its
+// end. locked tasks are left alone
+func (r *ProjectRepository) RollSprint(ctx context.Context, id int64, now time.Time) (*repository.SprintRollResult, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var dbProj dbProject
+	err = tx.GetContext(ctx, &dbProj, `
+		SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at
+		FROM projects
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found: %d", id)
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	project, err := dbProj.toProject()
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := domain.ParseSprintCadence(project.SprintCadence)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &repository.SprintRollResult{
+		ClosedSprint: max(project.SprintNumber, 1),
+		NextStart:    now,
+		NextEnd:      now.AddDate(0, 0, days),
+	}
+
+	var completedIDs, openIDs []int64
+	err = tx.SelectContext(ctx, &completedIDs, `
+		SELECT id FROM tasks
+		WHERE project_id = ? AND status = ? AND is_locked = 0
+			AND NOT EXISTS (SELECT 1 FROM json_each(tags) WHERE value = ?)
+		ORDER BY id
+	`, id, domain.StatusCompleted, domain.ArchivedTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed tasks: %w", err)
+	}
+	openWhere := ` WHERE project_id = ? AND status IN (?, ?) AND is_locked = 0`
+	openArgs := []interface{}{id, domain.StatusPending, domain.StatusInProgress}
+	if err := tx.SelectContext(ctx, &openIDs, `SELECT id FROM tasks`+openWhere+` ORDER BY id`, openArgs...); err != nil {
+		return nil, fmt.Errorf("failed to list open tasks: %w", err)
+	}
+
+	before, err := snapshotTasks(ctx, tx, append(slices.Clone(completedIDs), openIDs...))
+	if err != nil {
+		return nil, err
+	}
+	tasksOf := func(ids []int64) []*domain.Task {
+		tasks := make([]*domain.Task, len(ids))
+		for i, id := range ids {
+			tasks[i] = before[id]
+		}
+		return tasks
+	}
+
+	result.Completed, err = addTags(ctx, tx, tasksOf(completedIDs), []string{domain.ArchivedTag, domain.SprintTag(result.ClosedSprint)}, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive completed tasks: %w", err)
+	}
+	result.CarriedOver, err = addTags(ctx, tx, tasksOf(openIDs), []string{domain.SprintTag(result.ClosedSprint + 1)}, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to carry over tasks: %w", err)
+	}
+
+	// the overdue ones, due before today
+	args := append([]interface{}{*domain.NormalizeDueDate(&result.NextEnd), now}, openArgs...)
+	args = append(args, now.Format("2006-01-02"))
+	res, err := tx.ExecContext(ctx, `UPDATE tasks SET due_date = ?, updated_at = ?`+openWhere+` AND due_date <= ?`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reschedule carried over tasks: %w", err)
+	}
+	if result.Rescheduled, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE projects SET sprint_number = ?, sprint_started_at = ?, updated_at = ? WHERE id = ?`,
+		result.ClosedSprint+1, now, now, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sprint %d: %w", result.ClosedSprint+1, err)
+	}
+
+	if err := recordChanges(ctx, tx, before, now); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// EnsureInbox returns the reserved Inbox project, creating it on first use.
+// it is found by its flag, so it stays the inbox when renamed
+func (r *ProjectRepository) EnsureInbox(ctx context.Context) (*domain.Project, error) {
+	existing, err := r.GetInbox(ctx)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	inbox := domain.NewProject(domain.InboxProjectName)
+	inbox.Description = "Quick captures waiting to be sorted into a project"
+	inbox.Icon = "📥"
+	inbox.Inbox = true
+	if err := r.Create(ctx, inbox); err != nil {
+		return nil, fmt.Errorf("failed to create inbox: %w", err)
+	}
+	return inbox, nil
+}
+
+// GetInbox returns the reserved Inbox project, or nil when nothing has been
+// captured yet. unlike EnsureInbox it never writes
+func (r *ProjectRepository) GetInbox(ctx context.Context) (*domain.Project, error) {
+	var id int64
+	err := r.db.GetContext(ctx, &id, `SELECT id FROM projects WHERE is_inbox = 1`)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up inbox: %w", err)
+	}
+	return r.GetByID(ctx, id)
+}
+
 func (r *ProjectRepository) Search(ctx context.Context, query string, limit int) ([]*domain.Project, error) {
 	filter := repository.ProjectFilter{
 		SearchQuery: query,
@@ -595,7 +1041,7 @@ func (r *ProjectRepository) Search(ctx context.Context, query string, limit int)
 func (r *ProjectRepository) GetByAlias(ctx context.Context, alias string) (*domain.Project, error) {
 	query := `
 		SELECT projects.id, projects.name, projects.description, projects.parent_id, projects.color, projects.icon,
-		       projects.status, projects.is_favorite, projects.aliases, projects.notes, projects.created_at, projects.updated_at
+		       projects.status, projects.is_favorite, projects.aliases, projects.notes, projects.sprint_cadence, projects.sprint_number, projects.sprint_started_at, projects.source_template_id, projects.source_template_version, projects.key_prefix, projects.is_inbox, projects.created_at, projects.updated_at
 		FROM projects, json_each(projects.aliases)
 		WHERE LOWER(json_each.value) = LOWER(?)
 		LIMIT 1
@@ -636,15 +1082,73 @@ func (r *ProjectRepository) ValidateAliasUniqueness(ctx context.Context, alias s
 		return fmt.Errorf("alias '%s' is already in use by another project", alias)
 	}
 
+	// an alias equal to another project's name would make lookups ambiguous
+	nameQuery := "SELECT name FROM projects WHERE LOWER(name) = LOWER(?)"
+	nameArgs := []interface{}{alias}
+	if excludeProjectID != nil {
+		nameQuery += " AND id != ?"
+		nameArgs = append(nameArgs, *excludeProjectID)
+	}
+	nameQuery += " LIMIT 1"
+
+	var existingName string
+	err = r.db.GetContext(ctx, &existingName, nameQuery, nameArgs...)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check alias against project names: %w", err)
+	}
+
+	if err == nil {
+		return fmt.Errorf("alias '%s' matches the name of project '%s'", alias, existingName)
+	}
+
 	return nil
 }
 
+// the project that already uses prefix for its task keys, other than the one
+// being updated, makes it unavailable
+func (r *ProjectRepository) validateKeyPrefixUniqueness(ctx context.Context, prefix string, excludeProjectID *int64) error {
+	query := `SELECT name FROM projects WHERE key_prefix = ?`
+	args := []interface{}{prefix}
+	if excludeProjectID != nil {
+		query += " AND id != ?"
+		args = append(args, *excludeProjectID)
+	}
+
+	var existingName string
+	err := r.db.GetContext(ctx, &existingName, query, args...)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to check key prefix uniqueness: %w", err)
+	}
+	if err == nil {
+		return fmt.Errorf("key prefix '%s' is already used by project '%s'", prefix, existingName)
+	}
+	return nil
+}
+
+// base, numbered when another project has it already. "" stays "", a name
+// without letters or digits gives no task keys
+func (r *ProjectRepository) freeKeyPrefix(ctx context.Context, base string) (string, error) {
+	if base == "" {
+		return "", nil
+	}
+
+	var used []string
+	if err := r.db.SelectContext(ctx, &used, `SELECT key_prefix FROM projects WHERE key_prefix = ? OR key_prefix LIKE ?`, base, base+"-%"); err != nil {
+		return "", fmt.Errorf("failed to load key prefixes: %w", err)
+	}
+	taken := make(map[string]bool, len(used))
+	for _, prefix := range used {
+		taken[prefix] = true
+	}
+	return domain.UniqueKeyPrefix(base, func(prefix string) bool { return taken[prefix] }), nil
+}
+
 func (r *ProjectRepository) buildWhereClause(filter repository.ProjectFilter, isCount bool) (string, []interface{}) {
 	var query string
 	if isCount {
 		query = "SELECT COUNT(*) FROM projects WHERE 1=1"
 	} else {
-		query = "SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, created_at, updated_at FROM projects WHERE 1=1"
+		query = "SELECT id, name, description, parent_id, color, icon, status, is_favorite, aliases, notes, sprint_cadence, sprint_number, sprint_started_at, source_template_id, source_template_version, key_prefix, is_inbox, created_at, updated_at FROM projects WHERE 1=1"
 	}
 
 	args := make([]interface{}, 0)
@@ -673,6 +1177,14 @@ func (r *ProjectRepository) buildWhereClause(filter repository.ProjectFilter, is
 		args = append(args, domain.ProjectStatusArchived)
 	}
 
+	if filter.InactiveSince != nil {
+		// datetime() compares in UTC whatever offset the time was stored with
+		since := filter.InactiveSince.UTC().Format("2006-01-02 15:04:05")
+		query += " AND datetime(updated_at) < datetime(?)"
+		query += " AND NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.project_id = projects.id AND datetime(tasks.updated_at) >= datetime(?))"
+		args = append(args, since, since)
+	}
+
 	if filter.SearchQuery != "" {
 		searchPattern := "%" + filter.SearchQuery + "%"
 		query += " AND (name LIKE ? COLLATE NOCASE OR COALESCE(description, '') LIKE ? COLLATE NOCASE)"
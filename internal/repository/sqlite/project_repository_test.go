@@ -2,6 +2,8 @@ package sqlite
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -74,7 +76,10 @@ func TestProjectRepository_Create(t *testing.T) {
 		project2 := domain.NewProject("Duplicate")
 		err = repo.Create(ctx, project2)
 		if err == nil {
-			t.Error("expected error for duplicate name")
+			t.Fatal("expected error for duplicate name")
+		}
+		if !errors.Is(err, repository.ErrProjectExists) || !strings.Contains(err.Error(), `"Duplicate"`) {
+			t.Errorf("unexpected duplicate error: %v", err)
 		}
 	})
 
@@ -265,6 +270,36 @@ func TestProjectRepository_Delete(t *testing.T) {
 			t.Error("expected child to be cascade deleted")
 		}
 	})
+
+	t.Run("tasks left without a project are audited", func(t *testing.T) {
+		taskRepo := NewTaskRepository(db)
+		parent := domain.NewProject("Audited Parent")
+		if err := repo.Create(ctx, parent); err != nil {
+			t.Fatalf("failed to create parent: %v", err)
+		}
+		child := domain.NewProject("Audited Child")
+		child.ParentID = &parent.ID
+		if err := repo.Create(ctx, child); err != nil {
+			t.Fatalf("failed to create child: %v", err)
+		}
+		task := domain.NewTask("In the child")
+		task.ProjectID = &child.ID
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+
+		if err := repo.Delete(ctx, parent.ID); err != nil {
+			t.Fatalf("failed to delete parent: %v", err)
+		}
+
+		entries, err := taskRepo.ListAudit(ctx, repository.AuditFilter{TaskID: &task.ID})
+		if err != nil {
+			t.Fatalf("failed to list audit entries: %v", err)
+		}
+		if len(entries) != 2 || entries[0].Field != "project" || entries[0].OldValue != "Audited Child" || entries[0].NewValue != "" {
+			t.Errorf("expected the project change to be audited, got %+v", entries)
+		}
+	})
 }
 
 func TestProjectRepository_List(t *testing.T) {
@@ -729,6 +764,33 @@ func TestProjectRepository_Aliases(t *testing.T) {
 		}
 	})
 
+	t.Run("fail on mixed-case duplicate alias in same project", func(t *testing.T) {
+		project := domain.NewProject("Mixed Case Alias Test")
+		project.Aliases = []string{"API", "api"}
+
+		err := repo.Create(ctx, project)
+		if err == nil || !strings.Contains(err.Error(), "duplicate alias") {
+			t.Errorf("expected duplicate alias error, got %v", err)
+		}
+	})
+
+	t.Run("aliases stored lowercase", func(t *testing.T) {
+		project := domain.NewProject("Web UI")
+		project.Aliases = []string{"Web", " UI "}
+
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+
+		retrieved, err := repo.GetByID(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("failed to retrieve project: %v", err)
+		}
+		if len(retrieved.Aliases) != 2 || retrieved.Aliases[0] != "web" || retrieved.Aliases[1] != "ui" {
+			t.Errorf("expected aliases [web ui], got %v", retrieved.Aliases)
+		}
+	})
+
 	t.Run("fail on duplicate alias across projects", func(t *testing.T) {
 		project1 := domain.NewProject("Project 1")
 		project1.Aliases = []string{"shared"}
@@ -805,6 +867,61 @@ func TestProjectRepository_GetByAlias(t *testing.T) {
 	})
 }
 
+func TestProjectRepository_KeyPrefix(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	backend := domain.NewProject("Backend API")
+	if err := repo.Create(ctx, backend); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	if backend.KeyPrefix != "BACKEND-API" {
+		t.Errorf("expected the prefix from the name, got %q", backend.KeyPrefix)
+	}
+
+	t.Run("a taken prefix is numbered", func(t *testing.T) {
+		other := domain.NewProject("backend api!")
+		if err := repo.Create(ctx, other); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		if other.KeyPrefix != "BACKEND-API-2" {
+			t.Errorf("expected a numbered prefix, got %q", other.KeyPrefix)
+		}
+	})
+
+	t.Run("kept when the project is renamed", func(t *testing.T) {
+		backend.Name = "Platform"
+		backend.KeyPrefix = ""
+		if err := repo.Update(ctx, backend); err != nil {
+			t.Fatalf("failed to rename project: %v", err)
+		}
+		fetched, err := repo.GetByID(ctx, backend.ID)
+		if err != nil {
+			t.Fatalf("failed to get project: %v", err)
+		}
+		if fetched.KeyPrefix != "BACKEND-API" {
+			t.Errorf("expected the prefix to survive the rename, got %q", fetched.KeyPrefix)
+		}
+	})
+
+	t.Run("an explicit prefix must be free and well formed", func(t *testing.T) {
+		taken := domain.NewProject("Web")
+		taken.KeyPrefix = "BACKEND-API"
+		if err := repo.Create(ctx, taken); err == nil || !strings.Contains(err.Error(), "already used") {
+			t.Errorf("expected a used prefix to be refused, got %v", err)
+		}
+
+		invalid := domain.NewProject("Web")
+		invalid.KeyPrefix = "web app"
+		if err := repo.Create(ctx, invalid); err == nil {
+			t.Error("expected a malformed prefix to be refused")
+		}
+	})
+}
+
 func TestProjectRepository_ValidateAliasUniqueness(t *testing.T) {
 	db := setupProjectTestDB(t)
 	defer db.Close()
@@ -854,6 +971,23 @@ func TestProjectRepository_ValidateAliasUniqueness(t *testing.T) {
 			t.Error("expected error for another project's alias")
 		}
 	})
+
+	t.Run("fail validation for another project's name", func(t *testing.T) {
+		err := repo.ValidateAliasUniqueness(ctx, "existing project", nil)
+		if err == nil {
+			t.Fatal("expected error for alias matching a project name")
+		}
+		if !strings.Contains(err.Error(), "Existing Project") {
+			t.Errorf("expected error to name the colliding project, got: %v", err)
+		}
+	})
+
+	t.Run("allow own name when updating", func(t *testing.T) {
+		err := repo.ValidateAliasUniqueness(ctx, "EXISTING PROJECT", &project.ID)
+		if err != nil {
+			t.Errorf("expected no error when validating own name, got: %v", err)
+		}
+	})
 }
 
 func TestProjectRepository_Notes(t *testing.T) {
@@ -1053,3 +1187,537 @@ func TestProjectRepository_AliasesAndNotesTogether(t *testing.T) {
 		}
 	})
 }
+
+func TestProjectRepository_Merge(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	taskRepo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	createProject := func(name string, parentID *int64) *domain.Project {
+		project := domain.NewProject(name)
+		project.ParentID = parentID
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("failed to create project %s: %v", name, err)
+		}
+		return project
+	}
+
+	createTask := func(title string, projectID int64) {
+		task := domain.NewTask(title)
+		task.ProjectID = &projectID
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task %s: %v", title, err)
+		}
+	}
+
+	t.Run("moves tasks and children then deletes source", func(t *testing.T) {
+		source := createProject("Merge Source", nil)
+		dest := createProject("Merge Dest", nil)
+		child := createProject("Merge Child", &source.ID)
+		createTask("Task A", source.ID)
+		createTask("Task B", source.ID)
+
+		result, err := repo.Merge(ctx, source.ID, dest.ID, true)
+		if err != nil {
+			t.Fatalf("failed to merge: %v", err)
+		}
+
+		if result.TasksMoved != 2 {
+			t.Errorf("expected 2 tasks moved, got %d", result.TasksMoved)
+		}
+		if result.ChildrenMoved != 1 {
+			t.Errorf("expected 1 child moved, got %d", result.ChildrenMoved)
+		}
+
+		if _, err := repo.GetByID(ctx, source.ID); err == nil {
+			t.Error("expected source project to be deleted")
+		}
+
+		movedChild, err := repo.GetByID(ctx, child.ID)
+		if err != nil {
+			t.Fatalf("child should survive the merge: %v", err)
+		}
+		if movedChild.ParentID == nil || *movedChild.ParentID != dest.ID {
+			t.Error("expected child to be re-parented under dest")
+		}
+
+		count, err := repo.GetTaskCount(ctx, dest.ID)
+		if err != nil {
+			t.Fatalf("failed to count tasks: %v", err)
+		}
+		if count != 2 {
+			t.Errorf("expected dest to have 2 tasks, got %d", count)
+		}
+
+		tasks, err := taskRepo.List(ctx, repository.TaskFilter{ProjectID: &dest.ID})
+		if err != nil {
+			t.Fatalf("failed to list tasks: %v", err)
+		}
+		for _, task := range tasks {
+			entries, err := taskRepo.ListAudit(ctx, repository.AuditFilter{TaskID: &task.ID})
+			if err != nil {
+				t.Fatalf("failed to list audit entries: %v", err)
+			}
+			if len(entries) != 2 || entries[0].Field != "project" || entries[0].OldValue != "Merge Source" || entries[0].NewValue != "Merge Dest" {
+				t.Errorf("expected the move of %q to be audited, got %+v", task.Title, entries)
+			}
+		}
+	})
+
+	t.Run("without reparent lifts children to source parent", func(t *testing.T) {
+		grandparent := createProject("Lift Root", nil)
+		source := createProject("Lift Source", &grandparent.ID)
+		dest := createProject("Lift Dest", nil)
+		child := createProject("Lift Child", &source.ID)
+
+		if _, err := repo.Merge(ctx, source.ID, dest.ID, false); err != nil {
+			t.Fatalf("failed to merge: %v", err)
+		}
+
+		movedChild, err := repo.GetByID(ctx, child.ID)
+		if err != nil {
+			t.Fatalf("child should survive the merge: %v", err)
+		}
+		if movedChild.ParentID == nil || *movedChild.ParentID != grandparent.ID {
+			t.Error("expected child to move to the source's parent")
+		}
+	})
+
+	t.Run("reject merge into descendant", func(t *testing.T) {
+		source := createProject("Outer", nil)
+		inner := createProject("Inner", &source.ID)
+		createTask("Task C", source.ID)
+
+		if _, err := repo.Merge(ctx, source.ID, inner.ID, true); err == nil {
+			t.Fatal("expected error when merging into a descendant")
+		}
+
+		if _, err := repo.GetByID(ctx, source.ID); err != nil {
+			t.Error("source should be untouched after a rejected merge")
+		}
+	})
+
+	t.Run("reject merge into itself", func(t *testing.T) {
+		project := createProject("Self", nil)
+		if _, err := repo.Merge(ctx, project.ID, project.ID, true); err == nil {
+			t.Error("expected error when merging a project into itself")
+		}
+	})
+}
+
+func TestProjectRepository_Inbox(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	missing, err := repo.GetInbox(ctx)
+	if err != nil {
+		t.Fatalf("failed to look up inbox: %v", err)
+	}
+	if missing != nil {
+		t.Fatalf("expected no inbox before the first capture, got %+v", missing)
+	}
+
+	inbox, err := repo.EnsureInbox(ctx)
+	if err != nil {
+		t.Fatalf("failed to create inbox: %v", err)
+	}
+	if !inbox.IsInbox() || inbox.ID == 0 {
+		t.Fatalf("expected a stored Inbox project, got %+v", inbox)
+	}
+
+	t.Run("is created only once", func(t *testing.T) {
+		again, err := repo.EnsureInbox(ctx)
+		if err != nil {
+			t.Fatalf("failed to get inbox: %v", err)
+		}
+		if again.ID != inbox.ID {
+			t.Errorf("expected the existing inbox %d, got %d", inbox.ID, again.ID)
+		}
+	})
+
+	t.Run("is found without writing", func(t *testing.T) {
+		found, err := repo.GetInbox(ctx)
+		if err != nil {
+			t.Fatalf("failed to look up inbox: %v", err)
+		}
+		if found == nil || found.ID != inbox.ID {
+			t.Errorf("expected the existing inbox %d, got %+v", inbox.ID, found)
+		}
+	})
+
+	t.Run("cannot be deleted or merged away", func(t *testing.T) {
+		other := domain.NewProject("Sorted")
+		if err := repo.Create(ctx, other); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+
+		if err := repo.Delete(ctx, inbox.ID); err == nil {
+			t.Error("expected deleting the inbox to fail")
+		}
+		if _, err := repo.Merge(ctx, inbox.ID, other.ID, true); err == nil {
+			t.Error("expected merging the inbox away to fail")
+		}
+		if _, err := repo.GetByID(ctx, inbox.ID); err != nil {
+			t.Errorf("inbox should still exist: %v", err)
+		}
+	})
+
+	t.Run("stays the inbox when renamed", func(t *testing.T) {
+		renamed, err := repo.GetByID(ctx, inbox.ID)
+		if err != nil {
+			t.Fatalf("failed to get inbox: %v", err)
+		}
+		renamed.Name = "Triage"
+		if err := repo.Update(ctx, renamed); err != nil {
+			t.Fatalf("failed to rename inbox: %v", err)
+		}
+
+		again, err := repo.EnsureInbox(ctx)
+		if err != nil {
+			t.Fatalf("failed to get inbox: %v", err)
+		}
+		if again.ID != inbox.ID || !again.IsInbox() {
+			t.Errorf("expected the renamed inbox %d, got %+v", inbox.ID, again)
+		}
+		if err := repo.Delete(ctx, inbox.ID); err == nil {
+			t.Error("expected deleting the renamed inbox to fail")
+		}
+
+		named := domain.NewProject(domain.InboxProjectName)
+		if err := repo.Create(ctx, named); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		if named.IsInbox() {
+			t.Error("a project only named Inbox should not be the inbox")
+		}
+		if err := repo.Delete(ctx, named.ID); err != nil {
+			t.Errorf("expected a project only named Inbox to be deletable: %v", err)
+		}
+	})
+
+	t.Run("is recreated when missing", func(t *testing.T) {
+		if _, err := db.Exec(`DELETE FROM projects WHERE id = ?`, inbox.ID); err != nil {
+			t.Fatalf("failed to remove inbox: %v", err)
+		}
+
+		fresh, err := repo.EnsureInbox(ctx)
+		if err != nil {
+			t.Fatalf("failed to recreate inbox: %v", err)
+		}
+		if fresh.ID == inbox.ID || !fresh.IsInbox() {
+			t.Errorf("expected a new inbox, got %+v", fresh)
+		}
+	})
+}
+
+func TestProjectRepository_MaxDepth(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	db.settings.MaxProjectDepth = 3
+	ctx := context.Background()
+
+	create := func(name string, parent *domain.Project) (*domain.Project, error) {
+		project := domain.NewProject(name)
+		if parent != nil {
+			project.ParentID = &parent.ID
+		}
+		return project, repo.Create(ctx, project)
+	}
+	mustCreate := func(name string, parent *domain.Project) *domain.Project {
+		project, err := create(name, parent)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		return project
+	}
+
+	dev := mustCreate("Dev", nil)
+	backend := mustCreate("Backend", dev)
+	api := mustCreate("API", backend)
+	web := mustCreate("Web", nil)
+	frontend := mustCreate("Frontend", web)
+
+	t.Run("create within the limit", func(t *testing.T) {
+		mustCreate("Docs", backend)
+	})
+
+	t.Run("create beyond the limit", func(t *testing.T) {
+		_, err := create("Auth", api)
+		if err == nil || !strings.Contains(err.Error(), "maximum is 3") {
+			t.Errorf("expected max depth error, got %v", err)
+		}
+	})
+
+	t.Run("re-parent a subtree beyond the limit", func(t *testing.T) {
+		web.ParentID = &backend.ID
+		err := repo.Update(ctx, web)
+		if err == nil || !strings.Contains(err.Error(), "4 levels deep") {
+			t.Errorf("expected max depth error, got %v", err)
+		}
+		web.ParentID = nil
+	})
+
+	t.Run("re-parent within the limit", func(t *testing.T) {
+		web.ParentID = &dev.ID
+		if err := repo.Update(ctx, web); err != nil {
+			t.Fatalf("failed to re-parent: %v", err)
+		}
+	})
+
+	t.Run("unchanged parent skips the check", func(t *testing.T) {
+		db.settings.MaxProjectDepth = 2
+		defer func() { db.settings.MaxProjectDepth = 3 }()
+
+		frontend.Description = "still editable"
+		if err := repo.Update(ctx, frontend); err != nil {
+			t.Errorf("expected update with unchanged parent to succeed, got %v", err)
+		}
+	})
+
+	t.Run("merge moving children beyond the limit", func(t *testing.T) {
+		ops := mustCreate("Ops", nil)
+		mustCreate("Infra", ops)
+		if _, err := repo.Merge(ctx, ops.ID, api.ID, true); err == nil {
+			t.Error("expected merge to be rejected")
+		}
+		if _, err := repo.Merge(ctx, ops.ID, dev.ID, true); err != nil {
+			t.Errorf("expected merge within the limit to succeed, got %v", err)
+		}
+	})
+
+	t.Run("unlimited without a max depth", func(t *testing.T) {
+		db.settings.MaxProjectDepth = 0
+		defer func() { db.settings.MaxProjectDepth = 3 }()
+
+		unlimited := NewProjectRepository(db)
+		auth := domain.NewProject("Auth")
+		auth.ParentID = &api.ID
+		if err := unlimited.Create(ctx, auth); err != nil {
+			t.Errorf("expected no depth limit, got %v", err)
+		}
+	})
+}
+
+func TestProjectRepository_ArchiveBatch(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, name := range []string{"One", "Two", "Done"} {
+		project := domain.NewProject(name)
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		ids = append(ids, project.ID)
+	}
+	done, _ := repo.GetByID(ctx, ids[2])
+	done.Status = domain.ProjectStatusCompleted
+	if err := repo.Update(ctx, done); err != nil {
+		t.Fatalf("failed to complete project: %v", err)
+	}
+
+	t.Run("archives only active projects", func(t *testing.T) {
+		archived, err := repo.ArchiveBatch(ctx, ids)
+		if err != nil {
+			t.Fatalf("failed to archive projects: %v", err)
+		}
+		if archived != 2 {
+			t.Errorf("expected 2 projects archived, got %d", archived)
+		}
+
+		retrieved, _ := repo.GetByID(ctx, ids[2])
+		if retrieved.Status != domain.ProjectStatusCompleted {
+			t.Errorf("expected completed project to stay completed, got '%s'", retrieved.Status)
+		}
+	})
+
+	t.Run("unarchives only archived projects", func(t *testing.T) {
+		unarchived, err := repo.UnarchiveBatch(ctx, ids)
+		if err != nil {
+			t.Fatalf("failed to unarchive projects: %v", err)
+		}
+		if unarchived != 2 {
+			t.Errorf("expected 2 projects unarchived, got %d", unarchived)
+		}
+
+		for _, id := range ids[:2] {
+			retrieved, _ := repo.GetByID(ctx, id)
+			if retrieved.Status != domain.ProjectStatusActive {
+				t.Errorf("expected project %d active, got '%s'", id, retrieved.Status)
+			}
+		}
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		archived, err := repo.ArchiveBatch(ctx, nil)
+		if err != nil || archived != 0 {
+			t.Errorf("expected nothing archived, got %d (%v)", archived, err)
+		}
+	})
+}
+
+func TestProjectRepository_ListInactiveSince(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	idle := domain.NewProject("Idle")
+	busy := domain.NewProject("Busy")
+	for _, project := range []*domain.Project{idle, busy} {
+		if err := repo.Create(ctx, project); err != nil {
+			t.Fatalf("failed to create %s: %v", project.Name, err)
+		}
+	}
+
+	// inserts skip the updated_at triggers, so these keep their later times
+	later := time.Now().Add(2 * time.Hour)
+	if _, err := db.Exec(`INSERT INTO projects (name, status, updated_at) VALUES ('Fresh', 'active', ?)`, later); err != nil {
+		t.Fatalf("failed to insert project: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO tasks (title, project_id, updated_at) VALUES ('Recent', ?, ?)`, busy.ID, later); err != nil {
+		t.Fatalf("failed to insert task: %v", err)
+	}
+
+	since := time.Now().Add(time.Hour)
+	projects, err := repo.List(ctx, repository.ProjectFilter{InactiveSince: &since})
+	if err != nil {
+		t.Fatalf("failed to list projects: %v", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "Idle" {
+		t.Errorf("expected only Idle, got %v", projects)
+	}
+
+	count, err := repo.Count(ctx, repository.ProjectFilter{InactiveSince: &since})
+	if err != nil || count != 1 {
+		t.Errorf("expected count 1, got %d (%v)", count, err)
+	}
+}
+
+func TestProjectRepository_RollSprint(t *testing.T) {
+	db := setupProjectTestDB(t)
+	defer db.Close()
+
+	repo := NewProjectRepository(db)
+	taskRepo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	start := time.Now().AddDate(0, 0, -14)
+	project := domain.NewProject("Backend")
+	project.SprintCadence = "2 weeks"
+	project.SprintNumber = 1
+	project.SprintStartedAt = &start
+	if err := repo.Create(ctx, project); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	newTask := func(title string, status domain.Status, due *time.Time) *domain.Task {
+		task := domain.NewTask(title)
+		task.Status = status
+		task.ProjectID = &project.ID
+		task.DueDate = due
+		if err := taskRepo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		return task
+	}
+	getTask := func(id int64) *domain.Task {
+		task, err := taskRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("failed to get task: %v", err)
+		}
+		return task
+	}
+
+	overdue := time.Now().AddDate(0, 0, -3)
+	later := time.Now().AddDate(0, 1, 0)
+	done := newTask("Ship login", domain.StatusCompleted, nil)
+	late := newTask("Fix flaky test", domain.StatusPending, &overdue)
+	active := newTask("Write docs", domain.StatusInProgress, &later)
+	locked := newTask("Frozen", domain.StatusPending, &overdue)
+	if err := taskRepo.SetLocked(ctx, locked.ID, true); err != nil {
+		t.Fatalf("failed to lock task: %v", err)
+	}
+	other := domain.NewTask("Unrelated")
+	if err := taskRepo.Create(ctx, other); err != nil {
+		t.Fatalf("failed to create task: %v", err)
+	}
+
+	now := time.Now()
+	result, err := repo.RollSprint(ctx, project.ID, now)
+	if err != nil {
+		t.Fatalf("failed to roll sprint: %v", err)
+	}
+
+	if result.ClosedSprint != 1 || result.Completed != 1 || result.CarriedOver != 2 || result.Rescheduled != 1 {
+		t.Errorf("expected sprint 1 closed with 1 completed, 2 carried over and 1 rescheduled, got %+v", result)
+	}
+
+	if got := getTask(done.ID).Tags; strings.Join(got, ",") != "archived,sprint-1" {
+		t.Errorf("expected the completed task tagged archived and sprint-1, got %v", got)
+	}
+	got := getTask(late.ID)
+	if strings.Join(got.Tags, ",") != "sprint-2" {
+		t.Errorf("expected the late task tagged sprint-2, got %v", got.Tags)
+	}
+	if got.DueDate == nil || got.DueDate.Format("2006-01-02") != result.NextEnd.Format("2006-01-02") {
+		t.Errorf("expected the late task due at the sprint's end, got %v", got.DueDate)
+	}
+	if got := getTask(active.ID); got.DueDate == nil || got.DueDate.Format("2006-01-02") != later.Format("2006-01-02") {
+		t.Errorf("expected the active task's due date kept, got %v", got.DueDate)
+	}
+	if got := getTask(locked.ID); len(got.Tags) != 0 || got.DueDate.Format("2006-01-02") != overdue.Format("2006-01-02") {
+		t.Errorf("expected the locked task left alone, got tags %v due %v", got.Tags, got.DueDate)
+	}
+	if got := getTask(other.ID); len(got.Tags) != 0 {
+		t.Errorf("expected a task outside the project left alone, got %v", got.Tags)
+	}
+
+	entries, err := taskRepo.ListAudit(ctx, repository.AuditFilter{TaskID: &late.ID})
+	if err != nil {
+		t.Fatalf("failed to list audit entries: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Errorf("expected the tag and due date changes audited, got %+v", entries)
+	}
+
+	updated, err := repo.GetByID(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("failed to get project: %v", err)
+	}
+	if updated.SprintNumber != 2 || updated.SprintStartedAt == nil || updated.SprintStartedAt.Sub(now).Abs() > time.Second {
+		t.Errorf("expected sprint 2 started now, got %d at %v", updated.SprintNumber, updated.SprintStartedAt)
+	}
+
+	t.Run("archived tasks are not counted again", func(t *testing.T) {
+		result, err := repo.RollSprint(ctx, project.ID, time.Now())
+		if err != nil {
+			t.Fatalf("failed to roll sprint: %v", err)
+		}
+		if result.ClosedSprint != 2 || result.Completed != 0 || result.CarriedOver != 2 {
+			t.Errorf("expected sprint 2 closed with nothing completed and 2 carried over, got %+v", result)
+		}
+	})
+
+	t.Run("a project without sprints can't roll", func(t *testing.T) {
+		plain := domain.NewProject("No sprints")
+		if err := repo.Create(ctx, plain); err != nil {
+			t.Fatalf("failed to create project: %v", err)
+		}
+		if _, err := repo.RollSprint(ctx, plain.ID, time.Now()); err == nil {
+			t.Error("expected an error rolling a project without a cadence")
+		}
+	})
+}
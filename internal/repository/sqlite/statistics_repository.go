@@ -85,7 +85,7 @@ func (r *StatisticsRepository) GetProjectStatistics(ctx context.Context, project
 		return nil, fmt.Errorf("failed to get recently updated count: %w", err)
 	}
 
-	stats.CompletionRate = stats.GetCompletionRate()
+	stats.CompletionRate = stats.CompletionRateFor(r.db.settings.CancelledTreatment)
 
 	stats.CalculatedAt = time.Now()
 
@@ -147,7 +147,7 @@ func (r *StatisticsRepository) GetGlobalStatistics(ctx context.Context) (*domain
 		WHERE due_date IS NOT NULL
 		AND due_date < ?
 		AND status NOT IN ('completed', 'cancelled')
-	`, now).Scan(&stats.OverdueTasks)
+	`, domain.OverdueBefore(now, r.db.settings.OverdueGraceDays)).Scan(&stats.OverdueTasks)
 	if err != nil {
 		stats.OverdueTasks = 0
 	}
@@ -171,7 +171,7 @@ func (r *StatisticsRepository) GetGlobalStatistics(ctx context.Context) (*domain
 		stats.RecentTasks = 0
 	}
 
-	stats.OverallCompletionRate = stats.GetCompletionRate()
+	stats.OverallCompletionRate = stats.CompletionRateFor(r.db.settings.CancelledTreatment)
 
 	topProjects, err := r.GetTopProjectsByTaskCount(ctx, 5)
 	if err == nil {
@@ -237,6 +237,62 @@ func (r *StatisticsRepository) GetTopProjectsByTaskCount(ctx context.Context, li
 	return results, rows.Err()
 }
 
+// replays task creation and completion to get the open task count per day.
+// cancelling records no time of its own, so a cancelled task counts as closed
+// from its last update. nil when the project has never had a task
+func (r *StatisticsRepository) GetBurndown(ctx context.Context, projectID int64, includeDescendants bool, from, to time.Time) ([]domain.BurndownPoint, error) {
+	projectIDs := []int64{projectID}
+	if includeDescendants {
+		descendants, err := r.getDescendantIDs(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get descendants: %w", err)
+		}
+		projectIDs = append(projectIDs, descendants...)
+	}
+
+	query, args := buildINQuery(`
+		SELECT status, created_at, updated_at, completed_at
+		FROM tasks
+		WHERE project_id IN (?)
+	`, projectIDs)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+	defer rows.Close()
+
+	var spans []domain.TaskSpan
+	for rows.Next() {
+		var status string
+		var createdAt, updatedAt time.Time
+		var completedAt sql.NullTime
+		if err := rows.Scan(&status, &createdAt, &updatedAt, &completedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+
+		span := domain.TaskSpan{CreatedAt: createdAt}
+		switch domain.Status(status) {
+		case domain.StatusCompleted:
+			if completedAt.Valid {
+				span.ClosedAt = &completedAt.Time
+			} else {
+				span.ClosedAt = &updatedAt
+			}
+		case domain.StatusCancelled:
+			span.ClosedAt = &updatedAt
+		}
+		spans = append(spans, span)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+
+	if len(spans) == 0 {
+		return nil, nil
+	}
+	return domain.NewBurndown(spans, from, to), nil
+}
 
 func (r *StatisticsRepository) getDescendantIDs(ctx context.Context, projectID int64) ([]int64, error) {
 	query := `
@@ -342,7 +398,7 @@ func (r *StatisticsRepository) getOverdueTaskCount(ctx context.Context, projectI
 		AND status NOT IN ('completed', 'cancelled')
 	`, projectIDs)
 
-	args = append(args, now)
+	args = append(args, domain.OverdueBefore(now, r.db.settings.OverdueGraceDays))
 
 	var count int
 	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
@@ -4,36 +4,69 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/jmoiron/sqlx"
+
 	"task-management/internal/domain"
 	"task-management/internal/fuzzy"
 	"task-management/internal/repository"
 )
 
+const (
+	regexSearchTimeout = 2 * time.Second
+	regexSearchMaxRows = 1000
+)
+
 type TaskRepository struct {
 	db *DB
+
+	// limits for regex searches, which are matched row by row in Go
+	regexTimeout time.Duration
+	regexMaxRows int
 }
 
 func NewTaskRepository(db *DB) *TaskRepository {
-	return &TaskRepository{db: db}
+	return &TaskRepository{
+		db:           db,
+		regexTimeout: regexSearchTimeout,
+		regexMaxRows: regexSearchMaxRows,
+	}
 }
 
 type dbTask struct {
-	ID          int64          `db:"id"`
-	Title       string         `db:"title"`
-	Description string         `db:"description"`
-	Priority    string         `db:"priority"`
-	Status      string         `db:"status"`
-	Tags        sql.NullString `db:"tags"`
-	ProjectID   sql.NullInt64  `db:"project_id"`
-	ProjectName sql.NullString `db:"project_name"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   time.Time      `db:"updated_at"`
-	DueDate     sql.NullTime   `db:"due_date"`
+	ID           int64          `db:"id"`
+	Title        string         `db:"title"`
+	Description  string         `db:"description"`
+	Priority     string         `db:"priority"`
+	Status       string         `db:"status"`
+	Tags         sql.NullString `db:"tags"`
+	ProjectID    sql.NullInt64  `db:"project_id"`
+	ProjectName  sql.NullString `db:"project_name"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+	DueDate      sql.NullTime   `db:"due_date"`
+	Position     int            `db:"position"`
+	CompletedAt  sql.NullTime   `db:"completed_at"`
+	IsPinned     bool           `db:"is_pinned"`
+	SnoozedUntil sql.NullTime   `db:"snoozed_until"`
+	WaitingOn    string         `db:"waiting_on"`
+	IsLocked     bool           `db:"is_locked"`
+	Color        string         `db:"color"`
+	Recurrence   string         `db:"recurrence"`
+	// the project the task was numbered in, which can differ from project_id
+	ProjectTaskNumber int            `db:"project_task_number"`
+	NumberProjectID   sql.NullInt64  `db:"number_project_id"`
+	NumberProjectKey  sql.NullString `db:"number_project_key"`
+	// comma separated, in no particular order
+	DependsOn    sql.NullString `db:"depends_on"`
+	OpenBlockers int            `db:"open_blockers"`
 }
 
 func (dt *dbTask) toTask() (*domain.Task, error) {
@@ -45,6 +78,26 @@ func (dt *dbTask) toTask() (*domain.Task, error) {
 		Status:      domain.Status(dt.Status),
 		CreatedAt:   dt.CreatedAt,
 		UpdatedAt:   dt.UpdatedAt,
+		Position:    dt.Position,
+		IsPinned:    dt.IsPinned,
+		WaitingOn:   dt.WaitingOn,
+		IsLocked:    dt.IsLocked,
+		Color:       dt.Color,
+		Recurrence:  dt.Recurrence,
+
+		ProjectTaskNumber: dt.ProjectTaskNumber,
+		OpenBlockers:      dt.OpenBlockers,
+	}
+
+	if dt.DependsOn.Valid && dt.DependsOn.String != "" {
+		for _, field := range strings.Split(dt.DependsOn.String, ",") {
+			id, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse dependencies: %w", err)
+			}
+			task.DependsOn = append(task.DependsOn, id)
+		}
+		slices.Sort(task.DependsOn)
 	}
 
 	if dt.Tags.Valid && dt.Tags.String != "" {
@@ -63,21 +116,85 @@ func (dt *dbTask) toTask() (*domain.Task, error) {
 		task.ProjectName = dt.ProjectName.String
 	}
 
+	if dt.NumberProjectID.Valid {
+		task.NumberProjectID = &dt.NumberProjectID.Int64
+	}
+	if dt.NumberProjectKey.Valid {
+		task.NumberProjectKey = dt.NumberProjectKey.String
+	}
+
 	if dt.DueDate.Valid {
 		task.DueDate = &dt.DueDate.Time
 	}
 
+	if dt.CompletedAt.Valid {
+		task.CompletedAt = &dt.CompletedAt.Time
+	}
+
+	if dt.SnoozedUntil.Valid {
+		task.SnoozedUntil = &dt.SnoozedUntil.Time
+	}
+
 	return task, nil
 }
 
 func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, err := insertTask(ctx, tx, task)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	task.ID = id
+	return nil
+}
+
+// CreateBatch creates all tasks in one transaction. if one of them fails none
+// are created and the error names the failing task by its index
+func (r *TaskRepository) CreateBatch(ctx context.Context, tasks []*domain.Task) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]int64, len(tasks))
+	for i, task := range tasks {
+		if ids[i], err = insertTask(ctx, tx, task); err != nil {
+			return fmt.Errorf("task %d (%q): %w", i+1, task.Title, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for i, task := range tasks {
+		task.ID = ids[i]
+	}
+	return nil
+}
+
+// validates and inserts the task at the bottom of its project, logging its
+// creation. the ID is returned rather than set, the tx may still roll back
+func insertTask(ctx context.Context, tx *sqlx.Tx, task *domain.Task) (int64, error) {
+	task.DueDate = domain.NormalizeDueDate(task.DueDate)
 	if err := task.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return 0, fmt.Errorf("validation failed: %w", err)
 	}
 
 	tagsJSON, err := json.Marshal(task.Tags)
 	if err != nil {
-		return fmt.Errorf("failed to marshal tags: %w", err)
+		return 0, fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
 	if task.CreatedAt.IsZero() {
@@ -93,13 +210,43 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 	if task.Status == "" {
 		task.Status = domain.StatusPending
 	}
+	if task.Status != domain.StatusCompleted {
+		task.CompletedAt = nil
+	} else if task.CompletedAt == nil {
+		now := time.Now()
+		task.CompletedAt = &now
+	}
+
+	// new tasks go to the bottom of their project
+	if err := tx.GetContext(ctx, &task.Position,
+		`SELECT COALESCE(MAX(position), 0) + 1 FROM tasks WHERE project_id IS ?`,
+		nullInt64(task.ProjectID),
+	); err != nil {
+		return 0, fmt.Errorf("failed to get task position: %w", err)
+	}
+
+	// the project's next number, taken inside the tx so concurrent creates
+	// can't hand out the same one
+	task.ProjectTaskNumber, task.NumberProjectID = 0, nil
+	if task.ProjectID != nil {
+		if err := tx.GetContext(ctx, &task.ProjectTaskNumber, `
+			INSERT INTO project_task_numbers (project_id, last_number) VALUES (?, 1)
+			ON CONFLICT(project_id) DO UPDATE SET last_number = last_number + 1
+			RETURNING last_number`,
+			*task.ProjectID,
+		); err != nil {
+			return 0, fmt.Errorf("failed to number task: %w", err)
+		}
+		numberProjectID := *task.ProjectID
+		task.NumberProjectID = &numberProjectID
+	}
 
 	query := `
-		INSERT INTO tasks (title, description, priority, status, tags, project_id, created_at, updated_at, due_date)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO tasks (title, description, priority, status, tags, project_id, created_at, updated_at, due_date, position, completed_at, is_pinned, snoozed_until, waiting_on, is_locked, color, recurrence, project_task_number, number_project_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		task.Title,
 		task.Description,
 		task.Priority,
@@ -109,30 +256,41 @@ func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
 		task.CreatedAt,
 		task.UpdatedAt,
 		nullTime(task.DueDate),
+		task.Position,
+		nullTime(task.CompletedAt),
+		task.IsPinned,
+		nullTime(task.SnoozedUntil),
+		task.WaitingOn,
+		task.IsLocked,
+		task.Color,
+		task.Recurrence,
+		task.ProjectTaskNumber,
+		nullInt64(task.NumberProjectID),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to insert task: %w", err)
+		return 0, fmt.Errorf("failed to insert task: %w", err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return fmt.Errorf("failed to get last insert ID: %w", err)
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
 	}
 
-	task.ID = id
-	return nil
+	if err := insertAuditEntry(ctx, tx, &domain.TaskAuditEntry{
+		TaskID:    id,
+		TaskTitle: task.Title,
+		Action:    domain.AuditActionCreate,
+		NewValue:  task.Title,
+		ChangedAt: task.CreatedAt,
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
 func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
-	query := `
-		SELECT
-			t.id, t.title, t.description, t.priority, t.status, t.tags,
-			t.project_id, p.name as project_name,
-			t.created_at, t.updated_at, t.due_date
-		FROM tasks t
-		LEFT JOIN projects p ON t.project_id = p.id
-		WHERE t.id = ?
-	`
+	query := taskSelect + " WHERE t.id = ?"
 
 	var dbTask dbTask
 	if err := r.db.GetContext(ctx, &dbTask, query, id); err != nil {
@@ -145,10 +303,30 @@ func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, e
 	return dbTask.toTask()
 }
 
+// GetByNumber finds a task by the number it was given in projectID, which
+// it keeps after moving to another project
+func (r *TaskRepository) GetByNumber(ctx context.Context, projectID int64, number int) (*domain.Task, error) {
+	query := taskSelect + " WHERE t.number_project_id = ? AND t.project_task_number = ?"
+
+	var dbTask dbTask
+	if err := r.db.GetContext(ctx, &dbTask, query, projectID, number); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("task not found: number %d in project %d", number, projectID)
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return dbTask.toTask()
+}
+
 func (r *TaskRepository) Count(ctx context.Context, filter repository.TaskFilter) (int64, error) {
+	filter = r.resolveSearchMode(filter)
 	if filter.SearchMode == "fuzzy" && filter.SearchQuery != "" {
 		return r.countWithFuzzySearch(ctx, filter)
 	}
+	if filter.SearchMode == "regex" && filter.SearchQuery != "" {
+		return r.countWithRegexSearch(ctx, filter)
+	}
 
 	query, args := r.buildWhereClause(filter, true)
 
@@ -160,6 +338,55 @@ func (r *TaskRepository) Count(ctx context.Context, filter repository.TaskFilter
 	return count, nil
 }
 
+func (r *TaskRepository) Aggregate(ctx context.Context, filter repository.TaskFilter) (domain.TaskStats, error) {
+	stats := domain.NewTaskStats()
+	filter = r.resolveSearchMode(filter)
+	if filter.SearchQuery != "" && (filter.SearchMode == "fuzzy" || filter.SearchMode == "regex") {
+		return stats, fmt.Errorf("%s search is not supported for task stats", filter.SearchMode)
+	}
+
+	now := time.Now()
+	conditions, conditionArgs := taskConditions(filter)
+	query := `SELECT t.status, t.priority, COUNT(*),
+			SUM(CASE WHEN t.due_date < ? AND t.status NOT IN ('completed', 'cancelled') THEN 1 ELSE 0 END),
+			SUM(CASE WHEN t.status IN ('pending', 'in_progress') THEN julianday(?) - julianday(t.created_at) ELSE 0 END)
+		FROM tasks t
+		LEFT JOIN projects p ON t.project_id = p.id
+		WHERE 1=1` + conditions + `
+		GROUP BY t.status, t.priority`
+	args := append([]interface{}{domain.OverdueBefore(now, r.db.settings.OverdueGraceDays), now}, conditionArgs...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return stats, fmt.Errorf("failed to aggregate tasks: %w", err)
+	}
+	defer rows.Close()
+
+	// in days, summed over the open tasks
+	var openAge float64
+	for rows.Next() {
+		var status, priority string
+		var count, overdue int
+		var age float64
+		if err := rows.Scan(&status, &priority, &count, &overdue, &age); err != nil {
+			return stats, fmt.Errorf("failed to scan task counts: %w", err)
+		}
+		stats.Total += count
+		stats.ByStatus[domain.Status(status)] += count
+		stats.ByPriority[domain.Priority(priority)] += count
+		stats.Overdue += overdue
+		openAge += age
+	}
+	if err := rows.Err(); err != nil {
+		return stats, fmt.Errorf("failed to aggregate tasks: %w", err)
+	}
+
+	if open := stats.OpenTasks(); open > 0 {
+		stats.AverageOpenAge = time.Duration(openAge / float64(open) * float64(24*time.Hour))
+	}
+	return stats, nil
+}
+
 func (r *TaskRepository) countWithFuzzySearch(ctx context.Context, filter repository.TaskFilter) (int64, error) {
 	filterNoPagination := filter
 	filterNoPagination.Limit = 0
@@ -174,15 +401,32 @@ func (r *TaskRepository) countWithFuzzySearch(ctx context.Context, filter reposi
 }
 
 func (r *TaskRepository) List(ctx context.Context, filter repository.TaskFilter) ([]*domain.Task, error) {
+	filter = r.resolveSearchMode(filter)
 	if filter.SearchMode == "fuzzy" && filter.SearchQuery != "" {
 		return r.listWithFuzzySearch(ctx, filter)
 	}
+	if filter.SearchMode == "regex" && filter.SearchQuery != "" {
+		return r.listWithRegexSearch(ctx, filter)
+	}
+	if filter.SearchMode == "fts" && filter.SearchQuery != "" {
+		return r.listWithFTS(ctx, filter)
+	}
 
 	query, args := r.buildWhereClause(filter, false)
 
 	orderClause := r.buildOrderClause(filter)
+	if rank, rankArgs := buildSearchRank(filter); rank != "" {
+		// best matches first, the requested sort only breaks ties
+		orderClause = " ORDER BY " + rank + " DESC," + strings.TrimPrefix(orderClause, " ORDER BY")
+		args = append(args, rankArgs...)
+	}
 	query += orderClause
 
+	return r.selectTasks(ctx, query, args, filter)
+}
+
+// runs a listing query, paged by the filter's limit and offset
+func (r *TaskRepository) selectTasks(ctx context.Context, query string, args []interface{}, filter repository.TaskFilter) ([]*domain.Task, error) {
 	if filter.Limit > 0 {
 		query += " LIMIT ?"
 		args = append(args, filter.Limit)
@@ -210,6 +454,84 @@ func (r *TaskRepository) List(ctx context.Context, filter repository.TaskFilter)
 	return tasks, nil
 }
 
+// like the plain listing, but gives up after regexTimeout or regexMaxRows
+// matches. whatever was found by then is returned with ErrSearchTruncated
+func (r *TaskRepository) listWithRegexSearch(ctx context.Context, filter repository.TaskFilter) ([]*domain.Task, error) {
+	if _, err := compileRegexp(filter.SearchQuery); err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %w", err)
+	}
+
+	searchCtx, cancel := context.WithTimeout(ctx, r.regexTimeout)
+	defer cancel()
+
+	query, args := r.buildWhereClause(filter, false)
+	query += r.buildOrderClause(filter)
+
+	// fetch one row past the cap to tell a capped search from one that
+	// matched exactly regexMaxRows tasks
+	capped := filter.Limit <= 0 || filter.Limit > r.regexMaxRows
+	limit := filter.Limit
+	if capped {
+		limit = r.regexMaxRows + 1
+	}
+	query += " LIMIT ?"
+	args = append(args, limit)
+	if filter.Offset > 0 {
+		query += " OFFSET ?"
+		args = append(args, filter.Offset)
+	}
+
+	tasks := []*domain.Task{}
+	rows, err := r.db.QueryxContext(searchCtx, query, args...)
+	if err != nil {
+		if ctx.Err() == nil && searchCtx.Err() != nil {
+			return tasks, repository.ErrSearchTruncated
+		}
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dbTask dbTask
+		if err := rows.StructScan(&dbTask); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		task, err := dbTask.toTask()
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	timedOut := ctx.Err() == nil && searchCtx.Err() != nil
+	if err := rows.Err(); err != nil && !timedOut {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	if capped && len(tasks) > r.regexMaxRows {
+		return tasks[:r.regexMaxRows], repository.ErrSearchTruncated
+	}
+	if timedOut {
+		return tasks, repository.ErrSearchTruncated
+	}
+
+	return tasks, nil
+}
+
+// counts by listing, so the count stops at the same limits as the search
+func (r *TaskRepository) countWithRegexSearch(ctx context.Context, filter repository.TaskFilter) (int64, error) {
+	filterNoPagination := filter
+	filterNoPagination.Limit = 0
+	filterNoPagination.Offset = 0
+
+	tasks, err := r.listWithRegexSearch(ctx, filterNoPagination)
+	if err != nil && !errors.Is(err, repository.ErrSearchTruncated) {
+		return 0, err
+	}
+
+	return int64(len(tasks)), err
+}
+
 func (r *TaskRepository) buildWhereClause(filter repository.TaskFilter, isCount bool) (string, []interface{}) {
 	var query string
 	if isCount {
@@ -220,18 +542,33 @@ func (r *TaskRepository) buildWhereClause(filter repository.TaskFilter, isCount
 		query = `SELECT
 			t.id, t.title, t.description, t.priority, t.status, t.tags,
 			t.project_id, p.name as project_name,
-			t.created_at, t.updated_at, t.due_date
+			t.created_at, t.updated_at, t.due_date, t.position, t.completed_at, t.is_pinned, t.snoozed_until, t.waiting_on, t.is_locked, t.color, t.recurrence,
+			t.project_task_number, t.number_project_id, np.key_prefix as number_project_key,
+			` + dependencyColumns + `
 		FROM tasks t
 		LEFT JOIN projects p ON t.project_id = p.id
+		LEFT JOIN projects np ON t.number_project_id = np.id
 		WHERE 1=1`
 	}
 
+	conditions, args := taskConditions(filter)
+	return query + conditions, args
+}
+
+// the filter's conditions on tasks t joined with projects p, each one
+// starting with " AND "
+func taskConditions(filter repository.TaskFilter) (string, []interface{}) {
+	var query string
 	args := make([]interface{}, 0)
 
 	if filter.Status != "" {
 		query += " AND t.status = ?"
 		args = append(args, filter.Status)
 	}
+	for _, status := range filter.ExcludeStatuses {
+		query += " AND t.status != ?"
+		args = append(args, status)
+	}
 	if filter.Priority != "" {
 		query += " AND t.priority = ?"
 		args = append(args, filter.Priority)
@@ -240,6 +577,50 @@ func (r *TaskRepository) buildWhereClause(filter repository.TaskFilter, isCount
 		query += " AND t.project_id = ?"
 		args = append(args, *filter.ProjectID)
 	}
+	if len(filter.ProjectIDs) > 0 {
+		query += " AND t.project_id IN (?" + strings.Repeat(", ?", len(filter.ProjectIDs)-1) + ")"
+		for _, id := range filter.ProjectIDs {
+			args = append(args, id)
+		}
+	}
+	if len(filter.IDs) > 0 {
+		query += " AND t.id IN (?" + strings.Repeat(", ?", len(filter.IDs)-1) + ")"
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+	if filter.PinnedOnly {
+		query += " AND t.is_pinned = 1"
+	}
+	if filter.WaitingOnly {
+		query += " AND t.waiting_on != ''"
+	}
+	if filter.BlockedOnly {
+		query += " AND EXISTS (SELECT 1 " + openBlockersFrom + "t.id)"
+	}
+	if filter.HideBlocked {
+		query += " AND NOT EXISTS (SELECT 1 " + openBlockersFrom + "t.id)"
+	}
+	if filter.HideWaiting {
+		query += " AND t.waiting_on = ''"
+	}
+	if filter.LockedOnly {
+		query += " AND t.is_locked = 1"
+	}
+	if filter.UnlockedOnly {
+		query += " AND t.is_locked = 0"
+	}
+	if filter.OrphanedOnly {
+		query += " AND t.project_id IS NOT NULL AND p.id IS NULL"
+	}
+	if filter.HideSnoozed {
+		// datetime() compares in UTC whatever offset the time was stored with
+		query += " AND (t.snoozed_until IS NULL OR datetime(t.snoozed_until) <= datetime('now'))"
+	}
+	if filter.HideArchived && !slices.Contains(filter.Tags, domain.ArchivedTag) {
+		query += " AND NOT EXISTS (SELECT 1 FROM json_each(t.tags) WHERE value = ?)"
+		args = append(args, domain.ArchivedTag)
+	}
 
 	if len(filter.Tags) > 0 {
 		for _, tag := range filter.Tags {
@@ -256,7 +637,10 @@ func (r *TaskRepository) buildWhereClause(filter repository.TaskFilter, isCount
 	}
 
 	if filter.SearchQuery != "" {
-		if filter.SearchMode == "regex" {
+		if filter.SearchMode == "fts" {
+			query += " AND t.id IN (SELECT rowid FROM tasks_fts WHERE tasks_fts MATCH ?)"
+			args = append(args, ftsMatch(filter.SearchQuery))
+		} else if filter.SearchMode == "regex" {
 			query += ` AND (
 				t.title REGEXP ? OR
 				COALESCE(t.description, '') REGEXP ? OR
@@ -311,10 +695,58 @@ func (r *TaskRepository) buildWhereClause(filter repository.TaskFilter, isCount
 		args = append(args, *filter.UpdatedTo)
 	}
 
+	if filter.CompletedFrom != nil {
+		query += " AND t.completed_at >= ?"
+		args = append(args, *filter.CompletedFrom)
+	}
+	if filter.CompletedTo != nil {
+		query += " AND t.completed_at <= ?"
+		args = append(args, *filter.CompletedTo)
+	}
+
+	// each group is a parenthesised OR of its filters' own conditions
+	for _, group := range filter.AnyOf {
+		if len(group) == 0 {
+			continue
+		}
+		alternatives := make([]string, len(group))
+		for i, alternative := range group {
+			conditions, alternativeArgs := taskConditions(alternative)
+			alternatives[i] = "(1=1" + conditions + ")"
+			args = append(args, alternativeArgs...)
+		}
+		query += " AND (" + strings.Join(alternatives, " OR ") + ")"
+	}
+
 	return query, args
 }
 
+// ranks plain text search matches by the field they are in: title, then
+// tags or project, then description
+func buildSearchRank(filter repository.TaskFilter) (string, []interface{}) {
+	if filter.SearchQuery == "" || filter.SearchMode == "regex" || filter.SearchMode == "fuzzy" {
+		return "", nil
+	}
+
+	searchPattern := "%" + filter.SearchQuery + "%"
+	rank := `CASE
+			WHEN t.title LIKE ? COLLATE NOCASE THEN 3
+			WHEN COALESCE(t.tags, '') LIKE ? COLLATE NOCASE THEN 2
+			WHEN COALESCE(p.name, '') LIKE ? COLLATE NOCASE THEN 2
+			ELSE 1
+		END`
+	return rank, []interface{}{searchPattern, searchPattern, searchPattern}
+}
+
 func (r *TaskRepository) buildOrderClause(filter repository.TaskFilter) string {
+	orderClause := r.buildSortClause(filter)
+	if filter.PinnedFirst {
+		orderClause = " ORDER BY t.is_pinned DESC," + strings.TrimPrefix(orderClause, " ORDER BY")
+	}
+	return orderClause
+}
+
+func (r *TaskRepository) buildSortClause(filter repository.TaskFilter) string {
 	sortBy := filter.SortBy
 	sortOrder := filter.SortOrder
 
@@ -329,6 +761,12 @@ func (r *TaskRepository) buildOrderClause(filter repository.TaskFilter) string {
 		sortOrder = "desc"
 	}
 
+	// positions only mean something within a project, so keep each project's
+	// tasks together
+	if sortBy == "position" {
+		return fmt.Sprintf(" ORDER BY t.project_id IS NULL, t.project_id, t.position %s, t.id %s", sortOrder, sortOrder)
+	}
+
 	if sortBy == "priority" {
 		return fmt.Sprintf(` ORDER BY
 			CASE t.priority
@@ -340,6 +778,29 @@ func (r *TaskRepository) buildOrderClause(filter repository.TaskFilter) string {
 			END %s, t.created_at DESC`, sortOrder)
 	}
 
+	// most urgent first whatever the order: a score from the priority, being
+	// started or pinned and how soon the task is due, then the soonest due and
+	// the oldest
+	if sortBy == "ready" {
+		return ` ORDER BY (
+			CASE t.priority
+				WHEN 'urgent' THEN 40
+				WHEN 'high' THEN 30
+				WHEN 'medium' THEN 20
+				ELSE 10
+			END
+			+ CASE WHEN t.status = 'in_progress' THEN 15 ELSE 0 END
+			+ CASE WHEN t.is_pinned = 1 THEN 15 ELSE 0 END
+			+ CASE
+				WHEN t.due_date IS NULL THEN 0
+				WHEN datetime(t.due_date) < datetime('now') THEN 30
+				WHEN datetime(t.due_date) <= datetime('now', '+2 days') THEN 20
+				WHEN datetime(t.due_date) <= datetime('now', '+7 days') THEN 10
+				ELSE 0
+			END
+		) DESC, t.due_date IS NULL, t.due_date ASC, t.created_at ASC`
+	}
+
 	validColumns := map[string]string{
 		"created_at": "t.created_at",
 		"updated_at": "t.updated_at",
@@ -373,6 +834,11 @@ func (r *TaskRepository) listWithFuzzySearch(ctx context.Context, filter reposit
 		threshold = 60
 	}
 
+	scorer, err := fuzzy.NewScorer(filter.FuzzyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
 	filterWithoutSearch := filter
 	filterWithoutSearch.SearchQuery = ""
 	filterWithoutSearch.SearchMode = ""
@@ -398,36 +864,18 @@ func (r *TaskRepository) listWithFuzzySearch(ctx context.Context, filter reposit
 
 	scoredTasks := make([]taskWithScore, 0)
 	for _, task := range candidateTasks {
-		searchableTexts := []string{
-			task.Title,
-			task.Description,
-			task.ProjectName,
-		}
-
-		if len(task.Tags) > 0 {
-			searchableTexts = append(searchableTexts, strings.Join(task.Tags, " "))
-		}
-
-		bestScore := 0
-		for _, text := range searchableTexts {
-			if text == "" {
-				continue
-			}
-			score := fuzzy.Match(filter.SearchQuery, text)
-			if score > bestScore {
-				bestScore = score
-			}
-		}
-
-		if bestScore >= threshold {
+		score := task.SearchScore(func(text string) int {
+			return scorer.Score(filter.SearchQuery, text)
+		})
+		if score >= threshold {
 			scoredTasks = append(scoredTasks, taskWithScore{
 				task:  task,
-				score: bestScore,
+				score: score,
 			})
 		}
 	}
 
-	sort.Slice(scoredTasks, func(i, j int) bool {
+	sort.SliceStable(scoredTasks, func(i, j int) bool {
 		return scoredTasks[i].score > scoredTasks[j].score
 	})
 
@@ -454,24 +902,67 @@ func (r *TaskRepository) listWithFuzzySearch(ctx context.Context, filter reposit
 }
 
 func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	_, err := r.UpdateWithNext(ctx, task)
+	return err
+}
+
+func (r *TaskRepository) UpdateWithNext(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	task.DueDate = domain.NormalizeDueDate(task.DueDate)
 	if err := task.Validate(); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	before, err := snapshotTasks(ctx, tx, []int64{task.ID})
+	if err != nil {
+		return nil, err
+	}
+	current, ok := before[task.ID]
+	if !ok {
+		return nil, fmt.Errorf("task not found: %d", task.ID)
+	}
+	if current.IsLocked {
+		return nil, &repository.LockedTaskError{ID: task.ID}
+	}
+
+	if err := r.db.settings.StatusTransitions.Validate(current.Status, task.Status); err != nil {
+		return nil, err
 	}
 
 	tagsJSON, err := json.Marshal(task.Tags)
 	if err != nil {
-		return fmt.Errorf("failed to marshal tags: %w", err)
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
 	task.UpdatedAt = time.Now()
 
+	// completing a recurring task hands its rule on to a pending copy due on
+	// the next occurrence, the completed task stays behind as history
+	var next *domain.Task
+	recurrence := task.Recurrence
+	if task.Status == domain.StatusCompleted && current.Status != domain.StatusCompleted {
+		if next = task.NextInstance(task.UpdatedAt); next != nil {
+			recurrence = ""
+		}
+	}
+
+	// a task moved to another project goes to the bottom of it. the CASEs see
+	// the old project_id and status
 	query := `
 		UPDATE tasks
-		SET title = ?, description = ?, priority = ?, status = ?, tags = ?, project_id = ?, updated_at = ?, due_date = ?
+		SET title = ?, description = ?, priority = ?, status = ?, tags = ?, project_id = ?, updated_at = ?, due_date = ?, is_pinned = ?, snoozed_until = ?, waiting_on = ?, color = ?, recurrence = ?,
+			position = CASE WHEN project_id IS ? THEN position
+				ELSE (SELECT COALESCE(MAX(position), 0) + 1 FROM tasks WHERE project_id IS ?) END,
+			completed_at = ` + completedAtCase + `
 		WHERE id = ?
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		task.Title,
 		task.Description,
 		task.Priority,
@@ -480,30 +971,164 @@ func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
 		nullInt64(task.ProjectID),
 		task.UpdatedAt,
 		nullTime(task.DueDate),
+		task.IsPinned,
+		nullTime(task.SnoozedUntil),
+		task.WaitingOn,
+		task.Color,
+		recurrence,
+		nullInt64(task.ProjectID),
+		nullInt64(task.ProjectID),
+		task.Status,
+		task.UpdatedAt,
 		task.ID,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("task not found: %d", task.ID)
+		return nil, fmt.Errorf("task not found: %d", task.ID)
+	}
+
+	if task.Status != domain.StatusCompleted {
+		task.CompletedAt = nil
+	} else if err := tx.GetContext(ctx, &task.CompletedAt, `SELECT completed_at FROM tasks WHERE id = ?`, task.ID); err != nil {
+		return nil, fmt.Errorf("failed to get completion time: %w", err)
+	}
+
+	if err := recordChanges(ctx, tx, before, task.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	var nextID int64
+	if next != nil {
+		if nextID, err = insertTask(ctx, tx, next); err != nil {
+			return nil, fmt.Errorf("failed to create next occurrence: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	task.Recurrence = recurrence
+	if next != nil {
+		next.ID = nextID
+	}
+	return next, nil
+}
+
+// completed_at for a status change, takes the new status and the current time.
+// a task that stays completed keeps its completion time, one that is completed
+// again after being reopened gets a new one
+const completedAtCase = `CASE WHEN ? <> 'completed' THEN NULL
+				WHEN status = 'completed' AND completed_at IS NOT NULL THEN completed_at
+				ELSE ? END`
+
+func (r *TaskRepository) SetPinned(ctx context.Context, id int64, pinned bool) error {
+	return r.execAudited(ctx, id, "failed to set pinned",
+		`UPDATE tasks SET is_pinned = ?, updated_at = ? WHERE id = ?`, pinned, time.Now(), id)
+}
+
+// locking only guards edits and deletes, pinning and snoozing still work
+func (r *TaskRepository) SetLocked(ctx context.Context, id int64, locked bool) error {
+	return r.execAudited(ctx, id, "failed to set locked",
+		`UPDATE tasks SET is_locked = ?, updated_at = ? WHERE id = ?`, locked, time.Now(), id)
+}
+
+// snoozes a task until the given time, nil wakes it up again
+func (r *TaskRepository) SetSnoozed(ctx context.Context, id int64, until *time.Time) error {
+	return r.execAudited(ctx, id, "failed to set snooze",
+		`UPDATE tasks SET snoozed_until = ?, updated_at = ? WHERE id = ?`, nullTime(until), time.Now(), id)
+}
+
+// swaps the manual positions of two tasks in the same project. the project's
+// positions are renumbered 1..n first so gaps and duplicates left by deletes,
+// moves and older databases don't survive a reorder
+func (r *TaskRepository) SwapPositions(ctx context.Context, taskID, otherID int64) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var projects []sql.NullInt64
+	if err := tx.SelectContext(ctx, &projects, `SELECT project_id FROM tasks WHERE id IN (?, ?)`, taskID, otherID); err != nil {
+		return fmt.Errorf("failed to get tasks: %w", err)
+	}
+	if len(projects) != 2 {
+		return fmt.Errorf("task not found: %d or %d", taskID, otherID)
+	}
+	if projects[0] != projects[1] {
+		return fmt.Errorf("tasks %d and %d are in different projects", taskID, otherID)
+	}
+
+	var ids []int64
+	if err := tx.SelectContext(ctx, &ids,
+		`SELECT id FROM tasks WHERE project_id IS ? ORDER BY position, created_at, id`,
+		projects[0],
+	); err != nil {
+		return fmt.Errorf("failed to list task positions: %w", err)
+	}
+
+	positions := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		positions[id] = i + 1
+	}
+	positions[taskID], positions[otherID] = positions[otherID], positions[taskID]
+
+	for id, position := range positions {
+		if _, err := tx.ExecContext(ctx, `UPDATE tasks SET position = ? WHERE id = ?`, position, id); err != nil {
+			return fmt.Errorf("failed to update task position: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
 func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM tasks WHERE id = ?`
+	return r.execAudited(ctx, id, "failed to delete task", `DELETE FROM tasks WHERE id = ? AND is_locked = 0`, id)
+}
+
+// runs a statement that changes or deletes the task with the given id and
+// records the change in the audit log, in one transaction
+func (r *TaskRepository) execAudited(ctx context.Context, id int64, errPrefix, query string, args ...interface{}) error {
+	return r.execAuditedChecked(ctx, id, nil, errPrefix, query, args...)
+}
+
+// execAudited with a check that runs first in the same transaction, so what
+// it looked at can't change before the statement runs. an error from check is
+// returned as is and nothing is written
+func (r *TaskRepository) execAuditedChecked(ctx context.Context, id int64, check func(tx *sqlx.Tx) error, errPrefix, query string, args ...interface{}) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if check != nil {
+		if err := check(tx); err != nil {
+			return err
+		}
+	}
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	before, err := snapshotTasks(ctx, tx, []int64{id})
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, err)
 	}
 
 	rows, err := result.RowsAffected()
@@ -512,25 +1137,89 @@ func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rows == 0 {
+		// statements that must not touch locked tasks match none of them
+		if task, ok := before[id]; ok && task.IsLocked {
+			return &repository.LockedTaskError{ID: id}
+		}
 		return fmt.Errorf("task not found: %d", id)
 	}
 
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+func (r *TaskRepository) ListTags(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT j.value
+		FROM tasks t, json_each(t.tags) j
+		WHERE t.tags IS NOT NULL AND t.tags != ''
+		ORDER BY j.value COLLATE NOCASE
+	`
+
+	var tags []string
+	if err := r.db.SelectContext(ctx, &tags, query); err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// most used first, ties broken alphabetically
+func (r *TaskRepository) GetTagCounts(ctx context.Context) ([]domain.TagCount, error) {
+	query := `
+		SELECT j.value AS tag, COUNT(*) AS count
+		FROM tasks t, json_each(t.tags) j
+		WHERE t.tags IS NOT NULL AND t.tags != ''
+		GROUP BY j.value
+		ORDER BY count DESC, j.value COLLATE NOCASE
+	`
+
+	var counts []domain.TagCount
+	if err := r.db.SelectContext(ctx, &counts, query); err != nil {
+		return nil, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	return counts, nil
+}
+
 func (r *TaskRepository) BulkUpdate(ctx context.Context, filter repository.TaskFilter, updates repository.TaskUpdate) (int64, error) {
+	filter.UnlockedOnly = true
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := r.checkMaxAffected(ctx, tx, filter); err != nil {
+		return 0, err
+	}
+
+	before, err := r.snapshotMatching(ctx, tx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	if updates.Status != nil {
+		if err := r.validateTransitions(before, *updates.Status); err != nil {
+			return 0, err
+		}
+	}
+
+	now := time.Now()
 	query := "UPDATE tasks SET updated_at = ?"
-	args := []interface{}{time.Now()}
+	args := []interface{}{now}
 
 	if updates.Status != nil {
-		query += ", status = ?"
-		args = append(args, *updates.Status)
+		query += ", completed_at = " + completedAtCase + ", status = ?"
+		args = append(args, *updates.Status, now, *updates.Status)
 	}
 	if updates.Priority != nil {
 		query += ", priority = ?"
@@ -553,7 +1242,16 @@ func (r *TaskRepository) BulkUpdate(ctx context.Context, filter repository.TaskF
 		if *updates.DueDate == nil {
 			args = append(args, nil)
 		} else {
-			args = append(args, **updates.DueDate)
+			// stored like the due dates Create and Update write
+			due, err := domain.ParseDueDate(**updates.DueDate)
+			if err != nil {
+				return 0, fmt.Errorf("invalid due date: %w", err)
+			}
+			due = domain.NormalizeDueDate(due)
+			if err := domain.ValidateDueDate(*due); err != nil {
+				return 0, err
+			}
+			args = append(args, *due)
 		}
 	}
 
@@ -571,6 +1269,16 @@ func (r *TaskRepository) BulkUpdate(ctx context.Context, filter repository.TaskF
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if updates.Status != nil && *updates.Status == domain.StatusCompleted {
+		if err := spawnNextInstances(ctx, tx, before, now); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -578,13 +1286,67 @@ func (r *TaskRepository) BulkUpdate(ctx context.Context, filter repository.TaskF
 	return count, nil
 }
 
+// checks moving each task to status against the transition rules, reporting
+// the lowest ID that can't move so the result doesn't depend on map order
+func (r *TaskRepository) validateTransitions(tasks map[int64]*domain.Task, status domain.Status) error {
+	ids := make([]int64, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		if err := r.db.settings.StatusTransitions.Validate(tasks[id].Status, status); err != nil {
+			return fmt.Errorf("task %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// creates the next instance of each recurring task that was just completed,
+// like Update does for a single one
+func spawnNextInstances(ctx context.Context, tx *sqlx.Tx, before map[int64]*domain.Task, now time.Time) error {
+	ids := make([]int64, 0, len(before))
+	for id, task := range before {
+		if task.Status != domain.StatusCompleted && task.Recurrence != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		next := before[id].NextInstance(now)
+		if next == nil {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE tasks SET recurrence = '' WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
+		if _, err := insertTask(ctx, tx, next); err != nil {
+			return fmt.Errorf("failed to create next occurrence: %w", err)
+		}
+	}
+	return nil
+}
+
 func (r *TaskRepository) BulkMove(ctx context.Context, filter repository.TaskFilter, projectID *int64) (int64, error) {
+	filter.UnlockedOnly = true
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := r.checkMaxAffected(ctx, tx, filter); err != nil {
+		return 0, err
+	}
+
+	before, err := r.snapshotMatching(ctx, tx, filter)
+	if err != nil {
+		return 0, err
+	}
+
 	query := "UPDATE tasks SET project_id = ?, updated_at = ?"
 	args := []interface{}{nullInt64(projectID), time.Now()}
 
@@ -602,6 +1364,10 @@ func (r *TaskRepository) BulkMove(ctx context.Context, filter repository.TaskFil
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -610,30 +1376,62 @@ func (r *TaskRepository) BulkMove(ctx context.Context, filter repository.TaskFil
 }
 
 func (r *TaskRepository) BulkAddTags(ctx context.Context, filter repository.TaskFilter, tags []string) (int64, error) {
+	filter.UnlockedOnly = true
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	tasks, err := r.List(ctx, filter)
+	if err := r.checkMaxAffected(ctx, tx, filter); err != nil {
+		return 0, err
+	}
+
+	// selected in the transaction, so a task changed in between can't be
+	// written back with stale tags
+	before, err := r.snapshotMatching(ctx, tx, filter)
+	if err != nil {
+		return 0, err
+	}
+	tasks := snapshotInOrder(before)
+
+	count, err := addTags(ctx, tx, tasks, tags, time.Now())
 	if err != nil {
-		return 0, fmt.Errorf("failed to get tasks: %w", err)
+		return 0, err
+	}
+
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return count, nil
+}
+
+// the snapshot's tasks in ID order
+func snapshotInOrder(snapshot map[int64]*domain.Task) []*domain.Task {
+	tasks := make([]*domain.Task, 0, len(snapshot))
+	for _, task := range snapshot {
+		tasks = append(tasks, task)
 	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks
+}
 
+// adds tags to each task that doesn't carry them yet, keeping the tags it has
+// in order, and returns how many tasks were written
+func addTags(ctx context.Context, tx *sqlx.Tx, tasks []*domain.Task, tags []string, now time.Time) (int64, error) {
 	var count int64
 	for _, task := range tasks {
-		tagSet := make(map[string]bool)
-		for _, existingTag := range task.Tags {
-			tagSet[existingTag] = true
-		}
-		for _, newTag := range tags {
-			tagSet[newTag] = true
-		}
-
-		updatedTags := make([]string, 0, len(tagSet))
-		for tag := range tagSet {
-			updatedTags = append(updatedTags, tag)
+		updatedTags := slices.Clone(task.Tags)
+		for _, tag := range tags {
+			if !slices.Contains(updatedTags, tag) {
+				updatedTags = append(updatedTags, tag)
+			}
 		}
 
 		tagsJSON, err := json.Marshal(updatedTags)
@@ -641,8 +1439,7 @@ func (r *TaskRepository) BulkAddTags(ctx context.Context, filter repository.Task
 			return 0, fmt.Errorf("failed to marshal tags: %w", err)
 		}
 
-		query := "UPDATE tasks SET tags = ?, updated_at = ? WHERE id = ?"
-		result, err := tx.ExecContext(ctx, query, string(tagsJSON), time.Now(), task.ID)
+		result, err := tx.ExecContext(ctx, "UPDATE tasks SET tags = ?, updated_at = ? WHERE id = ?", string(tagsJSON), now, task.ID)
 		if err != nil {
 			return 0, fmt.Errorf("failed to update task tags: %w", err)
 		}
@@ -653,25 +1450,29 @@ func (r *TaskRepository) BulkAddTags(ctx context.Context, filter repository.Task
 		}
 		count += rows
 	}
-
-	if err := tx.Commit(); err != nil {
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return count, nil
 }
 
 func (r *TaskRepository) BulkRemoveTags(ctx context.Context, filter repository.TaskFilter, tags []string) (int64, error) {
+	filter.UnlockedOnly = true
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	tasks, err := r.List(ctx, filter)
+	if err := r.checkMaxAffected(ctx, tx, filter); err != nil {
+		return 0, err
+	}
+
+	// selected in the transaction, so a task changed in between can't be
+	// written back with stale tags
+	before, err := r.snapshotMatching(ctx, tx, filter)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get tasks: %w", err)
+		return 0, err
 	}
+	tasks := snapshotInOrder(before)
 
 	tagsToRemove := make(map[string]bool)
 	for _, tag := range tags {
@@ -705,6 +1506,10 @@ func (r *TaskRepository) BulkRemoveTags(ctx context.Context, filter repository.T
 		count += rows
 	}
 
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -713,12 +1518,23 @@ func (r *TaskRepository) BulkRemoveTags(ctx context.Context, filter repository.T
 }
 
 func (r *TaskRepository) BulkDelete(ctx context.Context, filter repository.TaskFilter) (int64, error) {
+	filter.UnlockedOnly = true
+
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return 0, fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := r.checkMaxAffected(ctx, tx, filter); err != nil {
+		return 0, err
+	}
+
+	before, err := r.snapshotMatching(ctx, tx, filter)
+	if err != nil {
+		return 0, err
+	}
+
 	query := "DELETE FROM tasks"
 	whereQuery, args := r.buildBulkWhereClause(filter)
 	query += whereQuery
@@ -733,6 +1549,10 @@ func (r *TaskRepository) BulkDelete(ctx context.Context, filter repository.TaskF
 		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if err := recordChanges(ctx, tx, before, time.Now()); err != nil {
+		return 0, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -740,67 +1560,30 @@ func (r *TaskRepository) BulkDelete(ctx context.Context, filter repository.TaskF
 	return count, nil
 }
 
-func (r *TaskRepository) buildBulkWhereClause(filter repository.TaskFilter) (string, []interface{}) {
-	query := " WHERE 1=1"
-	args := make([]interface{}, 0)
-
-	if filter.Status != "" {
-		query += " AND status = ?"
-		args = append(args, filter.Status)
-	}
-	if filter.Priority != "" {
-		query += " AND priority = ?"
-		args = append(args, filter.Priority)
-	}
-	if filter.ProjectID != nil {
-		query += " AND project_id = ?"
-		args = append(args, *filter.ProjectID)
-	}
-
-	if len(filter.Tags) > 0 {
-		for _, tag := range filter.Tags {
-			query += " AND EXISTS (SELECT 1 FROM json_each(tags) WHERE value = ?)"
-			args = append(args, tag)
-		}
+// counts the tasks a bulk operation would touch and refuses when there are
+// more than filter.MaxAffected
+func (r *TaskRepository) checkMaxAffected(ctx context.Context, tx *sqlx.Tx, filter repository.TaskFilter) error {
+	if filter.MaxAffected <= 0 {
+		return nil
 	}
 
-	if filter.SearchQuery != "" {
-		if filter.SearchMode == "regex" {
-			query += ` AND (
-				title REGEXP ? OR
-				COALESCE(description, '') REGEXP ? OR
-				COALESCE(tags, '') REGEXP ?
-			)`
-			for range 3 {
-				args = append(args, filter.SearchQuery)
-			}
-		} else {
-			searchPattern := "%" + filter.SearchQuery + "%"
-			query += ` AND (
-				title LIKE ? COLLATE NOCASE OR
-				COALESCE(description, '') LIKE ? COLLATE NOCASE OR
-				COALESCE(tags, '') LIKE ? COLLATE NOCASE
-			)`
-			for range 3 {
-				args = append(args, searchPattern)
-			}
-		}
+	whereQuery, args := r.buildBulkWhereClause(filter)
+	var count int64
+	if err := tx.GetContext(ctx, &count, "SELECT COUNT(*) FROM tasks"+whereQuery, args...); err != nil {
+		return fmt.Errorf("failed to count affected tasks: %w", err)
 	}
 
-	if filter.DueDateFrom != nil {
-		if *filter.DueDateFrom == "none" {
-			query += " AND due_date IS NULL"
-		} else {
-			query += " AND due_date >= ?"
-			args = append(args, *filter.DueDateFrom)
-		}
-	}
-	if filter.DueDateTo != nil {
-		query += " AND due_date <= ?"
-		args = append(args, *filter.DueDateTo)
+	if count > int64(filter.MaxAffected) {
+		return &repository.TooManyAffectedError{Count: count, Max: filter.MaxAffected}
 	}
+	return nil
+}
 
-	return query, args
+// a WHERE clause for statements on the tasks table matching the tasks List
+// returns for filter, since the conditions need the project join
+func (r *TaskRepository) buildBulkWhereClause(filter repository.TaskFilter) (string, []interface{}) {
+	conditions, args := taskConditions(filter)
+	return " WHERE id IN (SELECT t.id FROM tasks t LEFT JOIN projects p ON t.project_id = p.id WHERE 1=1" + conditions + ")", args
 }
 
 func nullTime(t *time.Time) sql.NullTime {
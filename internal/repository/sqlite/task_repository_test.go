@@ -202,6 +202,70 @@ func TestTaskRepository_Update(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_UpdateStatusTransitions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Transition Task")
+	task.Status = domain.StatusCancelled
+	require.NoError(t, repo.Create(ctx, task))
+
+	t.Run("unrestricted without rules", func(t *testing.T) {
+		task.Status = domain.StatusCompleted
+		require.NoError(t, repo.Update(ctx, task))
+
+		task.Status = domain.StatusCancelled
+		require.NoError(t, repo.Update(ctx, task))
+	})
+
+	db.settings.StatusTransitions = domain.DefaultStatusTransitions()
+
+	t.Run("terminal state rejected", func(t *testing.T) {
+		task.Status = domain.StatusCompleted
+		err := repo.Update(ctx, task)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "terminal state")
+
+		retrieved, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCancelled, retrieved.Status)
+	})
+
+	t.Run("reopen then complete", func(t *testing.T) {
+		task.Status = domain.StatusPending
+		require.NoError(t, repo.Update(ctx, task))
+
+		task.Status = domain.StatusCompleted
+		require.NoError(t, repo.Update(ctx, task))
+	})
+
+	t.Run("other fields on terminal task", func(t *testing.T) {
+		task.Title = "Renamed"
+		require.NoError(t, repo.Update(ctx, task))
+	})
+
+	t.Run("bulk status change", func(t *testing.T) {
+		open := domain.NewTask("Still open")
+		require.NoError(t, repo.Create(ctx, open))
+
+		cancelled := domain.StatusCancelled
+		_, err := repo.BulkUpdate(ctx, repository.TaskFilter{IDs: []int64{task.ID, open.ID}}, repository.TaskUpdate{Status: &cancelled})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("task %d: cannot change status from completed to cancelled", task.ID))
+
+		retrieved, err := repo.GetByID(ctx, open.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusPending, retrieved.Status, "nothing changes when one task can't move")
+
+		count, err := repo.BulkUpdate(ctx, repository.TaskFilter{IDs: []int64{open.ID}}, repository.TaskUpdate{Status: &cancelled})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+}
+
 func TestTaskRepository_Delete(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -229,6 +293,76 @@ func TestTaskRepository_Delete(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_ListTags(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	t.Run("no tasks", func(t *testing.T) {
+		tags, err := repo.ListTags(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tags)
+	})
+
+	t.Run("distinct sorted tags", func(t *testing.T) {
+		task1 := domain.NewTask("Task 1")
+		task1.Tags = []string{"backend", "urgent"}
+		require.NoError(t, repo.Create(ctx, task1))
+
+		task2 := domain.NewTask("Task 2")
+		task2.Tags = []string{"api", "backend"}
+		require.NoError(t, repo.Create(ctx, task2))
+
+		task3 := domain.NewTask("Task 3")
+		require.NoError(t, repo.Create(ctx, task3))
+
+		tags, err := repo.ListTags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "backend", "urgent"}, tags)
+	})
+}
+
+func TestTaskRepository_GetTagCounts(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	t.Run("no tasks", func(t *testing.T) {
+		counts, err := repo.GetTagCounts(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("most used first", func(t *testing.T) {
+		for _, tags := range [][]string{
+			{"backend", "urgent"},
+			{"api", "backend"},
+			{"backend", "api"},
+			{"Docs"},
+			nil,
+		} {
+			task := domain.NewTask("Task")
+			if tags != nil {
+				task.Tags = tags
+			}
+			require.NoError(t, repo.Create(ctx, task))
+		}
+
+		counts, err := repo.GetTagCounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.TagCount{
+			{Tag: "backend", Count: 3},
+			{Tag: "api", Count: 2},
+			{Tag: "Docs", Count: 1},
+			{Tag: "urgent", Count: 1},
+		}, counts)
+	})
+}
+
 func TestTaskRepository_Count(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -377,14 +511,9 @@ func TestTaskRepository_Pagination(t *testing.T) {
 	})
 }
 
-func TestTaskRepository_Search(t *testing.T) {
-	db, cleanup := setupTestDB(t)
-	defer cleanup()
-
-	repo := NewTaskRepository(db)
-	ctx := context.Background()
-
-	testTasks := []*domain.Task{
+// titles, descriptions and tags that text searches can tell apart
+func searchTestTasks() []*domain.Task {
+	return []*domain.Task{
 		{
 			Title:       "Implement user authentication",
 			Description: "Add login and signup functionality",
@@ -414,6 +543,16 @@ func TestTaskRepository_Search(t *testing.T) {
 			Tags:        []string{"refactor", "auth"},
 		},
 	}
+}
+
+func TestTaskRepository_Search(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	testTasks := searchTestTasks()
 
 	for _, task := range testTasks {
 		err := repo.Create(ctx, task)
@@ -829,6 +968,154 @@ func TestTaskRepository_BulkMove(t *testing.T) {
 	})
 }
 
+func TestTaskRepository_Positions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	taskRepo := NewTaskRepository(db)
+	projectRepo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	project1 := &domain.Project{Name: "Project 1", Status: domain.ProjectStatusActive}
+	require.NoError(t, projectRepo.Create(ctx, project1))
+	project2 := &domain.Project{Name: "Project 2", Status: domain.ProjectStatusActive}
+	require.NoError(t, projectRepo.Create(ctx, project2))
+
+	tasks := []*domain.Task{
+		{Title: "Task 1", ProjectID: &project1.ID},
+		{Title: "Task 2", ProjectID: &project1.ID},
+		{Title: "Task 3", ProjectID: &project1.ID},
+		{Title: "Other", ProjectID: &project2.ID},
+	}
+	for _, task := range tasks {
+		require.NoError(t, taskRepo.Create(ctx, task))
+	}
+
+	titles := func() []string {
+		list, err := taskRepo.List(ctx, repository.TaskFilter{ProjectID: &project1.ID, SortBy: "position", SortOrder: "asc"})
+		require.NoError(t, err)
+		result := make([]string, len(list))
+		for i, task := range list {
+			result[i] = task.Title
+		}
+		return result
+	}
+
+	t.Run("new tasks go to the bottom of their project", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3, 1}, []int{tasks[0].Position, tasks[1].Position, tasks[2].Position, tasks[3].Position})
+		assert.Equal(t, []string{"Task 1", "Task 2", "Task 3"}, titles())
+	})
+
+	t.Run("swap positions", func(t *testing.T) {
+		require.NoError(t, taskRepo.SwapPositions(ctx, tasks[2].ID, tasks[1].ID))
+		assert.Equal(t, []string{"Task 1", "Task 3", "Task 2"}, titles())
+	})
+
+	t.Run("duplicate positions are renumbered", func(t *testing.T) {
+		_, err := db.Exec(`UPDATE tasks SET position = 0 WHERE project_id = ?`, project1.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, taskRepo.SwapPositions(ctx, tasks[0].ID, tasks[1].ID))
+		assert.Equal(t, []string{"Task 2", "Task 1", "Task 3"}, titles())
+
+		var positions []int
+		require.NoError(t, db.Select(&positions, `SELECT position FROM tasks WHERE project_id = ? ORDER BY position`, project1.ID))
+		assert.Equal(t, []int{1, 2, 3}, positions)
+	})
+
+	t.Run("tasks in different projects cannot be swapped", func(t *testing.T) {
+		err := taskRepo.SwapPositions(ctx, tasks[0].ID, tasks[3].ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("moving a task puts it at the bottom of the new project", func(t *testing.T) {
+		task, err := taskRepo.GetByID(ctx, tasks[0].ID)
+		require.NoError(t, err)
+		task.ProjectID = &project2.ID
+		require.NoError(t, taskRepo.Update(ctx, task))
+
+		moved, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, moved.Position)
+
+		moved.Title = "Renamed"
+		require.NoError(t, taskRepo.Update(ctx, moved))
+		renamed, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, renamed.Position)
+	})
+}
+
+func TestTaskRepository_CompletedAt(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	taskRepo := NewTaskRepository(db)
+	projectRepo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	project := &domain.Project{Name: "Project", Status: domain.ProjectStatusActive}
+	require.NoError(t, projectRepo.Create(ctx, project))
+
+	task := domain.NewTask("Ship it")
+	task.ProjectID = &project.ID
+	task.CreatedAt = time.Now().Add(-48 * time.Hour)
+	require.NoError(t, taskRepo.Create(ctx, task))
+	assert.Nil(t, task.CompletedAt)
+
+	task.Status = domain.StatusCompleted
+	require.NoError(t, taskRepo.Update(ctx, task))
+	require.NotNil(t, task.CompletedAt)
+	firstCompletion := *task.CompletedAt
+
+	t.Run("stays put while the task stays completed", func(t *testing.T) {
+		task.Title = "Ship it now"
+		require.NoError(t, taskRepo.Update(ctx, task))
+
+		fetched, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, fetched.CompletedAt)
+		assert.WithinDuration(t, firstCompletion, *fetched.CompletedAt, time.Millisecond)
+
+		timeToDone, ok := fetched.TimeToDone()
+		assert.True(t, ok)
+		assert.InDelta(t, 48*time.Hour, timeToDone, float64(time.Minute))
+	})
+
+	t.Run("reopening clears it and completing again uses the latest time", func(t *testing.T) {
+		task.Status = domain.StatusPending
+		require.NoError(t, taskRepo.Update(ctx, task))
+		assert.Nil(t, task.CompletedAt)
+
+		time.Sleep(10 * time.Millisecond)
+		status := domain.StatusCompleted
+		_, err := taskRepo.BulkUpdate(ctx, repository.TaskFilter{ProjectID: &project.ID}, repository.TaskUpdate{Status: &status})
+		require.NoError(t, err)
+
+		fetched, err := taskRepo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		require.NotNil(t, fetched.CompletedAt)
+		assert.True(t, fetched.CompletedAt.After(firstCompletion))
+	})
+
+	t.Run("project time to done", func(t *testing.T) {
+		quick := domain.NewTask("Quick fix")
+		quick.ProjectID = &project.ID
+		quick.Status = domain.StatusCompleted
+		require.NoError(t, taskRepo.Create(ctx, quick))
+
+		open := domain.NewTask("Still open")
+		open.ProjectID = &project.ID
+		require.NoError(t, taskRepo.Create(ctx, open))
+
+		stats, err := projectRepo.GetTimeToDone(ctx, project.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Count)
+		assert.InDelta(t, 24*time.Hour, stats.Average, float64(time.Minute))
+		assert.InDelta(t, 24*time.Hour, stats.Median, float64(time.Minute))
+	})
+}
+
 func TestTaskRepository_BulkAddTags(t *testing.T) {
 	db, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -884,6 +1171,61 @@ func TestTaskRepository_BulkAddTags(t *testing.T) {
 			assert.Equal(t, 1, count, "Tag %s should not be duplicated", tag)
 		}
 	})
+
+	t.Run("bulk add tags to selected IDs", func(t *testing.T) {
+		filter := repository.TaskFilter{IDs: []int64{tasks[0].ID, tasks[2].ID}}
+
+		count, err := repo.BulkAddTags(ctx, filter, []string{"picked"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		for i, task := range tasks {
+			updated, err := repo.GetByID(ctx, task.ID)
+			require.NoError(t, err)
+			if i == 1 {
+				assert.NotContains(t, updated.Tags, "picked")
+			} else {
+				assert.Contains(t, updated.Tags, "picked")
+			}
+		}
+	})
+}
+
+func TestTaskRepository_BulkFilterMatchesList(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	later := time.Now().Add(24 * time.Hour)
+	plain := &domain.Task{Title: "Plain", Priority: domain.PriorityLow, Status: domain.StatusPending, Tags: []string{}}
+	archived := &domain.Task{Title: "Archived", Priority: domain.PriorityLow, Status: domain.StatusPending, Tags: []string{domain.ArchivedTag}}
+	excluded := &domain.Task{Title: "Work in progress", Priority: domain.PriorityLow, Status: domain.StatusPending, Tags: []string{"wip"}}
+	snoozed := &domain.Task{Title: "Snoozed", Priority: domain.PriorityLow, Status: domain.StatusPending, Tags: []string{}, SnoozedUntil: &later}
+	for _, task := range []*domain.Task{plain, archived, excluded, snoozed} {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	filter := repository.TaskFilter{HideArchived: true, HideSnoozed: true, ExcludeTags: []string{"wip"}}
+	listed, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, plain.ID, listed[0].ID)
+
+	count, err := repo.BulkAddTags(ctx, filter, []string{"triaged"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	for _, task := range []*domain.Task{archived, excluded, snoozed} {
+		updated, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, updated.Tags, "triaged", task.Title)
+	}
+
+	count, err = repo.BulkMove(ctx, filter, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
 }
 
 func TestTaskRepository_BulkRemoveTags(t *testing.T) {
@@ -1051,3 +1393,957 @@ func TestTaskRepository_BulkDelete(t *testing.T) {
 		}
 	})
 }
+
+func TestTaskRepository_BulkMaxAffected(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, repo.Create(ctx, domain.NewTask(fmt.Sprintf("Task %d", i))))
+	}
+
+	limited := repository.TaskFilter{MaxAffected: 2}
+	priority := domain.PriorityHigh
+
+	_, err := repo.BulkUpdate(ctx, limited, repository.TaskUpdate{Priority: &priority})
+	var tooMany *repository.TooManyAffectedError
+	require.ErrorAs(t, err, &tooMany)
+	assert.Equal(t, int64(3), tooMany.Count)
+	assert.Equal(t, 2, tooMany.Max)
+
+	_, err = repo.BulkAddTags(ctx, limited, []string{"x"})
+	assert.ErrorAs(t, err, &tooMany)
+
+	_, err = repo.BulkDelete(ctx, limited)
+	assert.ErrorAs(t, err, &tooMany)
+
+	count, err := repo.Count(ctx, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count, "refused operations must not change anything")
+
+	count, err = repo.BulkDelete(ctx, repository.TaskFilter{MaxAffected: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestTaskRepository_RegexSearchLimits(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	repo.regexMaxRows = 50
+	ctx := context.Background()
+
+	for i := 0; i < 120; i++ {
+		require.NoError(t, repo.Create(ctx, domain.NewTask(fmt.Sprintf("Task %d", i))))
+	}
+
+	broad := repository.TaskFilter{SearchQuery: ".*", SearchMode: "regex"}
+
+	t.Run("a broad pattern stops at the row cap with partial results", func(t *testing.T) {
+		tasks, err := repo.List(ctx, broad)
+		assert.ErrorIs(t, err, repository.ErrSearchTruncated)
+		assert.Len(t, tasks, 50)
+
+		count, err := repo.Count(ctx, broad)
+		assert.ErrorIs(t, err, repository.ErrSearchTruncated)
+		assert.Equal(t, int64(50), count)
+	})
+
+	t.Run("a page within the cap is not truncated", func(t *testing.T) {
+		filter := broad
+		filter.Limit = 20
+		filter.Offset = 100
+
+		tasks, err := repo.List(ctx, filter)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 20)
+	})
+
+	t.Run("a narrow pattern under the cap is not truncated", func(t *testing.T) {
+		tasks, err := repo.List(ctx, repository.TaskFilter{SearchQuery: `^Task 1[0-9]$`, SearchMode: "regex"})
+		require.NoError(t, err)
+		assert.Len(t, tasks, 10)
+	})
+
+	t.Run("the timeout returns what was found so far", func(t *testing.T) {
+		repo.regexTimeout = time.Nanosecond
+		defer func() { repo.regexTimeout = regexSearchTimeout }()
+
+		tasks, err := repo.List(ctx, broad)
+		assert.ErrorIs(t, err, repository.ErrSearchTruncated)
+		assert.LessOrEqual(t, len(tasks), 50)
+	})
+
+	t.Run("an invalid pattern is rejected up front", func(t *testing.T) {
+		_, err := repo.List(ctx, repository.TaskFilter{SearchQuery: "(", SearchMode: "regex"})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, repository.ErrSearchTruncated)
+	})
+}
+
+func TestTaskRepository_Pinned(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	var tasks []*domain.Task
+	for i, title := range []string{"Oldest", "Middle", "Newest"} {
+		task := domain.NewTask(title)
+		task.CreatedAt = time.Now().Add(time.Duration(i) * time.Hour)
+		require.NoError(t, repo.Create(ctx, task))
+		tasks = append(tasks, task)
+	}
+
+	require.NoError(t, repo.SetPinned(ctx, tasks[0].ID, true))
+
+	t.Run("pinned only", func(t *testing.T) {
+		pinned, err := repo.List(ctx, repository.TaskFilter{PinnedOnly: true})
+		require.NoError(t, err)
+		require.Len(t, pinned, 1)
+		assert.Equal(t, tasks[0].ID, pinned[0].ID)
+		assert.True(t, pinned[0].IsPinned)
+
+		count, err := repo.Count(ctx, repository.TaskFilter{PinnedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("pinned first keeps the requested sort for the rest", func(t *testing.T) {
+		listed, err := repo.List(ctx, repository.TaskFilter{PinnedFirst: true})
+		require.NoError(t, err)
+		require.Len(t, listed, 3)
+		assert.Equal(t, []string{"Oldest", "Newest", "Middle"},
+			[]string{listed[0].Title, listed[1].Title, listed[2].Title})
+
+		listed, err = repo.List(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, "Newest", listed[0].Title)
+	})
+
+	t.Run("survives updates and can be cleared", func(t *testing.T) {
+		task, err := repo.GetByID(ctx, tasks[0].ID)
+		require.NoError(t, err)
+		task.Title = "Oldest, renamed"
+		require.NoError(t, repo.Update(ctx, task))
+
+		fetched, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.True(t, fetched.IsPinned)
+
+		require.NoError(t, repo.SetPinned(ctx, task.ID, false))
+		fetched, err = repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.False(t, fetched.IsPinned)
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		assert.Error(t, repo.SetPinned(ctx, 9999, true))
+	})
+}
+
+func TestTaskRepository_Locked(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	locked := domain.NewTask("Reference")
+	require.NoError(t, repo.Create(ctx, locked))
+	open := domain.NewTask("Open")
+	require.NoError(t, repo.Create(ctx, open))
+	require.NoError(t, repo.SetLocked(ctx, locked.ID, true))
+
+	var lockedErr *repository.LockedTaskError
+
+	t.Run("refuses edits and deletes", func(t *testing.T) {
+		task, err := repo.GetByID(ctx, locked.ID)
+		require.NoError(t, err)
+		assert.True(t, task.IsLocked)
+
+		task.Title = "Renamed"
+		assert.ErrorAs(t, repo.Update(ctx, task), &lockedErr)
+		assert.ErrorAs(t, repo.Delete(ctx, locked.ID), &lockedErr)
+
+		fetched, err := repo.GetByID(ctx, locked.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Reference", fetched.Title)
+	})
+
+	t.Run("pinning still works", func(t *testing.T) {
+		require.NoError(t, repo.SetPinned(ctx, locked.ID, true))
+		require.NoError(t, repo.SetPinned(ctx, locked.ID, false))
+	})
+
+	t.Run("bulk operations skip locked tasks", func(t *testing.T) {
+		status := domain.StatusCompleted
+		count, err := repo.BulkUpdate(ctx, repository.TaskFilter{}, repository.TaskUpdate{Status: &status})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = repo.BulkAddTags(ctx, repository.TaskFilter{}, []string{"done"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = repo.BulkDelete(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		remaining, err := repo.List(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		require.Len(t, remaining, 1)
+		assert.Equal(t, locked.ID, remaining[0].ID)
+		assert.Equal(t, domain.StatusPending, remaining[0].Status)
+		assert.Empty(t, remaining[0].Tags)
+
+		count, err = repo.Count(ctx, repository.TaskFilter{LockedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("unlocked tasks can be edited again", func(t *testing.T) {
+		require.NoError(t, repo.SetLocked(ctx, locked.ID, false))
+		task, err := repo.GetByID(ctx, locked.ID)
+		require.NoError(t, err)
+		task.Title = "Renamed"
+		require.NoError(t, repo.Update(ctx, task))
+		require.NoError(t, repo.Delete(ctx, locked.ID))
+	})
+}
+
+func TestTaskRepository_HideArchived(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	open := domain.NewTask("Open")
+	require.NoError(t, repo.Create(ctx, open))
+	archived := domain.NewTask("Archived")
+	archived.Status = domain.StatusCompleted
+	archived.Tags = []string{domain.ArchivedTag, "sprint-1"}
+	require.NoError(t, repo.Create(ctx, archived))
+
+	listed, err := repo.List(ctx, repository.TaskFilter{HideArchived: true})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, open.ID, listed[0].ID)
+
+	count, err := repo.Count(ctx, repository.TaskFilter{HideArchived: true, Tags: []string{domain.ArchivedTag}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count, "asking for the tag lists them again")
+
+	count, err = repo.Count(ctx, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestTaskRepository_Snoozed(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	awake := domain.NewTask("Awake")
+	require.NoError(t, repo.Create(ctx, awake))
+	snoozed := domain.NewTask("Snoozed")
+	require.NoError(t, repo.Create(ctx, snoozed))
+	expired := domain.NewTask("Snooze over")
+	require.NoError(t, repo.Create(ctx, expired))
+
+	// a non-UTC offset checks that the comparison is not done on the raw text
+	zone := time.FixedZone("UTC-10", -10*60*60)
+	later := time.Now().Add(2 * time.Hour).In(zone)
+	earlier := time.Now().Add(-2 * time.Hour).In(zone)
+	require.NoError(t, repo.SetSnoozed(ctx, snoozed.ID, &later))
+	require.NoError(t, repo.SetSnoozed(ctx, expired.ID, &earlier))
+
+	t.Run("hidden until the snooze is over", func(t *testing.T) {
+		listed, err := repo.List(ctx, repository.TaskFilter{HideSnoozed: true, SortBy: "title", SortOrder: "asc"})
+		require.NoError(t, err)
+		require.Len(t, listed, 2)
+		assert.Equal(t, []string{"Awake", "Snooze over"}, []string{listed[0].Title, listed[1].Title})
+
+		count, err := repo.Count(ctx, repository.TaskFilter{HideSnoozed: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("listed when not hidden", func(t *testing.T) {
+		listed, err := repo.List(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Len(t, listed, 3)
+	})
+
+	t.Run("survives updates and can be cleared", func(t *testing.T) {
+		task, err := repo.GetByID(ctx, snoozed.ID)
+		require.NoError(t, err)
+		require.NotNil(t, task.SnoozedUntil)
+		assert.True(t, task.IsSnoozed(time.Now()))
+
+		task.Title = "Snoozed, renamed"
+		require.NoError(t, repo.Update(ctx, task))
+		fetched, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.True(t, fetched.IsSnoozed(time.Now()))
+
+		require.NoError(t, repo.SetSnoozed(ctx, task.ID, nil))
+		fetched, err = repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Nil(t, fetched.SnoozedUntil)
+	})
+
+	t.Run("unknown task", func(t *testing.T) {
+		until := time.Now().Add(time.Hour)
+		assert.Error(t, repo.SetSnoozed(ctx, 9999, &until))
+	})
+}
+
+func TestTaskRepository_WaitingOn(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	waiting := domain.NewTask("Contract review")
+	waiting.WaitingOn = "Legal"
+	require.NoError(t, repo.Create(ctx, waiting))
+	other := domain.NewTask("Write docs")
+	require.NoError(t, repo.Create(ctx, other))
+
+	t.Run("waiting only", func(t *testing.T) {
+		listed, err := repo.List(ctx, repository.TaskFilter{WaitingOnly: true})
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, waiting.ID, listed[0].ID)
+		assert.Equal(t, "Legal", listed[0].WaitingOn)
+
+		count, err := repo.Count(ctx, repository.TaskFilter{WaitingOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("updated, audited and cleared", func(t *testing.T) {
+		task, err := repo.GetByID(ctx, waiting.ID)
+		require.NoError(t, err)
+		task.WaitingOn = "Alice"
+		require.NoError(t, repo.Update(ctx, task))
+
+		fetched, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", fetched.WaitingOn)
+
+		entries, err := repo.ListAudit(ctx, repository.AuditFilter{TaskID: &task.ID})
+		require.NoError(t, err)
+		require.NotEmpty(t, entries)
+		assert.Equal(t, "waiting_on", entries[0].Field)
+		assert.Equal(t, "Legal", entries[0].OldValue)
+		assert.Equal(t, "Alice", entries[0].NewValue)
+
+		fetched.WaitingOn = ""
+		require.NoError(t, repo.Update(ctx, fetched))
+		count, err := repo.Count(ctx, repository.TaskFilter{WaitingOnly: true})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestTaskRepository_Color(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Critical fix")
+	task.Color = "red"
+	require.NoError(t, repo.Create(ctx, task))
+
+	fetched, err := repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "red", fetched.Color)
+
+	fetched.Color = ""
+	require.NoError(t, repo.Update(ctx, fetched))
+	fetched, err = repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, fetched.Color)
+
+	entries, err := repo.ListAudit(ctx, repository.AuditFilter{TaskID: &task.ID})
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, "color", entries[0].Field)
+	assert.Equal(t, "red", entries[0].OldValue)
+}
+
+func TestTaskRepository_ReadyFilter(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	create := func(title string, edit func(*domain.Task)) *domain.Task {
+		task := domain.NewTask(title)
+		if edit != nil {
+			edit(task)
+		}
+		require.NoError(t, repo.Create(ctx, task))
+		return task
+	}
+	yesterday := time.Now().AddDate(0, 0, -1)
+	nextWeek := time.Now().AddDate(0, 0, 7)
+
+	low := create("Low", func(task *domain.Task) { task.Priority = domain.PriorityLow })
+	urgent := create("Urgent", func(task *domain.Task) { task.Priority = domain.PriorityUrgent })
+	// medium, started and overdue outscores urgent
+	overdue := create("Overdue", func(task *domain.Task) {
+		task.Status = domain.StatusInProgress
+		task.DueDate = &yesterday
+	})
+	create("Done", func(task *domain.Task) { task.Status = domain.StatusCompleted })
+	create("Snoozed", func(task *domain.Task) { task.SnoozedUntil = &nextWeek })
+	create("Waiting", func(task *domain.Task) { task.WaitingOn = "Legal" })
+	create("Blocked", func(task *domain.Task) { task.Tags = []string{domain.BlockedTag} })
+
+	tasks, err := repo.List(ctx, repository.ReadyFilter(nil))
+	require.NoError(t, err)
+	var ids []int64
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	assert.Equal(t, []int64{overdue.ID, urgent.ID, low.ID}, ids)
+
+	count, err := repo.Count(ctx, repository.ReadyFilter(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+}
+
+func TestTaskRepository_Audit(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	history := func(t *testing.T, id int64) []*domain.TaskAuditEntry {
+		entries, err := repo.ListAudit(ctx, repository.AuditFilter{TaskID: &id})
+		require.NoError(t, err)
+		return entries
+	}
+
+	task := domain.NewTask("Write docs")
+	task.Tags = []string{"docs"}
+	require.NoError(t, repo.Create(ctx, task))
+	other := domain.NewTask("Other")
+	require.NoError(t, repo.Create(ctx, other))
+
+	t.Run("create is logged", func(t *testing.T) {
+		entries := history(t, task.ID)
+		require.Len(t, entries, 1)
+		assert.Equal(t, domain.AuditActionCreate, entries[0].Action)
+		assert.Equal(t, "Write docs", entries[0].NewValue)
+	})
+
+	t.Run("update logs each changed field", func(t *testing.T) {
+		task.Title = "Write the docs"
+		task.Priority = domain.PriorityHigh
+		require.NoError(t, repo.Update(ctx, task))
+
+		entries := history(t, task.ID)
+		require.Len(t, entries, 3)
+		changes := map[string][2]string{}
+		for _, entry := range entries[:2] {
+			assert.Equal(t, domain.AuditActionUpdate, entry.Action)
+			changes[entry.Field] = [2]string{entry.OldValue, entry.NewValue}
+		}
+		assert.Equal(t, map[string][2]string{
+			"title":    {"Write docs", "Write the docs"},
+			"priority": {"medium", "high"},
+		}, changes)
+	})
+
+	t.Run("no-op changes are not logged", func(t *testing.T) {
+		require.NoError(t, repo.Update(ctx, task))
+		_, err := repo.BulkAddTags(ctx, repository.TaskFilter{Tags: []string{"docs"}}, []string{"docs"})
+		require.NoError(t, err)
+		assert.Len(t, history(t, task.ID), 3)
+	})
+
+	t.Run("setters and bulk operations are logged", func(t *testing.T) {
+		require.NoError(t, repo.SetPinned(ctx, task.ID, true))
+		status := domain.StatusInProgress
+		_, err := repo.BulkUpdate(ctx, repository.TaskFilter{Tags: []string{"docs"}}, repository.TaskUpdate{Status: &status})
+		require.NoError(t, err)
+
+		entries := history(t, task.ID)
+		require.Len(t, entries, 5)
+		assert.Equal(t, "status", entries[0].Field)
+		assert.Equal(t, "in_progress", entries[0].NewValue)
+		assert.Equal(t, "pinned", entries[1].Field)
+		assert.Len(t, history(t, other.ID), 1, "tasks outside the filter are not logged")
+	})
+
+	t.Run("delete keeps the history", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, task.ID))
+
+		entries := history(t, task.ID)
+		require.Len(t, entries, 6)
+		assert.Equal(t, domain.AuditActionDelete, entries[0].Action)
+		assert.Equal(t, "Write the docs", entries[0].TaskTitle)
+	})
+
+	t.Run("since and limit", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		entries, err := repo.ListAudit(ctx, repository.AuditFilter{Since: &future})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		past := time.Now().Add(-time.Hour)
+		entries, err = repo.ListAudit(ctx, repository.AuditFilter{Since: &past, Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("log is append-only", func(t *testing.T) {
+		_, err := db.Exec(`DELETE FROM task_audit`)
+		assert.Error(t, err)
+		_, err = db.Exec(`UPDATE task_audit SET new_value = 'x'`)
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskRepository_CreateBatch(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	t.Run("creates every task in order", func(t *testing.T) {
+		tasks := []*domain.Task{domain.NewTask("One"), domain.NewTask("Two"), domain.NewTask("Three")}
+		require.NoError(t, repo.CreateBatch(ctx, tasks))
+
+		for i, task := range tasks {
+			require.NotZero(t, task.ID)
+			stored, err := repo.GetByID(ctx, task.ID)
+			require.NoError(t, err)
+			assert.Equal(t, task.Title, stored.Title)
+			assert.Equal(t, i+1, stored.Position)
+
+			history, err := repo.ListAudit(ctx, repository.AuditFilter{TaskID: &task.ID})
+			require.NoError(t, err)
+			assert.Len(t, history, 1)
+		}
+	})
+
+	t.Run("one bad task creates none", func(t *testing.T) {
+		before, err := repo.Count(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+
+		tasks := []*domain.Task{domain.NewTask("Fine"), domain.NewTask("")}
+		err = repo.CreateBatch(ctx, tasks)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "task 2")
+
+		after, err := repo.Count(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+		assert.Zero(t, tasks[0].ID)
+	})
+}
+
+func TestTaskRepository_ProjectTaskNumbers(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	projectRepo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	backend := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, backend))
+	web := domain.NewProject("Web App")
+	require.NoError(t, projectRepo.Create(ctx, web))
+
+	newTask := func(title string, projectID *int64) *domain.Task {
+		task := domain.NewTask(title)
+		task.ProjectID = projectID
+		return task
+	}
+
+	first := newTask("First", &backend.ID)
+	second := newTask("Second", &backend.ID)
+	other := newTask("Other", &web.ID)
+	loose := newTask("Loose", nil)
+	for _, task := range []*domain.Task{first, second, other, loose} {
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	assert.Equal(t, 1, first.ProjectTaskNumber)
+	assert.Equal(t, 2, second.ProjectTaskNumber)
+	assert.Equal(t, 1, other.ProjectTaskNumber)
+	assert.Equal(t, 0, loose.ProjectTaskNumber)
+
+	t.Run("keys come from the numbering project", func(t *testing.T) {
+		stored, err := repo.GetByID(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "WEB-APP-1", stored.Key())
+
+		stored, err = repo.GetByID(ctx, loose.ID)
+		require.NoError(t, err)
+		assert.Empty(t, stored.Key())
+	})
+
+	t.Run("moved tasks keep their key", func(t *testing.T) {
+		second.ProjectID = &web.ID
+		require.NoError(t, repo.Update(ctx, second))
+
+		moved, err := repo.GetByNumber(ctx, backend.ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, moved.ID)
+		assert.Equal(t, "BACKEND-2", moved.Key())
+		assert.Equal(t, "Web App", moved.ProjectName)
+
+		next := newTask("Next", &web.ID)
+		require.NoError(t, repo.Create(ctx, next))
+		assert.Equal(t, 2, next.ProjectTaskNumber)
+	})
+
+	t.Run("batches number in order", func(t *testing.T) {
+		tasks := []*domain.Task{newTask("A", &backend.ID), newTask("B", &backend.ID)}
+		require.NoError(t, repo.CreateBatch(ctx, tasks))
+		assert.Equal(t, 3, tasks[0].ProjectTaskNumber)
+		assert.Equal(t, 4, tasks[1].ProjectTaskNumber)
+	})
+
+	t.Run("unknown number", func(t *testing.T) {
+		_, err := repo.GetByNumber(ctx, backend.ID, 99)
+		assert.Error(t, err)
+	})
+}
+
+func TestTaskRepository_OrphanedOnly(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	projectRepo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	project := domain.NewProject("Backend")
+	require.NoError(t, projectRepo.Create(ctx, project))
+
+	assigned := domain.NewTask("Assigned")
+	assigned.ProjectID = &project.ID
+	require.NoError(t, repo.Create(ctx, assigned))
+	require.NoError(t, repo.Create(ctx, domain.NewTask("Unassigned")))
+
+	// what a bad import leaves behind, only possible with foreign keys off
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "INSERT INTO tasks (title, description, priority, status, project_id) VALUES ('Orphan', '', 'medium', 'pending', 999)")
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "PRAGMA foreign_keys = ON")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	filter := repository.TaskFilter{OrphanedOnly: true}
+	orphans, err := repo.List(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	assert.Equal(t, "Orphan", orphans[0].Title)
+	assert.Equal(t, int64(999), *orphans[0].ProjectID)
+
+	moved, err := repo.BulkMove(ctx, filter, &project.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), moved)
+
+	count, err := repo.Count(ctx, filter)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	fixed, err := repo.GetByID(ctx, orphans[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Backend", fixed.ProjectName)
+}
+
+func TestTaskRepository_DueDateNormalization(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+	midnight := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	task := domain.NewTask("Odd time")
+	due := time.Date(2025, 3, 14, 17, 45, 12, 0, time.Local)
+	task.DueDate = &due
+	require.NoError(t, repo.Create(ctx, task))
+
+	retrieved, err := repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, retrieved.DueDate)
+	assert.True(t, retrieved.DueDate.Equal(midnight), "got %v", retrieved.DueDate)
+
+	later := time.Date(2025, 3, 20, 9, 0, 0, 0, time.Local)
+	retrieved.DueDate = &later
+	require.NoError(t, repo.Update(ctx, retrieved))
+	retrieved, err = repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.True(t, retrieved.DueDate.Equal(midnight.AddDate(0, 0, 6)), "got %v", retrieved.DueDate)
+
+	dateStr := "2025-03-14"
+	datePtr := &dateStr
+	_, err = repo.BulkUpdate(ctx, repository.TaskFilter{}, repository.TaskUpdate{DueDate: &datePtr})
+	require.NoError(t, err)
+	retrieved, err = repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.True(t, retrieved.DueDate.Equal(midnight), "got %v", retrieved.DueDate)
+
+	absurd := time.Date(20250, 1, 1, 0, 0, 0, 0, time.UTC)
+	tooFar := domain.NewTask("Typo")
+	tooFar.DueDate = &absurd
+	err = repo.Create(ctx, tooFar)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of range")
+
+	retrieved.DueDate = &absurd
+	assert.Error(t, repo.Update(ctx, retrieved))
+
+	dateStr = "1066-10-14"
+	_, err = repo.BulkUpdate(ctx, repository.TaskFilter{}, repository.TaskUpdate{DueDate: &datePtr})
+	assert.Error(t, err)
+}
+
+func TestTaskRepository_AnyOf(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	create := func(title string, status domain.Status, priority domain.Priority, tags ...string) {
+		task := domain.NewTask(title)
+		task.Status = status
+		task.Priority = priority
+		task.Tags = tags
+		require.NoError(t, repo.Create(ctx, task))
+	}
+	create("pending high", domain.StatusPending, domain.PriorityHigh)
+	create("started high", domain.StatusInProgress, domain.PriorityHigh)
+	create("done high", domain.StatusCompleted, domain.PriorityHigh)
+	create("pending low", domain.StatusPending, domain.PriorityLow)
+	create("done bug", domain.StatusCompleted, domain.PriorityLow, "bug")
+
+	titles := func(filter repository.TaskFilter) []string {
+		tasks, err := repo.List(ctx, filter)
+		require.NoError(t, err)
+		var got []string
+		for _, task := range tasks {
+			got = append(got, task.Title)
+		}
+		count, err := repo.Count(ctx, filter)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(got)), count)
+		return got
+	}
+
+	// (status:pending OR status:in_progress) priority:high
+	open := []repository.TaskFilter{{Status: domain.StatusPending}, {Status: domain.StatusInProgress}}
+	assert.ElementsMatch(t, []string{"pending high", "started high"},
+		titles(repository.TaskFilter{Priority: domain.PriorityHigh, AnyOf: [][]repository.TaskFilter{open}}))
+
+	// priority:high status:pending OR tag:bug, with a nested group
+	nested := []repository.TaskFilter{
+		{Priority: domain.PriorityHigh, AnyOf: [][]repository.TaskFilter{{{Status: domain.StatusPending}}}},
+		{Tags: []string{"bug"}},
+	}
+	assert.ElementsMatch(t, []string{"pending high", "done bug"},
+		titles(repository.TaskFilter{AnyOf: [][]repository.TaskFilter{nested}}))
+
+	// every group has to match
+	lowOrDone := []repository.TaskFilter{{Priority: domain.PriorityLow}, {Status: domain.StatusCompleted}}
+	assert.ElementsMatch(t, []string{"pending low"},
+		titles(repository.TaskFilter{AnyOf: [][]repository.TaskFilter{open, lowOrDone}}))
+}
+
+func TestTaskRepository_Recurring(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	task := domain.NewTask("Standup")
+	task.Tags = []string{"team"}
+	task.Recurrence = "weekly:mon,wed"
+	require.NoError(t, repo.Create(ctx, task))
+
+	fetched, err := repo.GetByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "weekly:mon,wed", fetched.Recurrence)
+
+	t.Run("completing spawns the next instance", func(t *testing.T) {
+		fetched.Status = domain.StatusCompleted
+		spawned, err := repo.UpdateWithNext(ctx, fetched)
+		require.NoError(t, err)
+		assert.Empty(t, fetched.Recurrence, "the rule moves to the next instance")
+
+		done, err := repo.GetByID(ctx, task.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusCompleted, done.Status)
+		assert.Empty(t, done.Recurrence)
+
+		pending, err := repo.List(ctx, repository.TaskFilter{Status: domain.StatusPending})
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		next := pending[0]
+		require.NotNil(t, spawned)
+		assert.Equal(t, next.ID, spawned.ID)
+		assert.Equal(t, next.DueDate, spawned.DueDate)
+		assert.Equal(t, "Standup", next.Title)
+		assert.Equal(t, []string{"team"}, next.Tags)
+		assert.Equal(t, "weekly:mon,wed", next.Recurrence)
+		require.NotNil(t, next.DueDate)
+		assert.Contains(t, []time.Weekday{time.Monday, time.Wednesday}, next.DueDate.Weekday())
+		assert.True(t, next.DueDate.After(time.Now().AddDate(0, 0, -1)))
+
+		// reopening and completing the old one again spawns nothing more
+		done.Status = domain.StatusPending
+		require.NoError(t, repo.Update(ctx, done))
+		done.Status = domain.StatusCompleted
+		spawned, err = repo.UpdateWithNext(ctx, done)
+		require.NoError(t, err)
+		assert.Nil(t, spawned)
+		count, err := repo.Count(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("other updates don't spawn", func(t *testing.T) {
+		chore := domain.NewTask("Water plants")
+		chore.Recurrence = "daily"
+		require.NoError(t, repo.Create(ctx, chore))
+
+		chore.Status = domain.StatusInProgress
+		require.NoError(t, repo.Update(ctx, chore))
+		chore.Status = domain.StatusCancelled
+		require.NoError(t, repo.Update(ctx, chore))
+
+		count, err := repo.Count(ctx, repository.TaskFilter{SearchQuery: "Water plants"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("bulk completion spawns too", func(t *testing.T) {
+		for _, title := range []string{"Inbox zero", "Backups"} {
+			task := domain.NewTask(title)
+			task.Tags = []string{"chore"}
+			task.Recurrence = "monthly:1"
+			require.NoError(t, repo.Create(ctx, task))
+		}
+		oneOff := domain.NewTask("Call plumber")
+		oneOff.Tags = []string{"chore"}
+		require.NoError(t, repo.Create(ctx, oneOff))
+
+		completed := domain.StatusCompleted
+		count, err := repo.BulkUpdate(ctx, repository.TaskFilter{Tags: []string{"chore"}}, repository.TaskUpdate{Status: &completed})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		pending, err := repo.List(ctx, repository.TaskFilter{Tags: []string{"chore"}, Status: domain.StatusPending})
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		for _, next := range pending {
+			assert.Equal(t, "monthly:1", next.Recurrence)
+			require.NotNil(t, next.DueDate)
+			assert.Equal(t, 1, next.DueDate.Day())
+		}
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		bad := domain.NewTask("Broken")
+		bad.Recurrence = "fortnightly"
+		assert.Error(t, repo.Create(ctx, bad))
+
+		fetched.Recurrence = "weekly:someday"
+		assert.Error(t, repo.Update(ctx, fetched))
+	})
+}
+
+func TestTaskRepository_Aggregate(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewTaskRepository(db)
+	ctx := context.Background()
+
+	t.Run("no tasks", func(t *testing.T) {
+		stats, err := repo.Aggregate(ctx, repository.TaskFilter{})
+		require.NoError(t, err)
+		assert.Zero(t, stats.Total)
+		assert.Zero(t, stats.Overdue)
+		assert.Zero(t, stats.AverageOpenAge)
+		assert.Zero(t, stats.CompletionRateFor(domain.CancelledAsOpen))
+		assert.NotNil(t, stats.ByStatus)
+	})
+
+	now := time.Now()
+	yesterday := now.AddDate(0, 0, -1)
+	tasks := []*domain.Task{
+		{Title: "Due yesterday", Status: domain.StatusPending, Priority: domain.PriorityHigh, DueDate: &yesterday, CreatedAt: now.Add(-48 * time.Hour)},
+		{Title: "Due today", Status: domain.StatusInProgress, Priority: domain.PriorityHigh, DueDate: &now, CreatedAt: now.Add(-96 * time.Hour)},
+		{Title: "Done late", Status: domain.StatusCompleted, Priority: domain.PriorityLow, DueDate: &yesterday, CreatedAt: now.AddDate(0, 0, -30)},
+		{Title: "Dropped", Status: domain.StatusCancelled, Priority: domain.PriorityUrgent, CreatedAt: now.AddDate(0, 0, -30)},
+	}
+	for _, task := range tasks {
+		task.Tags = []string{}
+		task.UpdatedAt = task.CreatedAt
+		require.NoError(t, repo.Create(ctx, task))
+	}
+
+	stats, err := repo.Aggregate(ctx, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.Total)
+	assert.Equal(t, map[domain.Status]int{
+		domain.StatusPending: 1, domain.StatusInProgress: 1, domain.StatusCompleted: 1, domain.StatusCancelled: 1,
+	}, stats.ByStatus)
+	assert.Equal(t, 2, stats.ByPriority[domain.PriorityHigh])
+	assert.Equal(t, 1, stats.ByPriority[domain.PriorityUrgent])
+	assert.Zero(t, stats.ByPriority[domain.PriorityMedium])
+	assert.Equal(t, 1, stats.Overdue, "due today isn't overdue yet and closed tasks never are")
+	assert.InDelta(t, 72*time.Hour, stats.AverageOpenAge, float64(time.Minute), "only open tasks count towards the age")
+	assert.InDelta(t, 25.0, stats.CompletionRateFor(domain.CancelledAsOpen), 0.01)
+	assert.Equal(t, 2, stats.OpenTasks())
+
+	db.settings.OverdueGraceDays = 1
+	stats, err = repo.Aggregate(ctx, repository.TaskFilter{})
+	require.NoError(t, err)
+	assert.Zero(t, stats.Overdue, "a day of grace covers yesterday")
+	db.settings.OverdueGraceDays = 0
+
+	stats, err = repo.Aggregate(ctx, repository.TaskFilter{Priority: domain.PriorityHigh})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Total)
+	assert.Equal(t, 1, stats.Overdue)
+
+	since := now.Add(-72 * time.Hour).UTC().Format("2006-01-02 15:04:05")
+	stats, err = repo.Aggregate(ctx, repository.TaskFilter{CreatedFrom: &since})
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Total)
+
+	_, err = repo.Aggregate(ctx, repository.TaskFilter{SearchQuery: "due", SearchMode: "fuzzy"})
+	assert.Error(t, err)
+}
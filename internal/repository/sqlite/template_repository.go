@@ -27,14 +27,16 @@ type dbTemplate struct {
 	Description      sql.NullString `db:"description"`
 	TaskDefinitions  string         `db:"task_definitions"`
 	ProjectDefaults  sql.NullString `db:"project_defaults"`
+	Version          int            `db:"version"`
 	CreatedAt        time.Time      `db:"created_at"`
 	UpdatedAt        time.Time      `db:"updated_at"`
 }
 
 func (dt *dbTemplate) toTemplate() (*domain.ProjectTemplate, error) {
 	template := &domain.ProjectTemplate{
-		ID:   dt.ID,
-		Name: dt.Name,
+		ID:      dt.ID,
+		Name:    dt.Name,
+		Version: dt.Version,
 	}
 
 	if dt.Description.Valid {
@@ -91,7 +93,7 @@ func (r *TemplateRepository) Create(ctx context.Context, template *domain.Projec
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return fmt.Errorf("template with name %q already exists", template.Name)
+			return fmt.Errorf("%w: %q", repository.ErrTemplateExists, template.Name)
 		}
 		return fmt.Errorf("failed to insert template: %w", err)
 	}
@@ -108,6 +110,7 @@ func (r *TemplateRepository) Create(ctx context.Context, template *domain.Projec
 		return fmt.Errorf("failed to fetch created template: %w", err)
 	}
 
+	template.Version = created.Version
 	template.CreatedAt = created.CreatedAt
 	template.UpdatedAt = created.UpdatedAt
 
@@ -116,7 +119,7 @@ func (r *TemplateRepository) Create(ctx context.Context, template *domain.Projec
 
 func (r *TemplateRepository) GetByID(ctx context.Context, id int64) (*domain.ProjectTemplate, error) {
 	query := `
-		SELECT id, name, description, task_definitions, project_defaults, created_at, updated_at
+		SELECT id, name, description, task_definitions, project_defaults, version, created_at, updated_at
 		FROM project_templates
 		WHERE id = ?
 	`
@@ -135,7 +138,7 @@ func (r *TemplateRepository) GetByID(ctx context.Context, id int64) (*domain.Pro
 
 func (r *TemplateRepository) GetByName(ctx context.Context, name string) (*domain.ProjectTemplate, error) {
 	query := `
-		SELECT id, name, description, task_definitions, project_defaults, created_at, updated_at
+		SELECT id, name, description, task_definitions, project_defaults, version, created_at, updated_at
 		FROM project_templates
 		WHERE name = ?
 	`
@@ -171,9 +174,12 @@ func (r *TemplateRepository) Update(ctx context.Context, template *domain.Projec
 		projectDefaultsJSON = sql.NullString{String: string(defaultsBytes), Valid: true}
 	}
 
+	// a new version only when the tasks change, they are what template sync
+	// brings over to projects
 	query := `
 		UPDATE project_templates
-		SET name = ?, description = ?, task_definitions = ?, project_defaults = ?
+		SET name = ?, description = ?, task_definitions = ?, project_defaults = ?,
+			version = CASE WHEN task_definitions = ? THEN version ELSE version + 1 END
 		WHERE id = ?
 	`
 
@@ -182,11 +188,12 @@ func (r *TemplateRepository) Update(ctx context.Context, template *domain.Projec
 		nullString(template.Description),
 		string(taskDefsJSON),
 		projectDefaultsJSON,
+		string(taskDefsJSON),
 		template.ID,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return fmt.Errorf("template with name %q already exists", template.Name)
+			return fmt.Errorf("%w: %q", repository.ErrTemplateExists, template.Name)
 		}
 		return fmt.Errorf("failed to update template: %w", err)
 	}
@@ -205,6 +212,7 @@ func (r *TemplateRepository) Update(ctx context.Context, template *domain.Projec
 		return fmt.Errorf("failed to fetch updated template: %w", err)
 	}
 
+	template.Version = updated.Version
 	template.UpdatedAt = updated.UpdatedAt
 
 	return nil
@@ -232,7 +240,7 @@ func (r *TemplateRepository) Delete(ctx context.Context, id int64) error {
 
 func (r *TemplateRepository) List(ctx context.Context, filter repository.TemplateFilter) ([]*domain.ProjectTemplate, error) {
 	query := `
-		SELECT id, name, description, task_definitions, project_defaults, created_at, updated_at
+		SELECT id, name, description, task_definitions, project_defaults, version, created_at, updated_at
 		FROM project_templates
 	`
 
@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
 	"testing"
 
@@ -75,8 +76,13 @@ func TestTemplateCreateDuplicate(t *testing.T) {
 	template2.AddTaskDefinition(domain.TaskDefinition{Title: "Task 2", Priority: "medium"})
 
 	err = repo.Create(ctx, template2)
-	if err == nil {
-		t.Error("expected error for duplicate template name, got nil")
+	if !errors.Is(err, repository.ErrTemplateExists) {
+		t.Fatalf("expected ErrTemplateExists for duplicate template name, got %v", err)
+	}
+
+	existing := repository.DuplicateTemplate(ctx, repo, "Duplicate", err)
+	if existing == nil || existing.ID != template1.ID {
+		t.Errorf("expected DuplicateTemplate to return template %d, got %v", template1.ID, existing)
 	}
 }
 
@@ -252,6 +258,58 @@ func TestTemplateUpdate(t *testing.T) {
 	_ = originalUpdatedAt
 }
 
+func TestTemplateVersion(t *testing.T) {
+	db := setupTestTemplateDB(t)
+	defer db.Close()
+
+	repo := NewTemplateRepository(db)
+	projectRepo := NewProjectRepository(db)
+	ctx := context.Background()
+
+	template := domain.NewTemplate("Release")
+	template.AddTaskDefinition(domain.TaskDefinition{Title: "Tag release", Priority: "medium"})
+	if err := repo.Create(ctx, template); err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	if template.Version != 1 {
+		t.Errorf("new template version = %d, want 1", template.Version)
+	}
+
+	template.Description = "Only the description changed"
+	if err := repo.Update(ctx, template); err != nil {
+		t.Fatalf("failed to update template: %v", err)
+	}
+	if template.Version != 1 {
+		t.Errorf("version = %d after a description change, want 1", template.Version)
+	}
+
+	template.AddTaskDefinition(domain.TaskDefinition{Title: "Announce", Priority: "low"})
+	if err := repo.Update(ctx, template); err != nil {
+		t.Fatalf("failed to update template: %v", err)
+	}
+	fetched, err := repo.GetByID(ctx, template.ID)
+	if err != nil {
+		t.Fatalf("failed to get template: %v", err)
+	}
+	if template.Version != 2 || fetched.Version != 2 {
+		t.Errorf("version = %d (stored %d) after a task change, want 2", template.Version, fetched.Version)
+	}
+
+	project := domain.NewProject("v1.0")
+	project.SourceTemplateID = &template.ID
+	project.SourceTemplateVersion = 1
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+	stored, err := projectRepo.GetByID(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("failed to get project: %v", err)
+	}
+	if stored.SourceTemplateID == nil || *stored.SourceTemplateID != template.ID || stored.SourceTemplateVersion != 1 {
+		t.Errorf("project source = %v v%d, want template %d v1", stored.SourceTemplateID, stored.SourceTemplateVersion, template.ID)
+	}
+}
+
 func TestTemplateDelete(t *testing.T) {
 	db := setupTestTemplateDB(t)
 	defer db.Close()
@@ -229,7 +229,7 @@ func TestViewRepositoryList_Success(t *testing.T) {
 	ctx := context.Background()
 
 	for i := 0; i < 3; i++ {
-		view := domain.NewSavedView("View " + string(rune(i)))
+		view := domain.NewSavedView("View " + string(rune('A'+i)))
 		err := repo.Create(ctx, view)
 		if err != nil {
 			t.Fatalf("failed to create view %d: %v", i, err)
@@ -313,7 +313,7 @@ func TestViewRepositoryCount_Success(t *testing.T) {
 	ctx := context.Background()
 
 	for i := 0; i < 5; i++ {
-		view := domain.NewSavedView("View " + string(rune(i)))
+		view := domain.NewSavedView("View " + string(rune('A'+i)))
 		err := repo.Create(ctx, view)
 		if err != nil {
 			t.Fatalf("failed to create view: %v", err)
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"time"
+
 	"task-management/internal/domain"
 )
 
@@ -11,4 +13,6 @@ type StatisticsRepository interface {
 	GetGlobalStatistics(ctx context.Context) (*domain.GlobalStats, error)
 
 	GetTopProjectsByTaskCount(ctx context.Context, limit int) ([]domain.ProjectTaskCount, error)
+
+	GetBurndown(ctx context.Context, projectID int64, includeDescendants bool, from, to time.Time) ([]domain.BurndownPoint, error)
 }
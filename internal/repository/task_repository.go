@@ -2,18 +2,47 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"task-management/internal/domain"
 )
 
 type TaskRepository interface {
 	Create(ctx context.Context, task *domain.Task) error
+
+	CreateBatch(ctx context.Context, tasks []*domain.Task) error
 	GetByID(ctx context.Context, id int64) (*domain.Task, error)
+	GetByNumber(ctx context.Context, projectID int64, number int) (*domain.Task, error)
 	List(ctx context.Context, filter TaskFilter) ([]*domain.Task, error)
 	Count(ctx context.Context, filter TaskFilter) (int64, error)
+	// counts by status and priority, overdue tasks and the age of the open
+	// ones, all in one query. fuzzy and regex search aren't supported
+	Aggregate(ctx context.Context, filter TaskFilter) (domain.TaskStats, error)
+	// completing a recurring task also creates its next instance
 	Update(ctx context.Context, task *domain.Task) error
+	// like Update, returning the next instance a completed recurring task
+	// spawned, nil when it spawned none
+	UpdateWithNext(ctx context.Context, task *domain.Task) (*domain.Task, error)
 	Delete(ctx context.Context, id int64) error
+	ListTags(ctx context.Context) ([]string, error)
+	GetTagCounts(ctx context.Context) ([]domain.TagCount, error)
+	SwapPositions(ctx context.Context, taskID, otherID int64) error
+	SetPinned(ctx context.Context, id int64, pinned bool) error
+	SetSnoozed(ctx context.Context, id int64, until *time.Time) error
+	SetLocked(ctx context.Context, id int64, locked bool) error
+	ListAudit(ctx context.Context, filter AuditFilter) ([]*domain.TaskAuditEntry, error)
+	// makes taskID wait for dependsOnID, refusing dependencies that would
+	// form a cycle
+	AddDependency(ctx context.Context, taskID, dependsOnID int64) error
+	ValidateDependency(ctx context.Context, taskID, dependsOnID int64) error
+	RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error
+	// the tasks taskID depends on that are neither completed nor cancelled
+	GetBlockers(ctx context.Context, taskID int64) ([]*domain.Task, error)
 
-	// Bulk operations
+	// Bulk operations, all of them skip locked tasks. like Update, BulkUpdate
+	// creates the next instance of the recurring tasks it completes
 	BulkUpdate(ctx context.Context, filter TaskFilter, updates TaskUpdate) (int64, error)
 	BulkMove(ctx context.Context, filter TaskFilter, projectID *int64) (int64, error)
 	BulkAddTags(ctx context.Context, filter TaskFilter, tags []string) (int64, error)
@@ -26,8 +55,35 @@ type TaskFilter struct {
 	Status    domain.Status
 	Priority  domain.Priority
 	ProjectID *int64
-	Tags      []string
-	ExcludeTags []string
+	// tasks in any of these projects, e.g. a project and its descendants
+	ProjectIDs []int64
+	// only these tasks, e.g. a selection in the TUI
+	IDs             []int64
+	Tags            []string
+	ExcludeTags     []string
+	ExcludeStatuses []domain.Status
+	PinnedOnly      bool
+	// leaves out tasks snoozed until a later time
+	HideSnoozed bool
+	// leaves out tasks tagged archived, unless Tags asks for that tag
+	HideArchived bool
+	// only tasks waiting on someone
+	WaitingOnly bool
+	// leaves out tasks waiting on someone
+	HideWaiting bool
+	// only tasks with a dependency that is neither completed nor cancelled
+	BlockedOnly bool
+	// leaves out those tasks
+	HideBlocked bool
+	// only tasks whose project_id points to a project that doesn't exist
+	OrphanedOnly bool
+	LockedOnly   bool
+	// bulk operations always set it
+	UnlockedOnly bool
+	// OR groups from the query language: a task has to match at least one
+	// filter of every group as well as the fields above. only List and Count
+	// look at them, bulk operations don't
+	AnyOf [][]TaskFilter
 
 	// pagination
 	Limit  int
@@ -37,10 +93,13 @@ type TaskFilter struct {
 	SearchQuery    string
 	SearchMode     string
 	FuzzyThreshold int
+	FuzzyAlgorithm string
 
 	// sorting
 	SortBy    string
 	SortOrder string
+	// pinned tasks first, then the sort above
+	PinnedFirst bool
 
 	// date range
 	DueDateFrom *string
@@ -49,6 +108,61 @@ type TaskFilter struct {
 	CreatedTo   *string
 	UpdatedFrom *string
 	UpdatedTo   *string
+	// completion time, only completed tasks have one
+	CompletedFrom *string
+	CompletedTo   *string
+
+	// bulk operations refuse to run when they would affect more tasks than
+	// this, 0 means no limit
+	MaxAffected int
+}
+
+// ReadyFilter matches the tasks that can be started now: pending or in
+// progress, not snoozed, not waiting on anyone or on an unfinished dependency
+// and not tagged blocked, most urgent first (SortBy "ready"). projectID
+// scopes it to one project
+func ReadyFilter(projectID *int64) TaskFilter {
+	return TaskFilter{
+		ProjectID:       projectID,
+		ExcludeStatuses: []domain.Status{domain.StatusCompleted, domain.StatusCancelled},
+		ExcludeTags:     []string{domain.BlockedTag},
+		HideSnoozed:     true,
+		HideWaiting:     true,
+		HideBlocked:     true,
+		SortBy:          "ready",
+	}
+}
+
+// returned together with the tasks found so far when a regex search hits its
+// time or row limit
+var ErrSearchTruncated = errors.New("search truncated")
+
+// returned by a bulk operation that would affect more than
+// TaskFilter.MaxAffected tasks
+type TooManyAffectedError struct {
+	Count int64
+	Max   int
+}
+
+func (e *TooManyAffectedError) Error() string {
+	return fmt.Sprintf("operation would affect %d tasks, more than the limit of %d", e.Count, e.Max)
+}
+
+// returned when editing or deleting a locked task
+type LockedTaskError struct {
+	ID int64
+}
+
+func (e *LockedTaskError) Error() string {
+	return fmt.Sprintf("task %d is locked, unlock it first", e.ID)
+}
+
+// filters the task audit log, newest entries first
+type AuditFilter struct {
+	TaskID *int64
+	Since  *time.Time
+	// 0 means no limit
+	Limit int
 }
 
 type TaskUpdate struct {
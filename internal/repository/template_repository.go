@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"task-management/internal/domain"
 )
@@ -18,6 +19,24 @@ type TemplateRepository interface {
 	Search(ctx context.Context, query string, limit int) ([]*domain.ProjectTemplate, error)
 }
 
+// returned when creating or renaming a template to a name another template has
+var ErrTemplateExists = errors.New("template already exists")
+
+// DuplicateTemplate returns the template holding name when err is
+// ErrTemplateExists, or nil when err is something else
+func DuplicateTemplate(ctx context.Context, repo TemplateRepository, name string, err error) *domain.ProjectTemplate {
+	if !errors.Is(err, ErrTemplateExists) {
+		return nil
+	}
+
+	existing, lookupErr := repo.GetByName(ctx, name)
+	if lookupErr != nil {
+		return nil
+	}
+
+	return existing
+}
+
 type TemplateFilter struct {
 	SearchQuery string
 	SortBy      string
@@ -0,0 +1,124 @@
+package theme
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"task-management/internal/display"
+	"task-management/internal/domain"
+)
+
+// how statuses and priorities are shown, e.g. todo/doing/done. only the
+// display changes, stored values and the query language keep the internal
+// names. a nil map shows the defaults
+type Labels struct {
+	Status         map[domain.Status]string
+	StatusSymbol   map[domain.Status]string
+	Priority       map[domain.Priority]string
+	PrioritySymbol map[domain.Priority]string
+	ProjectStatus  map[domain.ProjectStatus]string
+}
+
+// a mapping ParseLabels rejected, Key is its config key
+type LabelError struct {
+	Key     string
+	Message string
+}
+
+func (e *LabelError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// the labels NewStyles starts from, set once from the config at startup
+var configuredLabels Labels
+
+func SetLabels(labels Labels) {
+	configuredLabels = labels
+}
+
+// ParseLabels checks the config mappings. each one given has to cover every
+// status or priority, with no unknown keys and no blank values
+func ParseLabels(statusLabels, statusSymbols, priorityLabels, prioritySymbols, projectStatusLabels map[string]string) (Labels, error) {
+	var labels Labels
+	var err error
+
+	if labels.Status, err = parseLabelMapping("status_labels", statusLabels, domain.Statuses); err != nil {
+		return Labels{}, err
+	}
+	if labels.StatusSymbol, err = parseLabelMapping("status_symbols", statusSymbols, domain.Statuses); err != nil {
+		return Labels{}, err
+	}
+	if labels.Priority, err = parseLabelMapping("priority_labels", priorityLabels, domain.Priorities); err != nil {
+		return Labels{}, err
+	}
+	if labels.PrioritySymbol, err = parseLabelMapping("priority_symbols", prioritySymbols, domain.Priorities); err != nil {
+		return Labels{}, err
+	}
+	if labels.ProjectStatus, err = parseLabelMapping("project_status_labels", projectStatusLabels, domain.ProjectStatuses); err != nil {
+		return Labels{}, err
+	}
+
+	return labels, nil
+}
+
+func parseLabelMapping[T ~string](key string, raw map[string]string, valid []T) (map[T]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	mapping := make(map[T]string, len(valid))
+	for name, label := range raw {
+		value := T(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(valid, value) {
+			return nil, &LabelError{Key: key, Message: fmt.Sprintf("unknown value '%s'", name)}
+		}
+		if strings.TrimSpace(label) == "" {
+			return nil, &LabelError{Key: key, Message: fmt.Sprintf("'%s' is blank", name)}
+		}
+		mapping[value] = label
+	}
+
+	for _, value := range valid {
+		if _, ok := mapping[value]; !ok {
+			return nil, &LabelError{Key: key, Message: fmt.Sprintf("missing '%s', the mapping has to cover every value", value)}
+		}
+	}
+
+	return mapping, nil
+}
+
+func (s *Styles) StatusLabel(status domain.Status) string {
+	if label, ok := s.Labels.Status[status]; ok {
+		return label
+	}
+	return string(status)
+}
+
+func (s *Styles) StatusSymbol(status domain.Status) string {
+	if symbol, ok := s.Labels.StatusSymbol[status]; ok {
+		return symbol
+	}
+	return display.GetStatusIcon(status)
+}
+
+func (s *Styles) PriorityLabel(priority domain.Priority) string {
+	if label, ok := s.Labels.Priority[priority]; ok {
+		return label
+	}
+	return string(priority)
+}
+
+func (s *Styles) PrioritySymbol(priority domain.Priority) string {
+	if symbol, ok := s.Labels.PrioritySymbol[priority]; ok {
+		return symbol
+	}
+	return display.GetPriorityIcon(priority)
+}
+
+func (s *Styles) ProjectStatusLabel(status domain.ProjectStatus) string {
+	if label, ok := s.Labels.ProjectStatus[status]; ok {
+		return label
+	}
+	return string(status)
+}
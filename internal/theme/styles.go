@@ -38,6 +38,10 @@ type Styles struct {
 	InProgressText    lipgloss.Style
 	PendingText       lipgloss.Style
 	CancelledText     lipgloss.Style
+	SearchMatch       lipgloss.Style
+
+	// display names and symbols of statuses and priorities
+	Labels Labels
 }
 
 // creates all styles based on the given theme
@@ -143,6 +147,14 @@ func NewStyles(t *Theme) *Styles {
 
 		CancelledText: lipgloss.NewStyle().
 			Foreground(lipgloss.Color(t.StatusCancelled)),
+
+		// search
+		SearchMatch: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(t.Warning)).
+			Bold(true).
+			Underline(true),
+
+		Labels: configuredLabels,
 	}
 }
 
@@ -176,6 +188,20 @@ func (s *Styles) GetPriorityTextStyle(priority domain.Priority) lipgloss.Style {
 	}
 }
 
+// tag cloud weights reuse the priority palette, heavier tags look more urgent
+func (s *Styles) GetTagWeightStyle(weight int) lipgloss.Style {
+	switch {
+	case weight >= 4:
+		return s.UrgentText
+	case weight == 3:
+		return s.HighText
+	case weight == 2:
+		return s.MediumText
+	default:
+		return s.LowText
+	}
+}
+
 func (s *Styles) GetStatusStyle(status domain.Status) lipgloss.Style {
 	switch status {
 	case domain.StatusCompleted:
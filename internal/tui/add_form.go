@@ -73,8 +73,8 @@ func NewAddFormModel(ctx context.Context, projectRepo repository.ProjectReposito
 	tagsInput.Width = 60
 
 	dueDateInput := textinput.New()
-	dueDateInput.Placeholder = "YYYY-MM-DD (optional)"
-	dueDateInput.CharLimit = 10
+	dueDateInput.Placeholder = "YYYY-MM-DD, tomorrow, +3d, friday (optional)"
+	dueDateInput.CharLimit = 20
 	dueDateInput.Width = 20
 
 	return AddFormModel{
@@ -95,6 +95,11 @@ func NewAddFormModel(ctx context.Context, projectRepo repository.ProjectReposito
 	}
 }
 
+// pre-fills the project field, the user can still change or clear it
+func (m *AddFormModel) SetDefaultProject(name string) {
+	m.projectInput.SetValue(name)
+}
+
 func (m AddFormModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -391,7 +396,7 @@ func (m AddFormModel) renderForm() string {
 	b.WriteString("\n\n")
 
 	priorityLabel := m.styles.DetailLabel.Render("Priority:")
-	priorityValue := m.styles.GetPriorityTextStyle(priorities[m.priorityIdx]).Render(string(priorities[m.priorityIdx]))
+	priorityValue := m.styles.GetPriorityTextStyle(priorities[m.priorityIdx]).Render(m.styles.PriorityLabel(priorities[m.priorityIdx]))
 	priorityHint := m.styles.TUISubtitle.Render(" (Ctrl+P to cycle)")
 	b.WriteString(priorityLabel)
 	b.WriteString(" ")
@@ -400,7 +405,7 @@ func (m AddFormModel) renderForm() string {
 	b.WriteString("\n")
 
 	statusLabel := m.styles.DetailLabel.Render("Status:")
-	statusValue := m.styles.GetStatusStyle(statuses[m.statusIdx]).Render(string(statuses[m.statusIdx]))
+	statusValue := m.styles.GetStatusStyle(statuses[m.statusIdx]).Render(m.styles.StatusLabel(statuses[m.statusIdx]))
 	statusHint := m.styles.TUISubtitle.Render(" (Ctrl+T to cycle)")
 	b.WriteString(statusLabel)
 	b.WriteString(" ")
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"task-management/internal/domain"
+)
+
+// what y copies, picked by the key pressed after it
+type yankKind int
+
+const (
+	yankDetails yankKind = iota
+	yankID
+	yankMarkdown
+)
+
+const yankPrompt = "Copy: y details • i ID • m markdown link • esc cancel"
+
+// shells out to pbcopy, xclip, xsel or wl-copy and fails when none of them
+// is installed. replaced in tests
+var writeClipboard = clipboard.WriteAll
+
+type clipboardMsg struct {
+	text string
+	err  error
+}
+
+func copyToClipboardCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		return clipboardMsg{text: text, err: writeClipboard(text)}
+	}
+}
+
+var taskURLPattern = regexp.MustCompile(`https?://[^\s<>()\[\]]+`)
+
+// tasks have no link field, so the first URL in the description stands in
+func taskURL(task *domain.Task) string {
+	return taskURLPattern.FindString(task.Description)
+}
+
+func yankText(task *domain.Task, kind yankKind) string {
+	url := taskURL(task)
+
+	switch kind {
+	case yankID:
+		return strconv.FormatInt(task.ID, 10)
+
+	case yankMarkdown:
+		title := strings.NewReplacer("[", `\[`, "]", `\]`).Replace(task.Title)
+		if url == "" {
+			return fmt.Sprintf("#%d %s", task.ID, title)
+		}
+		return fmt.Sprintf("[#%d %s](%s)", task.ID, title, url)
+	}
+
+	lines := []string{fmt.Sprintf("#%d %s", task.ID, task.Title)}
+	if task.ProjectName != "" {
+		lines = append(lines, "Project: "+task.ProjectName)
+	}
+	if url != "" {
+		lines = append(lines, url)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// the key after y picks what is copied, anything else cancels
+func (m Model) handleYankKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.yankPending = false
+
+	task := m.getSelectedTask()
+	if task == nil {
+		m.message = "No task selected"
+		return m, nil
+	}
+
+	var kind yankKind
+	switch msg.String() {
+	case "y":
+		kind = yankDetails
+	case "i":
+		kind = yankID
+	case "m":
+		kind = yankMarkdown
+	default:
+		m.message = "Copy cancelled"
+		return m, nil
+	}
+
+	return m, copyToClipboardCmd(yankText(task, kind))
+}
+
+// without a clipboard the text goes to the status bar to copy by hand
+func (m *Model) handleClipboard(msg clipboardMsg) {
+	text := strings.ReplaceAll(msg.text, "\n", " · ")
+	if msg.err != nil {
+		m.message = "No clipboard available, copy it from here: " + text
+		return
+	}
+	m.message = "Copied: " + text
+}
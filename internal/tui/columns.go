@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"task-management/internal/config"
+)
+
+// the cell style pads every column by one on each side
+const columnPadding = 2
+
+// the table columns for a window that many cells wide, 0 for no limit.
+// hidden columns get width 0, which the table skips. columns that don't fit
+// are narrowed, the widest first, until they do or none can shrink further
+func fitColumns(columns []config.TableColumn, width int) []table.Column {
+	widths := make([]int, len(columns))
+	total := 0
+	for i, column := range columns {
+		if column.Hidden {
+			continue
+		}
+		widths[i] = column.Width
+		total += column.Width + columnPadding
+	}
+
+	for width > 0 && total > width {
+		widest := -1
+		for i, w := range widths {
+			if w > config.MinColumnWidth && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	fitted := make([]table.Column, len(columns))
+	for i, column := range columns {
+		fitted[i] = table.Column{Title: column.Title, Width: widths[i]}
+	}
+	return fitted
+}
+
+// fits the columns to the window and rebuilds the rows, which truncate to
+// the column widths
+func (m *Model) applyColumns() {
+	m.table.SetColumns(fitColumns(m.columns, m.width))
+	m.updateTableRows()
+}
+
+// the width the named column is shown at, 0 while it is hidden
+func (m *Model) columnWidth(name string) int {
+	i := slices.IndexFunc(m.columns, func(column config.TableColumn) bool { return column.Name == name })
+	columns := m.table.Columns()
+	if i < 0 || i >= len(columns) {
+		return 0
+	}
+	return columns[i].Width
+}
+
+// the column settings as they are saved. default widths are left at 0 so
+// they keep following the defaults
+func (m Model) columnConfig() map[string]config.ColumnConfig {
+	defaults := config.TableColumns(nil)
+	columns := make(map[string]config.ColumnConfig, len(m.columns))
+	for i, column := range m.columns {
+		configured := config.ColumnConfig{Hidden: column.Hidden}
+		if column.Width != defaults[i].Width {
+			configured.Width = column.Width
+		}
+		columns[column.Name] = configured
+	}
+	return columns
+}
+
+// the columns | can toggle with their number keys, title can't be hidden
+func (m Model) columnPrompt() string {
+	var choices []string
+	for i, column := range m.columns {
+		if column.Name == "title" {
+			continue
+		}
+		mark := "✓"
+		if column.Hidden {
+			mark = "✗"
+		}
+		choices = append(choices, fmt.Sprintf("%d %s %s", i+1, mark, column.Title))
+	}
+	return "Show/hide column: " + strings.Join(choices, " • ") + " • esc cancel"
+}
+
+// the number key after | shows or hides that column, anything else cancels
+func (m Model) handleColumnKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.columnPending = false
+
+	n, err := strconv.Atoi(msg.String())
+	if err != nil || n < 1 || n > len(m.columns) {
+		m.message = ""
+		return m, nil
+	}
+	// the slice is shared with earlier copies of the model
+	m.columns = slices.Clone(m.columns)
+	column := &m.columns[n-1]
+	if column.Name == "title" {
+		m.message = "The Title column is always shown"
+		return m, nil
+	}
+
+	column.Hidden = !column.Hidden
+	if column.Hidden {
+		m.message = fmt.Sprintf("Column %s hidden", column.Title)
+	} else {
+		m.message = fmt.Sprintf("Column %s shown", column.Title)
+	}
+	m.applyColumns()
+
+	if m.saveColumns == nil {
+		return m, nil
+	}
+	return m, saveColumnsCmd(m.saveColumns, m.columnConfig())
+}
+
+func saveColumnsCmd(saveColumns func(map[string]config.ColumnConfig) error, columns map[string]config.ColumnConfig) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveColumns(columns); err != nil {
+			return errMsg{fmt.Errorf("failed to save columns: %w", err)}
+		}
+		return nil
+	}
+}
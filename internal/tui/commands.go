@@ -2,7 +2,10 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -11,14 +14,32 @@ import (
 	"task-management/internal/repository"
 )
 
-
 type tasksLoadedMsg struct {
 	tasks      []*domain.Task
 	totalCount int64
+	truncated  bool
+}
+
+type kanbanTasksLoadedMsg struct {
+	tasks []*domain.Task
 }
 
 type taskUpdatedMsg struct {
 	task *domain.Task
+	// the instance that replaced a completed recurring task, nil otherwise
+	next *domain.Task
+}
+
+type taskPinnedMsg struct {
+	task *domain.Task
+}
+
+type taskSnoozedMsg struct {
+	task *domain.Task
+}
+
+type taskLockedMsg struct {
+	task *domain.Task
 }
 
 type taskCreatedMsg struct {
@@ -40,32 +61,52 @@ func (e errMsg) Error() string {
 type queryParsedMsg struct {
 	filter   repository.TaskFilter
 	queryStr string
+	projects []query.ProjectResolution
 	err      error
 }
 
-
 func fetchTasksCmd(ctx context.Context, repo repository.TaskRepository, filter repository.TaskFilter, page int, pageSize int) tea.Cmd {
 	return func() tea.Msg {
 		filter.Limit = pageSize
 		filter.Offset = (page - 1) * pageSize
 
+		// a truncated regex search still has results worth showing
 		tasks, err := repo.List(ctx, filter)
-		if err != nil {
+		truncated := errors.Is(err, repository.ErrSearchTruncated)
+		if err != nil && !truncated {
 			return errMsg{err}
 		}
 
 		totalCount, err := repo.Count(ctx, filter)
-		if err != nil {
+		if errors.Is(err, repository.ErrSearchTruncated) {
+			truncated = true
+		} else if err != nil {
 			return errMsg{err}
 		}
 
 		return tasksLoadedMsg{
 			tasks:      tasks,
 			totalCount: totalCount,
+			truncated:  truncated,
 		}
 	}
 }
 
+// the board shows every task the filter matches, so it loads them without
+// the page's limit
+func fetchKanbanTasksCmd(ctx context.Context, repo repository.TaskRepository, filter repository.TaskFilter) tea.Cmd {
+	return func() tea.Msg {
+		filter.Limit = 0
+		filter.Offset = 0
+
+		tasks, err := repo.List(ctx, filter)
+		if err != nil && !errors.Is(err, repository.ErrSearchTruncated) {
+			return errMsg{err}
+		}
+		return kanbanTasksLoadedMsg{tasks: tasks}
+	}
+}
+
 func createTaskCmd(ctx context.Context, repo repository.TaskRepository, task *domain.Task) tea.Cmd {
 	return func() tea.Msg {
 		if err := repo.Create(ctx, task); err != nil {
@@ -75,12 +116,89 @@ func createTaskCmd(ctx context.Context, repo repository.TaskRepository, task *do
 	}
 }
 
+type inboxCapturedMsg struct {
+	task  *domain.Task
+	inbox *domain.Project
+}
+
+type inboxLoadedMsg struct {
+	inbox *domain.Project
+	err   error
+}
+
+// creates the task in the inbox, which is created first if it's missing
+func captureToInboxCmd(ctx context.Context, repo repository.TaskRepository, projectRepo repository.ProjectRepository, title string) tea.Cmd {
+	return func() tea.Msg {
+		inbox, err := projectRepo.EnsureInbox(ctx)
+		if err != nil {
+			return errMsg{err}
+		}
+		task := domain.NewTask(title)
+		task.ProjectID = &inbox.ID
+		if err := repo.Create(ctx, task); err != nil {
+			return errMsg{err}
+		}
+		return inboxCapturedMsg{task: task, inbox: inbox}
+	}
+}
+
+func fetchInboxCmd(ctx context.Context, projectRepo repository.ProjectRepository) tea.Cmd {
+	return func() tea.Msg {
+		inbox, err := projectRepo.EnsureInbox(ctx)
+		return inboxLoadedMsg{inbox: inbox, err: err}
+	}
+}
+
+// next is the instance the repository creates when this completes a
+// recurring task
 func updateTaskCmd(ctx context.Context, repo repository.TaskRepository, task *domain.Task) tea.Cmd {
 	return func() tea.Msg {
-		if err := repo.Update(ctx, task); err != nil {
+		next, err := repo.UpdateWithNext(ctx, task)
+		if err != nil {
 			return errMsg{err}
 		}
-		return taskUpdatedMsg{task: task}
+		return taskUpdatedMsg{task: task, next: next}
+	}
+}
+
+func fetchBlockersCmd(ctx context.Context, repo repository.TaskRepository, taskID int64) tea.Cmd {
+	return func() tea.Msg {
+		blockers, err := repo.GetBlockers(ctx, taskID)
+		return blockersLoadedMsg{taskID: taskID, blockers: blockers, err: err}
+	}
+}
+
+func setTaskPinnedCmd(ctx context.Context, repo repository.TaskRepository, task *domain.Task, pinned bool) tea.Cmd {
+	// a copy, the model's task is shared with the table until the refresh
+	updated := *task
+	return func() tea.Msg {
+		if err := repo.SetPinned(ctx, updated.ID, pinned); err != nil {
+			return errMsg{err}
+		}
+		updated.IsPinned = pinned
+		return taskPinnedMsg{task: &updated}
+	}
+}
+
+func setTaskLockedCmd(ctx context.Context, repo repository.TaskRepository, task *domain.Task, locked bool) tea.Cmd {
+	updated := *task
+	return func() tea.Msg {
+		if err := repo.SetLocked(ctx, updated.ID, locked); err != nil {
+			return errMsg{err}
+		}
+		updated.IsLocked = locked
+		return taskLockedMsg{task: &updated}
+	}
+}
+
+func setTaskSnoozedCmd(ctx context.Context, repo repository.TaskRepository, task *domain.Task, until *time.Time) tea.Cmd {
+	updated := *task
+	return func() tea.Msg {
+		if err := repo.SetSnoozed(ctx, updated.ID, until); err != nil {
+			return errMsg{err}
+		}
+		updated.SnoozedUntil = until
+		return taskSnoozedMsg{task: &updated}
 	}
 }
 
@@ -93,7 +211,198 @@ func deleteTaskCmd(ctx context.Context, repo repository.TaskRepository, taskID i
 	}
 }
 
+type blockersLoadedMsg struct {
+	taskID   int64
+	blockers []*domain.Task
+	err      error
+}
+
+type tagCountsLoadedMsg struct {
+	tags []domain.TagCount
+	err  error
+}
+
+type positionsSwappedMsg struct {
+	err error
+}
+
+func swapPositionsCmd(ctx context.Context, repo repository.TaskRepository, taskID, otherID int64) tea.Cmd {
+	return func() tea.Msg {
+		return positionsSwappedMsg{err: repo.SwapPositions(ctx, taskID, otherID)}
+	}
+}
+
+func saveThemeCmd(saveTheme func(name string) error, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveTheme(name); err != nil {
+			return errMsg{fmt.Errorf("failed to save theme: %w", err)}
+		}
+		return nil
+	}
+}
+
+func fetchTagCountsCmd(ctx context.Context, repo repository.TaskRepository) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := repo.GetTagCounts(ctx)
+		return tagCountsLoadedMsg{tags: tags, err: err}
+	}
+}
+
+type bulkFailure struct {
+	taskID int64
+	err    error
+}
+
+// result of a bulk action, failed tasks stay selected so they can be retried.
+// locked tasks are skipped without being tried
+type bulkResultMsg struct {
+	action    string
+	succeeded int
+	skipped   int
+	failures  []bulkFailure
+}
+
+func (msg bulkResultMsg) summary() string {
+	summary := fmt.Sprintf("%s %d", msg.action, msg.succeeded)
+	if msg.skipped > 0 {
+		summary += fmt.Sprintf(", skipped %d locked", msg.skipped)
+	}
+	if len(msg.failures) == 0 {
+		return summary
+	}
+
+	first := msg.failures[0]
+	summary += fmt.Sprintf(", failed %d (task #%d: %v", len(msg.failures), first.taskID, first.err)
+	if len(msg.failures) > 1 {
+		summary += fmt.Sprintf("; +%d more", len(msg.failures)-1)
+	}
+	return summary + ")"
+}
+
+// updates run one at a time so every failure is reported, tasks already
+// rejected before sending are passed in as failures
+func bulkUpdateTasksCmd(ctx context.Context, repo repository.TaskRepository, tasks []*domain.Task, skipped int, failures []bulkFailure) tea.Cmd {
+	return func() tea.Msg {
+		result := bulkResultMsg{action: "Updated", skipped: skipped, failures: failures}
+		for _, task := range tasks {
+			if err := repo.Update(ctx, task); err != nil {
+				result.failures = append(result.failures, bulkFailure{taskID: task.ID, err: err})
+				continue
+			}
+			result.succeeded++
+		}
+		return result
+	}
+}
+
+func bulkDeleteTasksCmd(ctx context.Context, repo repository.TaskRepository, taskIDs []int64, skipped int) tea.Cmd {
+	return func() tea.Msg {
+		result := bulkResultMsg{action: "Deleted", skipped: skipped}
+		for _, taskID := range taskIDs {
+			if err := repo.Delete(ctx, taskID); err != nil {
+				result.failures = append(result.failures, bulkFailure{taskID: taskID, err: err})
+				continue
+			}
+			result.succeeded++
+		}
+		return result
+	}
+}
+
+// tags go on or come off in one repository call, so an error fails every task
+func bulkTagTasksCmd(ctx context.Context, repo repository.TaskRepository, taskIDs []int64, tags []string, remove bool, skipped int) tea.Cmd {
+	return func() tea.Msg {
+		label := strings.Join(tags, "', '")
+		result := bulkResultMsg{action: fmt.Sprintf("Added '%s' to", label), skipped: skipped}
+		if remove {
+			result.action = fmt.Sprintf("Removed '%s' from", label)
+		}
+		if len(taskIDs) == 0 {
+			return result
+		}
+
+		filter := repository.TaskFilter{IDs: taskIDs}
+		var count int64
+		var err error
+		if remove {
+			count, err = repo.BulkRemoveTags(ctx, filter, tags)
+		} else {
+			count, err = repo.BulkAddTags(ctx, filter, tags)
+		}
+		if err != nil {
+			for _, taskID := range taskIDs {
+				result.failures = append(result.failures, bulkFailure{taskID: taskID, err: err})
+			}
+			return result
+		}
+
+		result.succeeded = int(count)
+		return result
+	}
+}
+
+type confirmTimeoutMsg struct {
+	seq int
+}
+
+// searchDebounceDelay is how long typing has to pause before a live search runs
+const searchDebounceDelay = 300 * time.Millisecond
+
+type searchDebounceMsg struct {
+	seq int
+}
+
+type incrementalSearchMsg struct {
+	seq        int
+	tasks      []*domain.Task
+	totalCount int64
+	err        error
+}
+
+func debounceSearchCmd(seq int) tea.Cmd {
+	return tea.Tick(searchDebounceDelay, func(time.Time) tea.Msg {
+		return searchDebounceMsg{seq: seq}
+	})
+}
+
+// relativeTimeRefresh is how often the table re-renders so relative times like
+// "5m ago" don't go stale
+const relativeTimeRefresh = time.Minute
+
+type relativeTimeTickMsg struct{}
+
+func relativeTimeTickCmd() tea.Cmd {
+	return tea.Tick(relativeTimeRefresh, func(time.Time) tea.Msg {
+		return relativeTimeTickMsg{}
+	})
+}
+
+func incrementalSearchCmd(ctx context.Context, repo repository.TaskRepository, filter repository.TaskFilter, page int, pageSize int, seq int) tea.Cmd {
+	return func() tea.Msg {
+		filter.Limit = pageSize
+		filter.Offset = (page - 1) * pageSize
+
+		tasks, err := repo.List(ctx, filter)
+		if err != nil {
+			return incrementalSearchMsg{seq: seq, err: err}
+		}
+
+		totalCount, err := repo.Count(ctx, filter)
+		if err != nil {
+			return incrementalSearchMsg{seq: seq, err: err}
+		}
+
+		return incrementalSearchMsg{seq: seq, tasks: tasks, totalCount: totalCount}
+	}
+}
+
 func (m *Model) refreshCmd() tea.Cmd {
+	if m.viewMode == kanbanView {
+		return tea.Batch(
+			fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize),
+			fetchKanbanTasksCmd(m.ctx, m.repo, m.filter),
+		)
+	}
 	return fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize)
 }
 
@@ -109,6 +418,6 @@ func parseQueryLanguageCmd(ctx context.Context, queryStr string, converterCtx *q
 			return queryParsedMsg{err: fmt.Errorf("query conversion error: %w", err)}
 		}
 
-		return queryParsedMsg{filter: filter, queryStr: queryStr}
+		return queryParsedMsg{filter: filter, queryStr: queryStr, projects: converterCtx.ResolvedProjects}
 	}
 }
@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"task-management/internal/display"
+	"task-management/internal/domain"
+)
+
+// the board's columns, left to right. cards move through them in this order
+var kanbanColumns = []domain.Status{
+	domain.StatusPending,
+	domain.StatusInProgress,
+	domain.StatusCompleted,
+	domain.StatusCancelled,
+}
+
+// the selected card on the board, by column and its place in that column
+type kanbanCursor struct {
+	column int
+	row    int
+}
+
+// the tasks grouped by status into the board's columns, each in the order of
+// the task list
+func kanbanBuckets(tasks []*domain.Task) [][]*domain.Task {
+	buckets := make([][]*domain.Task, len(kanbanColumns))
+	for _, task := range tasks {
+		if column := slices.Index(kanbanColumns, task.Status); column >= 0 {
+			buckets[column] = append(buckets[column], task)
+		}
+	}
+	return buckets
+}
+
+// the card under the cursor, nil in an empty column. the row is clamped since
+// a refresh can shrink the column under it
+func (m *Model) selectedCard() *domain.Task {
+	cards := kanbanBuckets(m.kanbanTasks)[m.kanban.column]
+	if len(cards) == 0 {
+		return nil
+	}
+	return cards[min(m.kanban.row, len(cards)-1)]
+}
+
+// points the cursor at task, or at the top of the first column when it
+// isn't on the board
+func (m *Model) selectCard(task *domain.Task) {
+	m.kanban = kanbanCursor{}
+	if task == nil {
+		return
+	}
+	if cursor, ok := m.kanbanPosition(task); ok {
+		m.kanban = cursor
+	}
+}
+
+// where task's card is on the board, found by ID since a reload brings new
+// copies of the tasks
+func (m *Model) kanbanPosition(task *domain.Task) (kanbanCursor, bool) {
+	for column, cards := range kanbanBuckets(m.kanbanTasks) {
+		if row := slices.IndexFunc(cards, func(card *domain.Task) bool { return card.ID == task.ID }); row >= 0 {
+			return kanbanCursor{column: column, row: row}, true
+		}
+	}
+	return kanbanCursor{}, false
+}
+
+func (m Model) toggleKanban() (tea.Model, tea.Cmd) {
+	switch m.viewMode {
+	case tableView:
+		// the page shows until the rest of the filtered tasks are loaded
+		m.kanbanTasks = m.tasks
+		m.selectCard(m.getSelectedTask())
+		m.viewMode = kanbanView
+		m.message = "Kanban board (b or Esc to go back)"
+		return m, fetchKanbanTasksCmd(m.ctx, m.repo, m.filter)
+	case kanbanView:
+		// a card from another page leaves the table cursor where it was
+		if card := m.selectedCard(); card != nil {
+			if row := slices.IndexFunc(m.tasks, func(task *domain.Task) bool { return task.ID == card.ID }); row >= 0 {
+				m.table.SetCursor(row)
+			}
+		}
+		m.viewMode = tableView
+		m.kanbanTasks = nil
+		m.message = ""
+	}
+	return m, nil
+}
+
+// steps to the column left or right, wrapping around at either end
+func (m Model) moveKanbanColumn(direction int) (tea.Model, tea.Cmd) {
+	m.kanban.column = (m.kanban.column + direction + len(kanbanColumns)) % len(kanbanColumns)
+	// an empty column keeps the row for the next one
+	if cards := kanbanBuckets(m.kanbanTasks)[m.kanban.column]; len(cards) > 0 {
+		m.kanban.row = min(m.kanban.row, len(cards)-1)
+	}
+	return m, nil
+}
+
+// steps up or down within the column, stopping at its ends
+func (m Model) moveKanbanRow(direction int) (tea.Model, tea.Cmd) {
+	cards := kanbanBuckets(m.kanbanTasks)[m.kanban.column]
+	m.kanban.row = max(0, min(m.kanban.row+direction, len(cards)-1))
+	return m, nil
+}
+
+// moves the selected card to the status of the column left or right of it.
+// completing goes through the same path as c, so blocked tasks still ask
+// first and recurring ones report their next instance
+func (m Model) moveCard(direction int) (tea.Model, tea.Cmd) {
+	task := m.selectedCard()
+	if task == nil {
+		return m, nil
+	}
+
+	column := slices.Index(kanbanColumns, task.Status) + direction
+	if column < 0 || column >= len(kanbanColumns) {
+		m.message = fmt.Sprintf("'%s' is already in the %s column", task.Title, m.styles.StatusLabel(task.Status))
+		return m, nil
+	}
+	status := kanbanColumns[column]
+
+	var model tea.Model = m
+	var cmd tea.Cmd
+	if status == domain.StatusCompleted {
+		model, cmd = m.handleMarkComplete()
+	} else {
+		if m.refuseLocked(task) {
+			return m, nil
+		}
+		if err := m.statusTransitions.Validate(task.Status, status); err != nil {
+			m.err = err
+			return m, nil
+		}
+		task.Status = status
+		m.loading = true
+		model, cmd = m, updateTaskCmd(m.ctx, m.repo, task)
+	}
+
+	// the cursor follows the card once its status has changed
+	next := model.(Model)
+	if task.Status == status {
+		next.selectCard(task)
+	}
+	return next, cmd
+}
+
+func (m Model) renderKanbanView() string {
+	var b strings.Builder
+
+	if header := m.renderContextHeader(); header != "" {
+		b.WriteString(header)
+		b.WriteString("\n")
+	}
+
+	// four columns with a border each, never narrower than a short title
+	columnWidth := max((m.width-len(kanbanColumns)*2)/len(kanbanColumns), 16)
+
+	// the cards that fit between the header and the more markers, 0 shows
+	// every card
+	visible := 0
+	if m.height > 0 {
+		chrome := m.renderTitle() + b.String() + "\n" + m.renderFooter()
+		visible = max(m.height-strings.Count(chrome, "\n")-6, minTableHeight)
+	}
+
+	buckets := kanbanBuckets(m.kanbanTasks)
+	columns := make([]string, len(kanbanColumns))
+	for i, status := range kanbanColumns {
+		columns[i] = m.renderKanbanColumn(status, buckets[i], i == m.kanban.column, columnWidth, visible)
+	}
+
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	return b.String()
+}
+
+func (m Model) renderKanbanColumn(status domain.Status, cards []*domain.Task, active bool, width, visible int) string {
+	lines := []string{
+		m.styles.GetStatusStyle(status).Bold(true).Render(fmt.Sprintf("%s %s (%d)", m.styles.StatusSymbol(status), m.styles.StatusLabel(status), len(cards))),
+		"",
+	}
+
+	selected := -1
+	if active && len(cards) > 0 {
+		selected = min(m.kanban.row, len(cards)-1)
+	}
+
+	// scrolls just far enough to keep the selected card in view
+	start, end := 0, len(cards)
+	if visible > 0 && len(cards) > visible {
+		start = max(0, selected-visible+1)
+		end = start + visible
+	}
+	if start > 0 {
+		lines = append(lines, m.styles.Info.Render(fmt.Sprintf("↑ %d more", start)))
+	}
+
+	cardStyle := lipgloss.NewStyle().Width(width - 2)
+	selectedStyle := cardStyle.
+		Foreground(lipgloss.Color(m.theme.SelectedFg)).
+		Background(lipgloss.Color(m.theme.SelectedBg)).
+		Bold(true)
+	for i, task := range cards[start:end] {
+		card := m.styles.PrioritySymbol(task.Priority) + " " + display.FormatTaskTitle(task, width-5)
+		if start+i == selected {
+			lines = append(lines, selectedStyle.Render(card))
+		} else {
+			lines = append(lines, cardStyle.Render(card))
+		}
+	}
+
+	if end < len(cards) {
+		lines = append(lines, m.styles.Info.Render(fmt.Sprintf("↓ %d more", len(cards)-end)))
+	}
+	if len(cards) == 0 {
+		lines = append(lines, m.styles.Info.Render("No tasks"))
+	}
+
+	border := lipgloss.Color(m.theme.BorderColor)
+	if active {
+		border = lipgloss.Color(m.theme.Primary)
+	}
+	return lipgloss.NewStyle().
+		Width(width).
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
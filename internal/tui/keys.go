@@ -1,6 +1,15 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"task-management/internal/domain"
+)
 
 type keyMap struct {
 	Up     key.Binding
@@ -14,22 +23,45 @@ type keyMap struct {
 
 	Sort      key.Binding
 	SortOrder key.Binding
+	Reorder   key.Binding
 
 	NextPage key.Binding
 	PrevPage key.Binding
 
 	New           key.Binding
 	Edit          key.Binding
+	EditTitle     key.Binding
 	MarkComplete  key.Binding
 	CyclePriority key.Binding
 	ToggleStatus  key.Binding
 	Delete        key.Binding
+	TogglePin     key.Binding
+	ToggleLock    key.Binding
+	CycleColor    key.Binding
+	Yank          key.Binding
+	CaptureInbox  key.Binding
+	GoToInbox     key.Binding
+	ReadyQueue    key.Binding
+	Snooze        key.Binding
+	SnoozeWeek    key.Binding
 	Refresh       key.Binding
 
+	// set a status or priority directly instead of cycling to it
+	SetPending    key.Binding
+	SetInProgress key.Binding
+	SetCompleted  key.Binding
+	SetCancelled  key.Binding
+	SetLow        key.Binding
+	SetMedium     key.Binding
+	SetHigh       key.Binding
+	SetUrgent     key.Binding
+
 	ToggleMultiSelect key.Binding
 	ToggleSelection   key.Binding
 	SelectAll         key.Binding
 	DeselectAll       key.Binding
+	BulkAddTag        key.Binding
+	BulkRemoveTag     key.Binding
 
 	ToggleProjects   key.Binding
 	ExpandProject    key.Binding
@@ -40,11 +72,20 @@ type keyMap struct {
 	DeleteProject    key.Binding
 	ArchiveProject   key.Binding
 	ProjectPicker    key.Binding
+	NextProject      key.Binding
+	PrevProject      key.Binding
 	FilterByProject  key.Binding
 	ViewNotes        key.Binding
 
+	ToggleKanban  key.Binding
+	ColumnLeft    key.Binding
+	ColumnRight   key.Binding
+	MoveCardLeft  key.Binding
+	MoveCardRight key.Binding
+
 	ViewPicker      key.Binding
 	FavoriteViews   key.Binding
+	ReapplyView     key.Binding
 	QuickAccess1    key.Binding
 	QuickAccess2    key.Binding
 	QuickAccess3    key.Binding
@@ -55,6 +96,12 @@ type keyMap struct {
 	QuickAccess8    key.Binding
 	QuickAccess9    key.Binding
 
+	ToggleCompact   key.Binding
+	ToggleColumn    key.Binding
+	ToggleHighlight key.Binding
+	TagCloud        key.Binding
+	CycleTheme      key.Binding
+
 	Quit key.Binding
 	Help key.Binding
 }
@@ -99,6 +146,10 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("S"),
 			key.WithHelp("S", "toggle sort order"),
 		),
+		Reorder: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "reorder tasks (↑/↓ to move, esc to finish)"),
+		),
 
 		NextPage: key.NewBinding(
 			key.WithKeys("]", "pgdown"),
@@ -117,6 +168,10 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit task"),
 		),
+		EditTitle: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "edit the title in place"),
+		),
 		MarkComplete: key.NewBinding(
 			key.WithKeys("c"),
 			key.WithHelp("c", "mark complete"),
@@ -133,11 +188,80 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "delete task"),
 		),
+		TogglePin: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "pin/unpin task"),
+		),
+		ToggleLock: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "lock/unlock task (locked tasks can't be edited)"),
+		),
+		CycleColor: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "cycle task color (overrides the project color)"),
+		),
+		Yank: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy task (then y details, i ID, m markdown link)"),
+		),
+		CaptureInbox: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "capture to inbox"),
+		),
+		GoToInbox: key.NewBinding(
+			key.WithKeys("I"),
+			key.WithHelp("I", "go to inbox"),
+		),
+		ReadyQueue: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "ready queue, tasks to start now (again to leave)"),
+		),
+		Snooze: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "snooze until tomorrow (again to wake)"),
+		),
+		SnoozeWeek: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "snooze for a week"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
 
+		SetPending: key.NewBinding(
+			key.WithKeys("alt+p"),
+			key.WithHelp("alt+p", "set pending"),
+		),
+		SetInProgress: key.NewBinding(
+			key.WithKeys("alt+i"),
+			key.WithHelp("alt+i", "set in progress"),
+		),
+		SetCompleted: key.NewBinding(
+			key.WithKeys("alt+c"),
+			key.WithHelp("alt+c", "set completed"),
+		),
+		SetCancelled: key.NewBinding(
+			key.WithKeys("alt+x"),
+			key.WithHelp("alt+x", "set cancelled"),
+		),
+		SetLow: key.NewBinding(
+			key.WithKeys("alt+1"),
+			key.WithHelp("alt+1", "set low priority"),
+		),
+		SetMedium: key.NewBinding(
+			key.WithKeys("alt+2"),
+			key.WithHelp("alt+2", "set medium priority"),
+		),
+		SetHigh: key.NewBinding(
+			key.WithKeys("alt+3"),
+			key.WithHelp("alt+3", "set high priority"),
+		),
+		SetUrgent: key.NewBinding(
+			key.WithKeys("alt+4"),
+			key.WithHelp("alt+4", "set urgent priority"),
+		),
+
 		ToggleMultiSelect: key.NewBinding(
 			key.WithKeys("v"),
 			key.WithHelp("v", "multi-select mode"),
@@ -154,6 +278,14 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("ctrl+d"),
 			key.WithHelp("ctrl+d", "deselect all"),
 		),
+		BulkAddTag: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "add a tag to the selection"),
+		),
+		BulkRemoveTag: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "remove a tag from the selection"),
+		),
 
 		ToggleProjects: key.NewBinding(
 			key.WithKeys("P"),
@@ -191,6 +323,14 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("ctrl+p"),
 			key.WithHelp("ctrl+p", "project picker"),
 		),
+		NextProject: key.NewBinding(
+			key.WithKeys("}"),
+			key.WithHelp("}", "next project"),
+		),
+		PrevProject: key.NewBinding(
+			key.WithKeys("{"),
+			key.WithHelp("{", "previous project"),
+		),
 		FilterByProject: key.NewBinding(
 			key.WithKeys("ctrl+f"),
 			key.WithHelp("ctrl+f", "filter by project"),
@@ -200,6 +340,27 @@ func defaultKeyMap() keyMap {
 			key.WithHelp("M", "view/edit notes"),
 		),
 
+		ToggleKanban: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "toggle kanban board"),
+		),
+		ColumnLeft: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "previous column"),
+		),
+		ColumnRight: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "next column"),
+		),
+		MoveCardLeft: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "move card to the previous status"),
+		),
+		MoveCardRight: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "move card to the next status"),
+		),
+
 		ViewPicker: key.NewBinding(
 			key.WithKeys("V"),
 			key.WithHelp("V", "view picker"),
@@ -208,6 +369,10 @@ func defaultKeyMap() keyMap {
 			key.WithKeys("L"),
 			key.WithHelp("L", "favorite views"),
 		),
+		ReapplyView: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "re-apply current view"),
+		),
 		QuickAccess1: key.NewBinding(
 			key.WithKeys("1"),
 			key.WithHelp("1", "quick access view 1"),
@@ -245,9 +410,30 @@ func defaultKeyMap() keyMap {
 			key.WithHelp("9", "quick access view 9"),
 		),
 
+		ToggleCompact: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "toggle compact mode"),
+		),
+		ToggleColumn: key.NewBinding(
+			key.WithKeys("|"),
+			key.WithHelp("|", "show/hide a column (then its number)"),
+		),
+		ToggleHighlight: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "toggle search highlights"),
+		),
+		TagCloud: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "tag cloud"),
+		),
+		CycleTheme: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "cycle theme"),
+		),
+
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q", "quit"),
+			key.WithHelp("q/ctrl+c", "quit"),
 		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
@@ -256,6 +442,110 @@ func defaultKeyMap() keyMap {
 	}
 }
 
+// the bindings that change tasks, projects or their order
+func (k *keyMap) writeBindings() []*key.Binding {
+	return []*key.Binding{
+		&k.New, &k.Edit, &k.EditTitle, &k.MarkComplete, &k.CyclePriority, &k.ToggleStatus,
+		&k.Delete, &k.TogglePin, &k.ToggleLock, &k.CycleColor, &k.CaptureInbox, &k.Snooze,
+		&k.SnoozeWeek, &k.Reorder, &k.BulkAddTag, &k.BulkRemoveTag, &k.MoveCardLeft, &k.MoveCardRight,
+		&k.SetPending, &k.SetInProgress, &k.SetCompleted, &k.SetCancelled,
+		&k.SetLow, &k.SetMedium, &k.SetHigh, &k.SetUrgent,
+		&k.NewProject, &k.EditProject, &k.DeleteProject, &k.ArchiveProject,
+	}
+}
+
+// the direct-set bindings by the status or priority they set, which is also
+// their name in the quick_keys config
+func (k *keyMap) quickSetBindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		string(domain.StatusPending):    &k.SetPending,
+		string(domain.StatusInProgress): &k.SetInProgress,
+		string(domain.StatusCompleted):  &k.SetCompleted,
+		string(domain.StatusCancelled):  &k.SetCancelled,
+		string(domain.PriorityLow):      &k.SetLow,
+		string(domain.PriorityMedium):   &k.SetMedium,
+		string(domain.PriorityHigh):     &k.SetHigh,
+		string(domain.PriorityUrgent):   &k.SetUrgent,
+	}
+}
+
+// the status msg sets directly, "" when it isn't a direct-set status key
+func (k *keyMap) quickStatus(msg tea.KeyMsg) domain.Status {
+	for name, b := range k.quickSetBindings() {
+		if key.Matches(msg, *b) && domain.Status(name).IsValid() {
+			return domain.Status(name)
+		}
+	}
+	return ""
+}
+
+// the priority msg sets directly, "" when it isn't a direct-set priority key
+func (k *keyMap) quickPriority(msg tea.KeyMsg) domain.Priority {
+	for name, b := range k.quickSetBindings() {
+		if key.Matches(msg, *b) && domain.Priority(name).IsValid() {
+			return domain.Priority(name)
+		}
+	}
+	return ""
+}
+
+// rebinds the direct-set keys from the quick_keys config, e.g. cancelled: X.
+// a key taken by any other binding, navigation and quick access views
+// included, is refused so it can't shadow or be shadowed
+func (k *keyMap) setQuickKeys(keys map[string]string) error {
+	bindings := k.quickSetBindings()
+	for name, keyName := range keys {
+		b, ok := bindings[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("unknown action '%s', use a status or a priority", name)
+		}
+		keyName = strings.TrimSpace(keyName)
+		if keyName == "" {
+			return fmt.Errorf("'%s' has no key", name)
+		}
+		b.SetKeys(keyName)
+		b.SetHelp(keyName, b.Help().Desc)
+	}
+
+	owners := make(map[string][]string)
+	others := []key.Binding{
+		k.ExpandProject, k.CollapseProject, k.NewProject, k.EditProject, k.DeleteProject,
+		k.ArchiveProject, k.ViewNotes, k.FilterByProject,
+	}
+	for _, group := range append(k.FullHelp(), others) {
+		for _, b := range group {
+			for _, keyName := range b.Keys() {
+				owners[keyName] = append(owners[keyName], b.Help().Desc)
+			}
+		}
+	}
+	for _, b := range bindings {
+		for _, keyName := range b.Keys() {
+			if len(owners[keyName]) > 1 {
+				return fmt.Errorf("%s is bound more than once (%s)", keyName, strings.Join(owners[keyName], ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// disabled bindings never match and are left out of the help
+func (k *keyMap) setWritesEnabled(enabled bool) {
+	for _, b := range k.writeBindings() {
+		b.SetEnabled(enabled)
+	}
+}
+
+// whether msg is the key of a write binding, enabled or not
+func (k *keyMap) isWriteKey(msg tea.KeyMsg) bool {
+	for _, b := range k.writeBindings() {
+		if slices.Contains(b.Keys(), msg.String()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (k keyMap) ShortHelp() []key.Binding {
 	return []key.Binding{k.Up, k.Down, k.Enter, k.New, k.Edit, k.Quit, k.Help}
 }
@@ -263,15 +553,139 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Back},
-		{k.New, k.Edit, k.Delete, k.Refresh},
-		{k.MarkComplete, k.CyclePriority, k.ToggleStatus},
-		{k.Filter, k.ClearFilters, k.Search},
-		{k.Sort, k.SortOrder, k.NextPage, k.PrevPage},
+		{k.New, k.Edit, k.EditTitle, k.Delete, k.Refresh, k.Yank},
+		{k.MarkComplete, k.CyclePriority, k.ToggleStatus, k.TogglePin, k.ToggleLock},
+		{k.CycleColor, k.Snooze, k.SnoozeWeek, k.CaptureInbox, k.GoToInbox, k.ReadyQueue},
+		{k.SetPending, k.SetInProgress, k.SetCompleted, k.SetCancelled},
+		{k.SetLow, k.SetMedium, k.SetHigh, k.SetUrgent},
+		{k.Filter, k.ClearFilters, k.Search, k.TagCloud},
+		{k.Sort, k.SortOrder, k.Reorder, k.NextPage, k.PrevPage},
 		{k.ToggleMultiSelect, k.ToggleSelection, k.SelectAll, k.DeselectAll},
-		{k.ToggleProjects, k.ViewProject, k.ProjectPicker},
-		{k.ViewPicker, k.FavoriteViews},
+		{k.BulkAddTag, k.BulkRemoveTag},
+		{k.ToggleProjects, k.ViewProject, k.ProjectPicker, k.PrevProject, k.NextProject},
+		{k.ViewPicker, k.FavoriteViews, k.ReapplyView},
+		{k.ToggleKanban, k.ColumnLeft, k.ColumnRight, k.MoveCardLeft, k.MoveCardRight},
 		{k.QuickAccess1, k.QuickAccess2, k.QuickAccess3, k.QuickAccess4},
 		{k.QuickAccess5, k.QuickAccess6, k.QuickAccess7, k.QuickAccess8},
-		{k.QuickAccess9, k.Quit, k.Help},
+		{k.QuickAccess9, k.ToggleCompact, k.ToggleColumn, k.ToggleHighlight, k.CycleTheme},
+		{k.Quit, k.Help},
+	}
+}
+
+// a titled group of bindings in the full help, which is built from the keymap
+// so it always shows the keys that are actually bound
+type helpSection struct {
+	title    string
+	bindings []key.Binding
+}
+
+// the binding under another description, for keys that do something else in
+// a mode or view
+func withHelpDesc(b key.Binding, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(b.Keys()...), key.WithHelp(b.Help().Key, desc))
+}
+
+// the keys that work in the current mode and view, grouped the way the full
+// help shows them
+func (m Model) helpSections() []helpSection {
+	k := m.keys
+
+	if m.uiMode == searchingMode {
+		return []helpSection{{"Search Mode (type to search)", []key.Binding{
+			withHelpDesc(k.Enter, "apply search"),
+			withHelpDesc(k.Back, "cancel"),
+		}}}
+	}
+	if m.uiMode == filteringMode {
+		return []helpSection{{"Filter Mode", []key.Binding{
+			k.Up, k.Down,
+			withHelpDesc(k.Enter, "select filter"),
+			withHelpDesc(k.Back, "cancel"),
+		}}}
+	}
+
+	views := helpSection{"Views", []key.Binding{k.ViewPicker, k.FavoriteViews, k.ReapplyView}}
+	if len(m.quickAccessViews) > 0 {
+		quickAccess := key.NewBinding(
+			key.WithKeys(slices.Concat(k.QuickAccess1.Keys(), k.QuickAccess9.Keys())...),
+			key.WithHelp(k.QuickAccess1.Help().Key+"-"+k.QuickAccess9.Help().Key, "apply quick access view"),
+		)
+		views.bindings = append(views.bindings, quickAccess)
+	}
+
+	quickSet := helpSection{"Set Status / Priority", []key.Binding{
+		k.SetPending, k.SetInProgress, k.SetCompleted, k.SetCancelled,
+		k.SetLow, k.SetMedium, k.SetHigh, k.SetUrgent,
+	}}
+
+	switch m.viewMode {
+	case projectView:
+		return []helpSection{
+			{"Projects", []key.Binding{
+				k.Up, k.Down, k.ExpandProject, k.CollapseProject, k.ViewProject,
+				k.NewProject, k.EditProject, k.DeleteProject, k.ArchiveProject, k.ViewNotes,
+				withHelpDesc(k.ToggleProjects, "back to tasks"), k.Refresh,
+			}},
+			views,
+			{"General", []key.Binding{k.Quit, k.Help}},
+		}
+
+	case detailView:
+		return []helpSection{
+			{"Detail View", []key.Binding{
+				withHelpDesc(k.Up, "previous task"), withHelpDesc(k.Down, "next task"), k.Back, k.Edit,
+			}},
+			{"Quick Actions", []key.Binding{
+				k.MarkComplete, k.CyclePriority, k.ToggleStatus, k.TogglePin, k.ToggleLock,
+				k.CycleColor, k.Snooze, k.SnoozeWeek, k.Delete, k.Yank,
+			}},
+			quickSet,
+			{"General", []key.Binding{k.ToggleHighlight, k.Quit, k.Help}},
+		}
+
+	case kanbanView:
+		return []helpSection{
+			{"Kanban Board", []key.Binding{
+				withHelpDesc(k.Up, "previous card"), withHelpDesc(k.Down, "next card"),
+				k.ColumnLeft, k.ColumnRight, k.MoveCardLeft, k.MoveCardRight,
+				withHelpDesc(k.ToggleKanban, "back to the table"), k.Refresh,
+			}},
+			{"Quick Actions", []key.Binding{
+				k.MarkComplete, k.CyclePriority, k.ToggleStatus, k.TogglePin, k.ToggleLock,
+				k.CycleColor, k.Snooze, k.SnoozeWeek, k.Delete, k.Yank,
+			}},
+			quickSet,
+			{"General", []key.Binding{k.ToggleCompact, k.Quit, k.Help}},
+		}
+	}
+
+	multiSelect := helpSection{"Multi-select", []key.Binding{
+		k.ToggleMultiSelect, k.ToggleSelection, k.SelectAll, k.DeselectAll, k.BulkAddTag, k.BulkRemoveTag,
+	}}
+	quickActions := helpSection{"Quick Actions", []key.Binding{
+		k.MarkComplete, k.CyclePriority, k.ToggleStatus, k.TogglePin, k.ToggleLock,
+		k.CycleColor, k.Snooze, k.SnoozeWeek, k.Delete, k.Yank, k.CaptureInbox, k.GoToInbox, k.ReadyQueue,
+	}}
+	if m.multiSelect.enabled {
+		multiSelect.title = "Multi-select (complete, priority, status, set and delete apply to the selection)"
+		multiSelect.bindings[0] = withHelpDesc(k.ToggleMultiSelect, "exit multi-select")
+	}
+
+	sections := []helpSection{
+		{"Table View", []key.Binding{
+			k.Up, k.Down, k.Enter, k.New, k.Edit, k.EditTitle, k.Filter, k.ClearFilters, k.Search, k.TagCloud,
+			k.Sort, k.SortOrder, k.Reorder, k.PrevPage, k.NextPage, k.Refresh,
+		}},
+		quickActions,
+		multiSelect,
+		quickSet,
+		{"Projects", []key.Binding{k.ToggleProjects, k.PrevProject, k.NextProject}},
+		{"Kanban Board", []key.Binding{k.ToggleKanban}},
+		views,
+		{"General", []key.Binding{k.ToggleCompact, k.ToggleColumn, k.ToggleHighlight, k.CycleTheme, k.Quit, k.Help}},
+	}
+	if m.multiSelect.enabled {
+		sections[1], sections[2] = multiSelect, quickActions
 	}
+	return sections
 }
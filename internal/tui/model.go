@@ -3,18 +3,23 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 
+	"task-management/internal/config"
 	"task-management/internal/display"
 	"task-management/internal/domain"
+	"task-management/internal/query"
 	"task-management/internal/repository"
 	"task-management/internal/theme"
 )
@@ -33,6 +38,7 @@ const (
 	templateView
 	viewPickerView
 	notesView
+	kanbanView
 )
 
 type uiMode int
@@ -48,6 +54,10 @@ type confirmDialog struct {
 	message   string
 	onConfirm func(m *Model) tea.Cmd
 	active    bool
+	// confirmWord has to be typed out and submitted with enter instead of
+	// pressing y, for actions that can't be undone
+	confirmWord string
+	typed       string
 }
 
 type ProjectTree struct {
@@ -91,6 +101,42 @@ type ViewPicker struct {
 	cursor      int
 	searchQuery string
 	selected    *domain.SavedView
+
+	// task count badges, a query per view so they are off by default and
+	// reused for viewCountsTTL
+	showCounts    bool
+	counts        map[int64]int64
+	countsAt      time.Time
+	countsLoading bool
+}
+
+const viewCountsTTL = 30 * time.Second
+
+type tagCloud struct {
+	active bool
+	tags   []domain.TagCount
+	cursor int
+}
+
+// one-line overlay that captures a task straight to the inbox
+type inboxCapture struct {
+	active bool
+	input  textinput.Model
+}
+
+// one-line edit of the selected task's title, shown in place of the footer
+// so the table stays in view
+type titleEdit struct {
+	active bool
+	task   *domain.Task
+	input  textinput.Model
+}
+
+// one-line overlay for the tag a bulk tag action adds or removes
+type bulkTagInput struct {
+	active bool
+	remove bool
+	input  textinput.Model
 }
 
 type notesViewer struct {
@@ -113,6 +159,8 @@ type Model struct {
 	projectCursor    int
 	projectPicker    ProjectPicker
 	projectStats     map[int64]projectStatsData
+	// sibling order in the project trees, task counts loaded when needed
+	projectOrder     domain.ProjectOrder
 
 	templates        []*domain.ProjectTemplate
 	templatePicker   TemplatePicker
@@ -121,6 +169,10 @@ type Model struct {
 	viewRepo         repository.ViewRepository
 	savedViews       []*domain.SavedView
 	viewPicker       ViewPicker
+	tagCloud         tagCloud
+	inboxCapture     inboxCapture
+	titleEdit        titleEdit
+	bulkTag          bulkTagInput
 	selectedView     *domain.SavedView
 	favoriteViews    []*domain.SavedView
 	quickAccessViews map[int]*domain.SavedView
@@ -133,23 +185,46 @@ type Model struct {
 		height int
 	}
 
-	filter          repository.TaskFilter
-	currentPage     int
-	pageSize        int
-	fuzzyMode       bool
-	fuzzyThreshold  int
+	filter         repository.TaskFilter
+	currentPage    int
+	pageSize       int
+	fuzzyMode      bool
+	fuzzyThreshold int
+	fuzzyAlgorithm string
+
+	// nil unless enforce_status_transitions is set in config
+	statusTransitions domain.StatusTransitions
+
+	// search-as-you-type, off by default since every keystroke hits the db
+	incrementalSearch bool
+	searchSeq         int
+	cancelSearch      context.CancelFunc
 
 	queryMode       bool
 	queryString     string
 	showQueryHelp   bool
 
 	table        table.Model
+	// the task table's columns from the config, fitted to the window by
+	// applyColumns
+	columns      []config.TableColumn
+	// persists the column settings, nil keeps them for this session only
+	saveColumns  func(map[string]config.ColumnConfig) error
+	// | was pressed, the next key picks the column to show or hide
+	columnPending bool
 	searchInput  textinput.Model
 	keys         keyMap
 
 	viewMode     viewMode
 	uiMode       uiMode
 	selectedTask *domain.Task
+	// the unfinished tasks selectedTask depends on, loaded with the detail view
+	blockers     []*domain.Task
+	// the selected card while the kanban board is shown
+	kanban       kanbanCursor
+	// every task the filter matches, not just the current page, while the
+	// kanban board is shown
+	kanbanTasks  []*domain.Task
 
 	filterPanel  filterPanel
 
@@ -158,6 +233,12 @@ type Model struct {
 	projectForm  projectForm
 
 	multiSelect  multiSelectState
+	// summary of the last bulk action, kept until the next one
+	bulkResult   string
+	bulkFailed   bool
+	// set when clearing the filters drops the current view, kept until a
+	// view is applied again
+	viewNotice   string
 
 	confirm      confirmDialog
 
@@ -166,12 +247,57 @@ type Model struct {
 	err          error
 	width        int
 	height       int
+	// tooSmall replaces the whole UI with a resize hint until the window grows
+	tooSmall     bool
 	showHelp     bool
+	// destructive confirm dialogs cancel after this long without a key press,
+	// zero keeps them open
+	confirmTimeout time.Duration
+	confirmSeq     int
+	// bulk actions on more selected tasks than this are refused, 0 is no limit
+	bulkMaxAffected int
+	// new child projects without a color or icon take their parent's
+	inheritProjectStyle bool
+	// jumping past the last or first project wraps around instead of stopping
+	projectJumpWrap bool
+	// pinned tasks sort to the top, kept across filters from views and queries
+	pinnedFirst bool
+	// snoozed tasks are left out, kept across filters like pinnedFirst
+	hideSnoozed bool
+	// archived tasks are left out, kept across filters like hideSnoozed
+	hideArchived bool
+	// the filter to go back to when leaving the ready queue, nil outside it
+	readyReturn *repository.TaskFilter
+	// new tasks start with this project filled in
+	defaultProject string
+	// projects the active query's project filters resolved to
+	queryProjects []query.ProjectResolution
+	// reordering makes up/down move the selected task within its project
+	reordering   bool
+	// completed and cancelled rows are dimmed and struck through
+	fadeDone bool
+	// days past its due day before a task counts as overdue
+	overdueGraceDays int
+	// how cancelled tasks count in project completion percentages
+	cancelledTreatment domain.CancelledTreatment
+	// the database is opened read-only, the keys that would change it are off
+	readOnly bool
+	// y was pressed, the next key picks what is copied
+	yankPending bool
+	// compact drops the title bar and quick access widget to fit more rows
+	compact      bool
+	// hideHighlights turns off marking what a text or regex search matched
+	hideHighlights bool
 	loading      bool
+	// the first load has finished. later loads keep the screen as it is
+	// and only show the spinner
+	loaded       bool
+	spinner      spinner.Model
 	message      string
 
 	theme        *theme.Theme
 	styles       *theme.Styles
+	saveTheme    func(name string) error
 
 	ctx          context.Context
 }
@@ -201,10 +327,13 @@ type editForm struct {
 	projectInput   textinput.Model
 	tagsInput      textinput.Model
 	dueDateInput   textinput.Model
+	waitingInput   textinput.Model
 	focusedField   int
 	priorityIdx    int
 	statusIdx      int
+	// err is for failures not tied to a field, e.g. the repository rejecting the save
 	err            string
+	errors         map[string]string
 }
 
 type multiSelectState struct {
@@ -231,36 +360,21 @@ type projectForm struct {
 	focusedField  int
 	statusIdx     int
 	errors        map[string]string
+	// what a new project took over from its parent, shown once it is created
+	inherited     string
 }
 
 func NewModel(repo repository.TaskRepository, projectRepo repository.ProjectRepository, viewRepo repository.ViewRepository, searchHistoryRepo repository.SearchHistoryRepository, initialFilter repository.TaskFilter, pageSize int, themeObj *theme.Theme, styles *theme.Styles) Model {
-	columns := []table.Column{
-		{Title: "Status", Width: 15},
-		{Title: "Priority", Width: 12},
-		{Title: "Title", Width: 45},
-		{Title: "Project", Width: 15},
-		{Title: "Tags", Width: 20},
-		{Title: "Due", Width: 12},
-	}
+	columns := config.TableColumns(nil)
 
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(fitColumns(columns, 0)),
 		table.WithRows([]table.Row{}),
 		table.WithFocused(true),
 		table.WithHeight(20),
 	)
 
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color(themeObj.BorderColor)).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color(themeObj.SelectedFg)).
-		Background(lipgloss.Color(themeObj.SelectedBg)).
-		Bold(true)
-	t.SetStyles(s)
+	t.SetStyles(tableStyles(themeObj))
 
 	si := textinput.New()
 	si.Placeholder = "Search tasks..."
@@ -292,11 +406,15 @@ func NewModel(repo repository.TaskRepository, projectRepo repository.ProjectRepo
 		quickAccessViews:  make(map[int]*domain.SavedView),
 		searchHistory:     []*domain.SearchHistory{},
 		filter:            initialFilter,
+		hideSnoozed:       initialFilter.HideSnoozed,
+		hideArchived:      initialFilter.HideArchived,
 		currentPage:       1,
 		pageSize:          pageSize,
 		fuzzyMode:         false,
 		fuzzyThreshold:    60,
+		fuzzyAlgorithm:    initialFilter.FuzzyAlgorithm,
 		table:             t,
+		columns:           columns,
 		searchInput:       si,
 		keys:              defaultKeyMap(),
 		viewMode:          tableView,
@@ -314,10 +432,126 @@ func NewModel(repo repository.TaskRepository, projectRepo repository.ProjectRepo
 			cursor: 0,
 			height: 5,
 		},
-		theme:  themeObj,
-		styles: styles,
-		ctx:    context.Background(),
+		loading: true,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Dot)),
+		theme:   themeObj,
+		styles:  styles,
+		ctx:     context.Background(),
+	}
+}
+
+func tableStyles(themeObj *theme.Theme) table.Styles {
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color(themeObj.BorderColor)).
+		BorderBottom(true).
+		Bold(true)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color(themeObj.SelectedFg)).
+		Background(lipgloss.Color(themeObj.SelectedBg)).
+		Bold(true)
+	return s
+}
+
+// switches the whole UI to another theme. everything else renders from
+// m.theme and m.styles on every View, the table is the only component that
+// keeps its own copy of the styles
+func (m *Model) applyTheme(themeObj *theme.Theme) {
+	m.theme = themeObj
+	m.styles = theme.NewStyles(themeObj)
+	m.table.SetStyles(tableStyles(themeObj))
+	m.updateTableRows()
+}
+
+// the theme after current in the registry order, wrapping around
+func nextThemeName(current string) string {
+	names := theme.ListThemes()
+	for i, name := range names {
+		if name == current {
+			return names[(i+1)%len(names)]
+		}
 	}
+	return names[0]
+}
+
+// saveTheme persists a theme picked in the TUI, nil keeps it for this session only
+func (m *Model) SetThemeSaver(saveTheme func(name string) error) {
+	m.saveTheme = saveTheme
+}
+
+func (m *Model) SetStatusTransitions(transitions domain.StatusTransitions) {
+	m.statusTransitions = transitions
+}
+
+func (m *Model) SetConfirmTimeout(timeout time.Duration) {
+	m.confirmTimeout = timeout
+}
+
+func (m *Model) SetBulkMaxAffected(limit int) {
+	m.bulkMaxAffected = max(limit, 0)
+}
+
+func (m *Model) SetInheritProjectStyle(inherit bool) {
+	m.inheritProjectStyle = inherit
+}
+
+func (m *Model) SetProjectJumpWrap(wrap bool) {
+	m.projectJumpWrap = wrap
+}
+
+func (m *Model) SetDefaultProject(name string) {
+	m.defaultProject = name
+}
+
+func (m *Model) SetPinnedFirst(pinnedFirst bool) {
+	m.pinnedFirst = pinnedFirst
+	m.filter.PinnedFirst = pinnedFirst
+}
+
+func (m *Model) SetFadeDone(fade bool) {
+	m.fadeDone = fade
+}
+
+func (m *Model) SetOverdueGraceDays(days int) {
+	m.overdueGraceDays = days
+}
+
+// rebinds the keys that set a status or priority directly, keyed by the
+// status or priority, e.g. cancelled: X
+func (m *Model) SetQuickKeys(keys map[string]string) error {
+	return m.keys.setQuickKeys(keys)
+}
+
+func (m *Model) SetCancelledTreatment(treatment domain.CancelledTreatment) {
+	m.cancelledTreatment = treatment
+}
+
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+	m.keys.setWritesEnabled(!readOnly)
+}
+
+func (m *Model) SetShowViewCounts(show bool) {
+	m.viewPicker.showCounts = show
+}
+
+func (m *Model) SetProjectOrder(order domain.ProjectOrder) {
+	m.projectOrder = order
+}
+
+// the width and visibility of the task table columns, keyed by column name
+func (m *Model) SetColumns(columns map[string]config.ColumnConfig) {
+	m.columns = config.TableColumns(columns)
+	m.applyColumns()
+}
+
+func (m *Model) SetColumnSaver(saveColumns func(map[string]config.ColumnConfig) error) {
+	m.saveColumns = saveColumns
+}
+
+func (m *Model) SetCompact(compact bool) {
+	m.compact = compact
 }
 
 func (m Model) Init() tea.Cmd {
@@ -325,6 +559,8 @@ func (m Model) Init() tea.Cmd {
 		ExcludeArchived: true,
 	}
 	return tea.Batch(
+		m.spinner.Tick,
+		relativeTimeTickCmd(),
 		fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize),
 		fetchProjectsCmd(m.ctx, m.projectRepo, projectFilter),
 		fetchViewsCmd(m.ctx, m.viewRepo),
@@ -340,52 +576,59 @@ func (m *Model) taskToRow(task *domain.Task) table.Row {
 	}
 
 	// status
-	statusIcon := display.GetStatusIcon(task.Status)
-	status := fmt.Sprintf("%s%s %s", selectionIndicator, statusIcon, task.Status)
+	statusIcon := m.styles.StatusSymbol(task.Status)
+	status := fmt.Sprintf("%s%s %s", selectionIndicator, statusIcon, m.styles.StatusLabel(task.Status))
 
 	// priority
-	priorityIcon := display.GetPriorityIcon(task.Priority)
-	priority := fmt.Sprintf("%s %s", priorityIcon, task.Priority)
+	priorityIcon := m.styles.PrioritySymbol(task.Priority)
+	priority := fmt.Sprintf("%s %s", priorityIcon, m.styles.PriorityLabel(task.Priority))
 
 	// truncate title - adjust for selection indicator
-	maxTitleLen := 37
+	titleWidth := m.columnWidth("title")
 	if selectionIndicator != "" {
-		maxTitleLen = 35 // Slightly shorter to compensate for indicator
-	}
-	title := task.Title
-	if len(title) > maxTitleLen {
-		title = title[:maxTitleLen] + "..."
+		titleWidth -= 2 // Slightly shorter to compensate for indicator
 	}
+	title := display.FormatTaskTitle(task, titleWidth)
+	query, regex, highlight := m.highlightQuery()
+	highlight = highlight && len(display.SearchMatches(task.Title, query, regex)) > 0
 
 	// project
-	project := task.ProjectName
+	project := display.TruncateText(display.TaskProjectLabel(task), m.columnWidth("project"))
 	if project == "" {
 		project = "-"
 	}
 
 	// tags
-	tags := strings.Join(task.Tags, ", ")
+	tags := display.TruncateText(strings.Join(task.Tags, ", "), m.columnWidth("tags"))
 	if tags == "" {
 		tags = "-"
 	}
-	if len(tags) > 17 {
-		tags = tags[:17] + "..."
-	}
 
 	// due date
 	dueDate := "-"
 	if task.DueDate != nil {
-		dueDate = display.FormatDueDate(task.DueDate)
+		dueDate = display.FormatDueDate(task.DueDate, time.Now(), m.overdueGraceDays)
+	}
+
+	updated := display.FormatRelativeTime(task.UpdatedAt, time.Now())
+
+	// unmet dependencies, counted by the list query
+	blockers := "-"
+	if task.IsBlocked() {
+		blockers = fmt.Sprintf("⛔%d", task.OpenBlockers)
 	}
 
-	// Apply project color if available
+	// the task's own color wins over its project's
 	var rowStyle lipgloss.Style
 	hasColor := false
-	if task.ProjectID != nil {
+	if task.Color != "" {
+		rowStyle = lipgloss.NewStyle().Foreground(terminalColor(task.Color))
+		hasColor = true
+	} else if task.ProjectID != nil {
 		for _, p := range m.projects {
 			if p.ID == *task.ProjectID {
 				if p.Color != "" {
-					rowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(p.Color))
+					rowStyle = lipgloss.NewStyle().Foreground(terminalColor(p.Color))
 					hasColor = true
 				}
 				break
@@ -393,14 +636,35 @@ func (m *Model) taskToRow(task *domain.Task) table.Row {
 		}
 	}
 
+	// faded rows keep their project color
+	fade := m.fadesRow(task)
+	if fade {
+		if !hasColor {
+			rowStyle = rowStyle.Foreground(lipgloss.Color(m.theme.TextMuted))
+		}
+		rowStyle = rowStyle.Faint(true)
+		hasColor = true
+	}
+	titleStyle := rowStyle
+	if fade {
+		titleStyle = titleStyle.Strikethrough(true)
+	}
+
 	// Apply color styling if project has a color
+	if highlight {
+		title = fitHighlightedTitle(task, query, regex, titleWidth, m.styles.SearchMatch, titleStyle)
+	}
 	if hasColor {
 		status = rowStyle.Render(status)
 		priority = rowStyle.Render(priority)
-		title = rowStyle.Render(title)
+		if !highlight {
+			title = titleStyle.Render(title)
+		}
 		project = rowStyle.Render(project)
 		tags = rowStyle.Render(tags)
 		dueDate = rowStyle.Render(dueDate)
+		updated = rowStyle.Render(updated)
+		blockers = rowStyle.Render(blockers)
 	}
 
 	return table.Row{
@@ -410,20 +674,98 @@ func (m *Model) taskToRow(task *domain.Task) table.Row {
 		project,
 		tags,
 		dueDate,
+		updated,
+		blockers,
+	}
+}
+
+// the ANSI colors behind the color names projects and tasks can have
+var terminalColors = map[string]string{
+	"black": "0", "red": "1", "green": "2", "yellow": "3",
+	"blue": "4", "magenta": "5", "cyan": "6", "white": "7",
+	"gray": "8", "bright-red": "9", "bright-green": "10", "bright-yellow": "11",
+	"bright-blue": "12", "bright-magenta": "13", "bright-cyan": "14", "bright-white": "15",
+}
+
+func terminalColor(name string) lipgloss.Color {
+	if code, ok := terminalColors[strings.ToLower(name)]; ok {
+		return lipgloss.Color(code)
+	}
+	return lipgloss.Color(name)
+}
+
+// done tasks fade, unless they are selected so the selection still stands out
+func (m *Model) fadesRow(task *domain.Task) bool {
+	if !m.fadeDone || !task.Status.IsTerminal() {
+		return false
+	}
+	if m.multiSelect.enabled && m.multiSelect.selectedTasks[task.ID] {
+		return false
 	}
+	return !m.isCursorTask(task)
+}
+
+func (m *Model) isCursorTask(task *domain.Task) bool {
+	cursor := m.table.Cursor()
+	return m.viewMode == tableView && cursor >= 0 && cursor < len(m.tasks) && m.tasks[cursor].ID == task.ID
+}
+
+// the active search to highlight. fuzzy matches aren't substrings, so only
+// text and regex searches are highlighted
+func (m Model) highlightQuery() (query string, regex bool, ok bool) {
+	if m.hideHighlights || m.filter.SearchQuery == "" {
+		return "", false, false
+	}
+	switch m.filter.SearchMode {
+	case "fuzzy":
+		return "", false, false
+	case "regex":
+		return m.filter.SearchQuery, true, true
+	default:
+		return m.filter.SearchQuery, false, true
+	}
+}
+
+// bubbles' table truncates cells with runewidth, which counts the bytes of
+// ANSI sequences as cells. a highlighted title gives up as much of its width
+// as its sequences need, so the table never cuts into them
+func fitHighlightedTitle(task *domain.Task, query string, regex bool, width int, match, base lipgloss.Style) string {
+	prefix := display.TaskTitlePrefix(task)
+	for w := width - lipgloss.Width(prefix); w > 3; w-- {
+		title := prefix + display.HighlightTruncated(task.Title, query, regex, w, match, base)
+		if runewidth.StringWidth(title) <= width {
+			return title
+		}
+	}
+	return base.Render(display.FormatTaskTitle(task, width))
 }
 
 func wrapText(text string, width int) string {
-	if len(text) <= width {
+	if lipgloss.Width(text) <= width {
 		return text
 	}
+	width = max(width, 1)
 
 	var wrapped []string
 	words := strings.Fields(text)
 	currentLine := ""
 
 	for _, word := range words {
-		if len(currentLine)+len(word)+1 <= width {
+		// break words that could never fit on a line of their own
+		for lipgloss.Width(word) > width {
+			if currentLine != "" {
+				wrapped = append(wrapped, currentLine)
+				currentLine = ""
+			}
+			var head string
+			head, word = display.SplitAtWidth(word, width)
+			wrapped = append(wrapped, head)
+		}
+		if word == "" {
+			continue
+		}
+
+		if lipgloss.Width(currentLine)+lipgloss.Width(word)+1 <= width {
 			if currentLine != "" {
 				currentLine += " "
 			}
@@ -443,22 +785,23 @@ func wrapText(text string, width int) string {
 	return strings.Join(wrapped, "\n"+strings.Repeat(" ", 16))
 }
 
-func formatDetailDueDate(dueDate *time.Time) string {
+func formatDetailDueDate(dueDate *time.Time, graceDays int) string {
 	if dueDate == nil {
 		return "-"
 	}
 
 	now := time.Now()
-	diff := dueDate.Sub(now)
+	days := domain.DueInDays(*dueDate, now)
 
 	dateStr := dueDate.Format("2006-01-02 (Mon)")
 
-	if diff < 0 {
-		days := int(-diff.Hours() / 24)
-		return fmt.Sprintf("%s - OVERDUE by %d day(s)", dateStr, days)
+	if domain.IsOverdue(dueDate, now, graceDays) {
+		return fmt.Sprintf("%s - OVERDUE by %d day(s)", dateStr, -days)
+	}
+	if days < 0 {
+		return fmt.Sprintf("%s - %d day(s) past due, within grace", dateStr, -days)
 	}
 
-	days := int(diff.Hours() / 24)
 	if days == 0 {
 		return fmt.Sprintf("%s - DUE TODAY", dateStr)
 	} else if days == 1 {
@@ -495,9 +838,10 @@ func buildProjectTree(projects []*domain.Project) *ProjectTree {
 		tree.flatMap[project.ID] = node
 	}
 
-	// build relationships
+	// build relationships in list order so the tree is stable between refreshes
 	var roots []*ProjectTreeNode
-	for _, node := range tree.flatMap {
+	for _, project := range projects {
+		node := tree.flatMap[project.ID]
 		if node.project.ParentID == nil {
 			roots = append(roots, node)
 		} else {
@@ -519,6 +863,27 @@ func buildProjectTree(projects []*domain.Project) *ProjectTree {
 	return tree
 }
 
+// orders every group of siblings, the roots included
+func (t *ProjectTree) sortSiblings(order domain.ProjectOrder) {
+	var sortNodes func(nodes []*ProjectTreeNode)
+	sortNodes = func(nodes []*ProjectTreeNode) {
+		slices.SortStableFunc(nodes, func(a, b *ProjectTreeNode) int {
+			return order.Compare(a.project, b.project)
+		})
+		for _, node := range nodes {
+			sortNodes(node.children)
+		}
+	}
+	sortNodes(t.roots)
+}
+
+// the project tree in the configured sibling order
+func (m Model) buildSortedProjectTree(projects []*domain.Project) *ProjectTree {
+	tree := buildProjectTree(projects)
+	tree.sortSiblings(m.projectOrder)
+	return tree
+}
+
 func calculateDepth(node *ProjectTreeNode, depth int) {
 	if node == nil {
 		return
@@ -663,23 +1028,48 @@ func (m *Model) initNotesViewer(project *domain.Project) {
 	m.notesViewer.viewport = vp
 }
 
+// form errors are only set through these helpers so a failed save can never
+// touch the values the user typed
+func (f *editForm) setFieldError(field, msg string) {
+	if f.errors == nil {
+		f.errors = make(map[string]string)
+	}
+	f.errors[field] = msg
+}
+
+func (f *editForm) clearErrors() {
+	f.err = ""
+	f.errors = make(map[string]string)
+}
+
+func (f editForm) hasErrors() bool {
+	return f.err != "" || len(f.errors) > 0
+}
+
+func (f *projectForm) setFieldError(field, msg string) {
+	if f.errors == nil {
+		f.errors = make(map[string]string)
+	}
+	f.errors[field] = msg
+}
+
+func (f *projectForm) clearErrors() {
+	f.errors = make(map[string]string)
+}
+
 func (m *Model) validateProjectForm() bool {
-	m.projectForm.errors = make(map[string]string)
+	m.projectForm.clearErrors()
 
 	name := strings.TrimSpace(m.projectForm.nameInput.Value())
 	if name == "" {
-		m.projectForm.errors["name"] = "Project name is required"
-		return false
-	}
-	if len(name) > 100 {
-		m.projectForm.errors["name"] = "Project name cannot exceed 100 characters"
-		return false
+		m.projectForm.setFieldError("name", "Project name is required")
+	} else if len(name) > 100 {
+		m.projectForm.setFieldError("name", "Project name cannot exceed 100 characters")
 	}
 
 	desc := strings.TrimSpace(m.projectForm.descInput.Value())
 	if len(desc) > 500 {
-		m.projectForm.errors["description"] = "Description cannot exceed 500 characters"
-		return false
+		m.projectForm.setFieldError("description", "Description cannot exceed 500 characters")
 	}
 
 	return len(m.projectForm.errors) == 0
@@ -748,3 +1138,21 @@ func (m *Model) buildBreadcrumb(task *domain.Task) string {
 
 	return strings.Join(path, " > ")
 }
+
+// keeps the leaf end of a breadcrumb within width cells by dropping the
+// outermost projects first. a width of 0 means the terminal size is unknown
+func fitBreadcrumb(breadcrumb string, width int) string {
+	if width <= 0 || lipgloss.Width(breadcrumb) <= width {
+		return breadcrumb
+	}
+
+	parts := strings.Split(breadcrumb, " > ")
+	for len(parts) > 1 {
+		parts = parts[1:]
+		if candidate := "... > " + strings.Join(parts, " > "); lipgloss.Width(candidate) <= width {
+			return candidate
+		}
+	}
+
+	return display.TruncateText(parts[0], width)
+}
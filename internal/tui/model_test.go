@@ -1,12 +1,24 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"task-management/internal/config"
 	"task-management/internal/domain"
+	"task-management/internal/query"
+	"task-management/internal/repository"
+	"task-management/internal/theme"
 )
 
 func TestBuildProjectTree(t *testing.T) {
@@ -150,6 +162,30 @@ func TestProjectTreeRelationships(t *testing.T) {
 	}
 }
 
+func TestProjectTreeSortSiblings(t *testing.T) {
+	projects := []*domain.Project{
+		{ID: 1, Name: "Work"},
+		{ID: 2, Name: "Home", IsFavorite: true},
+		{ID: 3, Name: "Zeta", ParentID: int64Ptr(1)},
+		{ID: 4, Name: "Alpha", ParentID: int64Ptr(1)},
+		{ID: 5, Name: "Mid", ParentID: int64Ptr(1), IsFavorite: true},
+	}
+
+	tree := buildProjectTree(projects)
+	tree.sortSiblings(domain.ProjectOrder{By: domain.ProjectSortName, FavoritesFirst: true})
+
+	if got := tree.roots[0].project.Name; got != "Home" {
+		t.Errorf("first root = %s, want Home", got)
+	}
+	var children []string
+	for _, child := range tree.flatMap[1].children {
+		children = append(children, child.project.Name)
+	}
+	if strings.Join(children, ",") != "Mid,Alpha,Zeta" {
+		t.Errorf("children = %v, want [Mid Alpha Zeta]", children)
+	}
+}
+
 func TestExpandCollapseState(t *testing.T) {
 	m := Model{
 		projectExpanded: make(map[int64]bool),
@@ -1022,3 +1058,2078 @@ func TestQueryModeIndicatorState(t *testing.T) {
 		})
 	}
 }
+
+func TestIncrementalQueryDetection(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"bug", true},
+		{"/login page", true},
+		{"re:^fix", false},
+		{"@backend bug", false},
+		{"status:pending", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := isIncrementalQuery(tt.query); got != tt.want {
+				t.Errorf("isIncrementalQuery(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncrementalSearchIgnoresStaleResults(t *testing.T) {
+	m := Model{
+		uiMode:            searchingMode,
+		incrementalSearch: true,
+	}
+
+	m.scheduleIncrementalSearch()
+	staleSeq := m.searchSeq
+	m.scheduleIncrementalSearch()
+
+	if m.searchSeq == staleSeq {
+		t.Fatal("scheduling a new search should invalidate the previous one")
+	}
+
+	updated, _ := m.updateSearchMode(incrementalSearchMsg{
+		seq:   staleSeq,
+		tasks: []*domain.Task{{ID: 1, Title: "stale"}},
+	})
+	if len(updated.(Model).tasks) != 0 {
+		t.Error("results from a superseded search should be dropped")
+	}
+
+	updated, cmd := m.updateSearchMode(searchDebounceMsg{seq: staleSeq})
+	if cmd != nil {
+		t.Error("a superseded debounce tick should not start a search")
+	}
+	if updated.(Model).cancelSearch != nil {
+		t.Error("a superseded debounce tick should not register a cancel func")
+	}
+}
+
+func TestBulkResultSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  bulkResultMsg
+		want string
+	}{
+		{
+			name: "all succeeded",
+			msg:  bulkResultMsg{action: "Updated", succeeded: 6},
+			want: "Updated 6",
+		},
+		{
+			name: "one failure",
+			msg: bulkResultMsg{action: "Updated", succeeded: 6, failures: []bulkFailure{
+				{taskID: 42, err: fmt.Errorf("database is locked")},
+			}},
+			want: "Updated 6, failed 1 (task #42: database is locked)",
+		},
+		{
+			name: "several failures",
+			msg: bulkResultMsg{action: "Deleted", succeeded: 1, failures: []bulkFailure{
+				{taskID: 3, err: fmt.Errorf("task not found")},
+				{taskID: 4, err: fmt.Errorf("task not found")},
+			}},
+			want: "Deleted 1, failed 2 (task #3: task not found; +1 more)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.summary(); got != tt.want {
+				t.Errorf("summary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkResultKeepsFailedTasksSelected(t *testing.T) {
+	m := Model{
+		multiSelect: multiSelectState{
+			enabled:       true,
+			selectedTasks: make(map[int64]bool),
+		},
+		loading: true,
+	}
+
+	updated, _ := m.Update(bulkResultMsg{
+		action:    "Updated",
+		succeeded: 2,
+		failures:  []bulkFailure{{taskID: 42, err: fmt.Errorf("boom")}},
+	})
+	result := updated.(Model)
+
+	if result.loading {
+		t.Error("loading should be cleared once the bulk action finishes")
+	}
+	if !result.bulkFailed || !strings.Contains(result.bulkResult, "task #42") {
+		t.Errorf("bulkResult = %q, want it to report task #42", result.bulkResult)
+	}
+	if len(result.multiSelect.selectedTasks) != 1 || !result.multiSelect.selectedTasks[42] {
+		t.Errorf("selectedTasks = %v, want only the failed task", result.multiSelect.selectedTasks)
+	}
+}
+
+func TestBulkActionRefusedOverLimit(t *testing.T) {
+	m := Model{
+		multiSelect: multiSelectState{
+			enabled:       true,
+			selectedTasks: map[int64]bool{1: true, 2: true, 3: true},
+		},
+		bulkMaxAffected: 2,
+	}
+
+	updated, cmd := m.handleBulkMarkComplete()
+	result := updated.(Model)
+
+	if cmd != nil {
+		t.Error("expected no command when the selection is over the limit")
+	}
+	if result.err == nil || !strings.Contains(result.err.Error(), "bulk_max_affected") {
+		t.Errorf("err = %v, want it to mention bulk_max_affected", result.err)
+	}
+	if len(result.multiSelect.selectedTasks) != 3 {
+		t.Errorf("selection should be kept, got %v", result.multiSelect.selectedTasks)
+	}
+
+	result.bulkMaxAffected = 3
+	if result.overBulkLimit() {
+		t.Error("a selection at the limit should be allowed")
+	}
+}
+
+func TestTableFitsTerminalHeight(t *testing.T) {
+	themeObj, err := theme.GetTheme("default")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+
+	m := NewModel(nil, nil, nil, nil, repository.TaskFilter{}, 50, themeObj, theme.NewStyles(themeObj))
+	m.loading = false
+	for i := 1; i <= 50; i++ {
+		m.tasks = append(m.tasks, &domain.Task{ID: int64(i), Title: fmt.Sprintf("Task %d", i), Status: domain.StatusPending, Priority: domain.PriorityMedium})
+	}
+	m.totalCount = 50
+	m.updateTableRows()
+	m.quickAccessViews[1] = &domain.SavedView{ID: 1, Name: "Inbox"}
+
+	const height = 30
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: height})
+	normal := updated.(Model)
+	if got := lipgloss.Height(normal.View()); got > height {
+		t.Errorf("normal view is %d lines, want at most %d", got, height)
+	}
+
+	updated, _ = normal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	compact := updated.(Model)
+	if !compact.compact {
+		t.Fatal("z should enable compact mode")
+	}
+	if got := lipgloss.Height(compact.View()); got > height {
+		t.Errorf("compact view is %d lines, want at most %d", got, height)
+	}
+	if compact.table.Height() <= normal.table.Height() {
+		t.Errorf("compact table height %d should exceed normal height %d", compact.table.Height(), normal.table.Height())
+	}
+
+	compact.err = fmt.Errorf("something went wrong")
+	updated, _ = compact.Update(tea.WindowSizeMsg{Width: 140, Height: height})
+	if got := lipgloss.Height(updated.(Model).View()); got > height {
+		t.Errorf("view with an error is %d lines, want at most %d", got, height)
+	}
+}
+
+func newFormTestModel(t *testing.T) Model {
+	t.Helper()
+	themeObj, err := theme.GetTheme("default")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+	m := NewModel(nil, nil, nil, nil, repository.TaskFilter{}, 20, themeObj, theme.NewStyles(themeObj))
+	// as if the first load had come back
+	m.loading = false
+	m.loaded = true
+	return m
+}
+
+func TestSaveTaskFailuresPreserveInput(t *testing.T) {
+	m := newFormTestModel(t)
+	m.projects = []*domain.Project{{ID: 1, Name: "Backend"}}
+
+	original := &domain.Task{ID: 7, Title: "Original", Status: domain.StatusCompleted, Priority: domain.PriorityMedium}
+	m.initEditForm(original)
+	m.editForm.active = true
+	m.statusTransitions = domain.DefaultStatusTransitions()
+
+	m.editForm.titleInput.SetValue("Renamed")
+	m.editForm.projectInput.SetValue("Frontend")
+	m.editForm.dueDateInput.SetValue("next week")
+	m.editForm.statusIdx = 3 // cancelled
+
+	updated, cmd := m.handleSaveTask()
+	result := updated.(Model)
+
+	if cmd != nil {
+		t.Error("an invalid form should not be saved")
+	}
+	for _, field := range []string{"project", "due_date", "status"} {
+		if result.editForm.errors[field] == "" {
+			t.Errorf("expected an error for %s, got %v", field, result.editForm.errors)
+		}
+	}
+	if got := result.editForm.errors["due_date"]; !strings.Contains(got, `"next week"`) {
+		t.Errorf("due date error = %q, want the reason the date was refused", got)
+	}
+	if got := result.editForm.titleInput.Value(); got != "Renamed" {
+		t.Errorf("title input = %q, want the typed value kept", got)
+	}
+	if got := result.editForm.projectInput.Value(); got != "Frontend" {
+		t.Errorf("project input = %q, want the typed value kept", got)
+	}
+	if got := result.editForm.dueDateInput.Value(); got != "next week" {
+		t.Errorf("due date input = %q, want the typed value kept", got)
+	}
+	if original.Title != "Original" || original.Status != domain.StatusCompleted {
+		t.Errorf("original task was modified: %+v", original)
+	}
+
+	// fixing the fields clears the errors
+	result.editForm.projectInput.SetValue("backend")
+	result.editForm.dueDateInput.SetValue("next friday")
+	result.editForm.statusIdx = 0 // pending
+	updated, cmd = result.handleSaveTask()
+	if cmd == nil || updated.(Model).editForm.hasErrors() {
+		t.Errorf("a valid form should be saved, errors: %v", updated.(Model).editForm.errors)
+	}
+	if original.Title != "Original" {
+		t.Error("the listed task should only change after the update succeeds")
+	}
+}
+
+func TestSaveProjectFailuresPreserveInput(t *testing.T) {
+	m := newFormTestModel(t)
+	original := &domain.Project{ID: 3, Name: "Backend", Status: domain.ProjectStatusActive}
+	m.projects = []*domain.Project{original}
+	m.initEditProjectForm(original)
+
+	m.projectForm.nameInput.SetValue("Platform")
+	m.projectForm.colorInput.SetValue("blue")
+	m.projectForm.parentInput.SetValue("Missing")
+
+	updated, cmd := m.handleSaveProject()
+	result := updated.(Model)
+
+	if cmd != nil {
+		t.Error("an invalid form should not be saved")
+	}
+	if result.projectForm.errors["parent"] == "" {
+		t.Errorf("expected a parent error, got %v", result.projectForm.errors)
+	}
+	if !result.projectForm.active {
+		t.Error("the form should stay open")
+	}
+	if got := result.projectForm.nameInput.Value(); got != "Platform" {
+		t.Errorf("name input = %q, want the typed value kept", got)
+	}
+	if got := result.projectForm.colorInput.Value(); got != "blue" {
+		t.Errorf("color input = %q, want the typed value kept", got)
+	}
+	if original.Name != "Backend" {
+		t.Errorf("original project was renamed to %q before saving", original.Name)
+	}
+
+	result.projectForm.nameInput.SetValue("")
+	updated, _ = result.handleSaveProject()
+	result = updated.(Model)
+	if result.projectForm.errors["name"] == "" {
+		t.Errorf("expected a name error, got %v", result.projectForm.errors)
+	}
+	if got := result.projectForm.parentInput.Value(); got != "Missing" {
+		t.Errorf("parent input = %q, want the typed value kept", got)
+	}
+}
+
+func TestNewChildProjectInheritsParentStyle(t *testing.T) {
+	m := newFormTestModel(t)
+	parent := &domain.Project{ID: 3, Name: "Backend", Color: "blue", Icon: "🚀", Status: domain.ProjectStatusActive}
+	m.projects = []*domain.Project{parent}
+	m.SetInheritProjectStyle(true)
+	m.viewMode = projectFormView
+	m.initNewProjectForm()
+
+	m.projectForm.nameInput.SetValue("API")
+	m.projectForm.parentInput.SetValue("Backend")
+	m.projectForm.colorInput.SetValue("red")
+
+	updated, cmd := m.handleSaveProject()
+	result := updated.(Model)
+	if cmd == nil {
+		t.Fatalf("expected the project to be saved, errors: %v", result.projectForm.errors)
+	}
+	if result.projectForm.inherited != "icon from 'Backend'" {
+		t.Errorf("inherited = %q, want only the icon taken from the parent", result.projectForm.inherited)
+	}
+
+	updated, _ = result.Update(projectCreatedMsg{project: &domain.Project{ID: 4, Name: "API"}})
+	result = updated.(Model)
+	if !strings.Contains(result.message, "inherited icon from 'Backend'") {
+		t.Errorf("message = %q, want it to say what was inherited", result.message)
+	}
+}
+
+func TestJumpProjectFollowsTreeOrder(t *testing.T) {
+	m := newFormTestModel(t)
+	backendID := int64(1)
+	m.projects = []*domain.Project{
+		{ID: 1, Name: "Backend", Status: domain.ProjectStatusActive},
+		{ID: 2, Name: "API", ParentID: &backendID, Status: domain.ProjectStatusActive},
+		{ID: 3, Name: "Web", Status: domain.ProjectStatusActive},
+		{ID: 4, Name: "Done", Status: domain.ProjectStatusCompleted},
+	}
+	m.projectTree = buildProjectTree(m.projects)
+
+	jump := func(m Model, step int) Model {
+		t.Helper()
+		updated, _ := m.jumpProject(step)
+		return updated.(Model)
+	}
+	projectID := func(m Model) int64 {
+		t.Helper()
+		if m.filter.ProjectID == nil {
+			t.Fatal("expected a project filter")
+		}
+		return *m.filter.ProjectID
+	}
+
+	if got := projectID(jump(m, -1)); got != 3 {
+		t.Errorf("previous without a filter = %d, want the last project", got)
+	}
+
+	for _, want := range []int64{1, 2, 3} {
+		m = jump(m, 1)
+		if got := projectID(m); got != want {
+			t.Fatalf("next project = %d, want %d", got, want)
+		}
+	}
+	if !strings.Contains(m.renderStatusBar(), "Project: Web") {
+		t.Errorf("status bar should name the current project, got %q", m.renderStatusBar())
+	}
+
+	m = jump(m, 1)
+	if got := projectID(m); got != 3 || !strings.Contains(m.message, "last project") {
+		t.Errorf("without wrap the jump should stop at the last project, got %d (%q)", got, m.message)
+	}
+
+	m.SetProjectJumpWrap(true)
+	if got := projectID(jump(m, 1)); got != 1 {
+		t.Errorf("with wrap the jump should go back to the first project, got %d", got)
+	}
+}
+
+func TestTagCloudSelectionFiltersByTag(t *testing.T) {
+	m := newFormTestModel(t)
+	m.tagCloud = tagCloud{active: true}
+
+	updated, _ := m.Update(tagCountsLoadedMsg{tags: []domain.TagCount{
+		{Tag: "backend", Count: 5},
+		{Tag: "docs", Count: 1},
+	}})
+	m = updated.(Model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = updated.(Model)
+	if m.tagCloud.cursor != 1 {
+		t.Fatalf("cursor = %d, want 1", m.tagCloud.cursor)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+
+	if m.tagCloud.active {
+		t.Error("selecting a tag should close the cloud")
+	}
+	if len(m.filter.Tags) != 1 || m.filter.Tags[0] != "docs" {
+		t.Errorf("filter tags = %v, want [docs]", m.filter.Tags)
+	}
+	if cmd == nil {
+		t.Error("selecting a tag should reload the tasks")
+	}
+}
+
+func TestLongTaskTitleRendering(t *testing.T) {
+	m := newFormTestModel(t)
+	m.width = 60
+	m.projects = []*domain.Project{
+		{ID: 1, Name: strings.Repeat("Platform ", 10)},
+		{ID: 2, Name: "Backend", ParentID: int64Ptr(1)},
+	}
+
+	longTitle := strings.Repeat("word ", 1000)
+	unbroken := strings.Repeat("界", 5000)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "Short", Status: domain.StatusPending, Priority: domain.PriorityLow},
+		{ID: 2, Title: longTitle, Description: unbroken, Tags: []string{strings.Repeat("t", 300)}, Status: domain.StatusPending, Priority: domain.PriorityHigh, ProjectID: int64Ptr(2), ProjectName: "Backend"},
+		{ID: 3, Title: unbroken, Status: domain.StatusCompleted, Priority: domain.PriorityMedium},
+	}
+	m.updateTableRows()
+
+	lines := strings.Split(m.table.View(), "\n")
+	width := lipgloss.Width(lines[0])
+	for i, line := range lines {
+		if got := lipgloss.Width(line); got != width {
+			t.Errorf("table line %d is %d cells wide, want %d", i, got, width)
+		}
+	}
+
+	for _, task := range m.tasks[1:] {
+		m.selectedTask = task
+		detail := m.renderDetailView()
+		for i, line := range strings.Split(detail, "\n") {
+			if got := lipgloss.Width(line); got > 100 {
+				t.Errorf("task #%d detail line %d is %d cells wide", task.ID, i, got)
+			}
+		}
+	}
+
+	m.selectedTask = m.tasks[1]
+	breadcrumb := strings.Split(m.renderDetailView(), "\n")[0]
+	if got := lipgloss.Width(breadcrumb); got > m.width {
+		t.Errorf("breadcrumb is %d cells wide, want at most %d", got, m.width)
+	}
+	if !strings.Contains(breadcrumb, "Backend") {
+		t.Errorf("breadcrumb %q should keep the task's own project", breadcrumb)
+	}
+}
+
+func TestSearchHistorySelectionRestoresMode(t *testing.T) {
+	threshold := 75
+	tests := []struct {
+		name          string
+		entry         *domain.SearchHistory
+		wantInput     string
+		wantMode      string
+		wantQuery     string
+		wantFuzzy     bool
+		wantThreshold int
+		wantProject   bool
+		wantQueryLang bool
+	}{
+		{
+			name:      "text",
+			entry:     &domain.SearchHistory{QueryText: "login", SearchMode: domain.SearchModeText, QueryType: domain.QueryTypeSimple},
+			wantInput: "login",
+			wantMode:  "text",
+			wantQuery: "login",
+		},
+		{
+			name:      "text that looks like a regex",
+			entry:     &domain.SearchHistory{QueryText: "re:view", SearchMode: domain.SearchModeText, QueryType: domain.QueryTypeSimple},
+			wantInput: "/re:view",
+			wantMode:  "text",
+			wantQuery: "re:view",
+		},
+		{
+			name:      "regex",
+			entry:     &domain.SearchHistory{QueryText: "^fix.*bug$", SearchMode: domain.SearchModeRegex, QueryType: domain.QueryTypeSimple},
+			wantInput: "re:^fix.*bug$",
+			wantMode:  "regex",
+			wantQuery: "^fix.*bug$",
+		},
+		{
+			name:          "fuzzy",
+			entry:         &domain.SearchHistory{QueryText: "bcknd", SearchMode: domain.SearchModeFuzzy, FuzzyThreshold: &threshold, QueryType: domain.QueryTypeSimple},
+			wantInput:     "bcknd",
+			wantMode:      "fuzzy",
+			wantQuery:     "bcknd",
+			wantFuzzy:     true,
+			wantThreshold: 75,
+		},
+		{
+			name:        "project mention",
+			entry:       &domain.SearchHistory{QueryText: "api", SearchMode: domain.SearchModeRegex, QueryType: domain.QueryTypeProjectMention, ProjectFilter: "backend"},
+			wantInput:   "re:api @backend",
+			wantMode:    "regex",
+			wantQuery:   "api",
+			wantProject: true,
+		},
+		{
+			name:          "query language",
+			entry:         &domain.SearchHistory{QueryText: "status:pending priority:high", SearchMode: domain.SearchModeText, QueryType: domain.QueryTypeQueryLanguage},
+			wantInput:     "status:pending priority:high",
+			wantQueryLang: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFormTestModel(t)
+			m.projectRepo = &mockProjectRepository{projects: []*domain.Project{{ID: 3, Name: "backend"}}}
+			m.uiMode = searchingMode
+			m.searchHistory = []*domain.SearchHistory{tt.entry}
+			m.historyDropdown.active = true
+			m.fuzzyMode = !tt.wantFuzzy
+			m.fuzzyThreshold = 60
+
+			updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			m = updated.(Model)
+			if got := m.searchInput.Value(); got != tt.wantInput {
+				t.Errorf("search input = %q, want %q", got, tt.wantInput)
+			}
+			if m.fuzzyMode != tt.wantFuzzy {
+				t.Errorf("fuzzyMode = %v, want %v", m.fuzzyMode, tt.wantFuzzy)
+			}
+
+			updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+			m = updated.(Model)
+
+			if tt.wantQueryLang {
+				if !m.queryMode || m.queryString != tt.wantInput {
+					t.Errorf("expected query language mode with %q, got queryMode=%v %q", tt.wantInput, m.queryMode, m.queryString)
+				}
+				return
+			}
+			if m.filter.SearchMode != tt.wantMode || m.filter.SearchQuery != tt.wantQuery {
+				t.Errorf("filter = %s %q, want %s %q", m.filter.SearchMode, m.filter.SearchQuery, tt.wantMode, tt.wantQuery)
+			}
+			if tt.wantFuzzy && m.filter.FuzzyThreshold != tt.wantThreshold {
+				t.Errorf("fuzzy threshold = %d, want %d", m.filter.FuzzyThreshold, tt.wantThreshold)
+			}
+			if tt.wantProject && (m.filter.ProjectID == nil || *m.filter.ProjectID != 3) {
+				t.Errorf("expected project filter 3, got %v", m.filter.ProjectID)
+			}
+		})
+	}
+}
+
+func TestCycleThemeRecolorsUI(t *testing.T) {
+	m := newFormTestModel(t)
+	oldStyles := m.styles
+
+	var saved []string
+	m.SetThemeSaver(func(name string) error {
+		saved = append(saved, name)
+		return nil
+	})
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	m = updated.(Model)
+
+	if m.theme.Name != "dark" {
+		t.Fatalf("theme = %q, want dark", m.theme.Name)
+	}
+	if m.styles == oldStyles {
+		t.Error("expected styles to be rebuilt")
+	}
+	if m.styles.Header.GetForeground() != lipgloss.Color(m.theme.HeaderFg) {
+		t.Errorf("header foreground = %v, want %v", m.styles.Header.GetForeground(), m.theme.HeaderFg)
+	}
+	want := lipgloss.Color(m.theme.SelectedBg)
+	if got := tableStyles(m.theme).Selected.GetBackground(); got != want {
+		t.Errorf("table selected background = %v, want %v", got, want)
+	}
+
+	if cmd == nil {
+		t.Fatal("expected a command to save the theme")
+	}
+	cmd()
+	if len(saved) != 1 || saved[0] != "dark" {
+		t.Errorf("saved themes = %v, want [dark]", saved)
+	}
+
+	names := theme.ListThemes()
+	if got := nextThemeName(names[len(names)-1]); got != names[0] {
+		t.Errorf("nextThemeName wraps to %q, want %q", got, names[0])
+	}
+}
+
+func TestTerminalTooSmall(t *testing.T) {
+	m := newFormTestModel(t)
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 40, Height: 12})
+	m = updated.(Model)
+	if got := m.View(); !strings.Contains(got, "Terminal too small") || lipgloss.Height(got) > 12 {
+		t.Errorf("expected a short resize hint, got %q", got)
+	}
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 12})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "Terminal too small") {
+		t.Error("a short window should still be too small")
+	}
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	if strings.Contains(m.View(), "Terminal too small") {
+		t.Error("expected the full UI once the window is big enough")
+	}
+}
+
+func TestReorderModeMovesTaskWithinProject(t *testing.T) {
+	m := newFormTestModel(t)
+	projectID, otherID := int64(1), int64(2)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "First", ProjectID: &projectID, Position: 1, Status: domain.StatusPending, Priority: domain.PriorityMedium},
+		{ID: 2, Title: "Second", ProjectID: &projectID, Position: 2, Status: domain.StatusPending, Priority: domain.PriorityMedium},
+		{ID: 3, Title: "Elsewhere", ProjectID: &otherID, Position: 1, Status: domain.StatusPending, Priority: domain.PriorityMedium},
+	}
+	m.updateTableRows()
+	m.filter.SortBy = "position"
+	m.filter.SortOrder = "asc"
+
+	press := func(keys string) tea.Cmd {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		m = updated.(Model)
+		return cmd
+	}
+
+	press("o")
+	if !m.reordering {
+		t.Fatal("o should enter reorder mode")
+	}
+
+	if cmd := press("j"); cmd == nil {
+		t.Error("expected a command to save the new order")
+	}
+	if m.tasks[0].Title != "Second" || m.tasks[1].Title != "First" {
+		t.Errorf("order = %s, %s, want Second, First", m.tasks[0].Title, m.tasks[1].Title)
+	}
+	if m.table.Cursor() != 1 {
+		t.Errorf("cursor = %d, want 1", m.table.Cursor())
+	}
+	if m.tasks[1].Position != 2 {
+		t.Errorf("moved task position = %d, want 2", m.tasks[1].Position)
+	}
+
+	if cmd := press("j"); cmd != nil {
+		t.Error("moving past the end of the project should not save anything")
+	}
+	if m.tasks[2].Title != "Elsewhere" {
+		t.Error("a task from another project should not move")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.reordering {
+		t.Error("esc should leave reorder mode")
+	}
+}
+
+func TestDestructiveConfirmRequiresTypedWord(t *testing.T) {
+	m := newFormTestModel(t)
+	m.SetConfirmTimeout(30 * time.Second)
+
+	confirmed := 0
+	onConfirm := func(model *Model) tea.Cmd {
+		confirmed++
+		return nil
+	}
+
+	send := func(msg tea.Msg) tea.Cmd {
+		updated, cmd := m.Update(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+
+	if cmd := m.openConfirm(confirmDialog{message: "Delete?", confirmWord: "delete", onConfirm: onConfirm}); cmd == nil {
+		t.Error("expected a destructive dialog to start the auto-cancel timer")
+	}
+
+	typeText("y")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if confirmed != 0 || !m.confirm.active {
+		t.Fatal("y and enter should not confirm a destructive dialog")
+	}
+
+	send(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText("Delete")
+	send(tea.KeyMsg{Type: tea.KeyEnter})
+	if confirmed != 1 || m.confirm.active {
+		t.Fatalf("typing the word should confirm, confirmed=%d active=%v", confirmed, m.confirm.active)
+	}
+
+	m.openConfirm(confirmDialog{message: "Delete?", confirmWord: "delete", onConfirm: onConfirm})
+	stale := m.confirmSeq
+	typeText("del")
+	send(confirmTimeoutMsg{seq: stale})
+	if !m.confirm.active {
+		t.Fatal("a key press should restart the timeout")
+	}
+	send(confirmTimeoutMsg{seq: m.confirmSeq})
+	if m.confirm.active || confirmed != 1 {
+		t.Error("expected the dialog to cancel after the timeout")
+	}
+
+	if cmd := m.openConfirm(confirmDialog{message: "Archive?", onConfirm: onConfirm}); cmd != nil {
+		t.Error("simple dialogs should not time out")
+	}
+	typeText("y")
+	if confirmed != 2 || m.confirm.active {
+		t.Error("y should still confirm a non-destructive dialog")
+	}
+}
+
+func TestQueryIndicatorShowsResolvedProject(t *testing.T) {
+	m := newFormTestModel(t)
+	m.queryMode = true
+	m.queryString = "@pay"
+
+	updated, _ := m.Update(queryParsedMsg{
+		queryStr: "@pay",
+		projects: []query.ProjectResolution{{
+			Input:        "pay",
+			Project:      &domain.Project{ID: 4, Name: "Payments Gateway"},
+			MatchedAlias: "pay",
+		}},
+	})
+	m = updated.(Model)
+
+	if got := m.renderContextHeader(); !strings.Contains(got, "pay → Payments Gateway (alias)") {
+		t.Errorf("expected the alias resolution in the header, got %q", got)
+	}
+}
+
+func TestContextHeaderFollowsViewAndFilters(t *testing.T) {
+	m := newFormTestModel(t)
+	m.projects = []*domain.Project{{ID: 2, Name: "Backend"}}
+	projectID := int64(2)
+
+	updated, _ := m.Update(viewAppliedMsg{view: &domain.SavedView{
+		Name:         "Sprint",
+		FilterConfig: domain.SavedViewFilter{Status: domain.StatusPending, ProjectID: &projectID},
+	}})
+	m = updated.(Model)
+
+	header := m.renderContextHeader()
+	if !strings.Contains(header, "View: Sprint") || !strings.Contains(header, "Project: Backend") {
+		t.Errorf("expected the view and its filters in the header, got %q", header)
+	}
+
+	m.viewMode = detailView
+	m.selectedTask = &domain.Task{ID: 1, Title: "Task"}
+	if !strings.Contains(m.renderDetailView(), "View: Sprint") {
+		t.Error("the header should stay visible in the detail view")
+	}
+
+	m.filter.Priority = domain.PriorityHigh
+	header = m.renderContextHeader()
+	if strings.Contains(header, "View: Sprint") {
+		t.Errorf("a changed filter should drop the view name, got %q", header)
+	}
+	if !strings.Contains(header, "Priority: high") {
+		t.Errorf("expected the filter summary, got %q", header)
+	}
+}
+
+func TestContextHeaderDropsQueryAfterFilterChange(t *testing.T) {
+	m := newFormTestModel(t)
+	m.queryMode = true
+	m.queryString = "status:pending"
+	m.filter.Status = domain.StatusPending
+
+	if got := m.renderContextHeader(); !strings.Contains(got, "Query: status:pending") {
+		t.Fatalf("expected the query in the header, got %q", got)
+	}
+
+	m.filterPanel.items = []filterItem{{filterType: "pinned", value: "pinned"}}
+	m.filterPanel.selectedItem = 0
+	updated, _ := m.applyFilterSelection()
+	m = updated.(Model)
+
+	got := m.renderContextHeader()
+	if strings.Contains(got, "Query:") {
+		t.Errorf("the query should no longer be shown, got %q", got)
+	}
+	if !strings.Contains(got, "Status: pending | Pinned only") {
+		t.Errorf("expected the resulting filters, got %q", got)
+	}
+}
+
+func TestHideSnoozedSurvivesFilterChanges(t *testing.T) {
+	themeObj, err := theme.GetTheme("default")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+	m := NewModel(nil, nil, nil, nil, repository.TaskFilter{HideSnoozed: true, HideArchived: true}, 20, themeObj, theme.NewStyles(themeObj))
+
+	updated, _ := m.Update(queryParsedMsg{queryStr: "status:pending", filter: repository.TaskFilter{Status: domain.StatusPending}})
+	m = updated.(Model)
+	if !m.filter.HideSnoozed || !m.filter.HideArchived {
+		t.Error("a query should not bring snoozed or archived tasks back")
+	}
+
+	m.filterPanel.items = []filterItem{{filterType: "snoozed", value: "show"}}
+	m.filterPanel.selectedItem = 0
+	updated, _ = m.applyFilterSelection()
+	m = updated.(Model)
+	if m.filter.HideSnoozed {
+		t.Error("show snoozed should list snoozed tasks")
+	}
+
+	updated, _ = m.Update(viewAppliedMsg{view: &domain.SavedView{Name: "All"}})
+	m = updated.(Model)
+	if m.filter.HideSnoozed {
+		t.Error("applying a view should keep snoozed tasks shown")
+	}
+	if !m.filter.HideArchived {
+		t.Error("applying a view should keep archived tasks hidden")
+	}
+}
+
+func TestNewTaskFormPrefillsDefaultProject(t *testing.T) {
+	m := newFormTestModel(t)
+	m.SetDefaultProject("Backend")
+
+	updated, _ := m.handleNewTask()
+	result := updated.(Model)
+	if got := result.editForm.projectInput.Value(); got != "Backend" {
+		t.Errorf("new task project = %q, want %q", got, "Backend")
+	}
+
+	result.initEditForm(&domain.Task{ID: 3, Title: "Existing", ProjectName: "Frontend"})
+	if got := result.editForm.projectInput.Value(); got != "Frontend" {
+		t.Errorf("edited task project = %q, want %q", got, "Frontend")
+	}
+}
+
+func TestRelativeTimeTickRerendersRowsWithoutLeavingForm(t *testing.T) {
+	m := newFormTestModel(t)
+	m.tasks = []*domain.Task{{ID: 1, Title: "Stale", UpdatedAt: time.Now().Add(-5 * time.Minute)}}
+	m.updateTableRows()
+	if got := m.table.Rows()[0][6]; got != "5m ago" {
+		t.Fatalf("updated column = %q, want %q", got, "5m ago")
+	}
+
+	// time passes while the user is editing
+	m.tasks[0].UpdatedAt = time.Now().Add(-2 * time.Hour)
+	m.initEditForm(nil)
+	m.editForm.active = true
+	m.editForm.titleInput.SetValue("half typed")
+
+	updated, cmd := m.Update(relativeTimeTickMsg{})
+	result := updated.(Model)
+
+	if got := result.table.Rows()[0][6]; got != "2h ago" {
+		t.Errorf("updated column after tick = %q, want %q", got, "2h ago")
+	}
+	if !result.editForm.active || result.editForm.titleInput.Value() != "half typed" {
+		t.Error("tick should leave the edit form as it was")
+	}
+	if cmd == nil {
+		t.Error("tick should schedule the next one")
+	}
+}
+
+func TestSearchHighlights(t *testing.T) {
+	m := newFormTestModel(t)
+	// the test renderer has no colors, so matches are marked with brackets
+	m.styles.SearchMatch = lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+
+	titleOf := func(m Model, title string) string {
+		return m.taskToRow(&domain.Task{ID: 1, Title: title, Status: domain.StatusPending, Priority: domain.PriorityLow})[2]
+	}
+
+	m.filter.SearchQuery = "api"
+	m.filter.SearchMode = "text"
+	if got := titleOf(m, "Fix API and api docs"); got != "Fix [API] and [api] docs" {
+		t.Errorf("text search: got %q", got)
+	}
+
+	m.filter.SearchQuery = "fix docs"
+	if got := titleOf(m, "Fix API docs"); got != "[Fix] API [docs]" {
+		t.Errorf("every term should be highlighted: got %q", got)
+	}
+
+	m.filter.SearchQuery = "ab bc"
+	if got := titleOf(m, "xabcx"); got != "x[abc]x" {
+		t.Errorf("overlapping matches should merge: got %q", got)
+	}
+
+	m.filter.SearchQuery = `v\d+`
+	m.filter.SearchMode = "regex"
+	if got := titleOf(m, "Ship v2 not V3"); got != "Ship [v2] not V3" {
+		t.Errorf("regex search: got %q", got)
+	}
+
+	m.filter.SearchQuery = "deploy"
+	m.filter.SearchMode = "text"
+	long := "Deploy " + strings.Repeat("the service ", 6) + "and deploy again"
+	got := titleOf(m, long)
+	if !strings.HasPrefix(got, "[Deploy]") || strings.Count(got, "[") != 1 || !strings.HasSuffix(got, "...") {
+		t.Errorf("only the match in the truncated window should be highlighted: got %q", got)
+	}
+	if width := lipgloss.Width(got); width > 40 {
+		t.Errorf("highlighted title is %d cells wide, column is 40", width)
+	}
+
+	m.filter.SearchMode = "fuzzy"
+	if got := titleOf(m, "Deploy"); got != "Deploy" {
+		t.Errorf("fuzzy search should not highlight: got %q", got)
+	}
+
+	m.filter.SearchMode = "text"
+	m.hideHighlights = true
+	if got := titleOf(m, "Deploy"); got != "Deploy" {
+		t.Errorf("hidden highlights should not be drawn: got %q", got)
+	}
+}
+
+func TestInboxCapture(t *testing.T) {
+	m := newFormTestModel(t)
+	m.projectRepo = &mockProjectRepository{}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = updated.(Model)
+	if !m.inboxCapture.active {
+		t.Fatal("expected i to open the capture overlay")
+	}
+	if !strings.Contains(m.View(), "Capture to Inbox") {
+		t.Error("expected the capture overlay to be rendered")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.inboxCapture.active || cmd != nil {
+		t.Error("expected an empty capture to be ignored")
+	}
+
+	for _, r := range "call the dentist" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.inboxCapture.active || cmd == nil {
+		t.Error("expected enter to close the overlay and capture the task")
+	}
+
+	updated, _ = m.Update(fetchInboxCmd(context.Background(), m.projectRepo)())
+	m = updated.(Model)
+	if m.filter.ProjectID == nil || *m.filter.ProjectID != 1 {
+		t.Errorf("expected the inbox to be filtered on, got %v", m.filter.ProjectID)
+	}
+}
+
+func TestCurrentViewInStatusBar(t *testing.T) {
+	m := newFormTestModel(t)
+
+	updated, _ := m.Update(viewAppliedMsg{view: &domain.SavedView{
+		Name:         "Home",
+		FilterConfig: domain.SavedViewFilter{Status: domain.StatusPending},
+	}})
+	m = updated.(Model)
+	if got := m.renderStatusBar(); !strings.Contains(got, "📌 View: Home") || strings.Contains(got, "re-apply") {
+		t.Errorf("expected the view in the status bar, got %q", got)
+	}
+
+	m.filter.Priority = domain.PriorityHigh
+	if got := m.renderStatusBar(); !strings.Contains(got, "View: Home (changed, R to re-apply)") {
+		t.Errorf("expected the view to be marked as changed, got %q", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if m.filter.Priority != "" || m.filter.Status != domain.StatusPending {
+		t.Errorf("re-applying should restore the view's filter, got %+v", m.filter)
+	}
+	if m.activeView() == nil {
+		t.Error("the view should be active again")
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if m.selectedView != nil {
+		t.Error("clearing the filters should drop the view")
+	}
+	updated, _ = m.Update(tasksLoadedMsg{})
+	m = updated.(Model)
+	if got := m.renderStatusBar(); !strings.Contains(got, "View Home no longer active") {
+		t.Errorf("expected a note that the view was dropped, got %q", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = updated.(Model)
+	if !strings.Contains(m.message, "No view to re-apply") {
+		t.Errorf("expected a hint when there is no view, got %q", m.message)
+	}
+}
+
+func TestSaveTaskProjectField(t *testing.T) {
+	archivedID := int64(9)
+	backendID := int64(1)
+
+	tests := []struct {
+		name    string
+		task    *domain.Task
+		input   string
+		want    *int64
+		wantErr bool
+	}{
+		{"empty clears the project", &domain.Task{ID: 1, Title: "Task", ProjectID: &backendID, ProjectName: "Backend"}, "  ", nil, false},
+		{"matching name", &domain.Task{ID: 1, Title: "Task"}, "backend", &backendID, false},
+		{"unmatched name", &domain.Task{ID: 1, Title: "Task", ProjectID: &backendID, ProjectName: "Backend"}, "Frontend", nil, true},
+		{"unchanged project that isn't loaded", &domain.Task{ID: 1, Title: "Task", ProjectID: &archivedID, ProjectName: "Old"}, "Old", &archivedID, false},
+		{"new task with an unknown name", nil, "Old", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFormTestModel(t)
+			m.projects = []*domain.Project{{ID: backendID, Name: "Backend"}}
+			m.initEditForm(tt.task)
+			m.editForm.active = true
+			m.editForm.projectInput.SetValue(tt.input)
+
+			got, err := m.formProjectID()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("formProjectID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("formProjectID() = %v, want %v", got, tt.want)
+			}
+
+			updated, cmd := m.handleSaveTask()
+			result := updated.(Model)
+			if tt.wantErr {
+				if cmd != nil || result.editForm.errors["project"] == "" {
+					t.Errorf("expected a project error and no save, errors: %v", result.editForm.errors)
+				}
+				if result.editForm.projectInput.Value() != tt.input {
+					t.Error("the typed project should be kept")
+				}
+			} else if cmd == nil || result.editForm.hasErrors() {
+				t.Errorf("expected the task to be saved, errors: %v", result.editForm.errors)
+			}
+		})
+	}
+}
+
+func TestViewPickerCounts(t *testing.T) {
+	m := newFormTestModel(t)
+	m.savedViews = []*domain.SavedView{{ID: 1, Name: "Pending"}, {ID: 2, Name: "Old project"}}
+	m.viewPicker.active = true
+	m.viewPicker.views = m.savedViews
+
+	if cmd := m.viewCountsCmd(); cmd != nil {
+		t.Fatal("counts are off by default, nothing should be fetched")
+	}
+
+	updated, cmd := m.updateViewPicker(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	m = updated.(Model)
+	if !m.viewPicker.showCounts || cmd == nil {
+		t.Fatal("c should turn counts on and fetch them")
+	}
+	if got := m.viewCountBadge(1); got != "(…)" {
+		t.Errorf("badge while counting = %q, want (…)", got)
+	}
+
+	updated, _ = m.updateViewPicker(viewCountsMsg{counts: map[int64]int64{1: 4, 2: danglingViewCount}})
+	m = updated.(Model)
+	if got := m.viewCountBadge(1); got != "(4)" {
+		t.Errorf("badge = %q, want (4)", got)
+	}
+	if got := m.viewCountBadge(2); got != "(—)" {
+		t.Errorf("badge of a view on a deleted project = %q, want (—)", got)
+	}
+	if cmd := m.viewCountsCmd(); cmd != nil {
+		t.Error("fresh counts should be reused")
+	}
+
+	m.savedViews = append(m.savedViews, &domain.SavedView{ID: 3, Name: "New"})
+	if cmd := m.viewCountsCmd(); cmd == nil {
+		t.Error("a view without a count should trigger a fetch")
+	}
+}
+
+func TestYankTask(t *testing.T) {
+	task := &domain.Task{ID: 12, Title: "Fix [login]", ProjectName: "Backend", Description: "see https://example.com/issues/3 for logs"}
+
+	tests := []struct {
+		kind yankKind
+		want string
+	}{
+		{yankID, "12"},
+		{yankMarkdown, `[#12 Fix \[login\]](https://example.com/issues/3)`},
+		{yankDetails, "#12 Fix [login]\nProject: Backend\nhttps://example.com/issues/3"},
+	}
+	for _, tt := range tests {
+		if got := yankText(task, tt.kind); got != tt.want {
+			t.Errorf("yankText(%d) = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+	if got := yankText(&domain.Task{ID: 3, Title: "No link"}, yankMarkdown); got != "#3 No link" {
+		t.Errorf("markdown without a URL = %q, want plain text", got)
+	}
+
+	var copied string
+	var clipboardErr error
+	original := writeClipboard
+	writeClipboard = func(text string) error { copied = text; return clipboardErr }
+	defer func() { writeClipboard = original }()
+
+	m := newFormTestModel(t)
+	m.viewMode = detailView
+	m.selectedTask = task
+
+	press := func(m Model, r rune) (Model, tea.Cmd) {
+		updated, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		return updated.(Model), cmd
+	}
+
+	m, _ = press(m, 'y')
+	if !m.yankPending || m.message != yankPrompt {
+		t.Fatalf("y should ask what to copy, message = %q", m.message)
+	}
+	m, cmd := press(m, 'i')
+	if m.yankPending || cmd == nil {
+		t.Fatal("i should copy the ID")
+	}
+	updated, _ := m.updateNormalMode(cmd())
+	m = updated.(Model)
+	if copied != "12" || m.message != "Copied: 12" {
+		t.Errorf("copied %q, message %q", copied, m.message)
+	}
+
+	clipboardErr = errors.New("no clipboard utilities available")
+	m, _ = press(m, 'y')
+	m, cmd = press(m, 'y')
+	updated, _ = m.updateNormalMode(cmd())
+	m = updated.(Model)
+	if !strings.Contains(m.message, "No clipboard available") || !strings.Contains(m.message, "#12 Fix [login] · Project: Backend") {
+		t.Errorf("without a clipboard the text should be shown, got %q", m.message)
+	}
+
+	m, _ = press(m, 'y')
+	m, cmd = press(m, 'q')
+	if cmd != nil || m.yankPending || m.message != "Copy cancelled" {
+		t.Errorf("other keys should cancel, message %q", m.message)
+	}
+}
+
+func TestFadeDoneTasks(t *testing.T) {
+	m := newFormTestModel(t)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "Open", Status: domain.StatusPending},
+		{ID: 2, Title: "Done", Status: domain.StatusCompleted},
+		{ID: 3, Title: "Dropped", Status: domain.StatusCancelled},
+	}
+	m.updateTableRows()
+
+	if m.fadesRow(m.tasks[1]) {
+		t.Error("rows only fade with fade_done_tasks on")
+	}
+
+	m.SetFadeDone(true)
+	if m.fadesRow(m.tasks[0]) {
+		t.Error("open tasks should not fade")
+	}
+	if !m.fadesRow(m.tasks[1]) || !m.fadesRow(m.tasks[2]) {
+		t.Error("completed and cancelled tasks should fade")
+	}
+
+	m.table.SetCursor(1)
+	if m.fadesRow(m.tasks[1]) {
+		t.Error("the row under the cursor should not fade")
+	}
+
+	m.multiSelect.enabled = true
+	m.multiSelect.selectedTasks = map[int64]bool{3: true}
+	if m.fadesRow(m.tasks[2]) {
+		t.Error("multi-selected rows should not fade")
+	}
+}
+
+func TestLockedTasks(t *testing.T) {
+	m := newFormTestModel(t)
+	locked := &domain.Task{ID: 1, Title: "Reference", Status: domain.StatusPending, Priority: domain.PriorityLow, IsLocked: true}
+	open := &domain.Task{ID: 2, Title: "Open", Status: domain.StatusPending, Priority: domain.PriorityLow}
+	m.tasks = []*domain.Task{locked, open}
+	m.viewMode = detailView
+	m.selectedTask = locked
+
+	for _, r := range []rune{'e', 'c', 'p', 'x', 'd'} {
+		updated, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		got := updated.(Model)
+		if cmd != nil || got.viewMode != detailView || !strings.Contains(got.message, "locked") {
+			t.Errorf("%c on a locked task should be refused, message = %q", r, got.message)
+		}
+	}
+	if locked.Status != domain.StatusPending || locked.Priority != domain.PriorityLow {
+		t.Errorf("locked task was changed: %s, %s", locked.Status, locked.Priority)
+	}
+
+	m.viewMode = tableView
+	m.multiSelect.enabled = true
+	m.multiSelect.selectedTasks = map[int64]bool{1: true, 2: true}
+	_, cmd := m.handleBulkCyclePriority()
+	if cmd == nil {
+		t.Fatal("expected a bulk update command")
+	}
+	if locked.Priority != domain.PriorityLow || open.Priority != domain.PriorityMedium {
+		t.Errorf("bulk priority = %s/%s, want the locked task skipped", locked.Priority, open.Priority)
+	}
+
+	msg := bulkResultMsg{action: "Updated", succeeded: 1, skipped: 1}
+	if got := msg.summary(); got != "Updated 1, skipped 1 locked" {
+		t.Errorf("summary() = %q", got)
+	}
+}
+
+func TestFullHelpFollowsKeymap(t *testing.T) {
+	m := newFormTestModel(t)
+	m.viewMode = tableView
+	m.keys.Delete = key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "delete task"))
+	m.keys.TagCloud.SetEnabled(false)
+
+	help := m.renderFullHelp()
+	if !strings.Contains(help, "X           delete task") {
+		t.Errorf("help should show the remapped delete key:\n%s", help)
+	}
+	if strings.Contains(help, "tag cloud") {
+		t.Error("help shows a disabled binding")
+	}
+	if strings.Contains(help, "quick access") {
+		t.Error("help shows quick access keys without quick access views")
+	}
+
+	m.quickAccessViews = map[int]*domain.SavedView{1: {ID: 1, Name: "Today"}}
+	if help := m.renderFullHelp(); !strings.Contains(help, "1-9         apply quick access view") {
+		t.Errorf("help should list quick access keys once views are set up:\n%s", help)
+	}
+
+	m.viewMode = projectView
+	help = m.renderFullHelp()
+	if !strings.Contains(help, "Projects:") || !strings.Contains(help, "new project") || strings.Contains(help, "Table View:") {
+		t.Errorf("project view help should list the project keys:\n%s", help)
+	}
+
+	m.viewMode = tableView
+	m.uiMode = searchingMode
+	if help := m.renderFullHelp(); !strings.Contains(help, "apply search") || strings.Contains(help, "delete task") {
+		t.Errorf("search help should only list search keys:\n%s", help)
+	}
+}
+
+func TestNotesEditor(t *testing.T) {
+	themeObj, err := theme.GetTheme("default")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+
+	edit := func(keys ...tea.KeyMsg) NotesEditorModel {
+		var m tea.Model = NewNotesEditorModel("Notes: Backend", "line one", 100, themeObj, theme.NewStyles(themeObj))
+		for _, k := range keys {
+			m, _ = m.Update(k)
+		}
+		return m.(NotesEditorModel)
+	}
+
+	typed := []tea.KeyMsg{
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("two")},
+	}
+
+	m := edit(append(typed, tea.KeyMsg{Type: tea.KeyCtrlS})...)
+	notes, saved := m.GetNotes()
+	if !saved || notes != "line one\ntwo" {
+		t.Errorf("GetNotes() = %q, %v, want %q, true", notes, saved, "line one\ntwo")
+	}
+
+	m = edit(append(typed, tea.KeyMsg{Type: tea.KeyEsc})...)
+	if _, saved := m.GetNotes(); saved {
+		t.Error("cancelled editor should not report its notes as saved")
+	}
+}
+
+func TestBulkTagInput(t *testing.T) {
+	m := newFormTestModel(t)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "Reference", IsLocked: true},
+		{ID: 2, Title: "Open"},
+	}
+	m.viewMode = tableView
+	m.multiSelect.enabled = true
+	m.multiSelect.selectedTasks = map[int64]bool{1: true, 2: true}
+
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'-'}})
+	m = updated.(Model)
+	if !m.bulkTag.active || !m.bulkTag.remove {
+		t.Fatal("- in multi-select should open the remove tag input")
+	}
+	if !strings.Contains(m.renderBulkTagInput(), "Remove tag from 2 selected task(s)") {
+		t.Errorf("unexpected overlay:\n%s", m.renderBulkTagInput())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.bulkTag.active || len(m.multiSelect.selectedTasks) != 2 {
+		t.Error("esc should close the input and keep the selection")
+	}
+
+	updated, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'+'}})
+	m = updated.(Model)
+	m.bulkTag.input.SetValue(" bug, ")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if cmd == nil || m.bulkTag.active || !m.loading {
+		t.Fatal("enter should close the input and dispatch the bulk tag command")
+	}
+	if len(m.multiSelect.selectedTasks) != 0 {
+		t.Errorf("selection should be cleared, got %v", m.multiSelect.selectedTasks)
+	}
+
+	msg := bulkResultMsg{action: "Added 'bug' to", succeeded: 1, skipped: 1}
+	if got := msg.summary(); got != "Added 'bug' to 1, skipped 1 locked" {
+		t.Errorf("summary() = %q", got)
+	}
+}
+
+func TestReadOnlyModeBlocksWriteKeys(t *testing.T) {
+	m := newFormTestModel(t)
+	m.SetReadOnly(true)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "First", Status: domain.StatusPending, Priority: domain.PriorityMedium},
+	}
+	m.updateTableRows()
+
+	for _, keys := range []string{"n", "e", "c", "d", "o", "*"} {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		got := updated.(Model)
+		if cmd != nil || got.uiMode != normalMode || got.reordering {
+			t.Errorf("%s should do nothing in read-only mode", keys)
+		}
+		if !strings.Contains(got.message, "Read-only") {
+			t.Errorf("%s: message = %q, want the read-only notice", keys, got.message)
+		}
+	}
+
+	if !strings.Contains(m.renderStatusBar(), "Read-only") {
+		t.Error("status bar should show read-only mode")
+	}
+	if strings.Contains(m.renderFullHelp(), "new task") {
+		t.Error("help should leave out disabled keys")
+	}
+}
+
+func TestTaskRowLabels(t *testing.T) {
+	m := newFormTestModel(t)
+	task := &domain.Task{ID: 1, Title: "Ship it", Status: domain.StatusInProgress, Priority: domain.PriorityHigh}
+
+	row := m.taskToRow(task)
+	if row[0] != "⚡ in_progress" || row[1] != "⬆ high" {
+		t.Errorf("default labels: got %q, %q", row[0], row[1])
+	}
+
+	m.styles.Labels = theme.Labels{
+		Status:         map[domain.Status]string{domain.StatusInProgress: "doing"},
+		PrioritySymbol: map[domain.Priority]string{domain.PriorityHigh: "!!"},
+	}
+	row = m.taskToRow(task)
+	if row[0] != "⚡ doing" || row[1] != "!! high" {
+		t.Errorf("custom labels: got %q, %q", row[0], row[1])
+	}
+	if task.Status != domain.StatusInProgress {
+		t.Error("labels must not change the stored status")
+	}
+
+	m.styles.Labels.Priority = map[domain.Priority]string{domain.PriorityUrgent: "fire"}
+	m.filter.Status = domain.StatusInProgress
+	m.filter.Priority = domain.PriorityUrgent
+	if summary := m.filterSummary(); summary != "Status: doing | Priority: fire" {
+		t.Errorf("filter summary labels: got %q", summary)
+	}
+}
+
+func TestTaskRowBlockers(t *testing.T) {
+	m := newFormTestModel(t)
+	blocked := &domain.Task{ID: 1, Title: "Deploy", Status: domain.StatusPending, Priority: domain.PriorityHigh, OpenBlockers: 2}
+	free := &domain.Task{ID: 2, Title: "Write docs", Status: domain.StatusPending, Priority: domain.PriorityLow}
+
+	if row := m.taskToRow(blocked); len(row) != len(m.columns) || row[7] != "⛔2" {
+		t.Errorf("blocked task: got %q", row)
+	}
+	if row := m.taskToRow(free); row[7] != "-" {
+		t.Errorf("task without open dependencies: got %q", row[7])
+	}
+	if m.columnWidth("blockers") != 0 {
+		t.Error("the blockers column should be hidden by default")
+	}
+}
+
+func TestOverdueGraceDays(t *testing.T) {
+	m := newFormTestModel(t)
+	m.SetOverdueGraceDays(2)
+	m.SetReadOnly(true)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	task := &domain.Task{ID: 1, Title: "Renew cert", Status: domain.StatusPending, Priority: domain.PriorityHigh, DueDate: &yesterday}
+
+	if due := m.taskToRow(task)[5]; due != "Yesterday" {
+		t.Errorf("a task within the grace days shows as %q, want Yesterday", due)
+	}
+
+	// the filter panel's overdue preset
+	m.filterPanel.items = m.buildFilterItems()
+	m.filterPanel.selectedItem = slices.IndexFunc(m.filterPanel.items, func(item filterItem) bool {
+		return item.filterType == "duedate" && item.value == "overdue"
+	})
+	updated, _ := m.applyFilterSelection()
+	dueTo := updated.(Model).filter.DueDateTo
+	if dueTo == nil || *dueTo >= yesterday.Format("2006-01-02") {
+		t.Errorf("overdue preset ends at %v, want before yesterday", dueTo)
+	}
+
+	// due:overdue typed into the search
+	m.uiMode = searchingMode
+	m.searchInput.SetValue("due:overdue")
+	_, cmd := m.updateSearchMode(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("the query should be parsed")
+	}
+	var parsed *queryParsedMsg
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = nil
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if msg, ok := msg.(queryParsedMsg); ok {
+			parsed = &msg
+		}
+	}
+	if parsed == nil || parsed.err != nil {
+		t.Fatalf("expected a parsed query, got %+v", parsed)
+	}
+	if dueTo := parsed.filter.DueDateTo; dueTo == nil || *dueTo >= yesterday.Format("2006-01-02") {
+		t.Errorf("due:overdue ends at %v, want before yesterday", dueTo)
+	}
+}
+
+func TestLoadingSpinner(t *testing.T) {
+	themeObj, err := theme.GetTheme("default")
+	if err != nil {
+		t.Fatalf("failed to load theme: %v", err)
+	}
+	m := NewModel(nil, nil, nil, nil, repository.TaskFilter{}, 20, themeObj, theme.NewStyles(themeObj))
+	m.width, m.height = 140, 40
+
+	if !strings.Contains(m.View(), "Loading...") {
+		t.Error("the first load should show the loading screen")
+	}
+
+	updated, _ := m.Update(tasksLoadedMsg{tasks: []*domain.Task{{ID: 1, Title: "Write docs", Status: domain.StatusPending, Priority: domain.PriorityLow}}, totalCount: 1})
+	m = updated.(Model)
+	if m.loading || !m.loaded {
+		t.Fatal("the first tasks should finish the initial load")
+	}
+
+	// a refresh keeps the table on screen with the spinner beside it
+	m.loading = true
+	view := m.View()
+	if !strings.Contains(view, "Write docs") || !strings.Contains(view, "Loading") {
+		t.Errorf("a refresh should keep the content and show the spinner, got:\n%s", view)
+	}
+
+	m.loading = false
+	if _, cmd := m.Update(m.spinner.Tick()); cmd != nil {
+		t.Error("the spinner should stop ticking once nothing loads")
+	}
+}
+
+func TestCycleTaskColor(t *testing.T) {
+	m := newFormTestModel(t)
+	task := &domain.Task{ID: 1, Title: "Hotfix", Status: domain.StatusPending, Priority: domain.PriorityHigh}
+	m.tasks = []*domain.Task{task}
+	m.updateTableRows()
+
+	for _, want := range []string{"red", "yellow"} {
+		updated, cmd := m.handleCycleColor()
+		m = updated.(Model)
+		if task.Color != want || cmd == nil {
+			t.Fatalf("got color %q, want %q", task.Color, want)
+		}
+	}
+
+	task.Color = "bright-white"
+	updated, _ := m.handleCycleColor()
+	m = updated.(Model)
+	if task.Color != "" {
+		t.Errorf("a color outside the cycle should go back to none, got %q", task.Color)
+	}
+
+	task.IsLocked = true
+	if _, cmd := m.handleCycleColor(); cmd != nil {
+		t.Error("locked tasks should keep their color")
+	}
+
+	if terminalColor("Red") != "1" || terminalColor("#ff0000") != "#ff0000" {
+		t.Error("color names should map to their ANSI codes")
+	}
+}
+
+func TestFilterPanelRemembersPositionAndSummarizes(t *testing.T) {
+	m := newFormTestModel(t)
+	m.hideSnoozed = true
+	m.filter.Status = domain.StatusPending
+	m.filter.PinnedOnly = true
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(Model)
+	if !strings.Contains(m.renderFilterPanel(), "Active: Status: pending | Pinned only") {
+		t.Errorf("expected the active filters in the panel, got:\n%s", m.renderFilterPanel())
+	}
+
+	for range 3 {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(Model)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(Model)
+	if m.filterPanel.selectedItem != 3 {
+		t.Errorf("the panel should reopen on item 3, got %d", m.filterPanel.selectedItem)
+	}
+
+	m.filter = repository.TaskFilter{}
+	if got := m.filterPanelSummary(); got != "none" {
+		t.Errorf("no filters: got %q", got)
+	}
+}
+
+func TestToggleReadyQueue(t *testing.T) {
+	m := newFormTestModel(t)
+	projectID := int64(3)
+	m.filter = repository.TaskFilter{ProjectID: &projectID, Priority: domain.PriorityHigh, SortBy: "title"}
+
+	updated, cmd := m.toggleReadyQueue()
+	m = updated.(Model)
+	if cmd == nil || m.filter.SortBy != "ready" || !m.filter.HideWaiting || m.filter.Priority != "" {
+		t.Fatalf("expected the ready filter, got %+v", m.filter)
+	}
+	if m.filter.ProjectID == nil || *m.filter.ProjectID != projectID {
+		t.Error("the ready queue should stay in the filtered project")
+	}
+	if !strings.Contains(m.filterSummary(), "Ready queue") {
+		t.Errorf("the header should name the ready queue, got %q", m.filterSummary())
+	}
+
+	updated, _ = m.toggleReadyQueue()
+	m = updated.(Model)
+	if m.readyReturn != nil || m.filter.SortBy != "title" || m.filter.Priority != domain.PriorityHigh {
+		t.Errorf("leaving should restore the previous filter, got %+v", m.filter)
+	}
+}
+
+func TestQuickSetStatusAndPriority(t *testing.T) {
+	m := newFormTestModel(t)
+	first := &domain.Task{ID: 1, Title: "First", Status: domain.StatusPending, Priority: domain.PriorityLow}
+	second := &domain.Task{ID: 2, Title: "Second", Status: domain.StatusInProgress, Priority: domain.PriorityLow}
+	locked := &domain.Task{ID: 3, Title: "Locked", Status: domain.StatusPending, Priority: domain.PriorityLow, IsLocked: true}
+	m.tasks = []*domain.Task{first, second, locked}
+	m.updateTableRows()
+	m.viewMode = detailView
+	m.selectedTask = first
+
+	alt := func(r rune) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}, Alt: true} }
+
+	updated, cmd := m.handleKeyPress(alt('x'))
+	m = updated.(Model)
+	if first.Status != domain.StatusCancelled || cmd == nil {
+		t.Errorf("alt+x should cancel the task, got %s", first.Status)
+	}
+	updated, cmd = m.handleKeyPress(alt('4'))
+	m = updated.(Model)
+	if first.Priority != domain.PriorityUrgent || cmd == nil {
+		t.Errorf("alt+4 should make the task urgent, got %s", first.Priority)
+	}
+
+	m.viewMode = tableView
+	m.multiSelect.enabled = true
+	m.multiSelect.selectedTasks = map[int64]bool{2: true, 3: true}
+	updated, cmd = m.handleKeyPress(alt('c'))
+	m = updated.(Model)
+	if cmd == nil || second.Status != domain.StatusCompleted || locked.Status != domain.StatusPending {
+		t.Errorf("alt+c on the selection = %s/%s, want the locked task skipped", second.Status, locked.Status)
+	}
+
+	if err := m.SetQuickKeys(map[string]string{"cancelled": "X", "High": "alt+h"}); err != nil {
+		t.Fatalf("SetQuickKeys() error = %v", err)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")}, m.keys.SetCancelled) || m.keys.quickPriority(alt('h')) != domain.PriorityHigh {
+		t.Error("the configured keys should be bound")
+	}
+	if help := m.renderFullHelp(); !strings.Contains(help, "X           set cancelled") {
+		t.Errorf("help should show the configured key:\n%s", help)
+	}
+
+	for _, keys := range []map[string]string{
+		{"cancelled": "j"},
+		{"urgent": "5"},
+		{"pending": "alt+x", "completed": "alt+x"},
+		{"blocked": "b"},
+	} {
+		m := newFormTestModel(t)
+		if err := m.SetQuickKeys(keys); err == nil {
+			t.Errorf("SetQuickKeys(%v) should be refused", keys)
+		}
+	}
+}
+
+func TestMarkCompleteAsksForBlockedTasks(t *testing.T) {
+	m := newFormTestModel(t)
+	blocked := &domain.Task{ID: 1, Title: "Deploy", Status: domain.StatusPending, OpenBlockers: 2}
+	free := &domain.Task{ID: 2, Title: "Docs", Status: domain.StatusPending}
+	m.tasks = []*domain.Task{blocked, free}
+	m.updateTableRows()
+	m.viewMode = detailView
+	m.selectedTask = blocked
+
+	press := func(r rune) tea.Cmd {
+		updated, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+		return cmd
+	}
+
+	press('c')
+	if !m.confirm.active || blocked.Status != domain.StatusPending {
+		t.Fatal("completing a blocked task should ask first")
+	}
+	if !strings.Contains(m.confirm.message, "2 unfinished") {
+		t.Errorf("confirm message = %q, want the blocker count", m.confirm.message)
+	}
+	updated, _ := m.updateConfirmDialog(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if blocked.Status != domain.StatusPending {
+		t.Error("declining should leave the task open")
+	}
+
+	press('c')
+	updated, cmd := m.updateConfirmDialog(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if cmd == nil || blocked.Status != domain.StatusCompleted {
+		t.Error("confirming should complete the task anyway")
+	}
+
+	m.selectedTask = free
+	if cmd := press('c'); cmd == nil || m.confirm.active || free.Status != domain.StatusCompleted {
+		t.Error("tasks without open blockers complete right away")
+	}
+}
+
+func TestSetStatusAsksForBlockedTasks(t *testing.T) {
+	m := newFormTestModel(t)
+	blocked := &domain.Task{ID: 1, Title: "Deploy", Status: domain.StatusPending, OpenBlockers: 1}
+	free := &domain.Task{ID: 2, Title: "Docs", Status: domain.StatusPending}
+	m.tasks = []*domain.Task{blocked, free}
+	m.updateTableRows()
+	m.viewMode = detailView
+	m.selectedTask = blocked
+
+	altC := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}, Alt: true}
+	updated, _ := m.handleKeyPress(altC)
+	m = updated.(Model)
+	if !m.confirm.active || blocked.Status != domain.StatusPending {
+		t.Fatal("alt+c on a blocked task should ask first")
+	}
+	updated, _ = m.updateConfirmDialog(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+
+	m.viewMode = tableView
+	m.multiSelect.enabled = true
+	m.multiSelect.selectedTasks = map[int64]bool{1: true, 2: true}
+	updated, _ = m.handleKeyPress(altC)
+	m = updated.(Model)
+	if !m.confirm.active || free.Status != domain.StatusPending {
+		t.Fatal("completing a selection with a blocked task should ask first")
+	}
+	if !strings.Contains(m.confirm.message, "1 of the 2 tasks") {
+		t.Errorf("confirm message = %q, want the blocked count", m.confirm.message)
+	}
+	updated, _ = m.updateConfirmDialog(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(Model)
+	if blocked.Status != domain.StatusPending || len(m.multiSelect.selectedTasks) != 2 {
+		t.Error("declining should leave the tasks and the selection alone")
+	}
+
+	updated, _ = m.handleKeyPress(altC)
+	m = updated.(Model)
+	updated, cmd := m.updateConfirmDialog(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(Model)
+	if cmd == nil || blocked.Status != domain.StatusCompleted || free.Status != domain.StatusCompleted {
+		t.Error("confirming should complete the whole selection")
+	}
+}
+
+func newKanbanTestModel(t *testing.T) Model {
+	t.Helper()
+	m := newFormTestModel(t)
+	m.tasks = []*domain.Task{
+		{ID: 1, Title: "Write spec", Status: domain.StatusPending, Priority: domain.PriorityMedium},
+		{ID: 2, Title: "Build API", Status: domain.StatusInProgress, Priority: domain.PriorityHigh},
+		{ID: 3, Title: "Review copy", Status: domain.StatusPending, Priority: domain.PriorityLow},
+		{ID: 4, Title: "Ship beta", Status: domain.StatusCompleted, Priority: domain.PriorityUrgent},
+	}
+	m.kanbanTasks = m.tasks
+	m.updateTableRows()
+	return m
+}
+
+// lists the filtered tasks, the rest of the interface is unused
+type kanbanTaskRepo struct {
+	repository.TaskRepository
+	tasks  []*domain.Task
+	filter repository.TaskFilter
+}
+
+func (r *kanbanTaskRepo) List(ctx context.Context, filter repository.TaskFilter) ([]*domain.Task, error) {
+	r.filter = filter
+	return r.tasks, nil
+}
+
+func TestKanbanLoadsEveryFilteredTask(t *testing.T) {
+	m := newKanbanTestModel(t)
+	m.kanbanTasks = nil
+	m.filter = repository.TaskFilter{Priority: domain.PriorityHigh, Limit: 4, Offset: 4}
+	repo := &kanbanTaskRepo{tasks: append(slices.Clone(m.tasks),
+		&domain.Task{ID: 5, Title: "Next page", Status: domain.StatusCancelled, Priority: domain.PriorityLow})}
+	m.repo = repo
+
+	m.table.SetCursor(1)
+	updated, cmd := m.toggleKanban()
+	m = updated.(Model)
+	if cmd == nil {
+		t.Fatal("opening the board should load the filtered tasks")
+	}
+	if card := m.getSelectedTask(); card == nil || card.ID != 2 {
+		t.Fatalf("the page should show until they load, got %v", card)
+	}
+
+	msg := cmd()
+	if repo.filter.Limit != 0 || repo.filter.Offset != 0 || repo.filter.Priority != domain.PriorityHigh {
+		t.Errorf("the board should list the whole filter, got %+v", repo.filter)
+	}
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	if cards := kanbanBuckets(m.kanbanTasks)[3]; len(cards) != 1 || cards[0].ID != 5 {
+		t.Errorf("the cancelled column should show the task from the next page, got %v", cards)
+	}
+	if card := m.getSelectedTask(); card == nil || card.ID != 2 {
+		t.Errorf("the selected card should stay selected across the load, got %v", card)
+	}
+
+	m.kanban = kanbanCursor{column: 3}
+	updated, _ = m.toggleKanban()
+	m = updated.(Model)
+	if m.viewMode != tableView || m.table.Cursor() != 1 || m.kanbanTasks != nil {
+		t.Errorf("a card off the page should leave the table cursor alone, got cursor %d", m.table.Cursor())
+	}
+
+	updated, _ = m.Update(msg)
+	if updated.(Model).kanbanTasks != nil {
+		t.Error("tasks loaded after leaving the board should be dropped")
+	}
+}
+
+func TestKanbanBucketsByStatus(t *testing.T) {
+	m := newKanbanTestModel(t)
+
+	buckets := kanbanBuckets(m.tasks)
+	want := [][]int64{{1, 3}, {2}, {4}, {}}
+	for i, ids := range want {
+		if len(buckets[i]) != len(ids) {
+			t.Fatalf("column %s has %d card(s), want %d", kanbanColumns[i], len(buckets[i]), len(ids))
+		}
+		for j, id := range ids {
+			if buckets[i][j].ID != id {
+				t.Errorf("column %s card %d = #%d, want #%d", kanbanColumns[i], j, buckets[i][j].ID, id)
+			}
+		}
+	}
+
+	m.width, m.height = 120, 40
+	m.viewMode = kanbanView
+	view := m.View()
+	headers := []string{
+		m.styles.StatusLabel(domain.StatusPending) + " (2)",
+		m.styles.StatusLabel(domain.StatusInProgress) + " (1)",
+		m.styles.StatusLabel(domain.StatusCompleted) + " (1)",
+		m.styles.StatusLabel(domain.StatusCancelled) + " (0)",
+	}
+	for _, text := range append(headers, "Write spec", "Ship beta", "No tasks") {
+		if !strings.Contains(view, text) {
+			t.Errorf("board is missing %q", text)
+		}
+	}
+	if strings.Index(view, "Write spec") > strings.Index(view, "Review copy") {
+		t.Error("cards should keep the order of the task list")
+	}
+}
+
+func TestKanbanNavigationWrapsAroundColumns(t *testing.T) {
+	m := newKanbanTestModel(t)
+
+	press := func(msg tea.KeyMsg) tea.Cmd {
+		updated, cmd := m.handleKeyPress(msg)
+		m = updated.(Model)
+		return cmd
+	}
+	left := tea.KeyMsg{Type: tea.KeyLeft}
+	right := tea.KeyMsg{Type: tea.KeyRight}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+
+	m.table.SetCursor(2)
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m.viewMode != kanbanView {
+		t.Fatal("b should open the kanban board")
+	}
+	if card := m.getSelectedTask(); card == nil || card.ID != 3 {
+		t.Fatalf("board should start on the task selected in the table, got %v", card)
+	}
+
+	press(left)
+	if m.kanban.column != len(kanbanColumns)-1 || m.getSelectedTask() != nil {
+		t.Errorf("left from the first column should wrap to the empty last one, got column %d", m.kanban.column)
+	}
+	press(right)
+	if m.kanban.column != 0 {
+		t.Errorf("right from the last column should wrap to the first, got column %d", m.kanban.column)
+	}
+	if card := m.getSelectedTask(); card == nil || card.ID != 3 {
+		t.Errorf("the row should be kept where the column allows it, got %v", card)
+	}
+
+	press(right)
+	if card := m.getSelectedTask(); card == nil || card.ID != 2 {
+		t.Errorf("a shorter column should clamp the row, got %v", card)
+	}
+	press(down)
+	if m.kanban.row != 0 {
+		t.Errorf("down past the last card should stay put, got row %d", m.kanban.row)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.viewMode != tableView || m.table.Cursor() != 1 {
+		t.Errorf("esc should go back to the table on the selected card, got view %d cursor %d", m.viewMode, m.table.Cursor())
+	}
+}
+
+func TestKanbanMoveCardChangesStatus(t *testing.T) {
+	m := newKanbanTestModel(t)
+	m.viewMode = kanbanView
+
+	press := func(keys string) tea.Cmd {
+		updated, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		m = updated.(Model)
+		return cmd
+	}
+
+	if cmd := press("<"); cmd != nil || m.tasks[0].Status != domain.StatusPending {
+		t.Error("a card in the first column has nowhere to go left")
+	}
+
+	if cmd := press(">"); cmd == nil || m.tasks[0].Status != domain.StatusInProgress {
+		t.Fatalf("> should move the card to in progress, got %s", m.tasks[0].Status)
+	}
+	if card := m.getSelectedTask(); card == nil || card.ID != 1 {
+		t.Errorf("the cursor should follow the moved card, got %v", card)
+	}
+
+	if cmd := press(">"); cmd == nil || m.tasks[0].Status != domain.StatusCompleted {
+		t.Errorf("> should complete the card, got %s", m.tasks[0].Status)
+	}
+}
+
+// records the task Update is called with, the rest of the interface is unused
+type titleEditTaskRepo struct {
+	repository.TaskRepository
+	updated *domain.Task
+}
+
+func (r *titleEditTaskRepo) UpdateWithNext(ctx context.Context, task *domain.Task) (*domain.Task, error) {
+	r.updated = task
+	return nil, nil
+}
+
+func TestInlineTitleEdit(t *testing.T) {
+	repo := &titleEditTaskRepo{}
+	due := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	task := &domain.Task{ID: 7, Title: "Fix login", Status: domain.StatusInProgress, Priority: domain.PriorityHigh, Tags: []string{"auth"}, DueDate: &due}
+
+	newModel := func() Model {
+		m := newFormTestModel(t)
+		m.repo = repo
+		m.tasks = []*domain.Task{task}
+		m.updateTableRows()
+		return m
+	}
+	send := func(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+		updated, cmd := m.Update(msg)
+		return updated.(Model), cmd
+	}
+	runes := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+	t.Run("enter saves just the title", func(t *testing.T) {
+		m := newModel()
+		m, _ = send(m, runes("a"))
+		if !m.titleEdit.active || m.titleEdit.input.Value() != "Fix login" {
+			t.Fatalf("a should open the title edit with the current title, got %q", m.titleEdit.input.Value())
+		}
+		if m.editForm.active {
+			t.Error("the inline edit should not open the edit form")
+		}
+
+		m, _ = send(m, runes(" page"))
+		updated, cmd := m.updateTitleEdit(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+		if m.titleEdit.active || cmd == nil {
+			t.Fatal("enter should close the edit and save")
+		}
+		msg := cmd()
+		if _, ok := msg.(taskUpdatedMsg); !ok {
+			t.Fatalf("expected taskUpdatedMsg, got %T", msg)
+		}
+		if repo.updated == nil || repo.updated.Title != "Fix login page" {
+			t.Fatalf("saved %+v, want the new title", repo.updated)
+		}
+		if repo.updated.Status != domain.StatusInProgress || repo.updated.Priority != domain.PriorityHigh ||
+			repo.updated.DueDate != &due || len(repo.updated.Tags) != 1 {
+			t.Error("everything but the title should be saved as it was")
+		}
+
+		if m, _ = send(m, runes("j")); m.titleEdit.active || m.titleEdit.input.Value() == "Fix login pagej" {
+			t.Error("keys should go back to the table after saving")
+		}
+	})
+
+	t.Run("esc cancels without saving", func(t *testing.T) {
+		repo.updated = nil
+		m := newModel()
+		m, _ = send(m, runes("a"))
+		m, _ = send(m, runes("!!"))
+		m, cmd := send(m, tea.KeyMsg{Type: tea.KeyEsc})
+		if m.titleEdit.active || cmd != nil || repo.updated != nil {
+			t.Error("esc should close the edit without saving")
+		}
+		if task.Title != "Fix login" {
+			t.Errorf("the loaded task changed to %q", task.Title)
+		}
+	})
+}
+
+func TestFitColumnsClampsToWindowWidth(t *testing.T) {
+	columns := config.TableColumns(map[string]config.ColumnConfig{"tags": {Hidden: true}})
+
+	total := func(fitted []table.Column) int {
+		sum := 0
+		for _, column := range fitted {
+			if column.Width > 0 {
+				sum += column.Width + columnPadding
+			}
+		}
+		return sum
+	}
+
+	unlimited := fitColumns(columns, 0)
+	if unlimited[2].Width != 40 || unlimited[4].Width != 0 {
+		t.Errorf("without a limit title = %d, tags = %d, want 40 and hidden", unlimited[2].Width, unlimited[4].Width)
+	}
+
+	fitted := fitColumns(columns, 80)
+	if got := total(fitted); got > 80 {
+		t.Errorf("columns take %d cells, want at most 80", got)
+	}
+	if fitted[4].Width != 0 {
+		t.Error("a hidden column should stay hidden")
+	}
+	if fitted[2].Width >= 40 || fitted[5].Width != 12 {
+		t.Errorf("the widest column should give way first, got title %d, due %d", fitted[2].Width, fitted[5].Width)
+	}
+
+	tiny := fitColumns(columns, 10)
+	for _, column := range tiny {
+		if column.Width != 0 && column.Width < config.MinColumnWidth {
+			t.Errorf("%s shrank to %d, below the minimum", column.Title, column.Width)
+		}
+	}
+}
+
+func TestToggleColumnSavesVisibility(t *testing.T) {
+	m := newFormTestModel(t)
+	var saved map[string]config.ColumnConfig
+	m.SetColumnSaver(func(columns map[string]config.ColumnConfig) error { saved = columns; return nil })
+	m.SetColumns(map[string]config.ColumnConfig{"title": {Width: 50}})
+	m.tasks = []*domain.Task{{ID: 1, Title: "Tagged", Status: domain.StatusPending, Priority: domain.PriorityMedium, Tags: []string{"bug"}}}
+	m.updateTableRows()
+
+	press := func(keys string) tea.Cmd {
+		updated, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)})
+		m = updated.(Model)
+		return cmd
+	}
+
+	press("|")
+	if !m.columnPending || !strings.Contains(m.message, "5 ✓ Tags") {
+		t.Fatalf("| should ask for a column, message = %q", m.message)
+	}
+	cmd := press("5")
+	if m.columnPending || m.columnWidth("tags") != 0 || cmd == nil {
+		t.Fatal("5 should hide the tags column and save")
+	}
+	cmd()
+	if !saved["tags"].Hidden || saved["title"].Width != 50 || saved["status"].Width != 0 {
+		t.Errorf("saved %+v, want tags hidden, the title width kept and defaults left at 0", saved)
+	}
+	if strings.Contains(m.table.View(), "bug") {
+		t.Error("the hidden column should not be rendered")
+	}
+
+	press("|")
+	press("3")
+	if m.columnWidth("title") == 0 || m.message != "The Title column is always shown" {
+		t.Error("the title column can't be hidden")
+	}
+
+	press("|")
+	if cmd := press("x"); cmd != nil || m.columnPending {
+		t.Error("any other key should cancel without a change")
+	}
+}
+
+type flagTaskRepo struct {
+	repository.TaskRepository
+}
+
+func (flagTaskRepo) SetPinned(ctx context.Context, id int64, pinned bool) error       { return nil }
+func (flagTaskRepo) SetLocked(ctx context.Context, id int64, locked bool) error       { return nil }
+func (flagTaskRepo) SetSnoozed(ctx context.Context, id int64, until *time.Time) error { return nil }
+
+func TestFlagCommandsLeaveLoadedTaskAlone(t *testing.T) {
+	ctx := context.Background()
+	task := &domain.Task{ID: 3, Title: "Renew cert"}
+	until := time.Now().AddDate(0, 0, 1)
+
+	pinned := setTaskPinnedCmd(ctx, flagTaskRepo{}, task, true)().(taskPinnedMsg)
+	locked := setTaskLockedCmd(ctx, flagTaskRepo{}, task, true)().(taskLockedMsg)
+	snoozed := setTaskSnoozedCmd(ctx, flagTaskRepo{}, task, &until)().(taskSnoozedMsg)
+
+	if !pinned.task.IsPinned || !locked.task.IsLocked || snoozed.task.SnoozedUntil == nil {
+		t.Error("the messages should carry the changed task")
+	}
+	if task.IsPinned || task.IsLocked || task.SnoozedUntil != nil {
+		t.Errorf("the loaded task changed: %+v", task)
+	}
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"task-management/internal/theme"
+)
+
+// NotesEditorModel is a minimal multi-line editor, used when there is no
+// $EDITOR to hand notes off to
+type NotesEditorModel struct {
+	theme  *theme.Theme
+	styles *theme.Styles
+
+	title     string
+	input     textarea.Model
+	charLimit int
+
+	width    int
+	height   int
+	quitting bool
+	saved    bool
+}
+
+func NewNotesEditorModel(title, notes string, charLimit int, themeObj *theme.Theme, styles *theme.Styles) NotesEditorModel {
+	input := textarea.New()
+	input.Placeholder = "Notes (markdown supported)"
+	input.CharLimit = charLimit
+	input.ShowLineNumbers = false
+	input.SetWidth(70)
+	input.SetHeight(15)
+	input.SetValue(notes)
+	input.Focus()
+
+	return NotesEditorModel{
+		theme:     themeObj,
+		styles:    styles,
+		title:     title,
+		input:     input,
+		charLimit: charLimit,
+		width:     100,
+		height:    30,
+	}
+}
+
+func (m NotesEditorModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m NotesEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		// leave room for the border, title and help
+		if msg.Height > 10 {
+			m.input.SetHeight(min(msg.Height-10, 25))
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "ctrl+s":
+			m.saved = true
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m NotesEditorModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString(m.styles.TUITitle.Render(m.title))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	count := fmt.Sprintf("%d/%d characters", m.input.Length(), m.charLimit)
+	b.WriteString(m.styles.TUISubtitle.Render(count))
+	b.WriteString("\n")
+
+	sep := strings.Repeat("─", 70)
+	b.WriteString(m.styles.Separator.Render(sep))
+	b.WriteString("\n")
+	b.WriteString(m.styles.TUIHelp.Render("Ctrl+S: save • Esc: cancel"))
+
+	contentStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.BorderColor)).
+		Padding(1, 2).
+		Width(80)
+
+	return contentStyle.Render(b.String())
+}
+
+// the edited notes, ok is false when the editor was cancelled
+func (m NotesEditorModel) GetNotes() (notes string, ok bool) {
+	return m.input.Value(), m.saved
+}
@@ -16,13 +16,15 @@ type (
 	}
 
 	projectCreatedMsg struct {
-		project *domain.Project
-		err     error
+		project   *domain.Project
+		duplicate *domain.Project
+		err       error
 	}
 
 	projectUpdatedMsg struct {
-		project *domain.Project
-		err     error
+		project   *domain.Project
+		duplicate *domain.Project
+		err       error
 	}
 
 	projectDeletedMsg struct {
@@ -36,6 +38,11 @@ type (
 		taskCount int
 		err       error
 	}
+
+	projectTaskCountsMsg struct {
+		counts map[int64]int
+		err    error
+	}
 )
 
 func fetchProjectsCmd(ctx context.Context, repo repository.ProjectRepository, filter repository.ProjectFilter) tea.Cmd {
@@ -52,7 +59,7 @@ func createProjectCmd(ctx context.Context, repo repository.ProjectRepository, pr
 	return func() tea.Msg {
 		err := repo.Create(ctx, project)
 		if err != nil {
-			return projectCreatedMsg{err: err}
+			return projectCreatedMsg{err: err, duplicate: repository.DuplicateProject(ctx, repo, project.Name, err)}
 		}
 		return projectCreatedMsg{project: project}
 	}
@@ -62,7 +69,7 @@ func updateProjectCmd(ctx context.Context, repo repository.ProjectRepository, pr
 	return func() tea.Msg {
 		err := repo.Update(ctx, project)
 		if err != nil {
-			return projectUpdatedMsg{err: err}
+			return projectUpdatedMsg{err: err, duplicate: repository.DuplicateProject(ctx, repo, project.Name, err)}
 		}
 		return projectUpdatedMsg{project: project}
 	}
@@ -92,6 +99,21 @@ func archiveProjectCmd(ctx context.Context, repo repository.ProjectRepository, p
 	}
 }
 
+// task counts of all the projects, for sorting the tree by task count
+func fetchProjectTaskCountsCmd(ctx context.Context, repo repository.ProjectRepository, projects []*domain.Project) tea.Cmd {
+	return func() tea.Msg {
+		counts := make(map[int64]int, len(projects))
+		for _, project := range projects {
+			count, err := repo.GetTaskCount(ctx, project.ID)
+			if err != nil {
+				return projectTaskCountsMsg{err: err}
+			}
+			counts[project.ID] = count
+		}
+		return projectTaskCountsMsg{counts: counts}
+	}
+}
+
 func fetchProjectStatsCmd(ctx context.Context, repo repository.ProjectRepository, projectID int64) tea.Cmd {
 	return func() tea.Msg {
 		taskCount, err := repo.GetTaskCount(ctx, projectID)
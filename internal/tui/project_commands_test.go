@@ -104,6 +104,14 @@ func (m *mockProjectRepository) Unarchive(ctx context.Context, id int64) error {
 	return nil
 }
 
+func (m *mockProjectRepository) ArchiveBatch(ctx context.Context, ids []int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockProjectRepository) UnarchiveBatch(ctx context.Context, ids []int64) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockProjectRepository) SetFavorite(ctx context.Context, id int64, isFavorite bool) error {
 	return nil
 }
@@ -132,6 +140,10 @@ func (m *mockProjectRepository) GetTaskCountByStatus(ctx context.Context, projec
 	return m.statsByStatus, nil
 }
 
+func (m *mockProjectRepository) GetTimeToDone(ctx context.Context, projectID int64) (*domain.TimeToDoneStats, error) {
+	return domain.NewTimeToDoneStats(nil), nil
+}
+
 func (m *mockProjectRepository) Count(ctx context.Context, filter repository.ProjectFilter) (int64, error) {
 	return int64(len(m.projects)), nil
 }
@@ -152,6 +164,37 @@ func (m *mockProjectRepository) ValidateAliasUniqueness(ctx context.Context, ali
 	return nil
 }
 
+func (m *mockProjectRepository) Merge(ctx context.Context, sourceID int64, destID int64, reparentChildren bool) (*repository.ProjectMergeResult, error) {
+	return &repository.ProjectMergeResult{}, nil
+}
+
+func (m *mockProjectRepository) EnsureInbox(ctx context.Context) (*domain.Project, error) {
+	for _, p := range m.projects {
+		if p.IsInbox() {
+			return p, nil
+		}
+	}
+	inbox := domain.NewProject(domain.InboxProjectName)
+	inbox.Inbox = true
+	if err := m.Create(ctx, inbox); err != nil {
+		return nil, err
+	}
+	return inbox, nil
+}
+
+func (m *mockProjectRepository) GetInbox(ctx context.Context) (*domain.Project, error) {
+	for _, p := range m.projects {
+		if p.IsInbox() {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockProjectRepository) RollSprint(ctx context.Context, id int64, now time.Time) (*repository.SprintRollResult, error) {
+	return &repository.SprintRollResult{}, nil
+}
+
 func TestFetchProjectsCmd_Success(t *testing.T) {
 	now := time.Now()
 	mockRepo := &mockProjectRepository{
@@ -240,7 +240,7 @@ func (m Model) renderProjectDetails(width int) string {
 		case domain.ProjectStatusCompleted:
 			statusStyle = m.styles.Success
 	}
-	output.WriteString(statusStyle.Render(string(project.Status)))
+	output.WriteString(statusStyle.Render(m.styles.ProjectStatusLabel(project.Status)))
 	output.WriteString("\n")
 
 	if project.ParentID != nil {
@@ -345,6 +345,13 @@ func (m Model) renderProjectDetails(width int) string {
 		output.WriteString(m.styles.DetailValue.Render(fmt.Sprintf("%d", stats.taskCount)))
 		output.WriteString("\n")
 
+		if stats.taskCount > 0 {
+			percent := domain.CompletionPercent(stats.stats[domain.StatusCompleted], stats.stats[domain.StatusCancelled], stats.taskCount, m.cancelledTreatment)
+			output.WriteString(m.styles.DetailLabel.Render("  Done: "))
+			output.WriteString(m.styles.DetailValue.Render(fmt.Sprintf("%d%%", int(percent))))
+			output.WriteString("\n")
+		}
+
 		if len(stats.stats) > 0 {
 			output.WriteString("\n")
 			output.WriteString(m.styles.DetailLabel.Render("  By Status:"))
@@ -360,7 +367,7 @@ func (m Model) renderProjectDetails(width int) string {
 			for _, status := range statusOrder {
 				if count, exists := stats.stats[status]; exists && count > 0 {
 					statusStyle := m.styles.GetStatusStyle(status)
-					statusText := statusStyle.Render(string(status))
+					statusText := statusStyle.Render(m.styles.StatusLabel(status))
 					output.WriteString(fmt.Sprintf("    %s: %s\n", statusText, m.styles.DetailValue.Render(fmt.Sprintf("%d", count))))
 				}
 			}
@@ -50,3 +50,12 @@ func recordSearchCmd(ctx context.Context, repo repository.SearchHistoryRepositor
 		return searchRecordedMsg{entry: entry}
 	}
 }
+
+// records a search unless the database is read-only, which keeps the history
+// as it is
+func (m Model) recordSearch(entry *domain.SearchHistory) tea.Cmd {
+	if m.readOnly {
+		return nil
+	}
+	return recordSearchCmd(m.ctx, m.searchHistoryRepo, entry)
+}
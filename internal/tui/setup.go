@@ -10,7 +10,6 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"task-management/internal/config"
-	"task-management/internal/display"
 	"task-management/internal/domain"
 	"task-management/internal/theme"
 )
@@ -245,7 +244,7 @@ func (m SetupModel) renderPreview(styles *theme.Styles, width int) string {
 func (m SetupModel) renderTaskPreview(styles *theme.Styles, task *domain.Task, width int) string {
 	var b strings.Builder
 
-	statusIcon := display.GetStatusIcon(task.Status)
+	statusIcon := styles.StatusSymbol(task.Status)
 	titleLine := fmt.Sprintf("%s %s", statusIcon, task.Title)
 	titleStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(m.currentTheme.TextPrimary)).
@@ -253,14 +252,14 @@ func (m SetupModel) renderTaskPreview(styles *theme.Styles, task *domain.Task, w
 	b.WriteString(titleStyle.Render(titleLine))
 	b.WriteString("\n")
 
-	priorityIcon := display.GetPriorityIcon(task.Priority)
+	priorityIcon := styles.PrioritySymbol(task.Priority)
 	priorityStyle := styles.GetPriorityTextStyle(task.Priority)
 	statusStyle := styles.GetStatusStyle(task.Status)
 
 	infoLine := fmt.Sprintf("  %s %s | %s",
 		priorityIcon,
-		priorityStyle.Render(string(task.Priority)),
-		statusStyle.Render(string(task.Status)),
+		priorityStyle.Render(styles.PriorityLabel(task.Priority)),
+		statusStyle.Render(styles.StatusLabel(task.Status)),
 	)
 	b.WriteString(infoLine)
 	b.WriteString("\n")
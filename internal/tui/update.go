@@ -2,33 +2,103 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"task-management/internal/display"
 	"task-management/internal/domain"
-	"task-management/internal/fuzzy"
 	"task-management/internal/query"
 	"task-management/internal/repository"
+	"task-management/internal/theme"
 )
 
+// Update routes the message and then fits the table to whatever chrome the
+// new state renders
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	wasLoading := m.loading
+	model, cmd := m.route(msg)
+	if updated, ok := model.(Model); ok {
+		updated.resizeTable()
+		// the spinner only ticks while something loads, so a load starting
+		// anywhere in route starts it again
+		if updated.loading && !wasLoading {
+			cmd = tea.Batch(cmd, updated.spinner.Tick)
+		}
+		if wasLoading && !updated.loading {
+			updated.loaded = true
+		}
+		return updated, cmd
+	}
+	return model, cmd
+}
+
+func (m Model) route(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = size.Width
+		m.height = size.Height
+		m.tooSmall = size.Width < minTerminalWidth || size.Height < minTerminalHeight
+		m.applyColumns()
+	}
+
+	// handled before any mode so the ticks keep coming while a form, dialog
+	// or search is open. only the rows are rebuilt, nothing is refetched
+	if _, ok := msg.(relativeTimeTickMsg); ok {
+		m.updateTableRows()
+		return m, relativeTimeTickCmd()
+	}
+
+	// dropping the tick once loading is done stops the spinner
+	if tick, ok := msg.(spinner.TickMsg); ok {
+		if !m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(tick)
+		return m, cmd
+	}
+
 	if m.confirm.active {
 		return m.updateConfirmDialog(msg)
 	}
 
+	// the picker closes before the view or the counts come back, so their
+	// results are routed there explicitly
+	switch msg.(type) {
+	case viewAppliedMsg, viewCountsMsg:
+		return m.updateViewPicker(msg)
+	}
 	if m.viewPicker.active {
 		return m.updateViewPicker(msg)
 	}
 
+	if m.tagCloud.active {
+		return m.updateTagCloud(msg)
+	}
+
+	if m.inboxCapture.active {
+		return m.updateInboxCapture(msg)
+	}
+
+	if m.titleEdit.active {
+		return m.updateTitleEdit(msg)
+	}
+
+	if m.bulkTag.active {
+		return m.updateBulkTagInput(msg)
+	}
+
 	if m.projectPicker.active {
 		return m.updateProjectPicker(msg)
 	}
@@ -52,9 +122,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m.updateNormalMode(msg)
 }
 
+// opens a confirm dialog and, for destructive ones, starts the inactivity timer
+func (m *Model) openConfirm(dialog confirmDialog) tea.Cmd {
+	dialog.active = true
+	m.confirm = dialog
+	return m.confirmTimeoutCmd()
+}
+
+// (re)starts the auto-cancel countdown, an older countdown still running is
+// ignored because its seq no longer matches
+func (m *Model) confirmTimeoutCmd() tea.Cmd {
+	m.confirmSeq++
+	if m.confirmTimeout <= 0 || m.confirm.confirmWord == "" {
+		return nil
+	}
+	seq := m.confirmSeq
+	return tea.Tick(m.confirmTimeout, func(time.Time) tea.Msg {
+		return confirmTimeoutMsg{seq: seq}
+	})
+}
+
 func (m Model) updateConfirmDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case confirmTimeoutMsg:
+		if msg.seq == m.confirmSeq {
+			m.confirm.active = false
+			m.message = "Confirmation timed out, nothing was changed"
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		if m.confirm.confirmWord != "" {
+			return m.updateTypedConfirm(msg)
+		}
+
 		switch msg.String() {
 		case "y", "Y":
 			m.confirm.active = false
@@ -69,6 +170,32 @@ func (m Model) updateConfirmDialog(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// destructive dialogs only confirm once the confirm word is typed and
+// submitted, a stray y or enter does nothing
+func (m Model) updateTypedConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.confirm.active = false
+		return m, nil
+
+	case tea.KeyEnter:
+		if strings.EqualFold(strings.TrimSpace(m.confirm.typed), m.confirm.confirmWord) {
+			m.confirm.active = false
+			return m, m.confirm.onConfirm(&m)
+		}
+
+	case tea.KeyBackspace:
+		if runes := []rune(m.confirm.typed); len(runes) > 0 {
+			m.confirm.typed = string(runes[:len(runes)-1])
+		}
+
+	case tea.KeyRunes, tea.KeySpace:
+		m.confirm.typed += string(msg.Runes)
+	}
+
+	return m, m.confirmTimeoutCmd()
+}
+
 func (m Model) updateProjectPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -110,50 +237,18 @@ func (m *Model) getVisiblePickerProjects() []*domain.Project {
 	return m.projectPicker.projects
 }
 
-func (m *Model) lookupProjectByFuzzyName(ctx context.Context, searchName string, threshold int) (*int64, error) {
+// matches names and aliases, see query.ResolveProject
+func (m *Model) lookupProjectByFuzzyName(ctx context.Context, searchName string) (*int64, error) {
 	if strings.TrimSpace(searchName) == "" {
 		return nil, fmt.Errorf("search name cannot be empty")
 	}
 
-	filter := repository.ProjectFilter{
-		ExcludeArchived: true,
-	}
-
-	projects, err := m.projectRepo.List(ctx, filter)
+	resolution, err := query.ResolveProject(ctx, m.projectRepo, searchName, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list projects: %w", err)
-	}
-
-	if len(projects) == 0 {
-		return nil, fmt.Errorf("no matching project found for '%s'", searchName)
-	}
-
-	type projectWithScore struct {
-		project *domain.Project
-		score   int
-	}
-
-	scoredProjects := make([]projectWithScore, 0, len(projects))
-	for _, proj := range projects {
-		score := fuzzy.Match(searchName, proj.Name)
-		if score >= threshold {
-			scoredProjects = append(scoredProjects, projectWithScore{
-				project: proj,
-				score:   score,
-			})
-		}
-	}
-
-	if len(scoredProjects) == 0 {
-		return nil, fmt.Errorf("no matching project found for '%s' (threshold: %d)", searchName, threshold)
+		return nil, err
 	}
 
-	sort.Slice(scoredProjects, func(i, j int) bool {
-		return scoredProjects[i].score > scoredProjects[j].score
-	})
-
-	bestMatch := scoredProjects[0].project
-	return &bestMatch.ID, nil
+	return &resolution.Project.ID, nil
 }
 
 func (m *Model) lookupProjectID(ctx context.Context, projectStr string) (*int64, error) {
@@ -169,18 +264,44 @@ func (m *Model) lookupProjectID(ctx context.Context, projectStr string) (*int64,
 		return &project.ID, nil
 	}
 
-	project, err := m.projectRepo.GetByName(ctx, projectStr)
+	resolution, err := query.ResolveProject(ctx, m.projectRepo, projectStr, false)
 	if err != nil {
-		return nil, fmt.Errorf("project '%s' not found: %w", projectStr, err)
+		return nil, err
 	}
 
-	return &project.ID, nil
+	return &resolution.Project.ID, nil
 }
 
 func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case searchDebounceMsg:
+		if msg.seq != m.searchSeq || !m.incrementalSearch {
+			return m, nil
+		}
+		return m, m.runIncrementalSearch()
+
+	case incrementalSearchMsg:
+		if msg.seq != m.searchSeq {
+			return m, nil
+		}
+		if m.cancelSearch != nil {
+			m.cancelSearch()
+			m.cancelSearch = nil
+		}
+		if msg.err != nil {
+			if !errors.Is(msg.err, context.Canceled) {
+				m.err = msg.err
+			}
+			return m, nil
+		}
+		m.tasks = msg.tasks
+		m.totalCount = msg.totalCount
+		m.err = nil
+		m.updateTableRows()
+		return m, nil
+
 	case tea.KeyMsg:
 		if m.historyDropdown.active {
 			switch msg.String() {
@@ -204,15 +325,11 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "enter":
 				if m.historyDropdown.cursor < len(m.searchHistory) {
 					selected := m.searchHistory[m.historyDropdown.cursor]
-					m.searchInput.SetValue(selected.QueryText)
-
-					if selected.SearchMode == domain.SearchModeFuzzy {
-						m.fuzzyMode = true
-						if selected.FuzzyThreshold != nil {
-							m.fuzzyThreshold = *selected.FuzzyThreshold
-						}
-					} else {
-						m.fuzzyMode = false
+					m.searchInput.SetValue(searchHistoryInput(selected))
+
+					m.fuzzyMode = selected.SearchMode == domain.SearchModeFuzzy
+					if m.fuzzyMode && selected.FuzzyThreshold != nil {
+						m.fuzzyThreshold = *selected.FuzzyThreshold
 					}
 
 					m.historyDropdown.active = false
@@ -233,6 +350,7 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.historyDropdown.cursor = 0
 				return m, nil
 			}
+			m.stopIncrementalSearch()
 			m.uiMode = normalMode
 			m.searchInput.Blur()
 			return m, nil
@@ -248,13 +366,25 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case msg.String() == "f" || msg.String() == "F":
 			m.fuzzyMode = !m.fuzzyMode
+			if m.incrementalSearch {
+				return m, m.scheduleIncrementalSearch()
+			}
 			return m, nil
 
+		case msg.String() == "ctrl+t":
+			m.incrementalSearch = !m.incrementalSearch
+			if !m.incrementalSearch {
+				m.stopIncrementalSearch()
+				return m, nil
+			}
+			return m, m.scheduleIncrementalSearch()
+
 		case msg.String() == "?":
 			m.showQueryHelp = !m.showQueryHelp
 			return m, nil
 
 		case msg.Type == tea.KeyEnter:
+			m.stopIncrementalSearch()
 			searchQuery := m.searchInput.Value()
 
 			if query.IsQueryLanguage(searchQuery) {
@@ -262,7 +392,8 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.queryString = searchQuery
 
 				converterCtx := &query.ConverterContext{
-					ProjectRepo: m.projectRepo,
+					ProjectRepo:      m.projectRepo,
+					OverdueGraceDays: m.overdueGraceDays,
 				}
 				m.uiMode = normalMode
 				m.searchInput.Blur()
@@ -273,7 +404,7 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 					SearchMode: domain.SearchModeText,
 					QueryType:  domain.QueryTypeQueryLanguage,
 				}
-				recordCmd := recordSearchCmd(m.ctx, m.searchHistoryRepo, historyEntry)
+				recordCmd := m.recordSearch(historyEntry)
 
 				return m, tea.Batch(parseQueryLanguageCmd(m.ctx, searchQuery, converterCtx), recordCmd)
 			}
@@ -294,8 +425,7 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				ctx := context.Background()
 
 				if mention.Fuzzy {
-					fuzzyThreshold := 60
-					projectID, err := m.lookupProjectByFuzzyName(ctx, mention.Name, fuzzyThreshold)
+					projectID, err := m.lookupProjectByFuzzyName(ctx, mention.Name)
 					if err != nil {
 						m.err = err
 						m.uiMode = normalMode
@@ -325,6 +455,7 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.filter.SearchMode = "fuzzy"
 					m.filter.SearchQuery = searchQuery
 					m.filter.FuzzyThreshold = m.fuzzyThreshold
+					m.filter.FuzzyAlgorithm = m.fuzzyAlgorithm
 				} else if strings.HasPrefix(searchQuery, "/") {
 					m.filter.SearchMode = "text"
 					m.filter.SearchQuery = strings.TrimPrefix(searchQuery, "/")
@@ -373,7 +504,7 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 					historyEntry.ProjectFilter = mention.Name
 				}
 
-				recordCmd := recordSearchCmd(m.ctx, m.searchHistoryRepo, historyEntry)
+				recordCmd := m.recordSearch(historyEntry)
 				return m, tea.Batch(m.refreshCmd(), recordCmd)
 			}
 
@@ -381,10 +512,92 @@ func (m Model) updateSearchMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	previous := m.searchInput.Value()
 	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.incrementalSearch && m.searchInput.Value() != previous {
+		return m, tea.Batch(cmd, m.scheduleIncrementalSearch())
+	}
 	return m, cmd
 }
 
+// rebuilds the search input for a history entry so that submitting it runs
+// the same search again. history stores the query without its "re:" prefix
+// or project mention, and text that would otherwise be read as a regex or a
+// "/" search gets escaped with "/"
+func searchHistoryInput(entry *domain.SearchHistory) string {
+	text := entry.QueryText
+	if entry.QueryType == domain.QueryTypeQueryLanguage {
+		return text
+	}
+
+	switch entry.SearchMode {
+	case domain.SearchModeRegex:
+		text = "re:" + text
+	case domain.SearchModeText:
+		if strings.HasPrefix(text, "re:") || strings.HasPrefix(text, "/") {
+			text = "/" + text
+		}
+	}
+
+	if entry.QueryType == domain.QueryTypeProjectMention && entry.ProjectFilter != "" {
+		// a leading mention would be read as query language
+		text += " @" + entry.ProjectFilter
+	}
+
+	return text
+}
+
+// scheduleIncrementalSearch invalidates any pending or running live search and
+// starts a new debounce timer; only the latest timer is acted on
+func (m *Model) scheduleIncrementalSearch() tea.Cmd {
+	m.stopIncrementalSearch()
+	return debounceSearchCmd(m.searchSeq)
+}
+
+func (m *Model) stopIncrementalSearch() {
+	m.searchSeq++
+	if m.cancelSearch != nil {
+		m.cancelSearch()
+		m.cancelSearch = nil
+	}
+}
+
+// runIncrementalSearch applies the typed text as a plain text or fuzzy filter.
+// query language, regex and project mentions still wait for enter since they
+// are only meaningful once complete
+func (m *Model) runIncrementalSearch() tea.Cmd {
+	searchQuery := m.searchInput.Value()
+	if !isIncrementalQuery(searchQuery) {
+		return nil
+	}
+
+	searchQuery = strings.TrimPrefix(searchQuery, "/")
+	if strings.TrimSpace(searchQuery) == "" {
+		m.filter.SearchQuery = ""
+		m.filter.SearchMode = ""
+	} else if m.fuzzyMode {
+		m.filter.SearchMode = "fuzzy"
+		m.filter.SearchQuery = searchQuery
+		m.filter.FuzzyThreshold = m.fuzzyThreshold
+		m.filter.FuzzyAlgorithm = m.fuzzyAlgorithm
+	} else {
+		m.filter.SearchMode = "text"
+		m.filter.SearchQuery = searchQuery
+	}
+	m.currentPage = 1
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.cancelSearch = cancel
+	return incrementalSearchCmd(ctx, m.repo, m.filter, m.currentPage, m.pageSize, m.searchSeq)
+}
+
+func isIncrementalQuery(searchQuery string) bool {
+	if strings.HasPrefix(searchQuery, "re:") || strings.Contains(searchQuery, "@") {
+		return false
+	}
+	return !query.IsQueryLanguage(searchQuery)
+}
+
 func (m Model) updateFilterMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -445,41 +658,24 @@ func (m Model) applyFilterSelection() (tea.Model, tea.Cmd) {
 		}
 
 	case "duedate":
-		m.filter.DueDateFrom = nil
-		m.filter.DueDateTo = nil
+		m.filter.DueDateFrom, m.filter.DueDateTo = query.DueDateRange(item.value, time.Now(), m.overdueGraceDays)
 
-		now := time.Now()
-		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-
-		switch item.value {
-		case "":
-		case "overdue":
-			yesterday := today.AddDate(0, 0, -1).Format("2006-01-02")
-			m.filter.DueDateTo = &yesterday
-		case "today":
-			todayStr := today.Format("2006-01-02")
-			tomorrowStr := today.AddDate(0, 0, 1).Format("2006-01-02")
-			m.filter.DueDateFrom = &todayStr
-			m.filter.DueDateTo = &tomorrowStr
-		case "week":
-			todayStr := today.Format("2006-01-02")
-			weekStr := today.AddDate(0, 0, 7).Format("2006-01-02")
-			m.filter.DueDateFrom = &todayStr
-			m.filter.DueDateTo = &weekStr
-		case "month":
-			todayStr := today.Format("2006-01-02")
-			monthStr := today.AddDate(0, 0, 30).Format("2006-01-02")
-			m.filter.DueDateFrom = &todayStr
-			m.filter.DueDateTo = &monthStr
-		case "none":
-			noneMarker := "none"
-			m.filter.DueDateFrom = &noneMarker
-		}
+	case "pinned":
+		m.filter.PinnedOnly = item.value == "pinned"
+
+	case "waiting":
+		m.filter.WaitingOnly = item.value == "waiting"
+
+	case "snoozed":
+		m.hideSnoozed = item.value != "show"
+		m.filter.HideSnoozed = m.hideSnoozed
 
 	case "clear":
 		m.filter.Status = ""
 		m.filter.Priority = ""
 		m.filter.ProjectID = nil
+		m.filter.PinnedOnly = false
+		m.filter.WaitingOnly = false
 		m.filter.Tags = []string{}
 		m.filter.SearchQuery = ""
 		m.filter.SearchMode = ""
@@ -490,6 +686,8 @@ func (m Model) applyFilterSelection() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// the filter no longer matches the query it came from
+	m.clearQueryMode()
 	m.currentPage = 1
 	m.uiMode = normalMode
 	m.filterPanel.active = false
@@ -505,12 +703,6 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKeyPress(msg)
 
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		if m.viewMode == projectView {
-		} else {
-			m.table.SetHeight(msg.Height - 12)
-		}
 		return m, nil
 
 	case tasksLoadedMsg:
@@ -518,10 +710,31 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.totalCount = msg.totalCount
 		m.loading = false
 		m.message = ""
+		if msg.truncated {
+			m.message = "Search truncated: showing the first matches only, narrow the pattern to see the rest"
+		}
 		m.err = nil
 		m.updateTableRows()
 		return m, nil
 
+	case kanbanTasksLoadedMsg:
+		// the board may have been left while they loaded
+		if m.viewMode != kanbanView {
+			return m, nil
+		}
+		card := m.selectedCard()
+		m.kanbanTasks = msg.tasks
+		if card != nil {
+			if cursor, ok := m.kanbanPosition(card); ok {
+				m.kanban = cursor
+			}
+		}
+		return m, nil
+
+	case clipboardMsg:
+		m.handleClipboard(msg)
+		return m, nil
+
 	case queryParsedMsg:
 		m.loading = false
 		if msg.err != nil {
@@ -531,13 +744,63 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.filter = msg.filter
+		m.filter.PinnedFirst = m.pinnedFirst
+		m.filter.HideSnoozed = m.hideSnoozed
+		m.filter.HideArchived = m.hideArchived
+		m.queryProjects = msg.projects
 		m.currentPage = 1
 		m.message = fmt.Sprintf("🔍 Query: %s", msg.queryStr)
-		return m, fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize)
+		return m, m.refreshCmd()
 
 	case taskUpdatedMsg:
 		m.message = "Task updated successfully"
+		if msg.next != nil {
+			m.message = fmt.Sprintf("↻ Completed '%s', next one due %s", msg.task.Title, msg.next.DueDate.Format("2006-01-02"))
+		}
+		m.loading = false
+		return m, m.refreshCmd()
+
+	case taskPinnedMsg:
+		m.loading = false
+		if msg.task.IsPinned {
+			m.message = fmt.Sprintf("📌 Pinned '%s'", msg.task.Title)
+		} else {
+			m.message = fmt.Sprintf("Unpinned '%s'", msg.task.Title)
+		}
+		return m, m.refreshCmd()
+
+	case taskLockedMsg:
+		m.loading = false
+		if msg.task.IsLocked {
+			m.message = fmt.Sprintf("🔒 Locked '%s'", msg.task.Title)
+		} else {
+			m.message = fmt.Sprintf("Unlocked '%s'", msg.task.Title)
+		}
+		return m, m.refreshCmd()
+
+	case taskSnoozedMsg:
+		m.loading = false
+		if msg.task.SnoozedUntil != nil {
+			m.message = fmt.Sprintf("💤 Snoozed '%s' until %s", msg.task.Title, msg.task.SnoozedUntil.Format("Mon Jan 2"))
+		} else {
+			m.message = fmt.Sprintf("Woke up '%s'", msg.task.Title)
+		}
+		return m, m.refreshCmd()
+
+	case positionsSwappedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.refreshCmd()
+		}
+		return m, nil
+
+	case bulkResultMsg:
 		m.loading = false
+		m.bulkResult = msg.summary()
+		m.bulkFailed = len(msg.failures) > 0
+		for _, failure := range msg.failures {
+			m.multiSelect.selectedTasks[failure.taskID] = true
+		}
 		return m, m.refreshCmd()
 
 	case taskDeletedMsg:
@@ -547,6 +810,36 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.viewMode = tableView
 		return m, m.refreshCmd()
 
+	case inboxCapturedMsg:
+		m.loading = false
+		m.message = fmt.Sprintf("📥 Captured '%s' to %s", msg.task.Title, msg.inbox.Name)
+		// the inbox may have just been created
+		projectFilter := repository.ProjectFilter{ExcludeArchived: true}
+		return m, tea.Batch(m.refreshCmd(), fetchProjectsCmd(m.ctx, m.projectRepo, projectFilter))
+
+	case blockersLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if m.selectedTask != nil && m.selectedTask.ID == msg.taskID {
+			m.blockers = msg.blockers
+		}
+		return m, nil
+
+	case inboxLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.loading = false
+			return m, nil
+		}
+		m.filter.ProjectID = &msg.inbox.ID
+		m.filter.ProjectIDs = nil
+		m.viewMode = tableView
+		m.currentPage = 1
+		projectFilter := repository.ProjectFilter{ExcludeArchived: true}
+		return m, tea.Batch(m.refreshCmd(), fetchProjectsCmd(m.ctx, m.projectRepo, projectFilter))
+
 	case projectsLoadedMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -554,13 +847,28 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.projects = msg.projects
-		m.projectTree = buildProjectTree(msg.projects)
+		m.projectTree = m.buildSortedProjectTree(msg.projects)
 		m.loading = false
+		if m.projectOrder.By == domain.ProjectSortTaskCount {
+			return m, fetchProjectTaskCountsCmd(m.ctx, m.projectRepo, msg.projects)
+		}
+		return m, nil
+
+	case projectTaskCountsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.projectOrder.TaskCounts = msg.counts
+		m.projectTree = m.buildSortedProjectTree(m.projects)
 		return m, nil
 
 	case projectCreatedMsg:
 		if msg.err != nil {
 			m.err = msg.err
+			if msg.duplicate != nil {
+				m.err = fmt.Errorf("%s", repository.DuplicateProjectMessage(msg.duplicate))
+			}
 			m.loading = false
 			return m, nil
 		}
@@ -572,6 +880,9 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case projectUpdatedMsg:
 		if msg.err != nil {
 			m.err = msg.err
+			if msg.duplicate != nil {
+				m.err = fmt.Errorf("%s", repository.DuplicateProjectMessage(msg.duplicate))
+			}
 			m.loading = false
 			return m, nil
 		}
@@ -620,6 +931,12 @@ func (m Model) updateNormalMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// y then i copies an ID, it isn't inbox capture
+	if m.readOnly && !m.yankPending && m.keys.isWriteKey(msg) {
+		m.message = "Read-only mode: changes are disabled"
+		return m, nil
+	}
+
 	if m.viewMode == projectView {
 		return m.handleProjectViewKeyPress(msg)
 	}
@@ -628,6 +945,18 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleNotesViewKeyPress(msg)
 	}
 
+	if m.reordering {
+		return m.handleReorderKeyPress(msg)
+	}
+
+	if m.yankPending {
+		return m.handleYankKeyPress(msg)
+	}
+
+	if m.columnPending {
+		return m.handleColumnKeyPress(msg)
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
@@ -636,12 +965,79 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showHelp = !m.showHelp
 		return m, nil
 
+	case key.Matches(msg, m.keys.ToggleCompact):
+		m.compact = !m.compact
+		return m, nil
+
+	case key.Matches(msg, m.keys.ToggleColumn):
+		if m.viewMode == tableView {
+			m.columnPending = true
+			m.message = m.columnPrompt()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.ToggleHighlight):
+		m.hideHighlights = !m.hideHighlights
+		m.updateTableRows()
+		if m.hideHighlights {
+			m.message = "Search highlights off"
+		} else {
+			m.message = "Search highlights on"
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.TagCloud):
+		m.tagCloud = tagCloud{active: true}
+		return m, fetchTagCountsCmd(m.ctx, m.repo)
+
+	case key.Matches(msg, m.keys.CycleTheme):
+		themeObj, err := theme.GetTheme(nextThemeName(m.theme.Name))
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.applyTheme(themeObj)
+		m.message = fmt.Sprintf("Theme: %s", themeObj.Name)
+		if m.saveTheme == nil {
+			return m, nil
+		}
+		return m, saveThemeCmd(m.saveTheme, themeObj.Name)
+
 	case msg.String() == "?":
 		if m.queryMode {
 			m.showQueryHelp = !m.showQueryHelp
 			return m, nil
 		}
 
+	case key.Matches(msg, m.keys.NextProject):
+		return m.jumpProject(1)
+
+	case key.Matches(msg, m.keys.PrevProject):
+		return m.jumpProject(-1)
+
+	case key.Matches(msg, m.keys.ToggleKanban):
+		return m.toggleKanban()
+
+	case key.Matches(msg, m.keys.ColumnLeft):
+		if m.viewMode == kanbanView {
+			return m.moveKanbanColumn(-1)
+		}
+
+	case key.Matches(msg, m.keys.ColumnRight):
+		if m.viewMode == kanbanView {
+			return m.moveKanbanColumn(1)
+		}
+
+	case key.Matches(msg, m.keys.MoveCardLeft):
+		if m.viewMode == kanbanView {
+			return m.moveCard(-1)
+		}
+
+	case key.Matches(msg, m.keys.MoveCardRight):
+		if m.viewMode == kanbanView {
+			return m.moveCard(1)
+		}
+
 	case key.Matches(msg, m.keys.ToggleProjects):
 		m.viewMode = projectView
 		m.projectCursor = 0
@@ -655,8 +1051,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Filter):
 		m.uiMode = filteringMode
 		m.filterPanel.active = true
-		m.filterPanel.selectedItem = 0
+		// reopens where it was left, the project list may have shrunk since
 		m.filterPanel.items = m.buildFilterItems()
+		m.filterPanel.selectedItem = min(m.filterPanel.selectedItem, len(m.filterPanel.items)-1)
 		return m, nil
 
 	case key.Matches(msg, m.keys.ClearFilters):
@@ -664,12 +1061,33 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.filter.Priority = ""
 		m.filter.ProjectID = nil
 		m.filter.Tags = []string{}
+		m.filter.WaitingOnly = false
 		m.filter.SearchQuery = ""
 		m.filter.SearchMode = ""
+		if m.selectedView != nil {
+			m.viewNotice = fmt.Sprintf("View %s no longer active", m.selectedView.Name)
+			m.selectedView = nil
+		}
 		m.currentPage = 1
 		m.loading = true
 		return m, m.refreshCmd()
 
+	case key.Matches(msg, m.keys.ReapplyView):
+		if m.selectedView == nil {
+			m.message = "No view to re-apply, pick one with V"
+			return m, nil
+		}
+		return m.applyView(m.selectedView)
+
+	case key.Matches(msg, m.keys.Yank):
+		if m.getSelectedTask() == nil {
+			m.message = "No task selected"
+			return m, nil
+		}
+		m.yankPending = true
+		m.message = yankPrompt
+		return m, nil
+
 	case key.Matches(msg, m.keys.Search):
 		m.uiMode = searchingMode
 		m.searchInput.Focus()
@@ -692,6 +1110,21 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.loading = true
 		return m, m.refreshCmd()
 
+	case key.Matches(msg, m.keys.Reorder):
+		if m.viewMode != tableView {
+			return m, nil
+		}
+		m.reordering = true
+		m.message = "Reorder mode: ↑/↓ move the selected task, esc to finish"
+		if m.filter.SortBy == "position" && m.filter.SortOrder == "asc" {
+			return m, nil
+		}
+		m.filter.SortBy = "position"
+		m.filter.SortOrder = "asc"
+		m.currentPage = 1
+		m.loading = true
+		return m, m.refreshCmd()
+
 	case key.Matches(msg, m.keys.NextPage):
 		totalPages := m.calculateTotalPages()
 		if m.currentPage < totalPages {
@@ -719,6 +1152,10 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if selectedRow < len(m.tasks) {
 				m.selectedTask = m.tasks[selectedRow]
 				m.viewMode = detailView
+				m.blockers = nil
+				if m.selectedTask.IsBlocked() {
+					return m, fetchBlockersCmd(m.ctx, m.repo, m.selectedTask.ID)
+				}
 			}
 		}
 		return m, nil
@@ -729,6 +1166,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.selectedTask = nil
 			m.message = ""
 		}
+		if m.viewMode == kanbanView {
+			return m.toggleKanban()
+		}
 		return m, nil
 
 	case key.Matches(msg, m.keys.Up):
@@ -736,9 +1176,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case detailView:
 				m.navigateToPreviousTask()
 				return m, nil
+			case kanbanView:
+				return m.moveKanbanRow(-1)
 			case tableView:
 				var cmd tea.Cmd
 				m.table, cmd = m.table.Update(msg)
+				m.refreshFadedRows()
 				return m, cmd
 		}
 
@@ -747,9 +1190,12 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			case detailView:
 				m.navigateToNextTask()
 				return m, nil
+			case kanbanView:
+				return m.moveKanbanRow(1)
 			case tableView:
 				var cmd tea.Cmd
 				m.table, cmd = m.table.Update(msg)
+				m.refreshFadedRows()
 				return m, cmd
 		}
 
@@ -759,6 +1205,11 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Edit):
 		return m.handleEditTask()
 
+	case key.Matches(msg, m.keys.EditTitle):
+		if m.viewMode == tableView {
+			return m.startTitleEdit()
+		}
+
 	case key.Matches(msg, m.keys.ToggleMultiSelect):
 		return m.handleToggleMultiSelect()
 
@@ -800,26 +1251,214 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m.handleBulkToggleStatus()
 		}
 		return m.handleToggleStatus()
+
+	case m.keys.quickStatus(msg) != "":
+		return m.handleSetStatus(m.keys.quickStatus(msg))
+
+	case m.keys.quickPriority(msg) != "":
+		return m.handleSetPriority(m.keys.quickPriority(msg))
+
+	case key.Matches(msg, m.keys.BulkAddTag):
+		if m.multiSelect.enabled && len(m.multiSelect.selectedTasks) > 0 {
+			return m.openBulkTagInput(false)
+		}
+
+	case key.Matches(msg, m.keys.BulkRemoveTag):
+		if m.multiSelect.enabled && len(m.multiSelect.selectedTasks) > 0 {
+			return m.openBulkTagInput(true)
+		}
+
+	case key.Matches(msg, m.keys.TogglePin):
+		return m.handleTogglePin()
+
+	case key.Matches(msg, m.keys.ToggleLock):
+		return m.handleToggleLock()
+
+	case key.Matches(msg, m.keys.CycleColor):
+		return m.handleCycleColor()
+
+	case key.Matches(msg, m.keys.CaptureInbox):
+		input := textinput.New()
+		input.Placeholder = "What needs doing?"
+		input.CharLimit = 200
+		input.Width = 56
+		input.Focus()
+		m.inboxCapture = inboxCapture{active: true, input: input}
+		return m, textinput.Blink
+
+	case key.Matches(msg, m.keys.GoToInbox):
+		m.loading = true
+		return m, fetchInboxCmd(m.ctx, m.projectRepo)
+
+	case key.Matches(msg, m.keys.ReadyQueue):
+		return m.toggleReadyQueue()
+
+	case key.Matches(msg, m.keys.Snooze):
+		return m.handleSnooze(1)
+
+	case key.Matches(msg, m.keys.SnoozeWeek):
+		return m.handleSnooze(7)
 	}
 
 	return m, nil
 }
 
 
+func (m Model) handleReorderKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		return m, tea.Quit
+
+	case key.Matches(msg, m.keys.Up):
+		return m.moveSelectedTask(-1)
+
+	case key.Matches(msg, m.keys.Down):
+		return m.moveSelectedTask(1)
+
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Reorder):
+		m.reordering = false
+		m.message = ""
+	}
+
+	return m, nil
+}
+
+// swaps the selected task with the row above or below it and moves the cursor
+// along, the new order is saved in the background
+func (m Model) moveSelectedTask(direction int) (tea.Model, tea.Cmd) {
+	cursor := m.table.Cursor()
+	target := cursor + direction
+	if cursor >= len(m.tasks) || target < 0 || target >= len(m.tasks) {
+		return m, nil
+	}
+
+	task, other := m.tasks[cursor], m.tasks[target]
+	if !sameProject(task.ProjectID, other.ProjectID) {
+		m.message = "Tasks can only be reordered within their project"
+		return m, nil
+	}
+
+	m.tasks[cursor], m.tasks[target] = other, task
+	task.Position, other.Position = other.Position, task.Position
+	m.updateTableRows()
+	m.table.SetCursor(target)
+
+	return m, swapPositionsCmd(m.ctx, m.repo, task.ID, other.ID)
+}
+
+func sameProject(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
 func (m Model) handleMarkComplete() (tea.Model, tea.Cmd) {
 	task := m.getSelectedTask()
 	if task == nil {
 		return m, nil
 	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
 
+	next := domain.StatusCompleted
 	if task.Status == domain.StatusCompleted {
-		task.Status = domain.StatusPending
-	} else {
+		next = domain.StatusPending
+	}
+
+	if err := m.statusTransitions.Validate(task.Status, next); err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	if next == domain.StatusPending {
+		task.Status = next
+		m.loading = true
+		return m, updateTaskCmd(m.ctx, m.repo, task)
+	}
+
+	return m.confirmIfBlocked([]*domain.Task{task}, next, func(model *Model) tea.Cmd {
 		task.Status = domain.StatusCompleted
+		model.loading = true
+		return updateTaskCmd(model.ctx, model.repo, task)
+	})
+}
+
+// runs change right away, or after a confirmation when it completes tasks
+// that still wait for unfinished dependencies
+func (m Model) confirmIfBlocked(tasks []*domain.Task, status domain.Status, change func(*Model) tea.Cmd) (tea.Model, tea.Cmd) {
+	if status != domain.StatusCompleted {
+		return m, change(&m)
+	}
+
+	var blocked []*domain.Task
+	for _, task := range tasks {
+		if task.IsBlocked() {
+			blocked = append(blocked, task)
+		}
+	}
+
+	var message string
+	switch {
+	case len(blocked) == 0:
+		return m, change(&m)
+	case len(tasks) == 1:
+		message = fmt.Sprintf("'%s' still waits for %d unfinished task(s). Complete it anyway?", blocked[0].Title, blocked[0].OpenBlockers)
+	default:
+		message = fmt.Sprintf("%d of the %d tasks still wait for unfinished tasks. Complete them anyway?", len(blocked), len(tasks))
+	}
+
+	cmd := m.openConfirm(confirmDialog{message: message, onConfirm: change})
+	return m, cmd
+}
+
+func (m Model) handleTogglePin() (tea.Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
 	}
 
 	m.loading = true
-	return m, updateTaskCmd(m.ctx, m.repo, task)
+	return m, setTaskPinnedCmd(m.ctx, m.repo, task, !task.IsPinned)
+}
+
+func (m Model) handleToggleLock() (tea.Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+
+	m.loading = true
+	return m, setTaskLockedCmd(m.ctx, m.repo, task, !task.IsLocked)
+}
+
+// locked tasks can't be edited or deleted, they have to be unlocked first
+func (m *Model) refuseLocked(task *domain.Task) bool {
+	if !task.IsLocked {
+		return false
+	}
+	m.message = fmt.Sprintf("🔒 '%s' is locked, press K to unlock it", task.Title)
+	return true
+}
+
+// snoozes the selected task until the start of the day that many days ahead.
+// snoozing for a day wakes a task that is already snoozed
+func (m Model) handleSnooze(days int) (tea.Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+
+	now := time.Now()
+	var until *time.Time
+	if days > 1 || !task.IsSnoozed(now) {
+		wake := time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, time.Local)
+		until = &wake
+	}
+
+	m.loading = true
+	return m, setTaskSnoozedCmd(m.ctx, m.repo, task, until)
 }
 
 func (m Model) handleCyclePriority() (tea.Model, tea.Cmd) {
@@ -827,6 +1466,9 @@ func (m Model) handleCyclePriority() (tea.Model, tea.Cmd) {
 	if task == nil {
 		return m, nil
 	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
 
 	switch task.Priority {
 	case domain.PriorityLow:
@@ -845,21 +1487,44 @@ func (m Model) handleCyclePriority() (tea.Model, tea.Cmd) {
 	return m, updateTaskCmd(m.ctx, m.repo, task)
 }
 
+// the colors C steps through, the full set is left to update --color
+var taskColorCycle = []string{"", "red", "yellow", "green", "cyan", "blue", "magenta"}
+
+func (m Model) handleCycleColor() (tea.Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
+
+	// a color outside the cycle goes back to none
+	next := (slices.Index(taskColorCycle, task.Color) + 1) % len(taskColorCycle)
+	task.Color = taskColorCycle[next]
+
+	m.loading = true
+	return m, updateTaskCmd(m.ctx, m.repo, task)
+}
+
 func (m Model) handleDelete() (tea.Model, tea.Cmd) {
 	task := m.getSelectedTask()
 	if task == nil {
 		return m, nil
 	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
 
-	m.confirm = confirmDialog{
-		message: "Delete task: " + task.Title + "?",
-		active:  true,
+	cmd := m.openConfirm(confirmDialog{
+		message:     "Delete task: " + task.Title + "?",
+		confirmWord: "delete",
 		onConfirm: func(model *Model) tea.Cmd {
 			return deleteTaskCmd(model.ctx, model.repo, task.ID)
 		},
-	}
+	})
 
-	return m, nil
+	return m, cmd
 }
 
 func (m Model) handleToggleStatus() (tea.Model, tea.Cmd) {
@@ -867,25 +1532,75 @@ func (m Model) handleToggleStatus() (tea.Model, tea.Cmd) {
 	if task == nil {
 		return m, nil
 	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
 
-	switch task.Status {
-		case domain.StatusPending:
-			task.Status = domain.StatusInProgress
-		case domain.StatusInProgress:
-			task.Status = domain.StatusPending
-		default:
-			task.Status = domain.StatusInProgress
+	next := nextToggleStatus(task.Status)
+	if err := m.statusTransitions.Validate(task.Status, next); err != nil {
+		m.err = err
+		return m, nil
 	}
+	task.Status = next
 
 	m.loading = true
 	return m, updateTaskCmd(m.ctx, m.repo, task)
 }
 
+// sets the status of the selected task, or of every selected task in
+// multi-select mode
+func (m Model) handleSetStatus(status domain.Status) (tea.Model, tea.Cmd) {
+	if m.multiSelect.enabled && len(m.multiSelect.selectedTasks) > 0 {
+		return m.bulkChangeStatus(func(domain.Status) domain.Status { return status })
+	}
+
+	task := m.getSelectedTask()
+	if task == nil || task.Status == status {
+		return m, nil
+	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
+
+	if err := m.statusTransitions.Validate(task.Status, status); err != nil {
+		m.err = err
+		return m, nil
+	}
+
+	return m.confirmIfBlocked([]*domain.Task{task}, status, func(model *Model) tea.Cmd {
+		task.Status = status
+		model.loading = true
+		return updateTaskCmd(model.ctx, model.repo, task)
+	})
+}
+
+// sets the priority of the selected task, or of every selected task in
+// multi-select mode
+func (m Model) handleSetPriority(priority domain.Priority) (tea.Model, tea.Cmd) {
+	if m.multiSelect.enabled && len(m.multiSelect.selectedTasks) > 0 {
+		return m.bulkChangePriority(func(domain.Priority) domain.Priority { return priority })
+	}
+
+	task := m.getSelectedTask()
+	if task == nil || task.Priority == priority {
+		return m, nil
+	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
+	task.Priority = priority
+
+	m.loading = true
+	return m, updateTaskCmd(m.ctx, m.repo, task)
+}
 
 func (m *Model) getSelectedTask() *domain.Task {
 	if m.viewMode == detailView {
 		return m.selectedTask
 	}
+	if m.viewMode == kanbanView {
+		return m.selectedCard()
+	}
 	if m.viewMode == tableView && len(m.tasks) > 0 {
 		selectedRow := m.table.Cursor()
 		if selectedRow < len(m.tasks) {
@@ -906,6 +1621,8 @@ func (m *Model) cycleSortMode() {
 	case "due_date":
 		m.filter.SortBy = "title"
 	case "title":
+		m.filter.SortBy = "position"
+	case "position":
 		m.filter.SortBy = "created_at"
 	default:
 		m.filter.SortBy = "created_at"
@@ -919,6 +1636,13 @@ func (m *Model) calculateTotalPages() int {
 	return int((m.totalCount + int64(m.pageSize) - 1) / int64(m.pageSize))
 }
 
+// faded rows only fade away from the cursor, so they are rebuilt when it moves
+func (m *Model) refreshFadedRows() {
+	if m.fadeDone {
+		m.updateTableRows()
+	}
+}
+
 func (m *Model) updateTableRows() {
 	rows := make([]table.Row, len(m.tasks))
 	for i, task := range m.tasks {
@@ -928,45 +1652,20 @@ func (m *Model) updateTableRows() {
 }
 
 func (m *Model) buildFilterItems() []filterItem {
-	items := []filterItem{
-		{label: "Filter by Status", value: "", filterType: "status"},
-		{label: "  ○ All", value: "", filterType: "status"},
-		{label: "  ○ Pending", value: "pending", filterType: "status"},
-		{label: "  ○ In Progress", value: "in_progress", filterType: "status"},
-		{label: "  ○ Completed", value: "completed", filterType: "status"},
-		{label: "  ○ Cancelled", value: "cancelled", filterType: "status"},
-		{label: "", value: "", filterType: ""},
-		{label: "Filter by Priority", value: "", filterType: "priority"},
-		{label: "  ○ All", value: "", filterType: "priority"},
-		{label: "  ○ Low", value: "low", filterType: "priority"},
-		{label: "  ○ Medium", value: "medium", filterType: "priority"},
-		{label: "  ○ High", value: "high", filterType: "priority"},
-		{label: "  ○ Urgent", value: "urgent", filterType: "priority"},
-		{label: "", value: "", filterType: ""},
-		{label: "Filter by Project", value: "", filterType: "project"},
-		{label: "  ○ All", value: "", filterType: "project"},
+	var items []filterItem
+	for _, section := range display.FilterSections(m.projects) {
+		items = append(items, filterItem{label: section.Title, value: "", filterType: section.FilterType})
+		for _, choice := range section.Choices {
+			items = append(items, filterItem{
+				label:      "  ○ " + choice.Label,
+				value:      choice.Value,
+				filterType: section.FilterType,
+			})
+		}
+		items = append(items, filterItem{label: "", value: "", filterType: ""})
 	}
 
-	for _, proj := range m.projects {
-		items = append(items, filterItem{
-			label:      fmt.Sprintf("  ○ %s", proj.Name),
-			value:      fmt.Sprintf("%d", proj.ID),
-			filterType: "project",
-		})
-	}
-
-	items = append(items, []filterItem{
-		{label: "", value: "", filterType: ""},
-		{label: "Filter by Due Date", value: "", filterType: "duedate"},
-		{label: "  ○ All", value: "", filterType: "duedate"},
-		{label: "  ○ Overdue", value: "overdue", filterType: "duedate"},
-		{label: "  ○ Due Today", value: "today", filterType: "duedate"},
-		{label: "  ○ Due This Week", value: "week", filterType: "duedate"},
-		{label: "  ○ Due This Month", value: "month", filterType: "duedate"},
-		{label: "  ○ No Due Date", value: "none", filterType: "duedate"},
-		{label: "", value: "", filterType: ""},
-		{label: "Clear All Filters", value: "", filterType: "clear"},
-	}...)
+	items = append(items, filterItem{label: "Clear All Filters", value: "", filterType: "clear"})
 	return items
 }
 
@@ -1031,7 +1730,7 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "esc":
 			m.editForm.active = false
-			m.editForm.err = ""
+			m.editForm.clearErrors()
 			m.viewMode = tableView
 			return m, nil
 
@@ -1040,7 +1739,7 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "tab":
 			m.editForm.focusedField++
-			if m.editForm.focusedField > 4 {
+			if m.editForm.focusedField > 5 {
 				m.editForm.focusedField = 0
 			}
 			m.updateFormFocus()
@@ -1049,7 +1748,7 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "shift+tab":
 			m.editForm.focusedField--
 			if m.editForm.focusedField < 0 {
-				m.editForm.focusedField = 4
+				m.editForm.focusedField = 5
 			}
 			m.updateFormFocus()
 			return m, nil
@@ -1061,7 +1760,7 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.projectPicker.cursor = 0
 				m.projectPicker.searchQuery = ""
 				m.projectPicker.selected = nil
-				m.projectPicker.tree = buildProjectTree(m.projects)
+				m.projectPicker.tree = m.buildSortedProjectTree(m.projects)
 				return m, nil
 			} else {
 				priorities := []domain.Priority{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent}
@@ -1079,7 +1778,7 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.message = "Task created successfully"
 		m.loading = false
 		m.editForm.active = false
-		m.editForm.err = ""
+		m.editForm.clearErrors()
 		m.viewMode = tableView
 		return m, m.refreshCmd()
 
@@ -1087,7 +1786,10 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.message = "Task updated successfully"
 		m.loading = false
 		m.editForm.active = false
-		m.editForm.err = ""
+		m.editForm.clearErrors()
+		if m.selectedTask != nil && m.selectedTask.ID == msg.task.ID {
+			m.selectedTask = msg.task
+		}
 		m.viewMode = tableView
 		return m, m.refreshCmd()
 
@@ -1115,6 +1817,9 @@ func (m Model) updateEditMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case 4:
 		m.editForm.dueDateInput, cmd = m.editForm.dueDateInput.Update(msg)
 		cmds = append(cmds, cmd)
+	case 5:
+		m.editForm.waitingInput, cmd = m.editForm.waitingInput.Update(msg)
+		cmds = append(cmds, cmd)
 	}
 
 	return m, tea.Batch(cmds...)
@@ -1162,10 +1867,17 @@ func (m Model) updateProjectFormMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
-			m.projectForm.errors["general"] = msg.err.Error()
+			if msg.duplicate != nil {
+				m.projectForm.setFieldError("name", repository.DuplicateProjectMessage(msg.duplicate))
+				return m, nil
+			}
+			m.projectForm.setFieldError("general", msg.err.Error())
 			return m, nil
 		}
 		m.message = fmt.Sprintf("Project '%s' created successfully", msg.project.Name)
+		if m.projectForm.inherited != "" {
+			m.message += fmt.Sprintf(" (inherited %s)", m.projectForm.inherited)
+		}
 		m.resetProjectForm()
 		m.viewMode = projectView
 		projectFilter := repository.ProjectFilter{ExcludeArchived: true}
@@ -1175,10 +1887,17 @@ func (m Model) updateProjectFormMode(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
-			m.projectForm.errors["general"] = msg.err.Error()
+			if msg.duplicate != nil {
+				m.projectForm.setFieldError("name", repository.DuplicateProjectMessage(msg.duplicate))
+				return m, nil
+			}
+			m.projectForm.setFieldError("general", msg.err.Error())
 			return m, nil
 		}
 		m.message = fmt.Sprintf("Project '%s' updated successfully", msg.project.Name)
+		if m.selectedProject != nil && m.selectedProject.ID == msg.project.ID {
+			m.selectedProject = msg.project
+		}
 		m.resetProjectForm()
 		m.viewMode = projectView
 		projectFilter := repository.ProjectFilter{ExcludeArchived: true}
@@ -1240,12 +1959,13 @@ func (m Model) handleSaveProject() (tea.Model, tea.Cmd) {
 	icon := strings.TrimSpace(m.projectForm.iconInput.Value())
 
 	var parentID *int64
+	var parent *domain.Project
 	if parentInput != "" {
-		parent := m.lookupProjectForForm(parentInput)
+		parent = m.lookupProjectForForm(parentInput)
 		if parent != nil {
 			parentID = &parent.ID
 		} else {
-			m.projectForm.errors["parent"] = "Parent project not found"
+			m.projectForm.setFieldError("parent", "Parent project not found")
 			return m, nil
 		}
 	}
@@ -1257,20 +1977,29 @@ func (m Model) handleSaveProject() (tea.Model, tea.Cmd) {
 		project.Color = color
 		project.Icon = icon
 
+		m.projectForm.inherited = ""
+		if m.inheritProjectStyle && parent != nil {
+			if inherited := project.InheritStyle(parent); inherited != "" {
+				m.projectForm.inherited = fmt.Sprintf("%s from '%s'", inherited, parent.Name)
+			}
+		}
+
 		if err := project.Validate(); err != nil {
-			m.projectForm.errors["general"] = err.Error()
+			m.projectForm.setFieldError("general", err.Error())
 			return m, nil
 		}
 
 		m.loading = true
 		return m, createProjectCmd(m.ctx, m.projectRepo, project)
 	} else {
-		project := m.projectForm.editingProject
-		if project == nil {
-			m.projectForm.errors["general"] = "No project to edit"
+		if m.projectForm.editingProject == nil {
+			m.projectForm.setFieldError("general", "No project to edit")
 			return m, nil
 		}
 
+		// edit a copy so the project tree is untouched if validation or the update fails
+		editing := *m.projectForm.editingProject
+		project := &editing
 		project.Name = name
 		project.Description = desc
 		project.ParentID = parentID
@@ -1283,7 +2012,7 @@ func (m Model) handleSaveProject() (tea.Model, tea.Cmd) {
 		}
 
 		if err := project.Validate(); err != nil {
-			m.projectForm.errors["general"] = err.Error()
+			m.projectForm.setFieldError("general", err.Error())
 			return m, nil
 		}
 
@@ -1293,6 +2022,29 @@ func (m Model) handleSaveProject() (tea.Model, tea.Cmd) {
 }
 
 
+// the project typed in the edit form. an empty field means no project, a name
+// that matches no loaded project is an error. the task's own project is kept
+// when the name is unchanged, as it may be archived and not loaded
+func (m Model) formProjectID() (*int64, error) {
+	projectName := strings.TrimSpace(m.editForm.projectInput.Value())
+	if projectName == "" {
+		return nil, nil
+	}
+
+	for _, proj := range m.projects {
+		if strings.EqualFold(proj.Name, projectName) {
+			return &proj.ID, nil
+		}
+	}
+
+	if task := m.editForm.editingTask; !m.editForm.isNewTask && task != nil &&
+		task.ProjectID != nil && strings.EqualFold(task.ProjectName, projectName) {
+		return task.ProjectID, nil
+	}
+
+	return nil, fmt.Errorf("Project '%s' not found, clear the field for no project", projectName)
+}
+
 func (m Model) handleNewTask() (tea.Model, tea.Cmd) {
 	m.initEditForm(nil)
 	m.editForm.active = true
@@ -1306,6 +2058,9 @@ func (m Model) handleEditTask() (tea.Model, tea.Cmd) {
 	if task == nil {
 		return m, nil
 	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
 
 	m.initEditForm(task)
 	m.editForm.active = true
@@ -1337,17 +2092,23 @@ func (m *Model) initEditForm(task *domain.Task) {
 	tagsInput.Width = 60
 
 	dueDateInput := textinput.New()
-	dueDateInput.Placeholder = "Due date (YYYY-MM-DD, optional)"
-	dueDateInput.CharLimit = 10
+	dueDateInput.Placeholder = "Due date (YYYY-MM-DD, tomorrow, +3d, friday, optional)"
+	dueDateInput.CharLimit = 20
 	dueDateInput.Width = 20
 
+	waitingInput := textinput.New()
+	waitingInput.Placeholder = "Person or team the task waits for (optional)"
+	waitingInput.CharLimit = 100
+	waitingInput.Width = 40
+
 	m.editForm.titleInput = titleInput
 	m.editForm.descInput = descInput
 	m.editForm.projectInput = projectInput
 	m.editForm.tagsInput = tagsInput
 	m.editForm.dueDateInput = dueDateInput
+	m.editForm.waitingInput = waitingInput
 	m.editForm.focusedField = 0
-	m.editForm.err = ""
+	m.editForm.clearErrors()
 
 	if task != nil {
 		m.editForm.editingTask = task
@@ -1360,6 +2121,7 @@ func (m *Model) initEditForm(task *domain.Task) {
 		if task.DueDate != nil {
 			m.editForm.dueDateInput.SetValue(task.DueDate.Format("2006-01-02"))
 		}
+		m.editForm.waitingInput.SetValue(task.WaitingOn)
 
 		priorities := []domain.Priority{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent}
 		for i, p := range priorities {
@@ -1378,6 +2140,7 @@ func (m *Model) initEditForm(task *domain.Task) {
 		}
 	} else {
 		m.editForm.editingTask = nil
+		m.editForm.projectInput.SetValue(m.defaultProject)
 		m.editForm.priorityIdx = 1
 		m.editForm.statusIdx = 0
 	}
@@ -1391,6 +2154,7 @@ func (m *Model) updateFormFocus() {
 	m.editForm.projectInput.Blur()
 	m.editForm.tagsInput.Blur()
 	m.editForm.dueDateInput.Blur()
+	m.editForm.waitingInput.Blur()
 
 	switch m.editForm.focusedField {
 	case 0:
@@ -1403,27 +2167,25 @@ func (m *Model) updateFormFocus() {
 		m.editForm.tagsInput.Focus()
 	case 4:
 		m.editForm.dueDateInput.Focus()
+	case 5:
+		m.editForm.waitingInput.Focus()
 	}
 }
 
+// a failed save only records errors, the inputs are left exactly as typed
 func (m Model) handleSaveTask() (tea.Model, tea.Cmd) {
+	m.editForm.clearErrors()
+
 	title := strings.TrimSpace(m.editForm.titleInput.Value())
 	if title == "" {
-		m.editForm.err = "Title is required"
-		return m, nil
+		m.editForm.setFieldError("title", "Title is required")
 	}
 
 	description := strings.TrimSpace(m.editForm.descInput.Value())
 
-	projectName := strings.TrimSpace(m.editForm.projectInput.Value())
-	var projectID *int64
-	if projectName != "" {
-		for _, proj := range m.projects {
-			if strings.EqualFold(proj.Name, projectName) {
-				projectID = &proj.ID
-				break
-			}
-		}
+	projectID, err := m.formProjectID()
+	if err != nil {
+		m.editForm.setFieldError("project", err.Error())
 	}
 
 	var tags []string
@@ -1437,10 +2199,31 @@ func (m Model) handleSaveTask() (tea.Model, tea.Cmd) {
 		}
 	}
 
-	dueDateStr := strings.TrimSpace(m.editForm.dueDateInput.Value())
+	var dueDate *time.Time
+	if dueDateStr := strings.TrimSpace(m.editForm.dueDateInput.Value()); dueDateStr != "" {
+		parsed, err := domain.ParseDueDate(dueDateStr)
+		if err != nil {
+			m.editForm.setFieldError("due_date", err.Error())
+		} else {
+			dueDate = parsed
+		}
+	}
+
+	waitingOn := strings.TrimSpace(m.editForm.waitingInput.Value())
 
 	priorities := []domain.Priority{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent}
 	statuses := []domain.Status{domain.StatusPending, domain.StatusInProgress, domain.StatusCompleted, domain.StatusCancelled}
+	status := statuses[m.editForm.statusIdx]
+
+	if !m.editForm.isNewTask && m.editForm.editingTask != nil {
+		if err := m.statusTransitions.Validate(m.editForm.editingTask.Status, status); err != nil {
+			m.editForm.setFieldError("status", err.Error())
+		}
+	}
+
+	if m.editForm.hasErrors() {
+		return m, nil
+	}
 
 	if m.editForm.isNewTask {
 		task := domain.NewTask(title)
@@ -1448,38 +2231,32 @@ func (m Model) handleSaveTask() (tea.Model, tea.Cmd) {
 		task.ProjectID = projectID
 		task.Tags = tags
 		task.Priority = priorities[m.editForm.priorityIdx]
-		task.Status = statuses[m.editForm.statusIdx]
-
-		if dueDateStr != "" {
-			dueTime, err := domain.ParseDueDate(dueDateStr)
-			if err == nil {
-				task.DueDate = dueTime
-			}
-		}
+		task.Status = status
+		task.DueDate = dueDate
+		task.WaitingOn = waitingOn
 
 		m.loading = true
 		return m, createTaskCmd(m.ctx, m.repo, task)
-	} else {
-		task := m.editForm.editingTask
-		task.Title = title
-		task.Description = description
-		task.ProjectID = projectID
-		task.Tags = tags
-		task.Priority = priorities[m.editForm.priorityIdx]
-		task.Status = statuses[m.editForm.statusIdx]
-
-		if dueDateStr != "" {
-			dueTime, err := domain.ParseDueDate(dueDateStr)
-			if err == nil {
-				task.DueDate = dueTime
-			}
-		} else {
-			task.DueDate = nil
-		}
+	}
 
-		m.loading = true
-		return m, updateTaskCmd(m.ctx, m.repo, task)
+	if m.editForm.editingTask == nil {
+		m.editForm.err = "No task to edit"
+		return m, nil
 	}
+
+	// save a copy so the listed task is untouched if the update fails
+	task := *m.editForm.editingTask
+	task.Title = title
+	task.Description = description
+	task.ProjectID = projectID
+	task.Tags = tags
+	task.Priority = priorities[m.editForm.priorityIdx]
+	task.Status = status
+	task.DueDate = dueDate
+	task.WaitingOn = waitingOn
+
+	m.loading = true
+	return m, updateTaskCmd(m.ctx, m.repo, &task)
 }
 
 
@@ -1487,6 +2264,7 @@ func (m Model) handleToggleMultiSelect() (tea.Model, tea.Cmd) {
 	m.multiSelect.enabled = !m.multiSelect.enabled
 	if !m.multiSelect.enabled {
 		m.multiSelect.selectedTasks = make(map[int64]bool)
+		m.bulkResult = ""
 	}
 	m.updateTableRows()
 	return m, nil
@@ -1524,70 +2302,255 @@ func (m Model) handleDeselectAll() (tea.Model, tea.Cmd) {
 
 
 func (m Model) handleBulkMarkComplete() (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
+	return m.bulkChangeStatus(func(status domain.Status) domain.Status {
+		if status == domain.StatusCompleted {
+			return domain.StatusPending
+		}
+		return domain.StatusCompleted
+	})
+}
+
+func (m Model) handleBulkCyclePriority() (tea.Model, tea.Cmd) {
+	return m.bulkChangePriority(func(priority domain.Priority) domain.Priority {
+		switch priority {
+		case domain.PriorityLow:
+			return domain.PriorityMedium
+		case domain.PriorityMedium:
+			return domain.PriorityHigh
+		case domain.PriorityHigh:
+			return domain.PriorityUrgent
+		case domain.PriorityUrgent:
+			return domain.PriorityLow
+		default:
+			return domain.PriorityMedium
+		}
+	})
+}
+
+func (m Model) handleBulkToggleStatus() (tea.Model, tea.Cmd) {
+	return m.bulkChangeStatus(nextToggleStatus)
+}
+
+// moves every selected task to the status next gives for its current one.
+// locked tasks are skipped and moves the transition rules forbid reported
+func (m Model) bulkChangeStatus(next func(domain.Status) domain.Status) (tea.Model, tea.Cmd) {
+	if m.overBulkLimit() {
+		return m, nil
+	}
+
+	var tasks, completing []*domain.Task
+	var statuses []domain.Status
+	var rejected []bulkFailure
+	skipped := 0
 
 	for _, task := range m.tasks {
 		if m.multiSelect.selectedTasks[task.ID] {
-			if task.Status == domain.StatusCompleted {
-				task.Status = domain.StatusPending
-			} else {
-				task.Status = domain.StatusCompleted
+			if task.IsLocked {
+				skipped++
+				continue
+			}
+			status := next(task.Status)
+			if err := m.statusTransitions.Validate(task.Status, status); err != nil {
+				rejected = append(rejected, bulkFailure{taskID: task.ID, err: err})
+				continue
+			}
+			tasks = append(tasks, task)
+			statuses = append(statuses, status)
+			if status == domain.StatusCompleted {
+				completing = append(completing, task)
 			}
-			cmds = append(cmds, updateTaskCmd(m.ctx, m.repo, task))
 		}
 	}
 
-	m.multiSelect.selectedTasks = make(map[int64]bool)
-	m.loading = true
-	return m, tea.Batch(cmds...)
+	change := func(model *Model) tea.Cmd {
+		for i, task := range tasks {
+			task.Status = statuses[i]
+		}
+		model.multiSelect.selectedTasks = make(map[int64]bool)
+		model.bulkResult = ""
+		model.loading = true
+		return bulkUpdateTasksCmd(model.ctx, model.repo, tasks, skipped, rejected)
+	}
+	return m.confirmIfBlocked(completing, domain.StatusCompleted, change)
 }
 
-func (m Model) handleBulkCyclePriority() (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
+// gives every selected task the priority next returns for its current one,
+// locked tasks are skipped
+func (m Model) bulkChangePriority(next func(domain.Priority) domain.Priority) (tea.Model, tea.Cmd) {
+	if m.overBulkLimit() {
+		return m, nil
+	}
+
+	var tasks []*domain.Task
+	skipped := 0
 
 	for _, task := range m.tasks {
 		if m.multiSelect.selectedTasks[task.ID] {
-			switch task.Priority {
-			case domain.PriorityLow:
-				task.Priority = domain.PriorityMedium
-			case domain.PriorityMedium:
-				task.Priority = domain.PriorityHigh
-			case domain.PriorityHigh:
-				task.Priority = domain.PriorityUrgent
-			case domain.PriorityUrgent:
-				task.Priority = domain.PriorityLow
-			default:
-				task.Priority = domain.PriorityMedium
+			if task.IsLocked {
+				skipped++
+				continue
 			}
-			cmds = append(cmds, updateTaskCmd(m.ctx, m.repo, task))
+			task.Priority = next(task.Priority)
+			tasks = append(tasks, task)
 		}
 	}
 
 	m.multiSelect.selectedTasks = make(map[int64]bool)
+	m.bulkResult = ""
 	m.loading = true
-	return m, tea.Batch(cmds...)
+	return m, bulkUpdateTasksCmd(m.ctx, m.repo, tasks, skipped, nil)
 }
 
-func (m Model) handleBulkToggleStatus() (tea.Model, tea.Cmd) {
-	var cmds []tea.Cmd
+// active projects in the order the project tree shows them, children right
+// after their parent
+func (m Model) projectJumpOrder() []*domain.Project {
+	if m.projectTree == nil {
+		return nil
+	}
 
-	for _, task := range m.tasks {
-		if m.multiSelect.selectedTasks[task.ID] {
-			switch task.Status {
-				case domain.StatusPending:
-					task.Status = domain.StatusInProgress
-				case domain.StatusInProgress:
-					task.Status = domain.StatusPending
-				default:
-					task.Status = domain.StatusInProgress
+	var order []*domain.Project
+	var walk func(nodes []*ProjectTreeNode)
+	walk = func(nodes []*ProjectTreeNode) {
+		for _, node := range nodes {
+			if node.project.Status == domain.ProjectStatusActive {
+				order = append(order, node.project)
 			}
-			cmds = append(cmds, updateTaskCmd(m.ctx, m.repo, task))
+			walk(node.children)
 		}
 	}
+	walk(m.projectTree.roots)
+	return order
+}
 
-	m.multiSelect.selectedTasks = make(map[int64]bool)
+// moves the project filter step projects along the tree. with no project
+// filter the first jump goes to the first or last project
+func (m Model) jumpProject(step int) (tea.Model, tea.Cmd) {
+	order := m.projectJumpOrder()
+	if len(order) == 0 {
+		m.message = "No projects to jump to"
+		return m, nil
+	}
+
+	current := -1
+	if m.filter.ProjectID != nil {
+		for i, project := range order {
+			if project.ID == *m.filter.ProjectID {
+				current = i
+				break
+			}
+		}
+	}
+
+	next := current + step
+	if current == -1 && step < 0 {
+		next = len(order) - 1
+	}
+	if next < 0 || next >= len(order) {
+		if !m.projectJumpWrap {
+			if step > 0 {
+				m.message = "Already at the last project"
+			} else {
+				m.message = "Already at the first project"
+			}
+			return m, nil
+		}
+		next = (next + len(order)) % len(order)
+	}
+
+	projectID := order[next].ID
+	m.filter.ProjectID = &projectID
+	m.viewMode = tableView
+	m.message = ""
+	m.currentPage = 1
 	m.loading = true
-	return m, tea.Batch(cmds...)
+	return m, m.refreshCmd()
+}
+
+// refuses a bulk action on more selected tasks than bulk_max_affected allows
+func (m *Model) overBulkLimit() bool {
+	count := len(m.multiSelect.selectedTasks)
+	if m.bulkMaxAffected == 0 || count <= m.bulkMaxAffected {
+		return false
+	}
+	m.err = fmt.Errorf("%d tasks selected, more than bulk_max_affected (%d). Select fewer tasks or raise the limit", count, m.bulkMaxAffected)
+	return true
+}
+
+// pending <-> in_progress, anything else goes back to in_progress
+func nextToggleStatus(status domain.Status) domain.Status {
+	if status == domain.StatusInProgress {
+		return domain.StatusPending
+	}
+	return domain.StatusInProgress
+}
+
+func (m Model) openBulkTagInput(remove bool) (tea.Model, tea.Cmd) {
+	if m.overBulkLimit() {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = "tag (comma-separated for several)"
+	input.CharLimit = 200
+	input.Width = 56
+	input.Focus()
+	m.bulkTag = bulkTagInput{active: true, remove: remove, input: input}
+	return m, textinput.Blink
+}
+
+func (m Model) updateBulkTagInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.bulkTag.input, cmd = m.bulkTag.input.Update(msg)
+		model, normalCmd := m.updateNormalMode(msg)
+		return model, tea.Batch(cmd, normalCmd)
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.bulkTag.active = false
+		return m, nil
+
+	case tea.KeyEnter:
+		var tags []string
+		for _, tag := range strings.Split(m.bulkTag.input.Value(), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			return m, nil
+		}
+
+		locked := make(map[int64]bool)
+		for _, task := range m.tasks {
+			if task.IsLocked {
+				locked[task.ID] = true
+			}
+		}
+
+		taskIDs := make([]int64, 0, len(m.multiSelect.selectedTasks))
+		skipped := 0
+		for taskID := range m.multiSelect.selectedTasks {
+			if locked[taskID] {
+				skipped++
+				continue
+			}
+			taskIDs = append(taskIDs, taskID)
+		}
+		sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+
+		m.bulkTag.active = false
+		m.multiSelect.selectedTasks = make(map[int64]bool)
+		m.bulkResult = ""
+		m.loading = true
+		return m, bulkTagTasksCmd(m.ctx, m.repo, taskIDs, tags, m.bulkTag.remove, skipped)
+	}
+
+	var cmd tea.Cmd
+	m.bulkTag.input, cmd = m.bulkTag.input.Update(keyMsg)
+	return m, cmd
 }
 
 func (m Model) handleBulkDelete() (tea.Model, tea.Cmd) {
@@ -1595,21 +2558,40 @@ func (m Model) handleBulkDelete() (tea.Model, tea.Cmd) {
 	if count == 0 {
 		return m, nil
 	}
+	if m.overBulkLimit() {
+		return m, nil
+	}
 
-	m.confirm = confirmDialog{
-		message: fmt.Sprintf("Delete %d task(s)?", count),
-		active:  true,
+	cmd := m.openConfirm(confirmDialog{
+		message:     fmt.Sprintf("Delete %d task(s)?", count),
+		confirmWord: "delete",
 		onConfirm: func(model *Model) tea.Cmd {
-			var cmds []tea.Cmd
+			locked := make(map[int64]bool)
+			for _, task := range model.tasks {
+				if task.IsLocked {
+					locked[task.ID] = true
+				}
+			}
+
+			taskIDs := make([]int64, 0, len(model.multiSelect.selectedTasks))
+			skipped := 0
 			for taskID := range model.multiSelect.selectedTasks {
-				cmds = append(cmds, deleteTaskCmd(model.ctx, model.repo, taskID))
+				if locked[taskID] {
+					skipped++
+					continue
+				}
+				taskIDs = append(taskIDs, taskID)
 			}
+			sort.Slice(taskIDs, func(i, j int) bool { return taskIDs[i] < taskIDs[j] })
+
 			model.multiSelect.selectedTasks = make(map[int64]bool)
-			return tea.Batch(cmds...)
+			model.bulkResult = ""
+			model.loading = true
+			return bulkDeleteTasksCmd(model.ctx, model.repo, taskIDs, skipped)
 		},
-	}
+	})
 
-	return m, nil
+	return m, cmd
 }
 
 
@@ -1719,13 +2701,13 @@ func (m Model) handleProjectViewKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			confirmMsg += "\n  - Associated tasks will be orphaned (project_id set to NULL)"
 
-			m.confirm = confirmDialog{
-				message: confirmMsg,
-				active:  true,
+			return m, m.openConfirm(confirmDialog{
+				message:     confirmMsg,
+				confirmWord: "delete",
 				onConfirm: func(model *Model) tea.Cmd {
 					return deleteProjectCmd(model.ctx, model.projectRepo, project.ID)
 				},
-			}
+			})
 		}
 		return m, nil
 
@@ -1746,9 +2728,8 @@ func (m Model) handleProjectViewKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				confirmMsg += fmt.Sprintf("\n  - %d child project(s) will also be archived", childCount)
 			}
 
-			m.confirm = confirmDialog{
+			return m, m.openConfirm(confirmDialog{
 				message: confirmMsg,
-				active:  true,
 				onConfirm: func(model *Model) tea.Cmd {
 					if isArchived {
 						updatedProject := *project
@@ -1757,7 +2738,7 @@ func (m Model) handleProjectViewKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 					return archiveProjectCmd(model.ctx, model.projectRepo, project.ID)
 				},
-			}
+			})
 		}
 		return m, nil
 
@@ -1787,18 +2768,18 @@ func (m Model) handleProjectViewKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.viewPicker.active = true
 		m.viewPicker.cursor = 0
 		m.viewPicker.views = m.savedViews
-		m.message = "View Picker (↑/↓: navigate, enter: select, esc: cancel)"
-		return m, nil
+		m.message = "View Picker (↑/↓: navigate, enter: select, c: counts, esc: cancel)"
+		return m, m.viewCountsCmd()
 
 	case key.Matches(msg, m.keys.FavoriteViews):
 		if len(m.favoriteViews) > 0 {
 			m.viewPicker.active = true
 			m.viewPicker.cursor = 0
 			m.viewPicker.views = m.favoriteViews
-			m.message = "Favorite Views (↑/↓: navigate, enter: select, esc: cancel)"
-		} else {
-			m.message = "No favorite views"
+			m.message = "Favorite Views (↑/↓: navigate, enter: select, c: counts, esc: cancel)"
+			return m, m.viewCountsCmd()
 		}
+		m.message = "No favorite views"
 		return m, nil
 
 	case key.Matches(msg, m.keys.QuickAccess1):
@@ -1887,7 +2868,21 @@ func (m Model) updateViewPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, applyViewCmd(m.ctx, m.viewRepo, selectedView.ID)
 			}
 			return m, nil
+
+		case "c":
+			m.viewPicker.showCounts = !m.viewPicker.showCounts
+			return m, m.viewCountsCmd()
+		}
+
+	case viewCountsMsg:
+		m.viewPicker.countsLoading = false
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Failed to count view tasks: %v", msg.err)
+			return m, nil
 		}
+		m.viewPicker.counts = msg.counts
+		m.viewPicker.countsAt = time.Now()
+		return m, nil
 	case viewsLoadedMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -1917,13 +2912,7 @@ func (m Model) updateViewPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		m.selectedView = msg.view
-		m.filter = m.convertViewFilterToTaskFilter(msg.view.FilterConfig)
-		m.currentPage = 1
-		m.message = fmt.Sprintf("Applied view: %s", msg.view.Name)
-
-		m.loading = true
-		return m, fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize)
+		return m.applyView(msg.view)
 
 	case viewCreatedMsg:
 		if msg.err != nil {
@@ -1985,29 +2974,95 @@ func (m Model) applyQuickAccessView(hotKey int) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	_ = m.viewRepo.RecordViewAccess(m.ctx, view.ID)
+
+	return m.applyView(view)
+}
+
+// makes the view the current one and loads its tasks. it stays current when
+// the filter is changed afterwards, so it can be re-applied with R
+func (m Model) applyView(view *domain.SavedView) (tea.Model, tea.Cmd) {
 	m.selectedView = view
+	m.viewNotice = ""
 	m.filter = m.convertViewFilterToTaskFilter(view.FilterConfig)
+	m.clearQueryMode()
 	m.currentPage = 1
 	m.message = fmt.Sprintf("Applied view: %s", view.Name)
 
-	_ = m.viewRepo.RecordViewAccess(m.ctx, view.ID)
+	m.loading = true
+	return m, m.refreshCmd()
+}
+
+// counts the tasks of the saved views for the picker badges, nil when they
+// are off, being counted or all counted less than viewCountsTTL ago
+func (m *Model) viewCountsCmd() tea.Cmd {
+	picker := &m.viewPicker
+	if !picker.showCounts || picker.countsLoading {
+		return nil
+	}
+	if time.Since(picker.countsAt) < viewCountsTTL && m.allViewsCounted() {
+		return nil
+	}
+
+	filters := make(map[int64]repository.TaskFilter, len(m.savedViews))
+	for _, view := range m.savedViews {
+		filters[view.ID] = m.convertViewFilterToTaskFilter(view.FilterConfig)
+	}
+	picker.countsLoading = true
+	return fetchViewCountsCmd(m.ctx, m.repo, m.projectRepo, filters)
+}
+
+func (m Model) allViewsCounted() bool {
+	for _, view := range m.savedViews {
+		if _, ok := m.viewPicker.counts[view.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
 
+// switches to the tasks that can be started now, scoped to the filtered
+// project if there is one, and back to the previous filter
+func (m Model) toggleReadyQueue() (tea.Model, tea.Cmd) {
+	if m.readyReturn != nil {
+		m.filter = *m.readyReturn
+		m.readyReturn = nil
+		m.message = "Left the ready queue"
+	} else {
+		previous := m.filter
+		m.readyReturn = &previous
+		m.filter = repository.ReadyFilter(m.filter.ProjectID)
+		m.filter.PinnedFirst = m.pinnedFirst
+		m.clearQueryMode()
+		m.message = "Ready queue: open tasks that aren't snoozed, waiting or blocked, most urgent first"
+	}
+
+	m.currentPage = 1
 	m.loading = true
-	return m, fetchTasksCmd(m.ctx, m.repo, m.filter, m.currentPage, m.pageSize)
+	return m, m.refreshCmd()
+}
+
+func (m *Model) clearQueryMode() {
+	m.queryMode = false
+	m.queryString = ""
+	m.queryProjects = nil
 }
 
 func (m *Model) convertViewFilterToTaskFilter(vf domain.SavedViewFilter) repository.TaskFilter {
 	return repository.TaskFilter{
-		Status:      vf.Status,
-		Priority:    vf.Priority,
-		ProjectID:   vf.ProjectID,
-		Tags:        vf.Tags,
-		SearchQuery: vf.SearchQuery,
-		SearchMode:  vf.SearchMode,
-		SortBy:      vf.SortBy,
-		SortOrder:   vf.SortOrder,
-		DueDateFrom: vf.DueDateFrom,
-		DueDateTo:   vf.DueDateTo,
+		Status:       vf.Status,
+		Priority:     vf.Priority,
+		ProjectID:    vf.ProjectID,
+		Tags:         vf.Tags,
+		SearchQuery:  vf.SearchQuery,
+		SearchMode:   vf.SearchMode,
+		SortBy:       vf.SortBy,
+		SortOrder:    vf.SortOrder,
+		DueDateFrom:  vf.DueDateFrom,
+		DueDateTo:    vf.DueDateTo,
+		PinnedFirst:  m.pinnedFirst,
+		HideSnoozed:  m.hideSnoozed,
+		HideArchived: m.hideArchived,
 	}
 }
 
@@ -2020,3 +3075,143 @@ func sliceRemoveByID(views []*domain.SavedView, id int64) []*domain.SavedView {
 	}
 	return result
 }
+
+func (m Model) updateInboxCapture(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		// the list keeps loading behind the overlay, the input only needs
+		// its cursor blinks
+		var cmd tea.Cmd
+		m.inboxCapture.input, cmd = m.inboxCapture.input.Update(msg)
+		model, normalCmd := m.updateNormalMode(msg)
+		return model, tea.Batch(cmd, normalCmd)
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.inboxCapture.active = false
+		return m, nil
+
+	case tea.KeyEnter:
+		title := strings.TrimSpace(m.inboxCapture.input.Value())
+		if title == "" {
+			return m, nil
+		}
+		m.inboxCapture.active = false
+		m.loading = true
+		return m, captureToInboxCmd(m.ctx, m.repo, m.projectRepo, title)
+	}
+
+	var cmd tea.Cmd
+	m.inboxCapture.input, cmd = m.inboxCapture.input.Update(keyMsg)
+	return m, cmd
+}
+
+func (m Model) startTitleEdit() (tea.Model, tea.Cmd) {
+	task := m.getSelectedTask()
+	if task == nil {
+		return m, nil
+	}
+	if m.refuseLocked(task) {
+		return m, nil
+	}
+
+	input := textinput.New()
+	input.CharLimit = 200
+	input.Width = max(m.width-12, 20)
+	input.SetValue(task.Title)
+	input.CursorEnd()
+	input.Focus()
+	m.titleEdit = titleEdit{active: true, task: task, input: input}
+	m.resizeTable()
+	return m, textinput.Blink
+}
+
+// saves only the title. the update goes through a copy so a failed save
+// leaves the loaded task as it was, the refresh after it brings the change in
+func (m Model) updateTitleEdit(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.titleEdit.input, cmd = m.titleEdit.input.Update(msg)
+		model, normalCmd := m.updateNormalMode(msg)
+		return model, tea.Batch(cmd, normalCmd)
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.titleEdit.active = false
+		m.resizeTable()
+		return m, nil
+
+	case tea.KeyEnter:
+		title := strings.TrimSpace(m.titleEdit.input.Value())
+		if title == "" {
+			m.message = "Title can't be empty"
+			return m, nil
+		}
+		task := m.titleEdit.task
+		m.titleEdit.active = false
+		m.resizeTable()
+		if title == task.Title {
+			return m, nil
+		}
+
+		edited := *task
+		edited.Title = title
+		m.loading = true
+		return m, updateTaskCmd(m.ctx, m.repo, &edited)
+	}
+
+	var cmd tea.Cmd
+	m.titleEdit.input, cmd = m.titleEdit.input.Update(keyMsg)
+	return m, cmd
+}
+
+func (m Model) updateTagCloud(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tagCountsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			m.tagCloud.active = false
+			return m, nil
+		}
+		m.tagCloud.tags = msg.tags
+		m.tagCloud.cursor = 0
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "T", "q":
+			m.tagCloud.active = false
+			return m, nil
+
+		case "left", "h", "up", "k", "shift+tab":
+			if m.tagCloud.cursor > 0 {
+				m.tagCloud.cursor--
+			}
+			return m, nil
+
+		case "right", "l", "down", "j", "tab":
+			if m.tagCloud.cursor < len(m.tagCloud.tags)-1 {
+				m.tagCloud.cursor++
+			}
+			return m, nil
+
+		case "enter":
+			if m.tagCloud.cursor >= len(m.tagCloud.tags) {
+				return m, nil
+			}
+			tag := m.tagCloud.tags[m.tagCloud.cursor].Tag
+
+			m.tagCloud.active = false
+			m.filter.Tags = []string{tag}
+			m.currentPage = 1
+			m.viewMode = tableView
+			m.loading = true
+			return m, m.refreshCmd()
+		}
+	}
+
+	return m, nil
+}
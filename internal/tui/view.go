@@ -2,25 +2,31 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"task-management/internal/display"
 	"task-management/internal/domain"
 	"task-management/internal/query"
 )
 
 func (m Model) View() string {
-	if m.loading {
-		return m.styles.TUITitle.Render("Loading...") + "\n"
+	if m.tooSmall {
+		return m.styles.Error.Render(fmt.Sprintf("Terminal too small (need at least %dx%d)", minTerminalWidth, minTerminalHeight)) + "\n"
+	}
+
+	// only the first load blanks the screen, refreshes keep what is shown
+	// and put the spinner in the status bar
+	if m.loading && !m.loaded {
+		return m.styles.TUITitle.Render(m.spinner.View()+" Loading...") + "\n"
 	}
 
 	var b strings.Builder
 
-	title := m.styles.TUITitle.Render("  TaskFlow TUI  ")
-	b.WriteString(title)
-	b.WriteString("\n")
+	b.WriteString(m.renderTitle())
 
 	if m.confirm.active {
 		b.WriteString("\n")
@@ -43,6 +49,27 @@ func (m Model) View() string {
 		return b.String()
 	}
 
+	if m.tagCloud.active {
+		b.WriteString("\n")
+		b.WriteString(m.renderTagCloud())
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if m.inboxCapture.active {
+		b.WriteString("\n")
+		b.WriteString(m.renderInboxCapture())
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	if m.bulkTag.active {
+		b.WriteString("\n")
+		b.WriteString(m.renderBulkTagInput())
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	if m.notesViewer.active {
 		b.WriteString("\n")
 		b.WriteString(m.renderNotesViewer())
@@ -97,11 +124,34 @@ func (m Model) View() string {
 		b.WriteString(m.renderEditForm())
 	case projectView:
 		b.WriteString(m.renderProjectView())
+	case kanbanView:
+		b.WriteString(m.renderKanbanView())
 	}
 
 	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+
+	return b.String()
+}
+
+func (m Model) renderTitle() string {
+	if m.compact {
+		return ""
+	}
+	return m.styles.TUITitle.Render("  TaskFlow TUI  ") + "\n"
+}
+
+func (m Model) renderFooter() string {
+	var b strings.Builder
 
-	if (m.viewMode == tableView || m.viewMode == detailView) && len(m.quickAccessViews) > 0 {
+	if m.titleEdit.active {
+		b.WriteString(m.styles.TUISubtitle.Render("✎ Title: ") + m.titleEdit.input.View())
+		b.WriteString("\n")
+		b.WriteString(m.styles.TUIHelp.Render("Enter: save  •  Esc: cancel"))
+		return b.String()
+	}
+
+	if !m.compact && (m.viewMode == tableView || m.viewMode == detailView) && len(m.quickAccessViews) > 0 {
 		b.WriteString(m.renderQuickAccessWidget())
 		b.WriteString("\n")
 	}
@@ -118,28 +168,65 @@ func (m Model) View() string {
 	return b.String()
 }
 
+// minTableHeight keeps a few rows visible even when the chrome fills the screen
+const minTableHeight = 3
+
+// below this the layout breaks, View asks for a bigger window instead
+const (
+	minTerminalWidth  = 60
+	minTerminalHeight = 20
+)
+
+// counts the lines View renders around the table instead of assuming a fixed
+// amount, so the table grows and shrinks with filters, messages and help
+func (m Model) tableChromeHeight() int {
+	chrome := m.renderTitle() + m.renderTableHeader() + "\n" + m.renderFooter()
+	return strings.Count(chrome, "\n")
+}
+
+func (m *Model) resizeTable() {
+	if m.height == 0 {
+		return
+	}
+
+	height := m.height - m.tableChromeHeight()
+	if height < minTableHeight {
+		height = minTableHeight
+	}
+	m.table.SetHeight(height)
+}
+
 func (m Model) renderTableView() string {
+	return m.renderTableHeader() + m.renderTableBody()
+}
+
+func (m Model) renderTableHeader() string {
 	var b strings.Builder
 
-	if queryIndicator := m.renderQueryModeIndicator(); queryIndicator != "" {
-		b.WriteString(queryIndicator)
+	if header := m.renderContextHeader(); header != "" {
+		b.WriteString(header)
 		b.WriteString("\n")
 	}
 
-	if m.hasActiveFilters() {
-		b.WriteString(m.renderFilterSummary())
-		b.WriteString("\n")
+	gap := "\n\n"
+	if m.compact {
+		gap = "\n"
 	}
-
 	if m.message != "" {
 		b.WriteString(m.styles.Success.Render(m.message))
-		b.WriteString("\n\n")
+		b.WriteString(gap)
 	}
 	if m.err != nil {
 		b.WriteString(m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err)))
-		b.WriteString("\n\n")
+		b.WriteString(gap)
 	}
 
+	return b.String()
+}
+
+func (m Model) renderTableBody() string {
+	var b strings.Builder
+
 	if len(m.tasks) == 0 {
 		if m.hasActiveFilters() {
 			b.WriteString(m.styles.Info.Render("No tasks found matching the filters."))
@@ -162,6 +249,11 @@ func (m Model) renderDetailView() string {
 	task := m.selectedTask
 	var b strings.Builder
 
+	if header := m.renderContextHeader(); header != "" {
+		b.WriteString(header)
+		b.WriteString("\n")
+	}
+
 	if m.message != "" {
 		b.WriteString(m.styles.Success.Render(m.message))
 		b.WriteString("\n\n")
@@ -175,43 +267,84 @@ func (m Model) renderDetailView() string {
 		breadcrumbStyle := lipgloss.NewStyle().
 			Foreground(lipgloss.Color(m.theme.Info)).
 			Italic(true)
-		b.WriteString(breadcrumbStyle.Render("📍 " + breadcrumb))
+		prefix := "📍 "
+		b.WriteString(breadcrumbStyle.Render(prefix + fitBreadcrumb(breadcrumb, m.width-lipgloss.Width(prefix))))
 		b.WriteString("\n\n")
 	}
 
 	content := []string{}
 
 	content = append(content, m.renderDetailRow("ID:", fmt.Sprintf("#%d", task.ID)))
-	content = append(content, m.renderDetailRow("Title:", task.Title))
+	if key := task.Key(); key != "" {
+		content = append(content, m.renderDetailRow("Key:", key))
+	}
+	content = append(content, m.renderDetailRow("Title:", m.highlightDetailText(task.Title, 60)))
 
 	if task.Description != "" {
-		content = append(content, m.renderDetailRow("Description:", wrapText(task.Description, 60)))
+		content = append(content, m.renderDetailRow("Description:", m.highlightDetailText(task.Description, 60)))
 	}
 
 	statusStyle := m.styles.GetStatusStyle(task.Status)
-	statusText := statusStyle.Render(string(task.Status))
+	statusText := statusStyle.Render(m.styles.StatusLabel(task.Status))
 	content = append(content, m.renderDetailRow("Status:", statusText))
 
 	priorityStyle := m.styles.GetPriorityTextStyle(task.Priority)
-	priorityText := priorityStyle.Render(string(task.Priority))
+	priorityText := priorityStyle.Render(m.styles.PriorityLabel(task.Priority))
 	content = append(content, m.renderDetailRow("Priority:", priorityText))
 
 	if task.ProjectName != "" {
-		content = append(content, m.renderDetailRow("Project:", task.ProjectName))
+		content = append(content, m.renderDetailRow("Project:", wrapText(task.ProjectName, 60)))
 	}
 
 	if len(task.Tags) > 0 {
 		tagsText := strings.Join(task.Tags, ", ")
-		content = append(content, m.renderDetailRow("Tags:", tagsText))
+		content = append(content, m.renderDetailRow("Tags:", wrapText(tagsText, 60)))
+	}
+
+	if task.IsLocked {
+		content = append(content, m.renderDetailRow("Locked:", "🔒 yes"))
+	}
+	if task.IsPinned {
+		content = append(content, m.renderDetailRow("Pinned:", "📌 yes"))
+	}
+
+	if task.IsSnoozed(time.Now()) {
+		content = append(content, m.renderDetailRow("Snoozed:", "💤 until "+task.SnoozedUntil.Format("2006-01-02 15:04")))
+	}
+
+	if task.WaitingOn != "" {
+		content = append(content, m.renderDetailRow("Waiting on:", "⏳ "+task.WaitingOn))
+	}
+
+	if task.Recurrence != "" {
+		content = append(content, m.renderDetailRow("Repeats:", "↻ "+task.Recurrence))
+	}
+
+	for i, blocker := range m.blockers {
+		label := "Blocked by:"
+		if i > 0 {
+			label = strings.Repeat(" ", len(label))
+		}
+		content = append(content, m.renderDetailRow(label, fmt.Sprintf("⛔ #%d %s (%s)", blocker.ID, blocker.Title, m.styles.StatusLabel(blocker.Status))))
+	}
+
+	if task.Color != "" {
+		content = append(content, m.renderDetailRow("Color:", lipgloss.NewStyle().Foreground(terminalColor(task.Color)).Render(task.Color)))
 	}
 
 	if task.DueDate != nil {
-		dueText := formatDetailDueDate(task.DueDate)
+		dueText := formatDetailDueDate(task.DueDate, m.overdueGraceDays)
 		content = append(content, m.renderDetailRow("Due Date:", dueText))
 	}
 
 	content = append(content, m.renderDetailRow("Created:", task.CreatedAt.Format("2006-01-02 15:04:05")))
-	content = append(content, m.renderDetailRow("Updated:", task.UpdatedAt.Format("2006-01-02 15:04:05")))
+	content = append(content, m.renderDetailRow("Updated:", fmt.Sprintf("%s (%s)",
+		task.UpdatedAt.Format("2006-01-02 15:04:05"), display.FormatRelativeTime(task.UpdatedAt, time.Now()))))
+
+	if timeToDone, ok := task.TimeToDone(); ok {
+		content = append(content, m.renderDetailRow("Completed:", task.CompletedAt.Format("2006-01-02 15:04:05")))
+		content = append(content, m.renderDetailRow("Time to Done:", display.FormatElapsed(timeToDone)))
+	}
 
 	cardContent := strings.Join(content, "\n")
 	card := m.styles.DetailContainer.Render(cardContent)
@@ -264,7 +397,14 @@ func (m Model) renderSearchMode() string {
 		b.WriteString("\n")
 	}
 
-	hint := m.styles.TUIHelp.Render("Tip: Press 'f' for fuzzy • Use 're:' for regex • Use '@project' to filter by project")
+	if m.incrementalSearch {
+		b.WriteString(m.styles.Success.Render("● Live Search ON"))
+	} else {
+		b.WriteString(m.styles.TUIHelp.Render("○ Live Search OFF"))
+	}
+	b.WriteString("\n")
+
+	hint := m.styles.TUIHelp.Render("Tip: Press 'f' for fuzzy • 'ctrl+t' for live search • Use 're:' for regex • Use '@project' to filter by project")
 	b.WriteString(hint)
 
 	return b.String()
@@ -311,28 +451,83 @@ func (m Model) renderSearchHistoryDropdown() string {
 	return b.String()
 }
 
-func (m Model) renderQueryModeIndicator() string {
-	if !m.queryMode || m.queryString == "" {
+// one header describing what the task list shows: the saved view, the query
+// or the plain filters. shared by the table and detail views so the context
+// stays visible after opening a task
+func (m Model) renderContextHeader() string {
+	querying := m.queryMode && m.queryString != ""
+
+	var parts []string
+	if view := m.activeView(); view != nil {
+		parts = append(parts, fmt.Sprintf("View: %s", view.Name))
+	}
+	if querying {
+		parts = append(parts, fmt.Sprintf("Query: %s", m.queryString))
+	} else if m.hasActiveFilters() {
+		parts = append(parts, m.filterSummary())
+	}
+	if len(parts) == 0 {
 		return ""
 	}
 
 	var b strings.Builder
 
-	indicator := m.styles.Success.Render("🔍 Query Language Mode")
-	b.WriteString(indicator)
-	b.WriteString("\n")
-
-	queryDisplay := m.styles.Info.Render(fmt.Sprintf("Active Query: %s", m.queryString))
-	b.WriteString(queryDisplay)
+	line := "🔍 " + strings.Join(parts, " • ")
+	if m.width > 0 {
+		line = display.TruncateText(line, m.width)
+	}
+	b.WriteString(m.styles.Info.Render(line))
 	b.WriteString("\n")
 
-	helpHint := m.styles.TUIHelp.Render("Press '?' for query syntax help")
-	b.WriteString(helpHint)
-	b.WriteString("\n")
+	if querying {
+		for _, resolution := range m.queryProjects {
+			b.WriteString(m.styles.Info.Render(fmt.Sprintf("   Project: %s", resolution)))
+			b.WriteString("\n")
+		}
+		if !m.compact {
+			b.WriteString(m.styles.TUIHelp.Render("   Press '?' for query syntax help"))
+			b.WriteString("\n")
+		}
+	}
 
 	return b.String()
 }
 
+// the applied saved view, as long as the filter has not been changed since
+func (m Model) activeView() *domain.SavedView {
+	if m.selectedView == nil || m.queryMode {
+		return nil
+	}
+
+	vf := m.selectedView.FilterConfig
+	f := m.filter
+	if f.Status != vf.Status || f.Priority != vf.Priority ||
+		f.SearchQuery != vf.SearchQuery || f.SearchMode != vf.SearchMode ||
+		f.PinnedOnly || f.WaitingOnly ||
+		!equalInt64Ptr(f.ProjectID, vf.ProjectID) ||
+		!equalStringPtr(f.DueDateFrom, vf.DueDateFrom) ||
+		!equalStringPtr(f.DueDateTo, vf.DueDateTo) ||
+		strings.Join(f.Tags, ",") != strings.Join(vf.Tags, ",") {
+		return nil
+	}
+
+	return m.selectedView
+}
+
+func equalInt64Ptr(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func getQueryHelpContent() string {
 	content := `
 Query Language Syntax Reference
@@ -341,27 +536,31 @@ FIELD FILTERS:
   status:<value>       Filter by status (pending, in_progress, completed, cancelled)
   priority:<value>     Filter by priority (low, medium, high, urgent)
   tag:<value>          Filter by tag
-  project:<name>       Filter by project name
+  project:<name>       Filter by project name or alias
 
 NEGATION:
   -tag:<value>         Exclude tasks with tag
   -status:<value>      Exclude tasks with status
 
 PROJECT MENTIONS:
-  @<name>              Exact project name match
-  @~<name>             Fuzzy project name match
+  @<name>              Exact project name or alias match
+  @~<name>             Fuzzy project name or alias match
 
 DATE FILTERS:
   due:<date>           Due on specific date (YYYY-MM-DD)
   due:+<N>d            Due in next N days
   due:-<N>d            Due in last N days (overdue)
+  due:overdue          Overdue, honouring overdue_grace_days
   due:today            Due today
   due:tomorrow         Due tomorrow
   due:none             No due date
 
 COMBINING FILTERS:
-  Use spaces to combine multiple filters
+  Use spaces to combine multiple filters, all of them have to match
   Example: status:pending priority:high @backend -tag:wontfix
+  a OR b, a | b        Either side matches
+  ( ... )              Groups filters, e.g. (@api | @web) tag:bug
+  Spaces bind tighter than OR: a b OR c means (a b) OR c
 
 EXAMPLES:
   status:pending @frontend
@@ -411,6 +610,8 @@ func (m Model) renderFilterPanel() string {
 
 	label := m.styles.TUISubtitle.Render("Filter Options:")
 	b.WriteString(label)
+	b.WriteString("\n")
+	b.WriteString(m.styles.TUIHelp.Render("Active: " + m.filterPanelSummary()))
 	b.WriteString("\n\n")
 
 	for i, item := range m.filterPanel.items {
@@ -428,6 +629,8 @@ func (m Model) renderFilterPanel() string {
 			isActive = true
 		} else if item.filterType == "duedate" {
 			isActive = m.isDateFilterActive(item.value)
+		} else if item.filterType == "snoozed" {
+			isActive = (item.value == "show") != m.hideSnoozed
 		}
 
 		if i == m.filterPanel.selectedItem {
@@ -449,6 +652,22 @@ func (m Model) renderFilterPanel() string {
 	return b.String()
 }
 
+// the current filters in one line, so the panel shows them without scanning
+// for marks
+func (m Model) filterPanelSummary() string {
+	summary := m.filterSummary()
+	if !m.hideSnoozed {
+		if summary != "" {
+			summary += " | "
+		}
+		summary += "Snoozed shown"
+	}
+	if summary == "" {
+		return "none"
+	}
+	return summary
+}
+
 func (m Model) isDateFilterActive(value string) bool {
 	if m.filter.DueDateFrom == nil && m.filter.DueDateTo == nil {
 		return value == ""
@@ -458,30 +677,11 @@ func (m Model) isDateFilterActive(value string) bool {
 		return value == "none"
 	}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-
 	switch value {
-	case "overdue":
-		yesterday := today.AddDate(0, 0, -1).Format("2006-01-02")
-		return m.filter.DueDateFrom == nil && m.filter.DueDateTo != nil && *m.filter.DueDateTo == yesterday
-	case "today":
-		todayStr := today.Format("2006-01-02")
-		tomorrowStr := today.AddDate(0, 0, 1).Format("2006-01-02")
-		return m.filter.DueDateFrom != nil && *m.filter.DueDateFrom == todayStr &&
-			m.filter.DueDateTo != nil && *m.filter.DueDateTo == tomorrowStr
-	case "week":
-		todayStr := today.Format("2006-01-02")
-		weekStr := today.AddDate(0, 0, 7).Format("2006-01-02")
-		return m.filter.DueDateFrom != nil && *m.filter.DueDateFrom == todayStr &&
-			m.filter.DueDateTo != nil && *m.filter.DueDateTo == weekStr
-	case "month":
-		todayStr := today.Format("2006-01-02")
-		monthStr := today.AddDate(0, 0, 30).Format("2006-01-02")
-		return m.filter.DueDateFrom != nil && *m.filter.DueDateFrom == todayStr &&
-			m.filter.DueDateTo != nil && *m.filter.DueDateTo == monthStr
+	case "overdue", "today", "week", "month":
+		from, to := query.DueDateRange(value, time.Now(), m.overdueGraceDays)
+		return equalStringPtr(m.filter.DueDateFrom, from) && equalStringPtr(m.filter.DueDateTo, to)
 	}
-
 	return false
 }
 
@@ -494,6 +694,13 @@ func (m Model) renderConfirmDialog() string {
 		Render(m.confirm.message)
 
 	prompt := m.styles.TUISubtitle.Render("Are you sure? (y/n)")
+	if m.confirm.confirmWord != "" {
+		prompt = m.styles.TUISubtitle.Render(fmt.Sprintf("Type '%s' and press Enter to confirm, Esc to cancel", m.confirm.confirmWord)) +
+			"\n\n> " + m.confirm.typed + "█"
+		if m.confirmTimeout > 0 {
+			prompt += "\n\n" + m.styles.TUIHelp.Render(fmt.Sprintf("Cancels after %s without input", m.confirmTimeout))
+		}
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left, message, "", prompt)
 	box := lipgloss.NewStyle().
@@ -564,6 +771,99 @@ func (m Model) renderProjectPicker() string {
 	return box
 }
 
+func (m Model) renderInboxCapture() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.TUISubtitle.Render("📥 Capture to " + domain.InboxProjectName))
+	b.WriteString("\n\n")
+	b.WriteString(m.inboxCapture.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.TUIHelp.Render("Enter: capture  •  Esc: cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.BorderColor)).
+		Padding(1, 2).
+		Width(64).
+		Render(b.String())
+}
+
+func (m Model) renderBulkTagInput() string {
+	var b strings.Builder
+
+	title := fmt.Sprintf("🏷  Add tag to %d selected task(s)", len(m.multiSelect.selectedTasks))
+	if m.bulkTag.remove {
+		title = fmt.Sprintf("🏷  Remove tag from %d selected task(s)", len(m.multiSelect.selectedTasks))
+	}
+	b.WriteString(m.styles.TUISubtitle.Render(title))
+	b.WriteString("\n\n")
+	b.WriteString(m.bulkTag.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.TUIHelp.Render("Enter: apply  •  Esc: cancel"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.BorderColor)).
+		Padding(1, 2).
+		Width(64).
+		Render(b.String())
+}
+
+// tags flow left to right most used first, weight picks the color and the
+// most used tags are bold
+func (m Model) renderTagCloud() string {
+	var b strings.Builder
+
+	b.WriteString(m.styles.TUISubtitle.Render("Tag Cloud"))
+	b.WriteString("\n\n")
+
+	const lineWidth = 56
+
+	if len(m.tagCloud.tags) == 0 {
+		b.WriteString(m.styles.Info.Render("No tags yet."))
+	} else {
+		maxCount := m.tagCloud.tags[0].Count
+		lineLength := 0
+
+		for i, tag := range m.tagCloud.tags {
+			label := fmt.Sprintf("%s(%d)", tag.Tag, tag.Count)
+
+			weight := display.TagWeight(tag.Count, maxCount)
+			style := m.styles.GetTagWeightStyle(weight)
+			if weight >= 3 {
+				style = style.Bold(true)
+			}
+			if i == m.tagCloud.cursor {
+				style = lipgloss.NewStyle().
+					Foreground(lipgloss.Color(m.theme.SelectedFg)).
+					Background(lipgloss.Color(m.theme.SelectedBg)).
+					Bold(true)
+			}
+
+			if lineLength > 0 && lineLength+lipgloss.Width(label)+2 > lineWidth {
+				b.WriteString("\n")
+				lineLength = 0
+			}
+			if lineLength > 0 {
+				b.WriteString("  ")
+				lineLength += 2
+			}
+			b.WriteString(style.Render(label))
+			lineLength += lipgloss.Width(label)
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(m.styles.TUIHelp.Render("←/→: move  •  Enter: filter by tag  •  Esc: close"))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.BorderColor)).
+		Padding(1, 2).
+		Width(64).
+		Render(b.String())
+}
+
 func (m Model) renderViewPicker() string {
 	var b strings.Builder
 
@@ -583,6 +883,9 @@ func (m Model) renderViewPicker() string {
 			if view.HotKey != nil && *view.HotKey >= 1 && *view.HotKey <= 9 {
 				line += m.styles.Info.Render(fmt.Sprintf(" [%d]", *view.HotKey))
 			}
+			if m.viewPicker.showCounts {
+				line += m.styles.Subtitle.Render(" " + m.viewCountBadge(view.ID))
+			}
 
 			if i == m.viewPicker.cursor {
 				line = lipgloss.NewStyle().
@@ -601,7 +904,7 @@ func (m Model) renderViewPicker() string {
 
 	b.WriteString("\n")
 
-	help := m.styles.TUIHelp.Render("↑/↓: navigate  •  Enter: select  •  Esc: cancel")
+	help := m.styles.TUIHelp.Render("↑/↓: navigate  •  Enter: select  •  c: counts  •  Esc: cancel")
 	b.WriteString(help)
 
 	content := b.String()
@@ -615,6 +918,18 @@ func (m Model) renderViewPicker() string {
 	return box
 }
 
+// "(12)", "(—)" for a view on a deleted project and "(…)" until counted
+func (m Model) viewCountBadge(viewID int64) string {
+	count, ok := m.viewPicker.counts[viewID]
+	switch {
+	case !ok:
+		return "(…)"
+	case count == danglingViewCount:
+		return "(—)"
+	}
+	return fmt.Sprintf("(%d)", count)
+}
+
 func (m Model) renderQuickAccessWidget() string {
 	var b strings.Builder
 
@@ -641,12 +956,38 @@ func (m Model) renderQuickAccessWidget() string {
 func (m Model) renderStatusBar() string {
 	var items []string
 
+	if m.loading {
+		items = append(items, m.styles.Info.Render(m.spinner.View()+" Loading"))
+	}
+
+	if m.readOnly {
+		items = append(items, m.styles.Info.Render("🔒 Read-only"))
+	}
+
 	if m.multiSelect.enabled {
 		selectedCount := len(m.multiSelect.selectedTasks)
 		multiInfo := m.styles.Success.Render(fmt.Sprintf("✓ Multi-select: %d selected", selectedCount))
 		items = append(items, multiInfo)
 	}
 
+	if m.bulkResult != "" {
+		if m.bulkFailed {
+			items = append(items, m.styles.Error.Render("✗ "+m.bulkResult))
+		} else {
+			items = append(items, m.styles.Success.Render("✓ "+m.bulkResult))
+		}
+	}
+
+	if m.selectedView != nil {
+		if m.activeView() != nil {
+			items = append(items, m.styles.Success.Render("📌 View: "+m.selectedView.Name))
+		} else {
+			items = append(items, m.styles.Info.Render(fmt.Sprintf("📌 View: %s (changed, R to re-apply)", m.selectedView.Name)))
+		}
+	} else if m.viewNotice != "" {
+		items = append(items, m.styles.Info.Render(m.viewNotice))
+	}
+
 	if m.totalCount > 0 {
 		totalPages := m.calculateTotalPages()
 		if totalPages > 1 {
@@ -667,6 +1008,10 @@ func (m Model) renderStatusBar() string {
 	sortInfo := fmt.Sprintf("Sort: %s %s", m.filter.SortBy, sortIcon)
 	items = append(items, sortInfo)
 
+	if m.filter.ProjectID != nil {
+		items = append(items, fmt.Sprintf("Project: %s", m.projectName(*m.filter.ProjectID)))
+	}
+
 	filterCount := m.countActiveFilters()
 	if filterCount > 0 {
 		items = append(items, fmt.Sprintf("Filters: %d active", filterCount))
@@ -676,21 +1021,44 @@ func (m Model) renderStatusBar() string {
 	return m.styles.TUISubtitle.Render(statusText)
 }
 
-func (m Model) renderFilterSummary() string {
+// name of a loaded project, or its ID while projects are still loading
+func (m Model) projectName(id int64) string {
+	for _, p := range m.projects {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return fmt.Sprintf("#%d", id)
+}
+
+func (m Model) filterSummary() string {
 	var filters []string
 
+	if m.readyReturn != nil {
+		filters = append(filters, "Ready queue")
+	}
+
 	if m.filter.Status != "" {
-		filters = append(filters, fmt.Sprintf("Status: %s", m.filter.Status))
+		filters = append(filters, fmt.Sprintf("Status: %s", m.styles.StatusLabel(m.filter.Status)))
 	}
 	if m.filter.Priority != "" {
-		filters = append(filters, fmt.Sprintf("Priority: %s", m.filter.Priority))
+		filters = append(filters, fmt.Sprintf("Priority: %s", m.styles.PriorityLabel(m.filter.Priority)))
 	}
 	if m.filter.ProjectID != nil {
-		filters = append(filters, fmt.Sprintf("Project ID: %d", *m.filter.ProjectID))
+		filters = append(filters, fmt.Sprintf("Project: %s", m.projectName(*m.filter.ProjectID)))
 	}
 	if len(m.filter.Tags) > 0 {
 		filters = append(filters, fmt.Sprintf("Tags: %s", strings.Join(m.filter.Tags, ", ")))
 	}
+	if m.filter.DueDateFrom != nil || m.filter.DueDateTo != nil {
+		filters = append(filters, fmt.Sprintf("Due: %s", formatDueFilter(m.filter.DueDateFrom, m.filter.DueDateTo)))
+	}
+	if m.filter.PinnedOnly {
+		filters = append(filters, "Pinned only")
+	}
+	if m.filter.WaitingOnly {
+		filters = append(filters, "Waiting only")
+	}
 	if m.filter.SearchQuery != "" {
 		searchLabel := "Search"
 		if m.filter.SearchMode == "regex" {
@@ -699,8 +1067,20 @@ func (m Model) renderFilterSummary() string {
 		filters = append(filters, fmt.Sprintf("%s: %s", searchLabel, m.filter.SearchQuery))
 	}
 
-	summary := strings.Join(filters, " | ")
-	return m.styles.Info.Render("Active filters: " + summary)
+	return strings.Join(filters, " | ")
+}
+
+func formatDueFilter(from, to *string) string {
+	switch {
+	case from != nil && *from == "none":
+		return "none"
+	case from != nil && to != nil:
+		return fmt.Sprintf("%s..%s", *from, *to)
+	case from != nil:
+		return fmt.Sprintf("from %s", *from)
+	default:
+		return fmt.Sprintf("until %s", *to)
+	}
 }
 
 func (m Model) renderHelp() string {
@@ -713,77 +1093,28 @@ func (m Model) renderHelp() string {
 
 func (m Model) renderFullHelp() string {
 	var help []string
-
-	if m.uiMode == searchingMode {
-		help = []string{
-			"Search Mode:",
-			"  Type to search",
-			"  Enter       Apply search",
-			"  Esc         Cancel",
-		}
-	} else if m.uiMode == filteringMode {
-		help = []string{
-			"Filter Mode:",
-			"  ↑/k         Move up",
-			"  ↓/j         Move down",
-			"  Enter       Select filter",
-			"  Esc         Cancel",
+	for _, section := range m.helpSections() {
+		var lines []string
+		for _, binding := range section.bindings {
+			if !binding.Enabled() {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %-11s %s", binding.Help().Key, binding.Help().Desc))
 		}
-	} else if m.viewMode == tableView {
-		help = []string{
-			"Table View:",
-			"  ↑/k         Move up",
-			"  ↓/j         Move down",
-			"  Enter       View details",
-			"  n           New task",
-			"  e           Edit task",
-			"  f           Open filters",
-			"  F           Clear filters",
-			"  /           Search",
-			"  s           Cycle sort",
-			"  S           Toggle sort order",
-			"  [/]         Prev/Next page",
-			"  r           Refresh",
-			"",
-			"Quick Actions:",
-			"  c           Mark complete",
-			"  p           Cycle priority",
-			"  x           Toggle status",
-			"  d           Delete task",
-			"",
-			"Multi-select:",
-			"  v           Toggle multi-select mode",
-			"  Space       Toggle selection",
-			"  Ctrl+A      Select all",
-			"  Ctrl+D      Deselect all",
-			"",
-			"General:",
-			"  q/Ctrl+C    Quit",
-			"  ?           Toggle help",
+		if len(lines) == 0 {
+			continue
 		}
-	} else {
-		help = []string{
-			"Detail View:",
-			"  ↑/k         Previous task",
-			"  ↓/j         Next task",
-			"  Esc         Back to list",
-			"  e           Edit task",
-			"",
-			"Quick Actions:",
-			"  c           Mark complete",
-			"  p           Cycle priority",
-			"  x           Toggle status",
-			"  d           Delete task",
-			"",
-			"General:",
-			"  q/Ctrl+C    Quit",
-			"  ?           Toggle help",
+		if len(help) > 0 {
+			help = append(help, "")
 		}
+		help = append(help, section.title+":")
+		help = append(help, lines...)
 	}
 
 	return m.styles.TUIHelp.Render(strings.Join(help, "\n"))
 }
 
+
 func (m Model) renderQuickHelp() string {
 	var hints []string
 
@@ -812,6 +1143,15 @@ func (m Model) renderQuickHelp() string {
 				"?: help",
 			}
 		}
+	} else if m.viewMode == kanbanView {
+		hints = []string{
+			"←/→: column",
+			"↑/↓: card",
+			"</>: move card",
+			"c/p/x/d: actions",
+			"b/Esc: table",
+			"?: help",
+		}
 	} else {
 		hints = []string{
 			"↑/↓: prev/next",
@@ -822,9 +1162,40 @@ func (m Model) renderQuickHelp() string {
 		}
 	}
 
+	if m.readOnly {
+		hints = slices.DeleteFunc(hints, func(hint string) bool { return writeHints[hint] })
+	}
+
 	return m.styles.TUIHelp.Render(strings.Join(hints, "  •  "))
 }
 
+// quick help hints for keys that are off in read-only mode
+var writeHints = map[string]bool{
+	"n: new":            true,
+	"e: edit":           true,
+	"c/p/x/d: actions":  true,
+	"c/p/x/d: bulk ops": true,
+	"</>: move card":    true,
+}
+
+// wraps a detail value and highlights the active search line by line, so
+// wrapping never splits a highlighted word
+func (m Model) highlightDetailText(text string, width int) string {
+	wrapped := wrapText(text, width)
+	query, regex, ok := m.highlightQuery()
+	if !ok {
+		return wrapped
+	}
+
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		if matches := display.SearchMatches(line, query, regex); len(matches) > 0 {
+			lines[i] = display.Highlight(line, matches, m.styles.SearchMatch, m.styles.DetailValue)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m Model) renderDetailRow(label, value string) string {
 	return m.styles.DetailLabel.Render(label) + " " + m.styles.DetailValue.Render(value)
 }
@@ -835,6 +1206,10 @@ func (m *Model) hasActiveFilters() bool {
 		m.filter.Priority != "" ||
 		m.filter.ProjectID != nil ||
 		len(m.filter.Tags) > 0 ||
+		m.filter.DueDateFrom != nil ||
+		m.filter.DueDateTo != nil ||
+		m.filter.PinnedOnly ||
+		m.filter.WaitingOnly ||
 		m.filter.SearchQuery != ""
 }
 
@@ -852,6 +1227,15 @@ func (m *Model) countActiveFilters() int {
 	if len(m.filter.Tags) > 0 {
 		count++
 	}
+	if m.filter.DueDateFrom != nil || m.filter.DueDateTo != nil {
+		count++
+	}
+	if m.filter.PinnedOnly {
+		count++
+	}
+	if m.filter.WaitingOnly {
+		count++
+	}
 	if m.filter.SearchQuery != "" {
 		count++
 	}
@@ -886,6 +1270,7 @@ func (m Model) renderEditForm() string {
 	b.WriteString(m.styles.DetailLabel.Render(fieldLabel))
 	b.WriteString("\n  ")
 	b.WriteString(m.editForm.titleInput.View())
+	b.WriteString(m.renderEditFieldError("title"))
 	b.WriteString("\n\n")
 
 	fieldLabel = "Description:"
@@ -908,6 +1293,7 @@ func (m Model) renderEditForm() string {
 	b.WriteString(m.styles.DetailLabel.Render(fieldLabel))
 	b.WriteString("\n  ")
 	b.WriteString(m.editForm.projectInput.View())
+	b.WriteString(m.renderEditFieldError("project"))
 	b.WriteString("\n\n")
 
 	fieldLabel = "Tags:"
@@ -930,13 +1316,25 @@ func (m Model) renderEditForm() string {
 	b.WriteString(m.styles.DetailLabel.Render(fieldLabel))
 	b.WriteString("\n  ")
 	b.WriteString(m.editForm.dueDateInput.View())
+	b.WriteString(m.renderEditFieldError("due_date"))
+	b.WriteString("\n\n")
+
+	fieldLabel = "Waiting On:"
+	if m.editForm.focusedField == 5 {
+		fieldLabel = m.styles.Success.Render("▶ " + fieldLabel)
+	} else {
+		fieldLabel = "  " + fieldLabel
+	}
+	b.WriteString(m.styles.DetailLabel.Render(fieldLabel))
+	b.WriteString("\n  ")
+	b.WriteString(m.editForm.waitingInput.View())
 	b.WriteString("\n\n")
 
 	b.WriteString(m.styles.DetailLabel.Render("  Priority:"))
 	b.WriteString(" ")
 	priorityValue := domain.Priority(priorities[m.editForm.priorityIdx])
 	priorityStyle := m.styles.GetPriorityTextStyle(priorityValue)
-	b.WriteString(priorityStyle.Render(priorities[m.editForm.priorityIdx]))
+	b.WriteString(priorityStyle.Render(m.styles.PriorityLabel(priorityValue)))
 	b.WriteString(m.styles.TUIHelp.Render(" (Ctrl+P to cycle)"))
 	b.WriteString("\n\n")
 
@@ -944,13 +1342,22 @@ func (m Model) renderEditForm() string {
 	b.WriteString(" ")
 	statusValue := domain.Status(statuses[m.editForm.statusIdx])
 	statusStyle := m.styles.GetStatusStyle(statusValue)
-	b.WriteString(statusStyle.Render(statuses[m.editForm.statusIdx]))
+	b.WriteString(statusStyle.Render(m.styles.StatusLabel(statusValue)))
 	b.WriteString(m.styles.TUIHelp.Render(" (Ctrl+T to cycle)"))
+	b.WriteString(m.renderEditFieldError("status"))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+func (m Model) renderEditFieldError(field string) string {
+	errMsg, ok := m.editForm.errors[field]
+	if !ok {
+		return ""
+	}
+	return "\n  " + m.styles.Error.Render(errMsg)
+}
+
 func (m Model) renderEditHelp() string {
 	hints := []string{
 		"Tab/Shift+Tab: navigate fields",
@@ -44,6 +44,13 @@ type (
 		view *domain.SavedView
 		err  error
 	}
+
+	// counts by view ID, danglingViewCount for views filtering on a project
+	// that no longer exists
+	viewCountsMsg struct {
+		counts map[int64]int64
+		err    error
+	}
 )
 
 func fetchViewsCmd(ctx context.Context, repo repository.ViewRepository) tea.Cmd {
@@ -81,3 +88,26 @@ func applyViewCmd(ctx context.Context, repo repository.ViewRepository, viewID in
 		return viewAppliedMsg{view: view}
 	}
 }
+
+const danglingViewCount = -1
+
+// counts the tasks of every view in one go, filters by view ID
+func fetchViewCountsCmd(ctx context.Context, repo repository.TaskRepository, projectRepo repository.ProjectRepository, filters map[int64]repository.TaskFilter) tea.Cmd {
+	return func() tea.Msg {
+		counts := make(map[int64]int64, len(filters))
+		for id, filter := range filters {
+			if filter.ProjectID != nil {
+				if _, err := projectRepo.GetByID(ctx, *filter.ProjectID); err != nil {
+					counts[id] = danglingViewCount
+					continue
+				}
+			}
+			count, err := repo.Count(ctx, filter)
+			if err != nil {
+				return viewCountsMsg{err: err}
+			}
+			counts[id] = count
+		}
+		return viewCountsMsg{counts: counts}
+	}
+}